@@ -52,7 +52,7 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }],
+                fields: [{ name: 'content' }, { name: 'owner' }],
             },
         ],
     },
@@ -17,14 +17,17 @@ import { PerforceIcon, PhabricatorIcon } from '@sourcegraph/shared/src/component
 import { Link, Code, Text } from '@sourcegraph/wildcard'
 
 import awsCodeCommitSchemaJSON from '../../../../../schema/aws_codecommit.schema.json'
+import azureDevOpsSchemaJSON from '../../../../../schema/azuredevops.schema.json'
 import bitbucketCloudSchemaJSON from '../../../../../schema/bitbucket_cloud.schema.json'
 import bitbucketServerSchemaJSON from '../../../../../schema/bitbucket_server.schema.json'
 import gerritSchemaJSON from '../../../../../schema/gerrit.schema.json'
+import giteaSchemaJSON from '../../../../../schema/gitea.schema.json'
 import githubSchemaJSON from '../../../../../schema/github.schema.json'
 import gitlabSchemaJSON from '../../../../../schema/gitlab.schema.json'
 import gitoliteSchemaJSON from '../../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../../schema/jvm-packages.schema.json'
+import mercurialSchemaJSON from '../../../../../schema/mercurial.schema.json'
 import npmPackagesSchemaJSON from '../../../../../schema/npm-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
@@ -33,6 +36,7 @@ import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json
 import pythonPackagesJSON from '../../../../../schema/python-packages.schema.json'
 import rubyPackagesSchemaJSON from '../../../../../schema/ruby-packages.schema.json'
 import rustPackagesJSON from '../../../../../schema/rust-packages.schema.json'
+import subversionSchemaJSON from '../../../../../schema/subversion.schema.json'
 import { ExternalRepositoryFields, ExternalServiceKind } from '../../graphql-operations'
 import { EditorAction } from '../../settings/EditorActionsGroup'
 
@@ -1143,6 +1147,114 @@ const GENERIC_GIT: AddExternalServiceOptions = {
         },
     ],
 }
+const MERCURIAL: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.MERCURIAL,
+    title: 'Mercurial',
+    icon: GitIcon,
+    jsonSchema: mercurialSchemaJSON,
+    defaultDisplayName: 'Mercurial repositories',
+    defaultConfig: `{
+  "url": "https://hg.example.com",
+  "repos": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to be the URL of your Mercurial server.
+                </li>
+                <li>
+                    Add the paths of the repositories you wish to index to the <Field>repos</Field> field. These will be
+                    appended to the server URL to obtain the repository URLs.
+                </li>
+            </ol>
+            <Text>
+                The history of Mercurial repositories is converted to Git when they are cloned and updated. See{' '}
+                <Link
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    to="https://docs.sourcegraph.com/admin/repo/mercurial#configuration"
+                >
+                    the docs for more options
+                </Link>
+                .
+            </Text>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'addRepo',
+            label: 'Add a repository',
+            run: (config: string) => {
+                const value = 'path/to/repository'
+                const edits = modify(config, ['repos', -1], value, defaultModificationOptions)
+                return { edits, selectText: value }
+            },
+        },
+    ],
+}
+const SUBVERSION: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.SUBVERSION,
+    title: 'Subversion',
+    icon: GitIcon,
+    jsonSchema: subversionSchemaJSON,
+    defaultDisplayName: 'Subversion repositories',
+    defaultConfig: `{
+  "url": "https://svn.example.com/repos",
+  "username": "<username>",
+  "password": "<password>"
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to be the URL of your Subversion server root,
+                    below which the repositories are served.
+                </li>
+                <li>
+                    Set <Field>username</Field> and <Field>password</Field> to the credentials of a user who can read
+                    the repositories.
+                </li>
+                <li>
+                    All repositories listed by the server are synced. To sync only some of them, add their paths to the{' '}
+                    <Field>repos</Field> field.
+                </li>
+            </ol>
+            <Text>
+                The trunk, branches and tags of Subversion repositories are converted to Git when they are cloned and
+                updated. See{' '}
+                <Link
+                    rel="noopener noreferrer"
+                    target="_blank"
+                    to="https://docs.sourcegraph.com/admin/repo/subversion#configuration"
+                >
+                    the docs for more options
+                </Link>
+                .
+            </Text>
+        </div>
+    ),
+    editorActions: [
+        {
+            id: 'addRepo',
+            label: 'Add a repository',
+            run: (config: string) => {
+                const value = 'path/to/repository'
+                const edits = modify(config, ['repos', -1], value, defaultModificationOptions)
+                return { edits, selectText: value }
+            },
+        },
+        {
+            id: 'addAuthor',
+            label: 'Map a Subversion user to a Git author',
+            run: (config: string) => {
+                const value = 'Name <email>'
+                const edits = modify(config, ['authors', 'svn-username'], value, defaultModificationOptions)
+                return { edits, selectText: value }
+            },
+        },
+    ],
+}
 const PERFORCE: AddExternalServiceOptions = {
     kind: ExternalServiceKind.PERFORCE,
     title: 'Perforce',
@@ -1278,6 +1390,83 @@ const GERRIT: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const AZURE_DEVOPS: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.AZUREDEVOPS,
+    title: 'Azure DevOps',
+    icon: GitIcon,
+    jsonSchema: azureDevOpsSchemaJSON,
+    defaultDisplayName: 'Azure DevOps',
+    defaultConfig: `{
+  "url": "https://dev.azure.com",
+  "username": "<username>",
+  "token": "<personal access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>username</Field> to the user that owns the personal access
+                    token.
+                </li>
+                <li>
+                    Create a personal access token with the <Code>Code (Read)</Code> scope and set it as the value of
+                    the <Field>token</Field> field in the configuration below.
+                </li>
+                <li>
+                    Use <Field>orgs</Field> or <Field>projects</Field> to select the repositories to mirror.
+                </li>
+            </ol>
+            <Text>
+                See{' '}
+                <Link rel="noopener noreferrer" target="_blank" to="/help/admin/external_service/azuredevops">
+                    the docs for more options
+                </Link>
+                .
+            </Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const GITEA: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.GITEA,
+    title: 'Gitea',
+    icon: GitIcon,
+    jsonSchema: giteaSchemaJSON,
+    defaultDisplayName: 'Gitea',
+    defaultConfig: `{
+  "url": "https://gitea.example.com",
+  "token": "<access token>",
+  "orgs": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of your Gitea or Forgejo instance.
+                </li>
+                <li>
+                    Create a Gitea access token with read access to the repositories you want to mirror and set it as
+                    the value of the <Field>token</Field> field in the configuration below.
+                </li>
+                <li>
+                    Use <Field>orgs</Field>, <Field>repos</Field>, <Field>topics</Field>, or{' '}
+                    <Field>repositoryQuery</Field> to select the repositories to mirror.
+                </li>
+            </ol>
+            <Text>
+                See{' '}
+                <Link rel="noopener noreferrer" target="_blank" to="/help/admin/external_service/gitea">
+                    the docs for more options
+                </Link>
+                .
+            </Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const NPM_PACKAGES: AddExternalServiceOptions = {
     kind: ExternalServiceKind.NPMPACKAGES,
     title: 'npm Dependencies',
@@ -1448,6 +1637,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     aws_codecommit: AWS_CODE_COMMIT,
     srcservegit: SRC_SERVE_GIT,
     gitolite: GITOLITE,
+    azuredevops: AZURE_DEVOPS,
+    gitea: GITEA,
     git: GENERIC_GIT,
     ...(window.context?.experimentalFeatures?.pythonPackages === 'enabled' ? { pythonPackages: PYTHON_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.rustPackages === 'enabled' ? { rustPackages: RUST_PACKAGES } : {}),
@@ -1456,6 +1647,8 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     ...(window.context?.experimentalFeatures?.jvmPackages === 'enabled' ? { jvmPackages: JVM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'enabled' ? { npmPackages: NPM_PACKAGES } : {}),
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
+    ...(window.context?.experimentalFeatures?.mercurial === 'enabled' ? { mercurial: MERCURIAL } : {}),
+    ...(window.context?.experimentalFeatures?.subversion === 'enabled' ? { subversion: SUBVERSION } : {}),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
     ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
 }
@@ -1479,7 +1672,11 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.OTHER]: GENERIC_GIT,
     [ExternalServiceKind.AWSCODECOMMIT]: AWS_CODE_COMMIT,
     [ExternalServiceKind.PERFORCE]: PERFORCE,
+    [ExternalServiceKind.MERCURIAL]: MERCURIAL,
+    [ExternalServiceKind.SUBVERSION]: SUBVERSION,
     [ExternalServiceKind.GERRIT]: GERRIT,
+    [ExternalServiceKind.AZUREDEVOPS]: AZURE_DEVOPS,
+    [ExternalServiceKind.GITEA]: GITEA,
     [ExternalServiceKind.PAGURE]: PAGURE,
     [ExternalServiceKind.GOMODULES]: GO_MODULES,
     [ExternalServiceKind.JVMPACKAGES]: JVM_PACKAGES,
@@ -1,6 +1,14 @@
 import React from 'react'
 
-import { mdiCommentOutline, mdiLinkVariantRemove, mdiSync, mdiSourceBranch, mdiUpload, mdiOpenInNew } from '@mdi/js'
+import {
+    mdiCommentOutline,
+    mdiLinkVariantRemove,
+    mdiSync,
+    mdiSourceBranch,
+    mdiSourceBranchCheck,
+    mdiUpload,
+    mdiOpenInNew,
+} from '@mdi/js'
 import classNames from 'classnames'
 
 import { ErrorMessage } from '@sourcegraph/branded/src/components/alerts'
@@ -45,6 +53,11 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <Icon aria-hidden={true} className="text-muted" svgPath={mdiUpload} /> Publish changesets
         </>
     ),
+    AUTO_MERGE: (
+        <>
+            <Icon aria-hidden={true} className="text-muted" svgPath={mdiSourceBranchCheck} /> Auto-merge changesets
+        </>
+    ),
 }
 
 export interface BulkOperationNodeProps {
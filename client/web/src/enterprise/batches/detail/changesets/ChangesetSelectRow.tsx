@@ -145,6 +145,26 @@ const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
             )
         },
     },
+    [BulkOperationType.AUTO_MERGE]: {
+        type: 'auto_merge',
+        experimental: true,
+        buttonLabel: 'Auto-merge changesets',
+        dropdownTitle: 'Auto-merge changesets',
+        dropdownDescription:
+            'Merge all selected changesets once they have been approved and their checks have passed. Code hosts that support it will merge the changesets themselves.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => {
+            eventLogger.log('batch_change_details:bulk_action_auto_merge:clicked')
+            return (
+                <MergeChangesetsModal
+                    batchChangeID={batchChangeID}
+                    changesetIDs={changesetIDs}
+                    afterCreate={onDone}
+                    onCancel={onCancel}
+                    autoMerge={true}
+                />
+            )
+        },
+    },
 }
 
 export interface ChangesetSelectRowProps {
@@ -7,7 +7,7 @@ import { Button, Checkbox, Modal, H3, Text } from '@sourcegraph/wildcard'
 
 import { LoaderButton } from '../../../../components/LoaderButton'
 import { Scalars } from '../../../../graphql-operations'
-import { mergeChangesets as _mergeChangesets } from '../backend'
+import { autoMergeChangesets as _autoMergeChangesets, mergeChangesets as _mergeChangesets } from '../backend'
 
 export interface MergeChangesetsModalProps {
     onCancel: () => void
@@ -15,8 +15,16 @@ export interface MergeChangesetsModalProps {
     batchChangeID: Scalars['ID']
     changesetIDs: Scalars['ID'][]
 
+    /**
+     * If true, the changesets are merged once they have been approved and their
+     * checks have passed, instead of right away.
+     */
+    autoMerge?: boolean
+
     /** For testing only. */
     mergeChangesets?: typeof _mergeChangesets
+    /** For testing only. */
+    autoMergeChangesets?: typeof _autoMergeChangesets
 }
 
 export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildren<MergeChangesetsModalProps>> = ({
@@ -24,7 +32,9 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
     afterCreate,
     batchChangeID,
     changesetIDs,
+    autoMerge = false,
     mergeChangesets = _mergeChangesets,
+    autoMergeChangesets = _autoMergeChangesets,
 }) => {
     const [isLoading, setIsLoading] = useState<boolean | Error>(false)
     const [squash, setSquash] = useState<boolean>(false)
@@ -32,12 +42,13 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
     const onSubmit = useCallback<React.FormEventHandler>(async () => {
         setIsLoading(true)
         try {
-            await mergeChangesets(batchChangeID, changesetIDs, squash)
+            const merge = autoMerge ? autoMergeChangesets : mergeChangesets
+            await merge(batchChangeID, changesetIDs, squash)
             afterCreate()
         } catch (error) {
             setIsLoading(asError(error))
         }
-    }, [changesetIDs, mergeChangesets, batchChangeID, squash, afterCreate])
+    }, [changesetIDs, autoMerge, autoMergeChangesets, mergeChangesets, batchChangeID, squash, afterCreate])
 
     const onToggleSquash = useCallback<React.ChangeEventHandler<HTMLInputElement>>(event => {
         setSquash(event.target.checked)
@@ -45,8 +56,12 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
 
     return (
         <Modal onDismiss={onCancel} aria-labelledby={MODAL_LABEL_ID}>
-            <H3 id={MODAL_LABEL_ID}>Merge changesets</H3>
-            <Text className="mb-4">Are you sure you want to attempt to merge all the selected changesets?</Text>
+            <H3 id={MODAL_LABEL_ID}>{autoMerge ? 'Auto-merge changesets' : 'Merge changesets'}</H3>
+            <Text className="mb-4">
+                {autoMerge
+                    ? 'Are you sure you want to merge all the selected changesets once they have been approved and their checks have passed?'
+                    : 'Are you sure you want to attempt to merge all the selected changesets?'}
+            </Text>
             <Form>
                 <div className="form-group">
                     <Checkbox
@@ -75,7 +90,7 @@ export const MergeChangesetsModal: React.FunctionComponent<React.PropsWithChildr
                     variant="primary"
                     loading={isLoading === true}
                     alwaysShowLabel={true}
-                    label="Merge"
+                    label={autoMerge ? 'Auto-merge' : 'Merge'}
                 />
             </div>
         </Modal>
@@ -93,6 +93,7 @@ const PreviewAction: React.FunctionComponent<React.PropsWithChildren<PreviewActi
             return <PreviewActionReattach className={className} />
         case ChangesetSpecOperation.SYNC:
         case ChangesetSpecOperation.SLEEP:
+        case ChangesetSpecOperation.AUTO_MERGE:
             // We don't want to expose these states.
             return null
         default:
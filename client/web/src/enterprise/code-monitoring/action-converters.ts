@@ -8,9 +8,11 @@ import {
     MonitorEmailPriority,
     MonitorWebhookInput,
     MonitorSlackWebhookInput,
+    MonitorIssueInput,
     MonitorWebhookFields,
     MonitorSlackWebhookFields,
     MonitorEmailFields,
+    MonitorIssueFields,
 } from '../../graphql-operations'
 
 function convertEmailAction(
@@ -42,6 +44,14 @@ function convertWebhookAction(action: MonitorWebhookFields): MonitorWebhookInput
     }
 }
 
+function convertIssueAction(action: MonitorIssueFields): MonitorIssueInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        repository: action.repository.id,
+    }
+}
+
 export function convertActionsForCreate(
     actions: CodeMonitorFields['actions']['nodes'],
     authenticatedUserId: AuthenticatedUser['id']
@@ -60,6 +70,10 @@ export function convertActionsForCreate(
                 return {
                     webhook: convertWebhookAction(action),
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: convertIssueAction(action),
+                }
         }
     })
 }
@@ -92,6 +106,13 @@ export function convertActionsForUpdate(
                         update: convertWebhookAction(action),
                     },
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: {
+                        id: action.id || null,
+                        update: convertIssueAction(action),
+                    },
+                }
         }
     })
 }
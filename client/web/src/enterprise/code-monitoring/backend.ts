@@ -61,6 +61,20 @@ const MonitorSlackWebhookFragment = gql`
     }
 `
 
+const MonitorIssueFragment = gql`
+    fragment MonitorIssueFields on MonitorIssue {
+        __typename
+        id
+        enabled
+        includeResults
+        issueURL
+        repository {
+            id
+            name
+        }
+    }
+`
+
 const CodeMonitorFragment = gql`
     fragment CodeMonitorFields on Monitor {
         id
@@ -78,12 +92,14 @@ const CodeMonitorFragment = gql`
                 ...MonitorEmailFields
                 ...MonitorWebhookFields
                 ...MonitorSlackWebhookFields
+                ...MonitorIssueFields
             }
         }
     }
     ${MonitorEmailFragment}
     ${MonitorWebhookFragment}
     ${MonitorSlackWebhookFragment}
+    ${MonitorIssueFragment}
 `
 
 const ListCodeMonitorsFragment = gql`
@@ -227,6 +243,16 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 includeResults
                                 url
                             }
+                            ... on MonitorIssue {
+                                id
+                                enabled
+                                includeResults
+                                issueURL
+                                repository {
+                                    id
+                                    name
+                                }
+                            }
                         }
                     }
                     trigger {
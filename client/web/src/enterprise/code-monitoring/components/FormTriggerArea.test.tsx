@@ -88,6 +88,22 @@ describe('FormTriggerArea', () => {
             repoChecked: false,
             validChecked: true,
         },
+        {
+            query: 'test type:file',
+            isSourcegraphDotCom: true,
+            patternTypeChecked: true,
+            typeChecked: true,
+            repoChecked: false,
+            validChecked: true,
+        },
+        {
+            query: 'test type:symbol',
+            isSourcegraphDotCom: true,
+            patternTypeChecked: true,
+            typeChecked: true,
+            repoChecked: false,
+            validChecked: true,
+        },
         {
             query: 'test repo:test',
             isSourcegraphDotCom: true,
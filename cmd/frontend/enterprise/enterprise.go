@@ -26,6 +26,7 @@ type Services struct {
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
+	SearchJobsResultsHandler        http.Handler
 	NewCodeIntelUploadHandler       NewCodeIntelUploadHandler
 	RankingService                  RankingService
 	NewExecutorProxyHandler         NewExecutorProxyHandler
@@ -43,6 +44,7 @@ type Services struct {
 	ComputeResolver                 graphqlbackend.ComputeResolver
 	InsightsAggregationResolver     graphqlbackend.InsightsAggregationResolver
 	WebhooksResolver                graphqlbackend.WebhooksResolver
+	SearchJobsResolver              graphqlbackend.SearchJobsResolver
 }
 
 // NewCodeIntelUploadHandler creates a new handler for the LSIF upload endpoint. The
@@ -54,6 +56,7 @@ type RankingService interface {
 	LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error)
 	GetRepoRank(ctx context.Context, repoName api.RepoName) (_ []float64, err error)
 	GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error)
+	GetDocumentReferenceCounts(ctx context.Context, repoName api.RepoName) (_ map[string]float64, err error)
 }
 
 // NewExecutorProxyHandler creates a new proxy handler for routes accessible to the
@@ -79,6 +82,7 @@ func DefaultServices() Services {
 		BatchesChangesFileGetHandler:    makeNotFoundHandler("batches file get handler"),
 		BatchesChangesFileExistsHandler: makeNotFoundHandler("batches file exists handler"),
 		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
+		SearchJobsResultsHandler:        makeNotFoundHandler("search jobs results handler"),
 		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		RankingService:                  stubRankingService{},
 		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
@@ -170,3 +174,7 @@ func (s stubRankingService) GetRepoRank(ctx context.Context, repoName api.RepoNa
 func (s stubRankingService) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error) {
 	return nil, nil
 }
+
+func (s stubRankingService) GetDocumentReferenceCounts(ctx context.Context, repoName api.RepoName) (_ map[string]float64, err error) {
+	return nil, nil
+}
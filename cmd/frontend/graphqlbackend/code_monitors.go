@@ -81,6 +81,7 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorIssue() (MonitorIssueResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -109,6 +110,15 @@ type MonitorSlackWebhookResolver interface {
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorIssueResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	IssueURL() *string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -166,6 +176,7 @@ type CreateActionArgs struct {
 	Email        *CreateActionEmailArgs
 	Webhook      *CreateActionWebhookArgs
 	SlackWebhook *CreateActionSlackWebhookArgs
+	Issue        *CreateActionIssueArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -188,6 +199,12 @@ type CreateActionSlackWebhookArgs struct {
 	URL            string
 }
 
+type CreateActionIssueArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	Repository     graphql.ID
+}
+
 type ToggleCodeMonitorArgs struct {
 	Id      graphql.ID
 	Enabled bool
@@ -240,10 +257,16 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionIssueArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionIssueArgs
+}
+
 type EditActionArgs struct {
 	Email        *EditActionEmailArgs
 	Webhook      *EditActionWebhookArgs
 	SlackWebhook *EditActionSlackWebhookArgs
+	Issue        *EditActionIssueArgs
 }
 
 type EditTriggerArgs struct {
@@ -40,6 +40,11 @@ type InsightsResolver interface {
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
+	ExportInsightDefinitions(ctx context.Context) (string, error)
+	ImportInsightDefinitions(ctx context.Context, args *ImportInsightDefinitionsArgs) (*EmptyResponse, error)
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -79,6 +84,10 @@ type InsightViewDebugArgs struct {
 	Id graphql.ID
 }
 
+type ImportInsightDefinitionsArgs struct {
+	Definitions string
+}
+
 type InsightsDataPointResolver interface {
 	DateTime() gqlutil.DateTime
 	Value() float64
@@ -288,6 +297,38 @@ type UpdateInsightSeriesInput struct {
 	Enabled  *bool
 }
 
+type InsightSeriesAlertsArgs struct {
+	SeriesId string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	SeriesId        string
+	Kind            string
+	Threshold       float64
+	EmailRecipient  *graphql.ID
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Kind() string
+	Threshold() float64
+	EmailRecipient() *graphql.ID
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	LastTriggeredAt() *gqlutil.DateTime
+}
+
 type InsightSeriesMetadataResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Query(ctx context.Context) (string, error)
@@ -411,6 +452,7 @@ type LineChartSearchInsightDataSeriesInput struct {
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
 	GroupBy                    *string
+	GenerationMethod           *string
 }
 
 type LineChartDataSeriesOptionsInput struct {
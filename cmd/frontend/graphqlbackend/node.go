@@ -79,6 +79,11 @@ func (r *NodeResolver) ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, boo
 	return n, ok
 }
 
+func (r *NodeResolver) ToMonitorIssue() (MonitorIssueResolver, bool) {
+	n, ok := r.Node.(MonitorIssueResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToMonitorActionEvent() (MonitorActionEventResolver, bool) {
 	n, ok := r.Node.(MonitorActionEventResolver)
 	return n, ok
@@ -213,6 +218,11 @@ func (r *NodeResolver) ToNotebook() (NotebookResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToSearchJob() (SearchJobResolver, bool) {
+	n, ok := r.Node.(SearchJobResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToSite() (*siteResolver, bool) {
 	n, ok := r.Node.(*siteResolver)
 	return n, ok
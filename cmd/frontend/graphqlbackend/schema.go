@@ -59,6 +59,11 @@ var searchContextsSchema string
 //go:embed notebooks.graphql
 var notebooksSchema string
 
+// searchJobsSchema is the Search Jobs raw graqhql schema.
+//
+//go:embed search_jobs.graphql
+var searchJobsSchema string
+
 // insightsAggregationsSchema is the Code Insights Aggregations raw graqhql schema.
 //
 //go:embed insights_aggregations.graphql
@@ -0,0 +1,64 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+)
+
+type SearchJobsResolver interface {
+	CreateSearchJob(ctx context.Context, args CreateSearchJobArgs) (SearchJobResolver, error)
+	CancelSearchJob(ctx context.Context, args CancelSearchJobArgs) (*EmptyResponse, error)
+	SearchJobs(ctx context.Context, args ListSearchJobsArgs) (SearchJobConnectionResolver, error)
+
+	NodeResolvers() map[string]NodeByIDFunc
+}
+
+type CreateSearchJobArgs struct {
+	Query string
+}
+
+type CancelSearchJobArgs struct {
+	ID graphql.ID
+}
+
+type ListSearchJobsArgs struct {
+	First  int32
+	After  *string
+	UserID *graphql.ID
+}
+
+type SearchJobConnectionResolver interface {
+	Nodes(ctx context.Context) ([]SearchJobResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
+type SearchJobResolver interface {
+	ID() graphql.ID
+	Query() string
+	State(ctx context.Context) (string, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() gqlutil.DateTime
+	StartedAt() *gqlutil.DateTime
+	FinishedAt() *gqlutil.DateTime
+	FailureMessage() *string
+	Stats(ctx context.Context) (SearchJobStatsResolver, error)
+	ResultsURL(args SearchJobResultsURLArgs) (string, error)
+}
+
+type SearchJobResultsURLArgs struct {
+	Format string
+}
+
+type SearchJobStatsResolver interface {
+	Total() int32
+	InProgress() int32
+	Completed() int32
+	Failed() int32
+	Canceled() int32
+	ResultCount() int32
+}
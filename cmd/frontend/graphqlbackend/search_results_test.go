@@ -604,6 +604,24 @@ func TestZeroElapsedMilliseconds(t *testing.T) {
 	}
 }
 
+func TestSearchResultsResolver_SparklineOwnerMatch(t *testing.T) {
+	r := &SearchResultsResolver{
+		Matches: result.Matches{
+			&result.OwnerMatch{Handle: "alice"},
+			&result.RepoMatch{Name: "repo"},
+		},
+	}
+	sparkline, err := r.Sparkline(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, points := range sparkline {
+		if points != 0 {
+			t.Fatalf("unexpected %d points on day %d", points, i)
+		}
+	}
+}
+
 // Detailed filtering tests are below in TestSubRepoFilterFunc, this test is more
 // of an integration test to ensure that things are threaded through correctly
 // from the resolver
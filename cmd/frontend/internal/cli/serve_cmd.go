@@ -201,7 +201,7 @@ func Main(enterpriseSetupHook func(database.DB, conftypes.UnifiedWatchable) ente
 	if err != nil {
 		return errors.Wrap(err, "Failed to create sub-repo client")
 	}
-	ui.InitRouter(db)
+	ui.InitRouter(db, enterprise.RankingService)
 
 	if len(os.Args) >= 2 {
 		switch os.Args[1] {
@@ -278,6 +278,7 @@ func Main(enterpriseSetupHook func(database.DB, conftypes.UnifiedWatchable) ente
 		enterprise.ComputeResolver,
 		enterprise.InsightsAggregationResolver,
 		enterprise.WebhooksResolver,
+		enterprise.SearchJobsResolver,
 	)
 	if err != nil {
 		return err
@@ -336,6 +337,8 @@ func makeExternalAPI(db database.DB, schema *graphql.Schema, enterprise enterpri
 			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
 			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
 			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
+			SearchJobsResultsHandler:        enterprise.SearchJobsResultsHandler,
+			RankingService:                  enterprise.RankingService,
 			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
 			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
 		},
@@ -33,6 +33,8 @@ const (
 	BatchesFileExists = "batches.file.exists"
 	BatchesFileUpload = "batches.file.upload"
 
+	SearchJobsResults = "search.jobs.results"
+
 	ExternalURL            = "internal.app-url"
 	SendEmail              = "internal.send-email"
 	GitInfoRefs            = "internal.git.info-refs"
@@ -68,6 +70,7 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/files/batch-changes/{spec}").Methods("POST").Name(BatchesFileUpload)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/jobs/{id:[0-9]+}/results.{format}").Methods("GET").Name(SearchJobsResults)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
@@ -241,6 +241,9 @@ func (*fakeRankingService) GetRepoRank(ctx context.Context, repoName api.RepoNam
 func (*fakeRankingService) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (_ map[string][]float64, err error) {
 	return nil, nil
 }
+func (*fakeRankingService) GetDocumentReferenceCounts(ctx context.Context, repoName api.RepoName) (_ map[string]float64, err error) {
+	return nil, nil
+}
 
 // suffixIndexers mocks Indexers. ReposSubset will return all repoNames with
 // the suffix of hostname.
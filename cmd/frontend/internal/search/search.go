@@ -29,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/ranking"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
@@ -38,21 +39,28 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// StreamHandler is an http handler which streams back search results.
-func StreamHandler(db database.DB) http.Handler {
+// StreamHandler is an http handler which streams back search results. ranker
+// provides document reference counts for ranked searches and may be nil.
+func StreamHandler(db database.DB, ranker ranking.DocumentRanker) http.Handler {
 	logger := log.Scoped("searchStreamHandler", "")
 	return &streamHandler{
 		logger:              logger,
 		db:                  db,
+		ranker:              ranker,
 		searchClient:        client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
 		flushTickerInternal: 100 * time.Millisecond,
 		pingTickerInterval:  5 * time.Second,
 	}
 }
 
+// rankingWindow is the maximum time a ranked search holds back results in
+// order to sort them by relevance.
+const rankingWindow = 500 * time.Millisecond
+
 type streamHandler struct {
 	logger              log.Logger
 	db                  database.DB
+	ranker              ranking.DocumentRanker
 	searchClient        client.SearchClient
 	flushTickerInternal time.Duration
 	pingTickerInterval  time.Duration
@@ -97,6 +105,7 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		otlog.String("version", args.Version),
 		otlog.String("pattern_type", args.PatternType),
 		otlog.Int("search_mode", args.SearchMode),
+		otlog.Bool("ranked", args.Ranked),
 	)
 
 	settings, err := graphqlbackend.DecodedViewerFinalSettings(ctx, h.db)
@@ -178,6 +187,16 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		)
 		defer eventHandler.Done()
 
+		if args.Ranked {
+			scorer := ranking.NewScorer(h.logger, h.db, h.ranker)
+			rankedStream := streaming.NewRankingStream(rankingWindow, func(matches result.Matches) []float64 {
+				return scorer.Score(ctx, matches)
+			}, eventHandler)
+			defer rankedStream.Done()
+
+			return h.searchClient.Execute(ctx, rankedStream, inputs)
+		}
+
 		batchedStream := streaming.NewBatchingStream(50*time.Millisecond, eventHandler)
 		defer batchedStream.Done()
 
@@ -229,6 +248,10 @@ type args struct {
 	EnableChunkMatches bool
 	SearchMode         int
 
+	// Ranked buffers results for up to rankingWindow and sends them ordered
+	// by relevance instead of in the order they were found.
+	Ranked bool
+
 	// Optional decoration parameters for server-side rendering a result set
 	// or subset. Decorations may specify, e.g., highlighting results with
 	// HTML markup up-front, and/or including context lines around file results.
@@ -273,6 +296,11 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.Errorf("search mode must be integer, got %q: %w", searchMode, err)
 	}
 
+	ranked := get("ranked", "f")
+	if a.Ranked, err = strconv.ParseBool(ranked); err != nil {
+		return nil, errors.Errorf("ranked must be parseable as a boolean, got %q: %w", ranked, err)
+	}
+
 	decorationLimit := get("dl", "0")
 	if a.DecorationLimit, err = strconv.Atoi(decorationLimit); err != nil {
 		return nil, errors.Errorf("decorationLimit must be an integer, got %q: %w", decorationLimit, err)
@@ -301,6 +329,8 @@ func fromMatch(match result.Match, repoCache map[api.RepoID]*types.SearchedRepo,
 		return fromRepository(v, repoCache)
 	case *result.CommitMatch:
 		return fromCommit(v, repoCache)
+	case *result.OwnerMatch:
+		return fromOwner(v)
 	default:
 		panic(fmt.Sprintf("unknown match type %T", v))
 	}
@@ -487,6 +517,19 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	return repoEvent
 }
 
+func fromOwner(owner *result.OwnerMatch) *streamhttp.EventOwnerMatch {
+	ownerType := "person"
+	if owner.IsTeam() {
+		ownerType = "team"
+	}
+	return &streamhttp.EventOwnerMatch{
+		Type:      streamhttp.OwnerMatchType,
+		Handle:    owner.Handle,
+		Email:     owner.Email,
+		OwnerType: ownerType,
+	}
+}
+
 func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.SearchedRepo) *streamhttp.EventCommitMatch {
 	hls := commit.Body().ToHighlightedString()
 	ranges := make([][3]int32, len(hls.Highlights))
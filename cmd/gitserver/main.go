@@ -192,6 +192,9 @@ func main() {
 
 	gitserver.StartClonePipeline(ctx)
 
+	relocatorWorker, relocatorResetter := gitserver.NewRelocatorWorker(ctx, observationContext)
+	go goroutine.MonitorBackgroundRoutines(ctx, relocatorWorker, relocatorResetter)
+
 	addr := os.Getenv("GITSERVER_ADDR")
 	if addr == "" {
 		port := "3178"
@@ -482,6 +485,18 @@ func getVCSSyncer(
 			Client:       c.P4Client,
 			FusionConfig: configureFusionClient(c),
 		}, nil
+	case extsvc.TypeMercurial:
+		return &server.MercurialRepoSyncer{}, nil
+	case extsvc.TypeSubversion:
+		var c schema.SubversionConnection
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		return &server.SubversionRepoSyncer{
+			Layout:             c.Layout,
+			Authors:            c.Authors,
+			AuthorsEmailDomain: c.AuthorsEmailDomain,
+		}, nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if _, err := extractOptions(&c); err != nil {
@@ -529,7 +544,7 @@ func getVCSSyncer(
 		cli := rubygems.NewClient(urn, c.Repository, httpcli.ExternalDoer)
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
 	}
-	return &server.GitRepoSyncer{}, nil
+	return &server.GitRepoSyncer{PartialClone: server.PartialCloneEnabled(repo)}, nil
 }
 
 func syncSiteLevelExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
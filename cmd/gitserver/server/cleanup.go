@@ -201,6 +201,8 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 	}
 
 	repoToSize := make(map[api.RepoName]int64)
+	// replicas holds the repos stored on this instance as a replica of the instance owning them.
+	replicas := make(map[api.RepoName]struct{})
 	var wrongShardRepoCount int64
 	var wrongShardRepoSize int64
 	defer func() {
@@ -233,6 +235,12 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 			return
 		}
 
+		if !s.hostnameMatch(addr) && s.isReplicaOf(addr, gitServerAddrs) {
+			// The repo is kept up to date by the instance owning it.
+			replicas[name] = struct{}{}
+			return false, nil
+		}
+
 		if !s.hostnameMatch(addr) {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
@@ -322,6 +330,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 	}
 
 	maybeReclone := func(dir GitDir) (done bool, err error) {
+		// Replicas are cloned from the instance owning the repo, not from the code host.
+		if _, ok := replicas[s.name(dir)]; ok {
+			return false, nil
+		}
+
 		repoType, err := getRepositoryType(dir)
 		if err != nil {
 			return false, err
@@ -481,6 +494,9 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitS
 		// happen if several git-gc operations are running at the same time.
 		// We only disable if sg is managing gc.
 		{"auto gc config", ensureAutoGC},
+		// Partial clones accumulate the blobs fetched on demand. Remove them once they
+		// exceed the configured limit.
+		{"trim partial clone", s.maybeTrimPartialClone},
 	}
 
 	if gitGCMode == gitGCModeJanitorAutoGC {
@@ -219,6 +219,32 @@ func TestCleanupWrongShard(t *testing.T) {
 			t.Error("expected repoD assigned to different shard to be removed")
 		}
 	})
+	t.Run("replica", func(t *testing.T) {
+		root := t.TempDir()
+		// should be allocated to shard gitserver-1
+		testRepoD := "testrepo-D"
+
+		repoD := path.Join(root, testRepoD, ".git")
+		cmdD := exec.Command("git", "--bare", "init", repoD)
+		if err := cmdD.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		s := &Server{ReposDir: root,
+			Logger: logtest.Scoped(t),
+			DB:     database.NewMockDB(),
+		}
+		s.testSetup(t)
+		s.Hostname = "gitserver-0"
+		s.cleanupRepos(context.Background(), gitserver.GitServerAddresses{
+			Addresses:         []string{"gitserver-0.cluster.local:3178", "gitserver-1.cluster.local:3178"},
+			ReplicationFactor: 2,
+		})
+
+		if _, err := os.Stat(repoD); err != nil {
+			t.Error("expected replica of repoD not to be removed", err)
+		}
+	})
 	t.Run("cleanupDisabled", func(t *testing.T) {
 		root := t.TempDir()
 		// should be allocated to shard gitserver-1
@@ -0,0 +1,469 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Partial clones are fetched with --filter=blob:none, so they only contain commits and trees.
+// The code host is registered as the promisor remote "origin" without a URL, since we don't
+// store remote URLs on disk. Git can therefore not fetch missing blobs by itself: gitserver
+// fetches them before running the commands reading them, passing the URL on the command line
+// like for regular fetches.
+
+var (
+	lazyFetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_gitserver_lazy_blob_fetch_duration_seconds",
+		Help:    "Time taken to fetch the missing blobs of partial clones before serving a request.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"source", "success"})
+	lazyFetchBlobs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_lazy_blob_fetch_blobs_total",
+		Help: "Number of missing blobs of partial clones requested from code hosts.",
+	}, []string{"source"})
+	partialCloneTrims = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_partial_clone_trims_total",
+		Help: "Number of partial clones whose blobs were removed because they exceeded the size limit.",
+	}, []string{"success"})
+)
+
+// PartialCloneEnabled returns true if repo is configured to be cloned without blobs.
+func PartialCloneEnabled(repo api.RepoName) bool {
+	cfg := conf.Get().ExperimentalFeatures
+	if cfg == nil || cfg.GitServerPartialClones == nil {
+		return false
+	}
+	repo = protocol.NormalizeRepo(repo)
+	for _, name := range cfg.GitServerPartialClones.Repos {
+		if protocol.NormalizeRepo(api.RepoName(name)) == repo {
+			return true
+		}
+	}
+	return false
+}
+
+// partialCloneBlobsSizeLimit returns the size in bytes above which the blobs of partial clones
+// are trimmed, or 0 if there is no limit.
+func partialCloneBlobsSizeLimit() int64 {
+	cfg := conf.Get().ExperimentalFeatures
+	if cfg == nil || cfg.GitServerPartialClones == nil {
+		return 0
+	}
+	return int64(cfg.GitServerPartialClones.BlobsSizeLimitMB) * 1024 * 1024
+}
+
+// configurePartialClone turns the freshly initialized repository at dir into a partial clone.
+func configurePartialClone(dir GitDir) error {
+	for _, kv := range [][2]string{
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", "origin"},
+		{"remote.origin.promisor", "true"},
+		{"remote.origin.partialclonefilter", "blob:none"},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isPartialClone returns true if dir is a partial clone. It reads the git config directly
+// instead of running git since it is called for every exec request.
+func isPartialClone(dir GitDir) bool {
+	b, err := os.ReadFile(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+	return bytes.Contains(bytes.ToLower(b), []byte("partialclone = origin"))
+}
+
+// partialCloneFetchCmd returns the command fetching the commits and trees of a partial clone.
+func partialCloneFetchCmd(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	refspecs := defaultRefspecs
+	if useRefspecOverrides() {
+		refspecs = refspecOverrides
+	}
+	args := append([]string{
+		"-c", "remote.origin.url=" + remoteURL.String(),
+		"fetch", "--no-auto-gc", "--progress", "--prune", "--filter=blob:none", "origin",
+	}, refspecs...)
+	return exec.CommandContext(ctx, "git", args...)
+}
+
+// prefetchBlobs fetches the blobs of the partial clone at dir which are read by the git
+// command args. It only knows about the commands reading blobs which are served by gitserver,
+// other commands fail on missing blobs.
+func (s *Server) prefetchBlobs(ctx context.Context, repo api.RepoName, dir GitDir, args []string, stdin []byte) error {
+	if len(args) == 0 {
+		return nil
+	}
+
+	var (
+		blobs  []string
+		source = args[0]
+		err    error
+	)
+	switch args[0] {
+	case "archive":
+		treeish, pathspecs := archiveTreeish(args)
+		if treeish == "" {
+			return nil
+		}
+		blobs, err = missingBlobs(ctx, dir, treeish, pathspecs)
+	case "cat-file":
+		blobs, err = catFileObjects(ctx, dir, args, stdin)
+	default:
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.fetchBlobs(ctx, repo, dir, source, blobs)
+}
+
+// prefetchDiffBlobs fetches the blobs of the partial clone at dir which are needed to compute
+// the diffs of commits. The blobs of all commits are fetched at once, so that a diff search
+// doesn't run a fetch for every commit it reads.
+func (s *Server) prefetchDiffBlobs(ctx context.Context, repo api.RepoName, dir GitDir, commits [][]byte) error {
+	if len(commits) == 0 {
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "diff-tree", "--stdin", "-r", "--root", "--no-commit-id", "--no-renames")
+	dir.Set(cmd)
+	cmd.Stdin = bytes.NewReader(append(bytes.Join(commits, []byte("\n")), '\n'))
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "listing blobs of diffs")
+	}
+
+	// Lines have the format ":<old mode> <new mode> <old blob> <new blob> <status>\t<path>".
+	var blobs []string
+	seen := make(map[string]struct{})
+	for _, line := range strings.Split(string(out), "\n") {
+		meta, _, _ := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if len(fields) < 4 {
+			continue
+		}
+		for _, oid := range fields[2:4] {
+			if strings.Trim(oid, "0") == "" {
+				continue
+			}
+			if _, ok := seen[oid]; ok {
+				continue
+			}
+			seen[oid] = struct{}{}
+			blobs = append(blobs, oid)
+		}
+	}
+	return s.fetchBlobs(ctx, repo, dir, "search", blobs)
+}
+
+// fetchBlobs fetches the given blobs from the code host. Git skips the fetch if all of them
+// are present already.
+func (s *Server) fetchBlobs(ctx context.Context, repo api.RepoName, dir GitDir, source string, blobs []string) (err error) {
+	if len(blobs) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		lazyFetchDuration.WithLabelValues(source, strconv.FormatBool(err == nil)).Observe(time.Since(start).Seconds())
+	}()
+	lazyFetchBlobs.WithLabelValues(source).Add(float64(len(blobs)))
+
+	// We may be fetching from a private repo so we need an internal actor.
+	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		return errors.Wrap(err, "get remote URL")
+	}
+
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "remote.origin.url="+remoteURL.String(),
+		"fetch", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", "--filter=blob:none", "--stdin", "origin")
+	dir.Set(cmd)
+	cmd.Stdin = strings.NewReader(strings.Join(blobs, "\n") + "\n")
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch missing blobs with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// archiveTreeish returns the tree-ish and pathspecs of the git archive command args, which
+// separate them with "--" like the requests built by handleArchive.
+func archiveTreeish(args []string) (treeish string, pathspecs []string) {
+	for i := 2; i < len(args); i++ {
+		if args[i] == "--" {
+			return args[i-1], args[i+1:]
+		}
+	}
+	return "", nil
+}
+
+// missingBlobs returns the blobs below pathspecs in treeish which are missing in dir.
+func missingBlobs(ctx context.Context, dir GitDir, treeish string, pathspecs []string) ([]string, error) {
+	args := append([]string{"rev-list", "--objects", "--missing=print", treeish + "^{tree}", "--"}, pathspecs...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "listing missing blobs")
+	}
+
+	var blobs []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "?") {
+			blobs = append(blobs, strings.TrimSpace(line[1:]))
+		}
+	}
+	return blobs, nil
+}
+
+// catFileObjects returns the objects read by the git cat-file command args. Objects given as
+// <rev>:<path> are only returned if they are missing.
+func catFileObjects(ctx context.Context, dir GitDir, args []string, stdin []byte) ([]string, error) {
+	var specs []string
+	batch := false
+	for _, arg := range args[1:] {
+		switch {
+		case strings.HasPrefix(arg, "--batch"):
+			batch = true
+		case strings.HasPrefix(arg, "-"):
+		case arg == "blob" || arg == "tree" || arg == "commit" || arg == "tag":
+			// Object type expected by cat-file <type> <object>.
+		default:
+			specs = append(specs, arg)
+		}
+	}
+	if batch {
+		sc := bufio.NewScanner(bytes.NewReader(stdin))
+		for sc.Scan() {
+			if fields := strings.Fields(sc.Text()); len(fields) > 0 {
+				specs = append(specs, fields[0])
+			}
+		}
+	}
+
+	var objects []string
+	for _, spec := range specs {
+		if rev, path, ok := strings.Cut(spec, ":"); ok && rev != "" {
+			blobs, err := missingBlobs(ctx, dir, rev, []string{path})
+			if err != nil {
+				return nil, err
+			}
+			objects = append(objects, blobs...)
+			continue
+		}
+		// Other specs name objects which are either present or fetched directly.
+		cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--end-of-options", spec)
+		dir.Set(cmd)
+		out, err := cmd.Output()
+		if err != nil {
+			// Unknown objects are reported by cat-file itself.
+			continue
+		}
+		objects = append(objects, strings.TrimSpace(string(out)))
+	}
+	return objects, nil
+}
+
+// maybeTrimPartialClone removes the blobs of the partial clone at dir, except the ones of HEAD,
+// if their size exceeds the configured limit. Removed blobs are fetched again when read.
+func (s *Server) maybeTrimPartialClone(dir GitDir) (done bool, err error) {
+	limit := partialCloneBlobsSizeLimit()
+	if limit <= 0 || !isPartialClone(dir) {
+		return false, nil
+	}
+	// Blobs can't be larger than the repository, which is cheaper to measure.
+	if dirSize(dir.Path("objects")) <= limit {
+		return false, nil
+	}
+	size, err := blobsSize(dir)
+	if err != nil {
+		return false, err
+	}
+	if size <= limit {
+		return false, nil
+	}
+
+	repo := s.name(dir)
+	logger := s.Logger.Scoped("maybeTrimPartialClone", "").With(log.String("repo", string(repo)))
+
+	// Neither clones nor fetches may run while the objects are repacked.
+	lock, ok := s.locker.TryAcquire(dir, "trimming blobs")
+	if !ok {
+		return false, nil
+	}
+	defer lock.Release()
+	mu := s.repoUpdateMutex(repo)
+	if !mu.TryLock() {
+		return false, nil
+	}
+	defer mu.Unlock()
+
+	defer func() {
+		partialCloneTrims.WithLabelValues(strconv.FormatBool(err == nil)).Inc()
+	}()
+
+	ctx, cancel := context.WithTimeout(s.ctx, conf.GitLongCommandTimeout())
+	defer cancel()
+
+	if err := trimPartialCloneBlobs(ctx, dir); err != nil {
+		return false, err
+	}
+
+	logger.Info("trimmed blobs of partial clone", log.Int64("blobsSize", size), log.Int64("limit", limit))
+	return false, nil
+}
+
+// trimPartialCloneBlobs repacks the partial clone at dir in place without its blobs, except the
+// ones of HEAD.
+//
+// Like git repack -a -d, the new packs are written next to the existing objects, which are only
+// removed once the new packs are complete, so that commands reading the repository meanwhile
+// keep working. The new packs are marked as promisor packs, since their trees reference blobs
+// that are only available from the code host.
+func trimPartialCloneBlobs(ctx context.Context, dir GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "HEAD^{commit}")
+	dir.Set(cmd)
+	head, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "resolving HEAD")
+	}
+
+	// Keep the blobs of HEAD which are present in the repository.
+	cmd = exec.CommandContext(ctx, "git", "rev-list", "--objects", "--missing=print", strings.TrimSpace(string(head))+"^{tree}")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "listing blobs of HEAD")
+	}
+	var keep []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if oid, _, _ := strings.Cut(line, " "); oid != "" && !strings.HasPrefix(oid, "?") {
+			keep = append(keep, oid)
+		}
+	}
+
+	packDir := dir.Path("objects", "pack")
+	oldPacks, err := filepath.Glob(filepath.Join(packDir, "pack-*.pack"))
+	if err != nil {
+		return err
+	}
+
+	newPacks := make(map[string]struct{}, 2)
+	pack := func(stdin string, args ...string) error {
+		// pack-objects only filters objects written to stdout, the pack is indexed and
+		// marked as promisor pack by index-pack.
+		packCmd := exec.CommandContext(ctx, "git", append([]string{"pack-objects", "--stdout", "-q"}, args...)...)
+		dir.Set(packCmd)
+		packCmd.Stdin = strings.NewReader(stdin)
+		var stderr bytes.Buffer
+		packCmd.Stderr = &stderr
+		packOut, err := packCmd.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		if err := packCmd.Start(); err != nil {
+			return errors.Wrap(err, "packing objects")
+		}
+
+		indexCmd := exec.CommandContext(ctx, "git", "index-pack", "--stdin", "--promisor")
+		dir.Set(indexCmd)
+		indexCmd.Stdin = packOut
+		out, indexErr := indexCmd.Output()
+		if err := packCmd.Wait(); err != nil {
+			return errors.Wrapf(err, "packing objects failed with stderr %q", stderr.String())
+		}
+		if indexErr != nil {
+			return errors.Wrap(wrapCmdError(indexCmd, indexErr), "indexing pack")
+		}
+
+		// index-pack prints "pack\t<hash>".
+		_, hash, _ := strings.Cut(strings.TrimSpace(string(out)), "\t")
+		newPacks[filepath.Join(packDir, "pack-"+hash+".pack")] = struct{}{}
+		return nil
+	}
+	// Commits, trees and tags reachable from any ref.
+	if err := pack("", "--revs", "--all", "--filter=blob:none", "--missing=allow-promisor"); err != nil {
+		return err
+	}
+	if len(keep) > 0 {
+		if err := pack(strings.Join(keep, "\n")+"\n", "--missing=allow-promisor"); err != nil {
+			return err
+		}
+	}
+
+	// The multi-pack index references the packs removed below.
+	if err := os.Remove(filepath.Join(packDir, "multi-pack-index")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for _, oldPack := range oldPacks {
+		if _, ok := newPacks[oldPack]; ok {
+			continue
+		}
+		base := strings.TrimSuffix(oldPack, ".pack")
+		if _, err := os.Stat(base + ".keep"); err == nil {
+			continue
+		}
+		// The index is removed first, so that git no longer considers the pack.
+		for _, ext := range []string{".idx", ".pack", ".rev", ".bitmap", ".promisor"} {
+			if err := os.Remove(base + ext); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+
+	// Loose objects are either packed above or blobs which are trimmed.
+	loose, err := filepath.Glob(dir.Path("objects", "[0-9a-f][0-9a-f]"))
+	if err != nil {
+		return err
+	}
+	for _, d := range loose {
+		if err := os.RemoveAll(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// blobsSize returns the size on disk of the blobs stored in dir.
+func blobsSize(dir GitDir) (int64, error) {
+	cmd := exec.Command("git", "cat-file", "--batch-all-objects", "--batch-check=%(objecttype) %(objectsize:disk)")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, errors.Wrap(wrapCmdError(cmd, err), "listing objects")
+	}
+
+	var size int64
+	for _, line := range strings.Split(string(out), "\n") {
+		typ, n, ok := strings.Cut(line, " ")
+		if !ok || typ != "blob" {
+			continue
+		}
+		if v, err := strconv.ParseInt(n, 10, 64); err == nil {
+			size += v
+		}
+	}
+	return size, nil
+}
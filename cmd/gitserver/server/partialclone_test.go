@@ -0,0 +1,327 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/gitservice"
+)
+
+func TestArchiveTreeish(t *testing.T) {
+	tests := []struct {
+		args          []string
+		wantTreeish   string
+		wantPathspecs []string
+	}{
+		{
+			args:          []string{"archive", "--format=zip", "HEAD", "--"},
+			wantTreeish:   "HEAD",
+			wantPathspecs: []string{},
+		},
+		{
+			args:          []string{"archive", "--worktree-attributes", "--format=tar", "abc123", "--", "dir", "file.go"},
+			wantTreeish:   "abc123",
+			wantPathspecs: []string{"dir", "file.go"},
+		},
+		{
+			args: []string{"archive", "HEAD"},
+		},
+	}
+	for _, test := range tests {
+		treeish, pathspecs := archiveTreeish(test.args)
+		if treeish != test.wantTreeish {
+			t.Errorf("archiveTreeish(%q): got tree-ish %q, want %q", test.args, treeish, test.wantTreeish)
+		}
+		if diff := cmp.Diff(test.wantPathspecs, pathspecs); diff != "" {
+			t.Errorf("archiveTreeish(%q): unexpected pathspecs (-want +got):\n%s", test.args, diff)
+		}
+	}
+}
+
+func TestPartialClone(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	remote := filepath.Join(root, "remote")
+	runCmd(t, root, "git", "init", remote)
+	runCmd(t, remote, "git", "config", "uploadpack.allowFilter", "true")
+	runCmd(t, remote, "git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	runCmd(t, remote, "sh", "-c", "echo a > a.txt && mkdir b && echo c > b/c.txt")
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "initial")
+
+	if isPartialClone(GitDir(filepath.Join(remote, ".git"))) {
+		t.Fatal("full clone reported as partial clone")
+	}
+
+	clone := filepath.Join(root, "clone")
+	runCmd(t, root, "git", "init", "--bare", clone)
+	dir := GitDir(clone)
+	if err := configurePartialClone(dir); err != nil {
+		t.Fatal(err)
+	}
+	if !isPartialClone(dir) {
+		t.Fatal("partial clone not detected")
+	}
+	runCmd(t, clone, "git", "-c", "remote.origin.url="+remote, "fetch", "--filter=blob:none", "origin", "+refs/heads/*:refs/heads/*")
+
+	blobID := func(path string) string {
+		return strings.TrimSpace(runCmd(t, remote, "git", "rev-parse", "HEAD:"+path))
+	}
+
+	blobs, err := missingBlobs(ctx, dir, "HEAD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(blobs)
+	want := []string{blobID("a.txt"), blobID("b/c.txt")}
+	sort.Strings(want)
+	if diff := cmp.Diff(want, blobs); diff != "" {
+		t.Fatalf("unexpected missing blobs (-want +got):\n%s", diff)
+	}
+
+	objects, err := catFileObjects(ctx, dir, []string{"cat-file", "-p", "HEAD:b/c.txt"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{blobID("b/c.txt")}, objects); diff != "" {
+		t.Fatalf("unexpected cat-file objects (-want +got):\n%s", diff)
+	}
+
+	objects, err = catFileObjects(ctx, dir, []string{"cat-file", "--batch"}, []byte("HEAD:a.txt\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{blobID("a.txt")}, objects); diff != "" {
+		t.Fatalf("unexpected cat-file --batch objects (-want +got):\n%s", diff)
+	}
+
+	// Once fetched, blobs are no longer reported as missing.
+	cmd := exec.Command("git", "-c", "remote.origin.url="+remote, "fetch", "--no-tags", "--no-write-fetch-head", "--filter=blob:none", "--stdin", "origin")
+	cmd.Dir = clone
+	cmd.Stdin = strings.NewReader(blobID("a.txt") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("fetching blob failed: %s\nOutput: %s", err, out)
+	}
+	blobs, err = missingBlobs(ctx, dir, "HEAD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{blobID("b/c.txt")}, blobs); diff != "" {
+		t.Fatalf("unexpected missing blobs after fetch (-want +got):\n%s", diff)
+	}
+
+	size, err := blobsSize(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size <= 0 {
+		t.Fatalf("expected fetched blob to be counted, got size %d", size)
+	}
+}
+
+// newPartialCloneRemote returns a repository serving blobs to partial clones, with a
+// commit adding a.txt followed by a commit changing a.txt and adding b.txt.
+func newPartialCloneRemote(t *testing.T, root string) string {
+	t.Helper()
+	remote := filepath.Join(root, "remote")
+	runCmd(t, root, "git", "init", remote)
+	runCmd(t, remote, "git", "config", "uploadpack.allowFilter", "true")
+	runCmd(t, remote, "git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	runCmd(t, remote, "sh", "-c", "echo a > a.txt")
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "first")
+	runCmd(t, remote, "sh", "-c", "echo a2 > a.txt && echo b > b.txt")
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "second")
+	return remote
+}
+
+// newPartialClone returns a partial clone of remote without blobs.
+func newPartialClone(t *testing.T, root, remote, name string) GitDir {
+	t.Helper()
+	clone := filepath.Join(root, name)
+	runCmd(t, root, "git", "init", "--bare", clone)
+	dir := GitDir(clone)
+	if err := configurePartialClone(dir); err != nil {
+		t.Fatal(err)
+	}
+	runCmd(t, clone, "git", "-c", "remote.origin.url="+remote, "fetch", "--filter=blob:none", "origin", "+refs/heads/*:refs/heads/*")
+	return dir
+}
+
+func TestPartialCloneFromShard(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	remote := newPartialCloneRemote(t, root)
+	primary := newPartialClone(t, root, remote, "primary")
+
+	// Replicas and relocated repositories are fetched from the /git/ endpoint of the
+	// instance owning the repository.
+	srv := httptest.NewServer(http.StripPrefix("/git", &gitservice.Handler{
+		Logger: logtest.Scoped(t),
+		Dir:    func(string) string { return string(primary) },
+	}))
+	t.Cleanup(srv.Close)
+	remoteURL, err := vcs.ParseURL(srv.URL + "/git/example.com/foo/bar")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clone := func(syncer *GitRepoSyncer, name string) (GitDir, error) {
+		tmpPath := filepath.Join(root, name, ".git")
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", errors.Wrapf(err, "output: %s", out)
+		}
+		return GitDir(tmpPath), nil
+	}
+
+	// The blobs missing from the primary can't be served.
+	if _, err := clone(&GitRepoSyncer{}, "full"); err == nil {
+		t.Fatal("expected full clone of partial clone to fail")
+	}
+
+	replica, err := clone(&GitRepoSyncer{PartialClone: true}, "replica")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isPartialClone(replica) {
+		t.Fatal("replica is not a partial clone")
+	}
+	head := func(dir GitDir) string {
+		return strings.TrimSpace(runCmd(t, string(dir), "git", "rev-parse", "refs/heads/master"))
+	}
+	if head(replica) != head(primary) {
+		t.Fatalf("unexpected replica HEAD %s, want %s", head(replica), head(primary))
+	}
+
+	// Fetches of the replica keep the filter.
+	runCmd(t, remote, "sh", "-c", "echo c > c.txt")
+	runCmd(t, remote, "git", "add", ".")
+	runCmd(t, remote, "git", "commit", "-m", "third")
+	runCmd(t, string(primary), "git", "-c", "remote.origin.url="+remote, "fetch", "--filter=blob:none", "origin", "+refs/heads/*:refs/heads/*")
+	if err := (&GitRepoSyncer{}).Fetch(ctx, remoteURL, replica, ""); err != nil {
+		t.Fatal(err)
+	}
+	if head(replica) != head(primary) {
+		t.Fatalf("unexpected replica HEAD %s after fetch, want %s", head(replica), head(primary))
+	}
+}
+
+func TestPrefetchDiffBlobs(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	remote := newPartialCloneRemote(t, root)
+	dir := newPartialClone(t, root, remote, "clone")
+
+	// Every fetch of missing blobs looks up the remote URL.
+	var fetches int
+	s := &Server{
+		Logger: logtest.Scoped(t),
+		GetRemoteURLFunc: func(context.Context, api.RepoName) (string, error) {
+			fetches++
+			return remote, nil
+		},
+	}
+
+	mt, err := search.ToMatchTree(&protocol.DiffMatches{Expr: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	searcher := &search.CommitSearcher{
+		Logger:      logtest.Scoped(t),
+		RepoName:    "example.com/foo/bar",
+		RepoDir:     dir.Path(),
+		Query:       mt,
+		IncludeDiff: true,
+		PrefetchDiffs: func(ctx context.Context, commits [][]byte) error {
+			return s.prefetchDiffBlobs(ctx, "example.com/foo/bar", dir, commits)
+		},
+	}
+	var matches []*protocol.CommitMatch
+	err = searcher.Search(ctx, func(match *protocol.CommitMatch) {
+		matches = append(matches, match)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected both commits to match, got %d matches", len(matches))
+	}
+	if fetches != 1 {
+		t.Fatalf("expected the blobs of both diffs to be fetched at once, got %d fetches", fetches)
+	}
+}
+
+func TestTrimPartialCloneBlobs(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	remote := newPartialCloneRemote(t, root)
+	dir := newPartialClone(t, root, remote, "clone")
+
+	blobID := func(rev string) string {
+		return strings.TrimSpace(runCmd(t, remote, "git", "rev-parse", rev))
+	}
+	oldBlob := blobID("HEAD~1:a.txt")
+	headBlobs := []string{blobID("HEAD:a.txt"), blobID("HEAD:b.txt")}
+	sort.Strings(headBlobs)
+
+	// Fetch every blob, the fetch is small enough to be stored as loose objects.
+	cmd := exec.Command("git", "-c", "remote.origin.url="+remote, "fetch", "--no-tags", "--no-write-fetch-head", "--filter=blob:none", "--stdin", "origin")
+	cmd.Dir = string(dir)
+	cmd.Stdin = strings.NewReader(strings.Join(append([]string{oldBlob}, headBlobs...), "\n") + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("fetching blobs failed: %s\nOutput: %s", err, out)
+	}
+	if blobs, err := missingBlobs(ctx, dir, "HEAD~1", nil); err != nil || len(blobs) != 0 {
+		t.Fatalf("expected no missing blobs before trimming, got %v (err: %v)", blobs, err)
+	}
+
+	if err := trimPartialCloneBlobs(ctx, dir); err != nil {
+		t.Fatal(err)
+	}
+
+	blobs, err := missingBlobs(ctx, dir, "HEAD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 0 {
+		t.Fatalf("blobs of HEAD were trimmed: %v", blobs)
+	}
+	blobs, err = missingBlobs(ctx, dir, "HEAD~1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{oldBlob}, blobs); diff != "" {
+		t.Fatalf("unexpected missing blobs after trimming (-want +got):\n%s", diff)
+	}
+
+	// The repository stays consistent for the janitor jobs.
+	runCmd(t, string(dir), "git", "fsck", "--connectivity-only")
+	runCmd(t, string(dir), "git", "repack", "-a", "-d")
+	blobs, err = missingBlobs(ctx, dir, "HEAD", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blobs) != 0 {
+		t.Fatalf("blobs of HEAD were removed by repack: %v", blobs)
+	}
+}
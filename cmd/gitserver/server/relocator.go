@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	workerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// relocatorJob is a request to copy a repository from the gitserver instance that stored it
+// before the list of gitserver instances changed to the instance owning it now.
+type relocatorJob struct {
+	ID             int
+	RepoName       api.RepoName
+	SourceHostname string
+	DestHostname   string
+}
+
+// RecordID implements workerutil.Record.
+func (j *relocatorJob) RecordID() int {
+	return j.ID
+}
+
+var relocatorJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("id"),
+	sqlf.Sprintf("repo_name"),
+	sqlf.Sprintf("source_hostname"),
+	sqlf.Sprintf("dest_hostname"),
+}
+
+func scanRelocatorJob(s dbutil.Scanner) (*relocatorJob, error) {
+	var job relocatorJob
+	if err := s.Scan(&job.ID, &job.RepoName, &job.SourceHostname, &job.DestHostname); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// NewRelocatorWorker returns a worker processing the relocation jobs enqueued by syncRepoState
+// for repositories owned by this instance, and the resetter of stalled jobs.
+func (s *Server) NewRelocatorWorker(ctx context.Context, observationContext *observation.Context) (*workerutil.Worker, *dbworker.Resetter) {
+	logger := s.Logger.Scoped("relocator", "copies repositories from their previous gitserver instance")
+
+	store := workerstore.New(logger, s.DB.Handle(), workerstore.Options{
+		Name:              "gitserver_relocator_worker_store",
+		TableName:         "gitserver_relocator_jobs",
+		ViewName:          "gitserver_relocator_jobs_with_repo_name",
+		Scan:              workerstore.BuildWorkerScan(scanRelocatorJob),
+		OrderByExpression: sqlf.Sprintf("id"),
+		ColumnExpressions: relocatorJobColumns,
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      5,
+		MaxNumRetries:     5,
+	})
+
+	worker := dbworker.NewWorker(ctx, store, &relocatorHandler{s: s}, workerutil.WorkerOptions{
+		Name:              "gitserver_relocator_worker",
+		NumHandlers:       3,
+		Interval:          10 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationContext, "gitserver_relocator_processor"),
+	})
+
+	resetter := dbworker.NewResetter(logger, store, dbworker.ResetterOptions{
+		Name:     "gitserver_relocator_resetter",
+		Interval: time.Minute,
+		Metrics:  *dbworker.NewMetrics(observationContext, "gitserver_relocator"),
+	})
+
+	return worker, resetter
+}
+
+type relocatorHandler struct {
+	s *Server
+}
+
+var _ workerutil.WithPreDequeue = &relocatorHandler{}
+
+// PreDequeue only dequeues the jobs copying repositories to this instance.
+func (h *relocatorHandler) PreDequeue(ctx context.Context, logger log.Logger) (bool, any, error) {
+	for _, addr := range currentGitserverAddresses().Addresses {
+		if h.s.hostnameMatch(addr) {
+			return true, []*sqlf.Query{sqlf.Sprintf("dest_hostname = %s", addr)}, nil
+		}
+	}
+	return false, nil, nil
+}
+
+func (h *relocatorHandler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) error {
+	job := record.(*relocatorJob)
+	if repoCloned(h.s.dir(job.RepoName)) {
+		return nil
+	}
+
+	// The repository is cloned with its own syncer, so partial clones are copied without
+	// their blobs like the source instance serves them.
+	progress, err := h.s.cloneRepo(ctx, job.RepoName, &cloneOptions{
+		Block:          true,
+		CloneFromShard: "http://" + job.SourceHostname,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "copying repo from %s", job.SourceHostname)
+	}
+	if progress != "" {
+		// Another clone of the repository is in progress, the job is retried until it has
+		// finished.
+		return errors.Errorf("clone in progress: %s", progress)
+	}
+	return nil
+}
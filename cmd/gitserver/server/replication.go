@@ -0,0 +1,228 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/fileutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	replicaUpdatesRequested = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_replica_updates_requested_total",
+		Help: "Number of replica updates requested by the gitserver instance owning a repository.",
+	}, []string{"success"})
+	replicaUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_replica_updates_total",
+		Help: "Number of replicas cloned or fetched from the gitserver instance owning the repository.",
+	}, []string{"success"})
+)
+
+// replicaRequestDoer is the HTTP client used to send replica updates to other gitserver
+// instances. It is a variable so that tests can replace it.
+var replicaRequestDoer httpcli.Doer = httpcli.InternalDoer
+
+// isReplicaOf returns true if this instance holds a replica of the repositories owned by the
+// gitserver instance at primary.
+func (s *Server) isReplicaOf(primary string, gitServerAddrs gitserver.GitServerAddresses) bool {
+	for _, addr := range gitserver.ReplicaAddrs(primary, gitServerAddrs.Addresses, gitServerAddrs.ReplicationFactor) {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// replicateRepo asks the replicas of repo to fetch it from this instance. It is called after every
+// successful clone or fetch, does nothing if this instance doesn't own repo and doesn't wait for
+// the replicas to be updated. A replica which fails to update is brought up to date by the next
+// fetch of the repository.
+func (s *Server) replicateRepo(repo api.RepoName) {
+	gitServerAddrs := currentGitserverAddresses()
+	if gitServerAddrs.ReplicationFactor <= 1 {
+		return
+	}
+
+	ctx, cancel := s.serverContext()
+	go func() {
+		defer cancel()
+
+		ctx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+		defer cancel()
+
+		logger := s.Logger.Scoped("replicateRepo", "").With(log.String("repo", string(repo)))
+
+		addr, err := s.addrForRepo(ctx, repo, gitServerAddrs)
+		if err != nil {
+			logger.Warn("failed to get server address for repo", log.Error(err))
+			return
+		}
+		if !s.hostnameMatch(addr) {
+			return
+		}
+
+		for _, replica := range gitserver.ReplicaAddrs(addr, gitServerAddrs.Addresses, gitServerAddrs.ReplicationFactor) {
+			err := requestReplicaUpdate(ctx, repo, addr, replica)
+			if err != nil {
+				logger.Warn("failed to update replica", log.String("replica", replica), log.Error(err))
+			}
+			replicaUpdatesRequested.WithLabelValues(strconv.FormatBool(err == nil)).Inc()
+		}
+	}()
+}
+
+// requestReplicaUpdate sends a request to the gitserver instance at replica to fetch repo from the
+// gitserver instance at primary.
+func requestReplicaUpdate(ctx context.Context, repo api.RepoName, primary, replica string) error {
+	b, err := json.Marshal(&protocol.RepoUpdateRequest{
+		Repo:           repo,
+		CloneFromShard: "http://" + primary,
+		Replicate:      true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", "http://"+replica+"/repo-update", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Set header so that the replica knows the request is from us.
+	req.Header.Set("X-Requested-With", "Sourcegraph")
+
+	resp, err := replicaRequestDoer.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("http status %d", resp.StatusCode)
+	}
+
+	var info protocol.RepoUpdateResponse
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return err
+	}
+	if info.Error != "" {
+		return errors.New(info.Error)
+	}
+	return nil
+}
+
+// updateReplica clones or fetches the replica of repo from the gitserver instance owning it.
+//
+// Unlike a regular clone or fetch, the state of the repository in the database is left untouched
+// since it describes the copy of the instance owning the repository.
+func (s *Server) updateReplica(ctx context.Context, repo api.RepoName, primary string) (err error) {
+	defer func() {
+		replicaUpdates.WithLabelValues(strconv.FormatBool(err == nil)).Inc()
+	}()
+
+	if s.hostnameMatch(strings.TrimPrefix(primary, "http://")) {
+		return errors.Errorf("cannot replicate from the same gitserver instance")
+	}
+
+	remoteURL, err := vcs.ParseURL(primary)
+	if err != nil {
+		return err
+	}
+	remoteURL = remoteURL.JoinPath("git", string(repo))
+
+	dir := s.dir(repo)
+	lock, ok := s.locker.TryAcquire(dir, "updating replica")
+	if !ok {
+		// A clone or another replica update is in progress, the next fetch on the
+		// owning instance will request another update.
+		return nil
+	}
+	defer lock.Release()
+
+	// The syncer of the repository is only used for the repository type, the owning instance
+	// always serves the repository over git. Partial clones are served without their blobs,
+	// so they are replicated as partial clones too.
+	repoSyncer, err := s.GetVCSSyncer(ctx, repo)
+	if err != nil {
+		return errors.Wrap(err, "get VCS syncer")
+	}
+	syncer := &GitRepoSyncer{PartialClone: PartialCloneEnabled(repo)}
+	logger := s.Logger.Scoped("updateReplica", "").With(log.String("repo", string(repo)))
+
+	if repoCloned(dir) {
+		defer s.cleanTmpFiles(dir)
+
+		if err := syncer.Fetch(ctx, remoteURL, dir, ""); err != nil {
+			return errors.Wrapf(err, "failed to fetch replica of repo %q", repo)
+		}
+		removeBadRefs(ctx, dir)
+		if err := setHEAD(ctx, logger, dir, syncer, remoteURL); err != nil {
+			return errors.Wrapf(err, "failed to ensure HEAD exists for replica of repo %q", repo)
+		}
+		if err := setLastChanged(logger, dir); err != nil {
+			logger.Warn("failed to update last changed time", log.Error(err))
+		}
+		return nil
+	}
+
+	// We clone to a temporary location first to avoid having incomplete clones in the repo tree.
+	tmpPath, err := s.tempDir("replica-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpPath)
+	tmpPath = filepath.Join(tmpPath, ".git")
+	tmp := GitDir(tmpPath)
+
+	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "get clone command")
+	}
+	if output, err := runWith(ctx, cmd, true, nil); err != nil {
+		return errors.Wrapf(err, "replica clone failed. Output: %s", string(output))
+	}
+
+	removeBadRefs(ctx, tmp)
+	if err := setHEAD(ctx, logger, tmp, syncer, remoteURL); err != nil {
+		return errors.Wrap(err, "failed to ensure HEAD exists")
+	}
+	if err := setRepositoryType(tmp, repoSyncer.Type()); err != nil {
+		return errors.Wrap(err, `git config set "sourcegraph.type"`)
+	}
+	if err := setLastChanged(logger, tmp); err != nil {
+		return errors.Wrap(err, "failed to update last changed time")
+	}
+	if err := setGitAttributes(tmp); err != nil {
+		return err
+	}
+	if err := gitSetAutoGC(tmp); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(string(dir)), os.ModePerm); err != nil {
+		return err
+	}
+	if err := fileutil.RenameAndSync(tmpPath, string(dir)); err != nil {
+		return err
+	}
+
+	logger.Info("replica cloned", log.String("primary", primary))
+	return nil
+}
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+func TestRequestReplicaUpdate(t *testing.T) {
+	t.Cleanup(func() { replicaRequestDoer = httpcli.InternalDoer })
+
+	var got protocol.RepoUpdateRequest
+	respBody := "{}"
+	replicaRequestDoer = httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.String() != "http://gitserver-1:3178/repo-update" {
+			t.Fatalf("unexpected URL: %q", r.URL.String())
+		}
+		if r.Header.Get("X-Requested-With") != "Sourcegraph" {
+			t.Fatal("expected X-Requested-With header to be set")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBufferString(respBody)),
+		}, nil
+	})
+
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	if err := requestReplicaUpdate(context.Background(), repo, "gitserver-0:3178", "gitserver-1:3178"); err != nil {
+		t.Fatal(err)
+	}
+	want := protocol.RepoUpdateRequest{Repo: repo, CloneFromShard: "http://gitserver-0:3178", Replicate: true}
+	if got != want {
+		t.Fatalf("unexpected request, want %+v, got %+v", want, got)
+	}
+
+	// Errors reported in-band by the replica are returned.
+	respBody = `{"Error": "failed to fetch"}`
+	if err := requestReplicaUpdate(context.Background(), repo, "gitserver-0:3178", "gitserver-1:3178"); err == nil {
+		t.Fatal("expected error, got none")
+	}
+}
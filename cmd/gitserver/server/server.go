@@ -598,6 +598,8 @@ func currentGitserverAddresses() gitserver.GitServerAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		gitServerAddrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		gitServerAddrs.ReplicationFactor = cfg.ExperimentalFeatures.GitServerReplicationFactor
+		gitServerAddrs.Rebalancing = cfg.ExperimentalFeatures.GitServerRebalancing
 	}
 
 	return gitServerAddrs
@@ -679,15 +681,20 @@ func (s *Server) cloneJobConsumer(ctx context.Context, jobs <-chan *cloneJob) {
 // hostnameMatch checks whether the hostname matches the given address.
 // If we don't find an exact match, we look at the initial prefix.
 func (s *Server) hostnameMatch(addr string) bool {
-	if !strings.HasPrefix(addr, s.Hostname) {
+	return hostnameMatch(s.Hostname, addr)
+}
+
+// hostnameMatch checks whether hostname matches the given address.
+func hostnameMatch(hostname, addr string) bool {
+	if !strings.HasPrefix(addr, hostname) {
 		return false
 	}
-	if addr == s.Hostname {
+	if addr == hostname {
 		return true
 	}
-	// We know that s.Hostname is shorter than addr so we can safely check the next
+	// We know that hostname is shorter than addr so we can safely check the next
 	// char
-	next := addr[len(s.Hostname)]
+	next := addr[len(hostname)]
 	return next == '.' || next == ':'
 }
 
@@ -739,6 +746,12 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitServerAddresses, batc
 
 	ctx := s.ctx
 	store := s.DB.GitserverRepos()
+	relocator := s.DB.GitserverLocalClone()
+
+	// Repositories being relocated are still served by their previous instance, but this
+	// instance is responsible for them.
+	ownerAddrs := gitServerAddrs
+	ownerAddrs.Rebalancing = false
 
 	// The rate limit should be enforced across all instances
 	perSecond = perSecond / len(addrs)
@@ -807,7 +820,7 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitServerAddresses, batc
 			repo.Name = api.UndeletedRepoName(repo.Name)
 
 			// Ensure we're only dealing with repos we are responsible for.
-			addr, err := s.addrForRepo(ctx, repo.Name, gitServerAddrs)
+			addr, err := s.addrForRepo(ctx, repo.Name, ownerAddrs)
 			if err != nil {
 				return err
 			}
@@ -821,6 +834,18 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitServerAddresses, batc
 			cloned := repoCloned(dir)
 			_, cloning := s.locker.Status(dir)
 
+			// A repository stored by another instance is copied from there rather than
+			// recloned from the code host. The shard is only updated once the copy exists.
+			if gitServerAddrs.Rebalancing && !cloned && !cloning {
+				if source := previousShardAddr(repo.ShardID, s.Hostname, addrs); source != "" {
+					if _, err := relocator.EnqueueIfNotExists(ctx, int(repo.ID), source, addr, false); err != nil {
+						s.Logger.Error("Enqueueing relocation", log.String("repo", string(repo.Name)), log.Error(err))
+					}
+					repoSyncStateCounter.WithLabelValues("relocate").Inc()
+					continue
+				}
+			}
+
 			var shouldUpdate bool
 			if repo.ShardID != s.Hostname {
 				repo.ShardID = s.Hostname
@@ -856,6 +881,20 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitServerAddresses, batc
 	return nil
 }
 
+// previousShardAddr returns the address of the instance named by shardID, or an empty string if
+// shardID is unset, names this instance or an instance that is no longer part of addrs.
+func previousShardAddr(shardID, hostname string, addrs []string) string {
+	if shardID == "" || shardID == hostname {
+		return ""
+	}
+	for _, a := range addrs {
+		if hostnameMatch(shardID, a) {
+			return a
+		}
+	}
+	return ""
+}
+
 // Stop cancels the running background jobs and returns when done.
 func (s *Server) Stop() {
 	// idempotent so we can just always set and cancel
@@ -1016,7 +1055,14 @@ func (s *Server) handleRepoUpdate(w http.ResponseWriter, r *http.Request) {
 	defer cancel1()
 	ctx, cancel2 := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
 	defer cancel2()
-	if !repoCloned(dir) && !s.skipCloneForTests {
+	if req.Replicate && !s.skipCloneForTests {
+		// The request was sent by the gitserver instance owning the repo after it has been
+		// fetched, our copy is brought up to date from there instead of the code host.
+		if err := s.updateReplica(ctx, req.Repo, req.CloneFromShard); err != nil {
+			logger.Warn("error updating replica", log.String("repo", string(req.Repo)), log.Error(err))
+			resp.Error = err.Error()
+		}
+	} else if !repoCloned(dir) && !s.skipCloneForTests {
 		// We do not need to check if req.CloneFromShard is non-zero here since that has no effect on
 		// the code path at this point. Since the repo is already not cloned at this point, either
 		// this request was received for a repo migration or a regular clone - for both of which we
@@ -1308,6 +1354,11 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, match
 			IncludeDiff:          args.IncludeDiff,
 			IncludeModifiedFiles: args.IncludeModifiedFiles,
 		}
+		if isPartialClone(dir) {
+			searcher.PrefetchDiffs = func(ctx context.Context, commits [][]byte) error {
+				return s.prefetchDiffBlobs(ctx, args.Repo, dir, commits)
+			}
+		}
 
 		return searcher.Search(ctx, func(match *protocol.CommitMatch) {
 			select {
@@ -1697,6 +1748,12 @@ func (s *Server) exec(w http.ResponseWriter, r *http.Request, req *protocol.Exec
 	if s.ensureRevision(ctx, req.Repo, req.EnsureRevision, dir) {
 		ensureRevisionStatus = "fetched"
 	}
+	if isPartialClone(dir) {
+		// Failures are reported by the command when it reads a missing blob.
+		if err := s.prefetchBlobs(ctx, req.Repo, dir, req.Args, req.Stdin); err != nil {
+			logger.Warn("failed to fetch missing blobs", log.Error(err))
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Cache-Control", "no-cache")
@@ -2256,6 +2313,8 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir GitDir, syn
 	logger.Info("repo cloned")
 	repoClonedCounter.Inc()
 
+	s.replicateRepo(repo)
+
 	return nil
 }
 
@@ -2467,14 +2526,7 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 	}
 
 	s.repoUpdateLocksMu.Lock()
-	l, ok := s.repoUpdateLocks[repo]
-	if !ok {
-		l = &locks{
-			once: new(sync.Once),
-			mu:   new(sync.Mutex),
-		}
-		s.repoUpdateLocks[repo] = l
-	}
+	l := s.repoUpdateLocksLocked(repo)
 	once := l.once
 	mu := l.mu
 	s.repoUpdateLocksMu.Unlock()
@@ -2515,6 +2567,27 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 	}
 }
 
+// repoUpdateLocksLocked returns the locks serializing the updates of repo. The caller must hold
+// repoUpdateLocksMu.
+func (s *Server) repoUpdateLocksLocked(repo api.RepoName) *locks {
+	l, ok := s.repoUpdateLocks[repo]
+	if !ok {
+		l = &locks{
+			once: new(sync.Once),
+			mu:   new(sync.Mutex),
+		}
+		s.repoUpdateLocks[repo] = l
+	}
+	return l
+}
+
+// repoUpdateMutex returns the mutex held while repo is updated.
+func (s *Server) repoUpdateMutex(repo api.RepoName) *sync.Mutex {
+	s.repoUpdateLocksMu.Lock()
+	defer s.repoUpdateLocksMu.Unlock()
+	return s.repoUpdateLocksLocked(repo).mu
+}
+
 var doBackgroundRepoUpdateMock func(api.RepoName) error
 
 func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error {
@@ -2594,6 +2667,8 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		logger.Warn("failed to set repo size", log.Error(err))
 	}
 
+	s.replicateRepo(repo)
+
 	return nil
 }
 
@@ -1351,6 +1351,29 @@ func TestHostnameMatch(t *testing.T) {
 	}
 }
 
+func TestPreviousShardAddr(t *testing.T) {
+	addrs := []string{"gitserver-0:3178", "gitserver-1:3178", "gitserver-2:3178"}
+
+	testCases := []struct {
+		name    string
+		shardID string
+		want    string
+	}{
+		{name: "no shard", shardID: "", want: ""},
+		{name: "this instance", shardID: "gitserver-1", want: ""},
+		{name: "other instance", shardID: "gitserver-2", want: "gitserver-2:3178"},
+		{name: "removed instance", shardID: "gitserver-3", want: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if have := previousShardAddr(tc.shardID, "gitserver-1", addrs); have != tc.want {
+				t.Fatalf("Want %q, got %q", tc.want, have)
+			}
+		})
+	}
+}
+
 func TestSyncRepoState(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx, cancel := context.WithCancel(context.Background())
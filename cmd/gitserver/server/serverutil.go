@@ -214,7 +214,11 @@ func configureRemoteGitCommand(cmd *exec.Cmd, tlsConf *tlsConfig) {
 		panic(fmt.Sprintf("Only git or p4-fusion commands are supported, got %q", executable))
 	}
 
-	cmd.Env = append(cmd.Env, "GIT_ASKPASS=true") // disable password prompt
+	// Disable password prompts, unless the command answers them itself, as the
+	// SubversionRepoSyncer does.
+	if !hasEnv(cmd.Env, "GIT_ASKPASS") {
+		cmd.Env = append(cmd.Env, "GIT_ASKPASS=true")
+	}
 
 	// Suppress asking to add SSH host key to known_hosts (which will hang because
 	// the command is non-interactive).
@@ -251,6 +255,16 @@ func configureRemoteGitCommand(cmd *exec.Cmd, tlsConf *tlsConfig) {
 	cmd.Args = append(cmd.Args[:1], append(extraArgs, cmd.Args[1:]...)...)
 }
 
+// hasEnv reports whether the environment variable name is set in env.
+func hasEnv(env []string, name string) bool {
+	for _, kv := range env {
+		if strings.HasPrefix(kv, name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 // removeUnsupportedP4Args removes all -c arguments as `p4-fusion` command doesn't
 // support -c argument and passing this causes warning logs.
 func removeUnsupportedP4Args(args []string) []string {
@@ -115,6 +115,16 @@ func TestConfigureRemoteGitCommand(t *testing.T) {
 			expectedEnv:  append(expectedEnv, "GIT_SSL_CAINFO=/tmp/foo.certs"),
 			expectedArgs: []string{"git", "-c", "credential.helper=", "ls-remote"},
 		},
+		// Keep the password prompt of commands answering it
+		{
+			input: &exec.Cmd{Path: "git", Args: []string{"git", "svn", "fetch"}, Env: []string{"GIT_ASKPASS=/tmp/askpass"}},
+			expectedEnv: []string{
+				"GIT_ASKPASS=/tmp/askpass",
+				"GIT_SSH_COMMAND=ssh -o BatchMode=yes -o ConnectTimeout=30",
+				"GIT_HTTP_USER_AGENT=git/Sourcegraph-Bot",
+			},
+			expectedArgs: []string{"git", "-c", "credential.helper=", "-c", "protocol.version=2", "svn", "fetch"},
+		},
 		// Allow absolute git commands
 		{
 			input: exec.Command("/foo/bar/git", "ls-remote"),
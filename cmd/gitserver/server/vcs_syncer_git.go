@@ -10,7 +10,11 @@ import (
 )
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// PartialClone clones the repository without blobs, which are fetched when they are first
+	// read. Fetches into an existing clone follow the mode chosen when it was cloned.
+	PartialClone bool
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -54,15 +58,20 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 	if err := cmd.Run(); err != nil {
 		return nil, errors.Wrapf(err, "clone setup failed")
 	}
+	if s.PartialClone {
+		if err := configurePartialClone(GitDir(tmpPath)); err != nil {
+			return nil, errors.Wrapf(err, "clone setup failed")
+		}
+	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	cmd, _ = s.fetchCommand(ctx, remoteURL, s.PartialClone)
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, revspec string) error {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL, isPartialClone(dir))
 	dir.Set(cmd)
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
@@ -75,31 +84,38 @@ func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.UR
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
 }
 
-func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, configRemoteOpts bool) {
+func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, partialClone bool) (cmd *exec.Cmd, configRemoteOpts bool) {
 	configRemoteOpts = true
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
+	} else if partialClone {
+		cmd = partialCloneFetchCmd(ctx, remoteURL)
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
+		cmd = exec.CommandContext(ctx, "git", append([]string{"fetch",
 			// We already have janitor jobs that run git gc. We disable git gc here to avoid
 			// a possible corruption of repositories by competing gc processes.
 			"--no-auto-gc",
-			"--progress", "--prune", remoteURL.String(),
-			// Normal git refs
-			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
-			// GitHub pull requests
-			"+refs/pull/*:refs/pull/*",
-			// GitLab merge requests
-			"+refs/merge-requests/*:refs/merge-requests/*",
-			// Bitbucket pull requests
-			"+refs/pull-requests/*:refs/pull-requests/*",
-			// Gerrit changesets
-			"+refs/changes/*:refs/changes/*",
-			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+			"--progress", "--prune", remoteURL.String()},
+			defaultRefspecs...)...)
 	}
 	return cmd, configRemoteOpts
 }
+
+// defaultRefspecs are the refs fetched from code hosts.
+var defaultRefspecs = []string{
+	// Normal git refs
+	"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	// GitHub pull requests
+	"+refs/pull/*:refs/pull/*",
+	// GitLab merge requests
+	"+refs/merge-requests/*:refs/merge-requests/*",
+	// Bitbucket pull requests
+	"+refs/pull-requests/*:refs/pull-requests/*",
+	// Gerrit changesets
+	"+refs/changes/*:refs/changes/*",
+	// Possibly deprecated refs for sourcegraph zap experiment?
+	"+refs/sourcegraph/*:refs/sourcegraph/*",
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MercurialRepoSyncer is a syncer for Mercurial repositories. Their history is converted to Git
+// by the git-remote-hg remote helper.
+//
+// The remote helper keeps a copy of the Mercurial repository and the mapping between
+// changesets and commits in the "hg" directory of the Git repository, so that fetches only
+// pull and convert new changesets. The state is tied to the remote name, which is why clones
+// and fetches use the "origin" remote with the URL passed on the command line.
+type MercurialRepoSyncer struct{}
+
+func (s *MercurialRepoSyncer) Type() string {
+	return "hg"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *MercurialRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	args := []string{"identify", "--noninteractive", "--", remoteURL.String()}
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout(args))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "hg", args...)
+	cmd.Env = hgCommandEnv()
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Mercurial repository as a Git
+// repository.
+func (s *MercurialRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	cmd = s.fetchCommand(ctx, remoteURL)
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// Fetch tries to fetch updates of a Mercurial repository as a Git repository.
+func (s *MercurialRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	cmd := s.fetchCommand(ctx, remoteURL)
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote of a Mercurial
+// repository.
+func (s *MercurialRepoSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+	// The remote is only known to git-remote-hg. The default branch is converted to "master",
+	// which setHEAD falls back to.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+// fetchCommand returns the command converting the changesets of remoteURL which are not yet in
+// the Git repository. Mercurial branches are fetched as refs/heads/branches/<name>, bookmarks as
+// refs/heads/<name> and the default branch as refs/heads/master.
+func (s *MercurialRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git",
+		"-c", "remote.origin.url=hg::"+remoteURL.String(),
+		"fetch", "--progress", "--prune", "origin",
+		"+refs/heads/*:refs/heads/*",
+		"+refs/tags/*:refs/tags/*",
+	)
+	cmd.Env = hgCommandEnv()
+	return cmd
+}
+
+// hgCommandEnv returns the environment of commands running hg, which must not prompt for
+// credentials nor depend on user settings.
+func hgCommandEnv() []string {
+	return append(os.Environ(), "HGPLAIN=1")
+}
@@ -0,0 +1,221 @@
+package server
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SubversionRepoSyncer is a syncer for Subversion repositories. Their history is converted to
+// Git by git svn.
+//
+// git svn keeps the last imported revision of each branch and the mapping between revisions and
+// commits in the "svn" directory of the Git repository. Every revision is committed as soon as
+// it is imported, so fetches resume from the last imported revision, including after an
+// interrupted fetch.
+type SubversionRepoSyncer struct {
+	// Layout is the layout of the repositories: "standard" imports trunk/ as the master
+	// branch, branches/* as branches and tags/* as tags, "root" imports the whole repository
+	// as the master branch. It defaults to "standard".
+	Layout string
+
+	// Authors maps Subversion usernames to Git authors in the form "Name <email>".
+	Authors map[string]string
+
+	// AuthorsEmailDomain is the email domain of the Git authors of Subversion usernames
+	// missing from Authors. It defaults to the host of the remote URL.
+	AuthorsEmailDomain string
+}
+
+func (s *SubversionRepoSyncer) Type() string {
+	return "svn"
+}
+
+// IsCloneable checks to see if the Subversion remote URL is cloneable.
+func (s *SubversionRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	remote, username, password := decomposeSubversionRemoteURL(remoteURL)
+	args := []string{"info", "--non-interactive", "--no-auth-cache"}
+	if username != "" {
+		args = append(args, "--username", username)
+	}
+	if password != "" {
+		args = append(args, "--password-from-stdin")
+	}
+	args = append(args, "--", remote)
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout(args))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "svn", args...)
+	cmd.Env = svnCommandEnv()
+	cmd.Stdin = strings.NewReader(password)
+	out, err := runWith(ctx, cmd, false, nil)
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = errors.Errorf("%s (output follows)\n\n%s", err, newURLRedactor(remoteURL).redact(string(out)))
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand returns the command to be executed for cloning a Subversion repository as a Git
+// repository.
+func (s *SubversionRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "clone setup failed")
+	}
+
+	// Configure the remote like git svn init would, without contacting the server.
+	remote, _, _ := decomposeSubversionRemoteURL(remoteURL)
+	config := [][2]string{{"svn-remote.svn.url", remote}}
+	if s.Layout == "root" {
+		config = append(config, [2]string{"svn-remote.svn.fetch", ":refs/heads/master"})
+	} else {
+		config = append(config,
+			[2]string{"svn-remote.svn.fetch", "trunk:refs/heads/master"},
+			[2]string{"svn-remote.svn.branches", "branches/*:refs/heads/*"},
+			[2]string{"svn-remote.svn.tags", "tags/*:refs/tags/*"},
+		)
+	}
+	for _, kv := range config {
+		cmd = exec.CommandContext(ctx, "git", "config", kv[0], kv[1])
+		cmd.Dir = tmpPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return nil, errors.Wrapf(err, "clone setup failed with output %q", newURLRedactor(remoteURL).redact(string(out)))
+		}
+	}
+
+	cmd, err := s.fetchCommand(ctx, remoteURL, tmpPath)
+	if err != nil {
+		return nil, err
+	}
+	cmd.Dir = tmpPath
+	return cmd, nil
+}
+
+// Fetch tries to fetch updates of a Subversion repository as a Git repository.
+func (s *SubversionRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir, _ string) error {
+	cmd, err := s.fetchCommand(ctx, remoteURL, string(dir))
+	if err != nil {
+		return err
+	}
+	dir.Set(cmd)
+	if output, err := runWith(ctx, cmd, false, nil); err != nil {
+		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing Git remote of a Subversion
+// repository.
+func (s *SubversionRepoSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+	// The remote is only known to git svn. trunk/ is imported as "master", which setHEAD
+	// falls back to.
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
+
+const (
+	// svnAskPassScript answers the password prompts of git svn with the password of the
+	// remote URL, which is passed in the environment rather than stored in the repository.
+	svnAskPassScript = "#!/bin/sh\nprintf '%s\\n' \"$SRC_SVN_PASSWORD\"\n"
+
+	// svnAuthorsProgScript maps the Subversion usernames missing from the authors file to
+	// Git authors at the email domain passed in the environment.
+	svnAuthorsProgScript = "#!/bin/sh\nprintf '%s <%s@%s>\\n' \"$1\" \"$1\" \"$SRC_SVN_AUTHORS_EMAIL_DOMAIN\"\n"
+)
+
+// fetchCommand returns the command importing the revisions of remoteURL which are not yet in the
+// Git repository at dir, after writing the helper files it needs to dir.
+func (s *SubversionRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL, dir string) (*exec.Cmd, error) {
+	_, username, password := decomposeSubversionRemoteURL(remoteURL)
+
+	askPass := filepath.Join(dir, "svn-askpass")
+	authorsProg := filepath.Join(dir, "svn-authors-prog")
+	if err := os.WriteFile(askPass, []byte(svnAskPassScript), 0o700); err != nil {
+		return nil, errors.Wrap(err, "write svn-askpass")
+	}
+	if err := os.WriteFile(authorsProg, []byte(svnAuthorsProgScript), 0o700); err != nil {
+		return nil, errors.Wrap(err, "write svn-authors-prog")
+	}
+
+	args := []string{"svn", "fetch", "--no-auth-cache", "--authors-prog=" + authorsProg}
+	if username != "" {
+		args = append(args, "--username="+username)
+	}
+	if len(s.Authors) > 0 {
+		// The authors file is rewritten on every fetch so that changes to the mapping apply
+		// to the revisions imported afterwards.
+		authorsFile := filepath.Join(dir, "svn-authors")
+		if err := os.WriteFile(authorsFile, []byte(svnAuthorsFile(s.Authors)), 0o600); err != nil {
+			return nil, errors.Wrap(err, "write svn-authors")
+		}
+		args = append(args, "--authors-file="+authorsFile)
+	}
+
+	emailDomain := s.AuthorsEmailDomain
+	if emailDomain == "" {
+		emailDomain = remoteURL.Hostname()
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(svnCommandEnv(),
+		"GIT_ASKPASS="+askPass,
+		"SRC_SVN_PASSWORD="+password,
+		"SRC_SVN_AUTHORS_EMAIL_DOMAIN="+emailDomain,
+	)
+	return cmd, nil
+}
+
+// svnAuthorsFile returns the content of the git svn authors file mapping Subversion usernames to
+// the Git authors of authors.
+func svnAuthorsFile(authors map[string]string) string {
+	usernames := make([]string, 0, len(authors))
+	for username := range authors {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	var b strings.Builder
+	for _, username := range usernames {
+		b.WriteString(username + " = " + authors[username] + "\n")
+	}
+	return b.String()
+}
+
+// decomposeSubversionRemoteURL returns the URL of the Subversion repository without the
+// credentials of remoteURL, which Subversion does not accept in URLs, along with these
+// credentials. The username of svn+ssh URLs is kept because it is the SSH user.
+func decomposeSubversionRemoteURL(remoteURL *vcs.URL) (remote, username, password string) {
+	u := remoteURL.URL
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+	u.User = nil
+	if u.Scheme == "svn+ssh" && username != "" {
+		u.User = url.User(username)
+	}
+	return u.String(), username, password
+}
+
+// svnCommandEnv returns the environment of commands running svn, which must not prompt for the
+// SSH host key nor hang if the server is unreachable.
+func svnCommandEnv() []string {
+	return append(os.Environ(), "SVN_SSH=ssh -o BatchMode=yes -o ConnectTimeout=30")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
@@ -74,3 +75,11 @@ func doMain() error {
 
 	return nil
 }
+
+// writeLogEvent writes a successful log event with the given metadata to
+// stdout, so that it shows up in the execution logs.
+func writeLogEvent(operation batcheslib.LogEventOperation, metadata any) error {
+	e := batcheslib.LogEvent{Operation: operation, Status: batcheslib.LogEventStatusSuccess, Metadata: metadata}
+	e.Timestamp = time.Now().UTC().Truncate(time.Millisecond)
+	return json.NewEncoder(os.Stdout).Encode(e)
+}
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"time"
 
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
@@ -91,6 +90,31 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		return errors.Wrap(err, "failed to write step result file")
 	}
 
+	k, err := stepCacheKey(stepIdx, executionInput)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute cache key")
+	}
+
+	metadata := &batcheslib.CacheAfterStepResultMetadata{
+		Key:   k,
+		Value: stepResult,
+	}
+	if err := writeLogEvent(batcheslib.LogEventOperationCacheAfterStepResult, metadata); err != nil {
+		return errors.Wrap(err, "failed to encode after step result event")
+	}
+
+	return nil
+}
+
+// stepCacheKey returns the key under which the result of the step is cached.
+// The key is passed in the execution input, so that it matches the key that is
+// looked up when workspaces are resolved. Older inputs don't include the keys,
+// in which case the key is computed here.
+func stepCacheKey(stepIdx int, executionInput batcheslib.WorkspacesExecutionInput) (string, error) {
+	if k, ok := executionInput.StepCacheKeys[stepIdx]; ok {
+		return k, nil
+	}
+
 	key := cache.KeyForWorkspace(
 		&executionInput.BatchChangeAttributes,
 		batcheslib.Repository{
@@ -105,26 +129,9 @@ func execPost(ctx context.Context, stepIdx int, executionInput batcheslib.Worksp
 		executionInput.OnlyFetchWorkspace,
 		executionInput.Steps,
 		stepIdx,
-		nil, // todo: should not be nil.
+		nil,
 	)
-
-	k, err := key.Key()
-	if err != nil {
-		return errors.Wrap(err, "failed to compute cache key")
-	}
-
-	metadata := &batcheslib.CacheAfterStepResultMetadata{
-		Key:   k,
-		Value: stepResult,
-	}
-	e := batcheslib.LogEvent{Operation: batcheslib.LogEventOperationCacheAfterStepResult, Status: batcheslib.LogEventStatusSuccess, Metadata: metadata}
-	e.Timestamp = time.Now().UTC().Truncate(time.Millisecond)
-	err = json.NewEncoder(os.Stdout).Encode(e)
-	if err != nil {
-		return errors.Wrap(err, "failed to encode after step result event")
-	}
-
-	return nil
+	return key.Key()
 }
 
 func runGitCmd(ctx context.Context, args ...string) ([]byte, error) {
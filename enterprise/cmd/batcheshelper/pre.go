@@ -26,6 +26,14 @@ func execPre(ctx context.Context, stepIdx int, executionInput batcheslib.Workspa
 
 	step := executionInput.Steps[stepIdx]
 
+	// If the previous steps were skipped because their results were cached,
+	// report that so that the cache hits show up in the execution logs.
+	if executionInput.CachedStepResultFound && executionInput.CachedStepResult.StepIndex == stepIdx-1 {
+		if err := writeLogEvent(batcheslib.LogEventOperationTaskSkippingSteps, &batcheslib.TaskSkippingStepsMetadata{StartStep: stepIdx + 1}); err != nil {
+			return errors.Wrap(err, "failed to encode skipping steps event")
+		}
+	}
+
 	changes, err := git.ChangesInDiff([]byte(previousResult.Diff))
 	if err != nil {
 		return errors.Wrap(err, "failed to compute changes")
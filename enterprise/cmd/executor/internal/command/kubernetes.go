@@ -0,0 +1,301 @@
+package command
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/pointer"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type KubernetesOptions struct {
+	// Enabled determines if docker steps will be run as Kubernetes jobs.
+	Enabled bool
+
+	// Namespace is the namespace in which jobs are created.
+	Namespace string
+
+	// PersistenceVolumeClaimName is the name of the persistent volume claim shared by the
+	// executor and the jobs it creates. The executor creates workspaces at the root of this
+	// volume, and each job mounts the workspace of the job it belongs to.
+	PersistenceVolumeClaimName string
+
+	// NodeSelector restricts the nodes on which jobs are scheduled.
+	NodeSelector map[string]string
+}
+
+// kubernetesPollInterval is the interval between checks of the state of a job's pod.
+var kubernetesPollInterval = time.Second
+
+type kubernetesRunner struct {
+	client  kubernetes.Interface
+	dir     string
+	logger  Logger
+	options Options
+}
+
+var _ Runner = &kubernetesRunner{}
+
+// NewKubernetesRunner creates a runner that runs steps with an image as Kubernetes jobs. Steps
+// without an image are run directly on the host, as with the docker runner.
+func NewKubernetesRunner(client kubernetes.Interface, dir string, logger Logger, options Options) Runner {
+	return &kubernetesRunner{client: client, dir: dir, logger: logger, options: options}
+}
+
+func (r *kubernetesRunner) Setup(ctx context.Context) error {
+	return nil
+}
+
+func (r *kubernetesRunner) Teardown(ctx context.Context) error {
+	return nil
+}
+
+func (r *kubernetesRunner) Run(ctx context.Context, command CommandSpec) error {
+	if command.Image == "" {
+		return runCommand(ctx, formatRawOrDockerCommand(command, r.dir, r.options), r.logger)
+	}
+
+	job, err := newKubernetesJob(command, r.dir, r.options)
+	if err != nil {
+		return err
+	}
+
+	return runKubernetesJob(ctx, r.client, r.options.KubernetesOptions.Namespace, job, command, r.logger)
+}
+
+const (
+	kubernetesContainerName = "step"
+	kubernetesVolumeName    = "workspace"
+)
+
+// newKubernetesJob constructs the job that invokes the given spec. The pod of the job mounts the
+// workspace from the shared volume and runs the step's script, subject to the resource limits
+// specified in the given options.
+func newKubernetesJob(spec CommandSpec, dir string, options Options) (*batchv1.Job, error) {
+	resources, err := kubernetesResources(options.ResourceOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make([]corev1.EnvVar, 0, len(spec.Env))
+	for _, e := range spec.Env {
+		name, value, _ := strings.Cut(e, "=")
+		env = append(env, corev1.EnvVar{Name: name, Value: value})
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: kubernetesJobName(options.ExecutorName, spec.Key),
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "sourcegraph-executor",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			// Steps are not retried, a failing step fails the executor job.
+			BackoffLimit: pointer.Int32(0),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					NodeSelector:  options.KubernetesOptions.NodeSelector,
+					Containers: []corev1.Container{{
+						Name:       kubernetesContainerName,
+						Image:      spec.Image,
+						Command:    []string{"/bin/sh", filepath.Join("/data", ScriptsPath, spec.ScriptPath)},
+						WorkingDir: filepath.Join("/data", spec.Dir),
+						Env:        env,
+						Resources:  resources,
+						VolumeMounts: []corev1.VolumeMount{{
+							Name:      kubernetesVolumeName,
+							MountPath: "/data",
+							SubPath:   filepath.Base(dir),
+						}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: kubernetesVolumeName,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: options.KubernetesOptions.PersistenceVolumeClaimName,
+							},
+						},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+func kubernetesResources(options ResourceOptions) (corev1.ResourceRequirements, error) {
+	limits := corev1.ResourceList{}
+	if options.NumCPUs != 0 {
+		limits[corev1.ResourceCPU] = *resource.NewQuantity(int64(options.NumCPUs), resource.DecimalSI)
+	}
+	if options.Memory != "0" && options.Memory != "" {
+		memory, err := resource.ParseQuantity(options.Memory)
+		if err != nil {
+			return corev1.ResourceRequirements{}, errors.Wrapf(err, "invalid memory limit %q", options.Memory)
+		}
+		limits[corev1.ResourceMemory] = memory
+	}
+	if len(limits) == 0 {
+		return corev1.ResourceRequirements{}, nil
+	}
+
+	return corev1.ResourceRequirements{Limits: limits, Requests: limits}, nil
+}
+
+var invalidKubernetesNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// kubernetesJobName returns a valid job name for the step with the given key. Executor names
+// are unique per executor job, and step keys are unique within an executor job. The readable
+// part of the name is truncated to fit the 63 characters limit, so names end with a hash of
+// the key to keep the jobs of steps with a long common prefix apart.
+func kubernetesJobName(executorName, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	suffix := hex.EncodeToString(sum[:4])
+
+	name := invalidKubernetesNameChars.ReplaceAllString(strings.ToLower(executorName+"-"+key), "-")
+	if maxLen := 63 - len(suffix) - 1; len(name) > maxLen {
+		name = name[:maxLen]
+	}
+	return strings.Trim(name, "-") + "-" + suffix
+}
+
+// runKubernetesJob creates the given job and waits for its pod to exit. The output of the pod
+// is written to the given logger while it runs. The job and its pod are deleted afterwards.
+func runKubernetesJob(ctx context.Context, client kubernetes.Interface, namespace string, job *batchv1.Job, spec CommandSpec, logger Logger) (err error) {
+	ctx, _, endObservation := spec.Operation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	container := job.Spec.Template.Spec.Containers[0]
+	log15.Info(fmt.Sprintf("Running kubernetes job %s: %s", job.Name, strings.Join(container.Command, " ")))
+
+	handle := logger.Log(spec.Key, flatten("kubernetes", "job", job.Name, "--image", container.Image, "--", container.Command))
+	defer handle.Close()
+
+	jobs := client.BatchV1().Jobs(namespace)
+	if _, err := jobs.Create(ctx, job, metav1.CreateOptions{}); err != nil {
+		return errors.Wrap(err, "creating job")
+	}
+	defer func() {
+		// Delete the job outside of the step context, so that jobs are cleaned up
+		// when the step is canceled or times out.
+		propagation := metav1.DeletePropagationBackground
+		if deleteErr := jobs.Delete(context.Background(), job.Name, metav1.DeleteOptions{PropagationPolicy: &propagation}); deleteErr != nil {
+			err = errors.Append(err, errors.Wrap(deleteErr, "deleting job"))
+		}
+	}()
+
+	pods := client.CoreV1().Pods(namespace)
+	pod, err := waitForKubernetesPod(ctx, pods, job.Name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase != corev1.PodPending
+	})
+	if err != nil {
+		return err
+	}
+
+	stream, err := pods.GetLogs(pod.Name, &corev1.PodLogOptions{Container: kubernetesContainerName, Follow: true}).Stream(ctx)
+	if err != nil {
+		return errors.Wrap(err, "streaming pod logs")
+	}
+	defer stream.Close()
+
+	// Kubernetes does not separate the output streams of a container.
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 4*1024), 100*1024*1024)
+	for scanner.Scan() {
+		if _, err := fmt.Fprintf(handle, "stdout: %s\n", scanner.Text()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return errors.Wrap(err, "reading pod logs")
+	}
+
+	pod, err = waitForKubernetesPod(ctx, pods, job.Name, func(pod *corev1.Pod) bool {
+		return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	})
+	if err != nil {
+		return err
+	}
+
+	exitCode := kubernetesExitCode(pod)
+	handle.Finalize(exitCode)
+	if exitCode != 0 {
+		return errors.New("command failed")
+	}
+	return nil
+}
+
+// podsClient is the subset of the pods client used to wait for pods.
+type podsClient interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*corev1.PodList, error)
+}
+
+// waitForKubernetesPod polls the pod created for the given job until the given condition holds.
+func waitForKubernetesPod(ctx context.Context, pods podsClient, jobName string, condition func(pod *corev1.Pod) bool) (*corev1.Pod, error) {
+	for {
+		list, err := pods.List(ctx, metav1.ListOptions{LabelSelector: "job-name=" + jobName})
+		if err != nil {
+			return nil, errors.Wrap(err, "listing pods")
+		}
+
+		for i := range list.Items {
+			pod := &list.Items[i]
+			if condition(pod) {
+				return pod, nil
+			}
+			if err := kubernetesPodError(pod); err != nil {
+				return nil, err
+			}
+		}
+
+		select {
+		case <-time.After(kubernetesPollInterval):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// kubernetesPodError returns an error if the container of the given pod can't be started.
+func kubernetesPodError(pod *corev1.Pod) error {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		switch status.State.Waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError":
+			return errors.Newf("starting pod %s: %s: %s", pod.Name, status.State.Waiting.Reason, status.State.Waiting.Message)
+		}
+	}
+	return nil
+}
+
+func kubernetesExitCode(pod *corev1.Pod) int {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == kubernetesContainerName && status.State.Terminated != nil {
+			return int(status.State.Terminated.ExitCode)
+		}
+	}
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return 0
+	}
+
+	// The pod failed without a container status, e.g. because it was evicted.
+	return 1
+}
@@ -0,0 +1,278 @@
+package command
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestNewKubernetesJob(t *testing.T) {
+	job, err := newKubernetesJob(
+		CommandSpec{
+			Key:        "step.docker.0",
+			Image:      "alpine:latest",
+			ScriptPath: "myscript.sh",
+			Dir:        "subdir",
+			Env:        []string{"TEST=true", "CONTAINS_EQUALS=a=b"},
+			Operation:  makeTestOperation(),
+		},
+		"/workspaces/workspace-1234",
+		Options{
+			ExecutorName: "executor-deadbeef",
+			KubernetesOptions: KubernetesOptions{
+				Enabled:                    true,
+				Namespace:                  "executors",
+				PersistenceVolumeClaimName: "executor-workspaces",
+				NodeSelector:               map[string]string{"pool": "executors"},
+			},
+			ResourceOptions: ResourceOptions{
+				NumCPUs: 4,
+				Memory:  "20G",
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating job: %s", err)
+	}
+
+	if expected := kubernetesJobName("executor-deadbeef", "step.docker.0"); job.Name != expected {
+		t.Errorf("unexpected job name. want=%q have=%q", expected, job.Name)
+	}
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("unexpected backoff limit. want=%d have=%d", 0, *job.Spec.BackoffLimit)
+	}
+
+	limits := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("20G"),
+	}
+	expected := corev1.PodSpec{
+		RestartPolicy: corev1.RestartPolicyNever,
+		NodeSelector:  map[string]string{"pool": "executors"},
+		Containers: []corev1.Container{{
+			Name:       "step",
+			Image:      "alpine:latest",
+			Command:    []string{"/bin/sh", "/data/.sourcegraph-executor/myscript.sh"},
+			WorkingDir: "/data/subdir",
+			Env: []corev1.EnvVar{
+				{Name: "TEST", Value: "true"},
+				{Name: "CONTAINS_EQUALS", Value: "a=b"},
+			},
+			Resources: corev1.ResourceRequirements{Limits: limits, Requests: limits},
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      "workspace",
+				MountPath: "/data",
+				SubPath:   "workspace-1234",
+			}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name: "workspace",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "executor-workspaces"},
+			},
+		}},
+	}
+	if diff := cmp.Diff(expected, job.Spec.Template.Spec, cmp.Comparer(func(x, y resource.Quantity) bool { return x.Cmp(y) == 0 })); diff != "" {
+		t.Errorf("unexpected pod spec (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewKubernetesJobWithoutResourceAllocation(t *testing.T) {
+	job, err := newKubernetesJob(
+		CommandSpec{Key: "step.docker.0", Image: "alpine:latest", ScriptPath: "myscript.sh", Operation: makeTestOperation()},
+		"/workspaces/workspace-1234",
+		Options{ResourceOptions: ResourceOptions{NumCPUs: 0, Memory: "0"}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating job: %s", err)
+	}
+
+	if resources := job.Spec.Template.Spec.Containers[0].Resources; resources.Limits != nil || resources.Requests != nil {
+		t.Errorf("unexpected resources: %v", resources)
+	}
+}
+
+func TestKubernetesJobName(t *testing.T) {
+	testCases := map[string]string{
+		"step.docker.0":          "executor-deadbeef-step-docker-0-",
+		"step.docker.PRE_Index":  "executor-deadbeef-step-docker-pre-index-",
+		strings.Repeat("x", 100): "executor-deadbeef-" + strings.Repeat("x", 54-len("executor-deadbeef-")) + "-",
+	}
+
+	for key, expectedPrefix := range testCases {
+		name := kubernetesJobName("executor-deadbeef", key)
+		if !strings.HasPrefix(name, expectedPrefix) || len(name) != len(expectedPrefix)+8 {
+			t.Errorf("unexpected job name for key %q. want=%q followed by a hash have=%q", key, expectedPrefix, name)
+		}
+		if name != kubernetesJobName("executor-deadbeef", key) {
+			t.Errorf("expected job name for key %q to be stable", key)
+		}
+	}
+}
+
+func TestKubernetesJobNameLongPrefix(t *testing.T) {
+	// Executor names end with a UUID, which leaves little room for step keys.
+	executorName := "executor-8d2e4f36-0b4e-4a5e-9c55-0d0ab7f2c1e6"
+	keys := []string{
+		"step.docker.step.0.pre",
+		"step.docker.step.0.post",
+		"step.docker.step.1.pre",
+		"step.docker.pre-index.0",
+		"step.docker.pre-index.1",
+	}
+
+	names := map[string]string{}
+	for _, key := range keys {
+		name := kubernetesJobName(executorName, key)
+		if len(name) > 63 {
+			t.Errorf("job name %q for key %q is longer than 63 characters", name, key)
+		}
+		if invalidKubernetesNameChars.MatchString(name) || strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+			t.Errorf("invalid job name %q for key %q", name, key)
+		}
+		if other, ok := names[name]; ok {
+			t.Errorf("keys %q and %q have the same job name %q", other, key, name)
+		}
+		names[name] = key
+	}
+}
+
+func TestKubernetesRunnerRun(t *testing.T) {
+	setKubernetesPollInterval(t)
+
+	client := fake.NewSimpleClientset(newTestKubernetesPod(kubernetesJobName("executor-deadbeef", "step.docker.0"), corev1.PodSucceeded, 0))
+	var created *batchv1.Job
+	client.PrependReactor("create", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		created = action.(k8stesting.CreateAction).GetObject().(*batchv1.Job)
+		return false, nil, nil
+	})
+
+	logger := NewMockLogger()
+	logEntry := NewMockLogEntry()
+	logger.LogFunc.SetDefaultReturn(logEntry)
+
+	runner := NewKubernetesRunner(client, "/workspaces/workspace-1234", logger, Options{
+		ExecutorName:      "executor-deadbeef",
+		KubernetesOptions: KubernetesOptions{Enabled: true, Namespace: "executors"},
+	})
+	spec := CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:latest",
+		ScriptPath: "myscript.sh",
+		Operation:  makeTestOperation(),
+	}
+	if err := runner.Run(context.Background(), spec); err != nil {
+		t.Fatalf("unexpected error running job: %s", err)
+	}
+
+	if created == nil || created.Name != kubernetesJobName("executor-deadbeef", "step.docker.0") {
+		t.Fatalf("expected job to be created")
+	}
+	jobs, err := client.BatchV1().Jobs("executors").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error listing jobs: %s", err)
+	}
+	if len(jobs.Items) != 0 {
+		t.Errorf("expected job to be deleted, found %d jobs", len(jobs.Items))
+	}
+
+	var output string
+	for _, call := range logEntry.WriteFunc.History() {
+		output += string(call.Arg0)
+	}
+	if expected := "stdout: fake logs\n"; output != expected {
+		t.Errorf("unexpected output. want=%q have=%q", expected, output)
+	}
+	if history := logEntry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != 0 {
+		t.Errorf("expected log entry to be finalized with exit code 0")
+	}
+}
+
+func TestKubernetesRunnerRunFailure(t *testing.T) {
+	setKubernetesPollInterval(t)
+
+	client := fake.NewSimpleClientset(newTestKubernetesPod(kubernetesJobName("executor-deadbeef", "step.docker.0"), corev1.PodFailed, 2))
+	logger := NewMockLogger()
+	logEntry := NewMockLogEntry()
+	logger.LogFunc.SetDefaultReturn(logEntry)
+
+	runner := NewKubernetesRunner(client, "/workspaces/workspace-1234", logger, Options{
+		ExecutorName:      "executor-deadbeef",
+		KubernetesOptions: KubernetesOptions{Enabled: true, Namespace: "executors"},
+	})
+	spec := CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:latest",
+		ScriptPath: "myscript.sh",
+		Operation:  makeTestOperation(),
+	}
+	if err := runner.Run(context.Background(), spec); err == nil {
+		t.Fatalf("expected an error running job")
+	}
+
+	if history := logEntry.FinalizeFunc.History(); len(history) != 1 || history[0].Arg0 != 2 {
+		t.Errorf("expected log entry to be finalized with exit code 2")
+	}
+}
+
+func TestKubernetesRunnerRunImagePullFailure(t *testing.T) {
+	setKubernetesPollInterval(t)
+
+	pod := newTestKubernetesPod(kubernetesJobName("executor-deadbeef", "step.docker.0"), corev1.PodPending, 0)
+	pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "not found"},
+	}
+	client := fake.NewSimpleClientset(pod)
+	logger := NewMockLogger()
+	logger.LogFunc.SetDefaultReturn(NewMockLogEntry())
+
+	runner := NewKubernetesRunner(client, "/workspaces/workspace-1234", logger, Options{
+		ExecutorName:      "executor-deadbeef",
+		KubernetesOptions: KubernetesOptions{Enabled: true, Namespace: "executors"},
+	})
+	spec := CommandSpec{
+		Key:        "step.docker.0",
+		Image:      "alpine:does-not-exist",
+		ScriptPath: "myscript.sh",
+		Operation:  makeTestOperation(),
+	}
+	if err := runner.Run(context.Background(), spec); err == nil || !strings.Contains(err.Error(), "ImagePullBackOff") {
+		t.Fatalf("unexpected error running job: %v", err)
+	}
+}
+
+func setKubernetesPollInterval(t *testing.T) {
+	pollInterval := kubernetesPollInterval
+	kubernetesPollInterval = time.Millisecond
+	t.Cleanup(func() { kubernetesPollInterval = pollInterval })
+}
+
+// newTestKubernetesPod returns the pod the job controller would create for the given job.
+func newTestKubernetesPod(jobName string, phase corev1.PodPhase, exitCode int32) *corev1.Pod {
+	state := corev1.ContainerState{}
+	if phase == corev1.PodSucceeded || phase == corev1.PodFailed {
+		state.Terminated = &corev1.ContainerStateTerminated{ExitCode: exitCode}
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName + "-abcde",
+			Namespace: "executors",
+			Labels:    map[string]string{"job-name": jobName},
+		},
+		Status: corev1.PodStatus{
+			Phase:             phase,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "step", State: state}},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/c2h5oh/datasize"
@@ -16,41 +17,56 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	FrontendURL                   string
-	FrontendAuthorizationToken    string
-	QueueName                     string
-	QueuePollInterval             time.Duration
-	MaximumNumJobs                int
-	FirecrackerImage              string
-	FirecrackerKernelImage        string
-	FirecrackerSandboxImage       string
-	VMStartupScriptPath           string
-	VMPrefix                      string
-	KeepWorkspaces                bool
-	DockerHostMountPath           string
-	UseFirecracker                bool
-	JobNumCPUs                    int
-	JobMemory                     string
-	FirecrackerDiskSpace          string
-	FirecrackerBandwidthIngress   int
-	FirecrackerBandwidthEgress    int
-	MaximumRuntimePerJob          time.Duration
-	CleanupTaskInterval           time.Duration
-	NumTotalJobs                  int
-	MaxActiveTime                 time.Duration
-	NodeExporterURL               string
-	DockerRegistryNodeExporterURL string
-	WorkerHostname                string
-	DockerRegistryMirrorURL       string
+	FrontendURL                          string
+	FrontendAuthorizationToken           string
+	QueueName                            string
+	QueueNames                           string
+	QueueMaximumNumJobs                  string
+	Queues                               []QueueConfig
+	QueuePollInterval                    time.Duration
+	MaximumNumJobs                       int
+	FirecrackerImage                     string
+	FirecrackerKernelImage               string
+	FirecrackerSandboxImage              string
+	VMStartupScriptPath                  string
+	VMPrefix                             string
+	KeepWorkspaces                       bool
+	DockerHostMountPath                  string
+	UseFirecracker                       bool
+	UseKubernetes                        bool
+	KubernetesConfigPath                 string
+	KubernetesNamespace                  string
+	KubernetesPersistenceVolumeClaimName string
+	KubernetesNodeSelector               string
+	JobNumCPUs                           int
+	JobMemory                            string
+	FirecrackerDiskSpace                 string
+	FirecrackerBandwidthIngress          int
+	FirecrackerBandwidthEgress           int
+	MaximumRuntimePerJob                 time.Duration
+	CleanupTaskInterval                  time.Duration
+	NumTotalJobs                         int
+	MaxActiveTime                        time.Duration
+	NodeExporterURL                      string
+	DockerRegistryNodeExporterURL        string
+	WorkerHostname                       string
+	DockerRegistryMirrorURL              string
 }
 
 func (c *Config) Load() {
 	c.FrontendURL = c.Get("EXECUTOR_FRONTEND_URL", "", "The external URL of the sourcegraph instance.")
 	c.FrontendAuthorizationToken = c.Get("EXECUTOR_FRONTEND_PASSWORD", "", "The authorization token supplied to the frontend.")
-	c.QueueName = c.Get("EXECUTOR_QUEUE_NAME", "", "The name of the queue to listen to.")
+	c.QueueName = c.GetOptional("EXECUTOR_QUEUE_NAME", "The name of the queue to listen to. Mutually exclusive with EXECUTOR_QUEUE_NAMES.")
+	c.QueueNames = c.GetOptional("EXECUTOR_QUEUE_NAMES", "A comma-separated list of queues to listen to, each optionally followed by a scheduling weight (e.g. batches:3,codeintel:1). Mutually exclusive with EXECUTOR_QUEUE_NAME.")
+	c.QueueMaximumNumJobs = c.GetOptional("EXECUTOR_QUEUE_MAXIMUM_NUM_JOBS", "A comma-separated list of queues and the maximum number of their jobs that can be running at once (e.g. codeintel:2). Queues without a limit can use all of EXECUTOR_MAXIMUM_NUM_JOBS.")
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux"), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only.")
+	c.UseKubernetes = c.GetBool("EXECUTOR_USE_KUBERNETES", "false", "Whether to run commands as Kubernetes jobs. Mutually exclusive with EXECUTOR_USE_FIRECRACKER.")
+	c.KubernetesConfigPath = c.GetOptional("EXECUTOR_KUBERNETES_CONFIG_PATH", "The path to a kubeconfig file used to connect to the cluster. The in-cluster configuration is used if not set.")
+	c.KubernetesNamespace = c.Get("EXECUTOR_KUBERNETES_NAMESPACE", "default", "The namespace in which to create Kubernetes jobs.")
+	c.KubernetesPersistenceVolumeClaimName = c.GetOptional("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_CLAIM_NAME", "The name of the persistent volume claim holding the workspaces, shared by the executor and its Kubernetes jobs.")
+	c.KubernetesNodeSelector = c.GetOptional("EXECUTOR_KUBERNETES_NODE_SELECTOR", "A comma-separated list of labels nodes running Kubernetes jobs must have (e.g. disktype=ssd,pool=executors).")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -71,6 +87,12 @@ func (c *Config) Load() {
 	c.MaxActiveTime = c.GetInterval("EXECUTOR_MAX_ACTIVE_TIME", "0", "The maximum time that can be spent by the worker dequeueing records to be handled.")
 	c.DockerRegistryMirrorURL = c.GetOptional("EXECUTOR_DOCKER_REGISTRY_MIRROR_URL", "The address of a docker registry mirror to use in firecracker VMs. Supports multiple values, separated with a comma.")
 
+	queues, err := parseQueues(c.QueueName, c.QueueNames, c.QueueMaximumNumJobs)
+	if err != nil {
+		c.AddError(err)
+	}
+	c.Queues = queues
+
 	hn := hostname.Get()
 	// Be unique but also descriptive.
 	c.WorkerHostname = hn + "-" + uuid.New().String()
@@ -80,6 +102,36 @@ func (c *Config) Validate() error {
 	if c.QueueName != "" && c.QueueName != "batches" && c.QueueName != "codeintel" {
 		c.AddError(errors.New("EXECUTOR_QUEUE_NAME must be set to 'batches' or 'codeintel'"))
 	}
+	if c.QueueName != "" && c.QueueNames != "" {
+		c.AddError(errors.New("only one of EXECUTOR_QUEUE_NAME and EXECUTOR_QUEUE_NAMES can be set"))
+	}
+	if c.QueueName == "" && c.QueueNames == "" {
+		c.AddError(errors.New("one of EXECUTOR_QUEUE_NAME and EXECUTOR_QUEUE_NAMES must be set"))
+	}
+	if c.QueueNames != "" {
+		for _, queue := range c.Queues {
+			if queue.Name != "batches" && queue.Name != "codeintel" {
+				c.AddError(errors.Newf("EXECUTOR_QUEUE_NAMES contains unknown queue %q, queues must be 'batches' or 'codeintel'", queue.Name))
+			}
+		}
+	}
+	for _, queue := range c.Queues {
+		if queue.MaximumNumJobs > c.MaximumNumJobs {
+			c.AddError(errors.Newf("EXECUTOR_QUEUE_MAXIMUM_NUM_JOBS for queue %q must not exceed EXECUTOR_MAXIMUM_NUM_JOBS", queue.Name))
+		}
+	}
+
+	if c.UseKubernetes {
+		if c.UseFirecracker {
+			c.AddError(errors.New("only one of EXECUTOR_USE_FIRECRACKER and EXECUTOR_USE_KUBERNETES can be enabled"))
+		}
+		if c.KubernetesPersistenceVolumeClaimName == "" {
+			c.AddError(errors.New("EXECUTOR_KUBERNETES_PERSISTENCE_VOLUME_CLAIM_NAME must be set when EXECUTOR_USE_KUBERNETES is enabled"))
+		}
+		if _, err := ParseKubernetesNodeSelector(c.KubernetesNodeSelector); err != nil {
+			c.AddError(err)
+		}
+	}
 
 	if c.UseFirecracker {
 		// Validate that firecracker can work on this host.
@@ -104,3 +156,113 @@ func (c *Config) Validate() error {
 
 	return c.BaseConfig.Validate()
 }
+
+// QueueConfig describes a queue an executor dequeues jobs from.
+type QueueConfig struct {
+	// Name is the name of the queue.
+	Name string
+
+	// Weight is the share of EXECUTOR_MAXIMUM_NUM_JOBS the queue is entitled to,
+	// relative to the weights of the other queues.
+	Weight int
+
+	// MaximumNumJobs is the maximum number of jobs of the queue that can be running
+	// at once. Zero means no limit other than EXECUTOR_MAXIMUM_NUM_JOBS.
+	MaximumNumJobs int
+}
+
+// parseQueues returns the queues configured by EXECUTOR_QUEUE_NAME or EXECUTOR_QUEUE_NAMES
+// and EXECUTOR_QUEUE_MAXIMUM_NUM_JOBS.
+func parseQueues(queueName, queueNames, queueMaximumNumJobs string) ([]QueueConfig, error) {
+	var queues []QueueConfig
+	if queueNames == "" {
+		if queueName != "" {
+			queues = append(queues, QueueConfig{Name: queueName, Weight: 1})
+		}
+	} else {
+		weights, err := parseQueueValues("EXECUTOR_QUEUE_NAMES", queueNames, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, weight := range weights {
+			queues = append(queues, QueueConfig{Name: weight.name, Weight: weight.value})
+		}
+	}
+
+	limits, err := parseQueueValues("EXECUTOR_QUEUE_MAXIMUM_NUM_JOBS", queueMaximumNumJobs, 0)
+	if err != nil {
+		return nil, err
+	}
+outer:
+	for _, limit := range limits {
+		for i := range queues {
+			if queues[i].Name == limit.name {
+				queues[i].MaximumNumJobs = limit.value
+				continue outer
+			}
+		}
+		return nil, errors.Newf("EXECUTOR_QUEUE_MAXIMUM_NUM_JOBS contains queue %q that is not listened to", limit.name)
+	}
+
+	return queues, nil
+}
+
+type queueValue struct {
+	name  string
+	value int
+}
+
+// parseQueueValues parses a comma-separated list of name:value pairs with positive integer values.
+// Entries without a value are given the default value, unless it is zero.
+func parseQueueValues(envvar, raw string, defaultValue int) ([]queueValue, error) {
+	var values []queueValue
+	seen := map[string]struct{}{}
+
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, rawValue, hasValue := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if _, ok := seen[name]; ok {
+			return nil, errors.Newf("%s contains queue %q more than once", envvar, name)
+		}
+		seen[name] = struct{}{}
+
+		value := defaultValue
+		if hasValue {
+			v, err := strconv.Atoi(strings.TrimSpace(rawValue))
+			if err != nil || v <= 0 {
+				return nil, errors.Newf("%s contains invalid value %q for queue %q, must be a positive integer", envvar, rawValue, name)
+			}
+			value = v
+		} else if defaultValue == 0 {
+			return nil, errors.Newf("%s entry %q must be of the form name:value", envvar, entry)
+		}
+
+		values = append(values, queueValue{name: name, value: value})
+	}
+
+	return values, nil
+}
+
+// ParseKubernetesNodeSelector parses the value of EXECUTOR_KUBERNETES_NODE_SELECTOR into a
+// map from label names to their values.
+func ParseKubernetesNodeSelector(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	selector := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		name, labelValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" {
+			return nil, errors.Newf("invalid EXECUTOR_KUBERNETES_NODE_SELECTOR entry %q, expected label=value", pair)
+		}
+		selector[name] = labelValue
+	}
+
+	return selector, nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConfigQueues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		expected    []QueueConfig
+		expectedErr bool
+	}{
+		{
+			name:     "queue name",
+			env:      map[string]string{"EXECUTOR_QUEUE_NAME": "batches"},
+			expected: []QueueConfig{{Name: "batches", Weight: 1}},
+		},
+		{
+			name: "queue names",
+			env:  map[string]string{"EXECUTOR_QUEUE_NAMES": "batches:3,codeintel"},
+			expected: []QueueConfig{
+				{Name: "batches", Weight: 3},
+				{Name: "codeintel", Weight: 1},
+			},
+		},
+		{
+			name:        "both queue name and queue names",
+			env:         map[string]string{"EXECUTOR_QUEUE_NAME": "batches", "EXECUTOR_QUEUE_NAMES": "codeintel"},
+			expectedErr: true,
+		},
+		{
+			name:        "no queue",
+			env:         map[string]string{},
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			env := map[string]string{
+				"EXECUTOR_FRONTEND_URL":      "http://sourcegraph.test",
+				"EXECUTOR_FRONTEND_PASSWORD": "hunter2",
+				"EXECUTOR_USE_FIRECRACKER":   "false",
+			}
+			for k, v := range testCase.env {
+				env[k] = v
+			}
+
+			config := Config{}
+			config.SetMockGetter(mapGetter(env))
+			config.Load()
+
+			err := config.Validate()
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(testCase.expected, config.Queues); diff != "" {
+				t.Errorf("unexpected queues (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseQueues(t *testing.T) {
+	testCases := []struct {
+		name                string
+		queueName           string
+		queueNames          string
+		queueMaximumNumJobs string
+		expected            []QueueConfig
+		expectedErr         bool
+	}{
+		{
+			name:      "single queue",
+			queueName: "batches",
+			expected:  []QueueConfig{{Name: "batches", Weight: 1}},
+		},
+		{
+			name:                "weights and limits",
+			queueNames:          "batches:3, codeintel",
+			queueMaximumNumJobs: "codeintel:2",
+			expected: []QueueConfig{
+				{Name: "batches", Weight: 3},
+				{Name: "codeintel", Weight: 1, MaximumNumJobs: 2},
+			},
+		},
+		{
+			name:        "invalid weight",
+			queueNames:  "batches:0",
+			expectedErr: true,
+		},
+		{
+			name:        "duplicate queue",
+			queueNames:  "batches,batches:2",
+			expectedErr: true,
+		},
+		{
+			name:                "limit without value",
+			queueNames:          "batches",
+			queueMaximumNumJobs: "batches",
+			expectedErr:         true,
+		},
+		{
+			name:                "limit for unknown queue",
+			queueNames:          "batches",
+			queueMaximumNumJobs: "codeintel:1",
+			expectedErr:         true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			queues, err := parseQueues(testCase.queueName, testCase.queueNames, testCase.queueMaximumNumJobs)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(testCase.expected, queues); diff != "" {
+				t.Errorf("unexpected queues (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseKubernetesNodeSelector(t *testing.T) {
+	selector, err := ParseKubernetesNodeSelector("disktype=ssd, pool=executors")
+	if err != nil {
+		t.Fatalf("unexpected error parsing node selector: %s", err)
+	}
+	if diff := cmp.Diff(map[string]string{"disktype": "ssd", "pool": "executors"}, selector); diff != "" {
+		t.Errorf("unexpected node selector (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseKubernetesNodeSelector("disktype"); err == nil {
+		t.Errorf("expected an error parsing node selector without value")
+	}
+}
+
+func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
+	return func(name, defaultValue, description string) string {
+		if v, ok := env[name]; ok {
+			return v
+		}
+
+		return defaultValue
+	}
+}
@@ -50,7 +50,7 @@ func RunRun(cliCtx *cli.Context, logger log.Logger, cfg *config.Config) error {
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if cliCtx.Bool("verify") {
 		// Then, validate all tools that are required are installed.
-		if err := validateToolsRequired(cfg.UseFirecracker); err != nil {
+		if err := validateToolsRequired(cfg.UseFirecracker, cfg.UseKubernetes); err != nil {
 			return err
 		}
 
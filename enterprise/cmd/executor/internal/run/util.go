@@ -94,16 +94,17 @@ func getIgniteVersion(ctx context.Context) (string, error) {
 }
 
 func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOptions) apiworker.Options {
-	return apiworker.Options{
-		VMPrefix:           c.VMPrefix,
-		KeepWorkspaces:     c.KeepWorkspaces,
-		QueueName:          c.QueueName,
-		WorkerOptions:      workerOptions(c),
-		FirecrackerOptions: firecrackerOptions(c),
-		ResourceOptions:    resourceOptions(c),
-		GitServicePath:     "/.executors/git",
-		QueueOptions:       queueOptions(c, queueTelemetryOptions),
-		FilesOptions:       filesOptions(c),
+	options := apiworker.Options{
+		VMPrefix:             c.VMPrefix,
+		KeepWorkspaces:       c.KeepWorkspaces,
+		QueueName:            c.QueueName,
+		FirecrackerOptions:   firecrackerOptions(c),
+		KubernetesOptions:    kubernetesOptions(c),
+		KubernetesConfigPath: c.KubernetesConfigPath,
+		ResourceOptions:      resourceOptions(c),
+		GitServicePath:       "/.executors/git",
+		QueueOptions:         queueOptions(c, queueTelemetryOptions),
+		FilesOptions:         filesOptions(c),
 		RedactedValues: map[string]string{
 			// 🚨 SECURITY: Catch uses of the shared frontend token used to clone
 			// git repositories that make it into commands or stdout/stderr streams.
@@ -113,16 +114,45 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 		NodeExporterEndpoint:               c.NodeExporterURL,
 		DockerRegistryNodeExporterEndpoint: c.DockerRegistryNodeExporterURL,
 	}
+
+	if c.QueueNames == "" {
+		options.WorkerOptions = workerOptions(c, c.QueueName)
+	} else {
+		// Each queue has its own worker, which share the handlers and the
+		// total number of jobs of the executor.
+		options.WorkerOptions = workerutil.WorkerOptions{
+			NumHandlers:  c.MaximumNumJobs,
+			NumTotalJobs: c.NumTotalJobs,
+		}
+		options.Queues = queues(c)
+	}
+
+	return options
+}
+
+// queues returns the queues served by an executor configured with EXECUTOR_QUEUE_NAMES.
+func queues(c *config.Config) []apiworker.Queue {
+	queues := make([]apiworker.Queue, 0, len(c.Queues))
+	for _, queue := range c.Queues {
+		queues = append(queues, apiworker.Queue{
+			Name:           queue.Name,
+			Weight:         queue.Weight,
+			MaximumNumJobs: queue.MaximumNumJobs,
+			WorkerOptions:  workerOptions(c, queue.Name),
+		})
+	}
+
+	return queues
 }
 
-func workerOptions(c *config.Config) workerutil.WorkerOptions {
+func workerOptions(c *config.Config, queueName string) workerutil.WorkerOptions {
 	return workerutil.WorkerOptions{
-		Name:                 fmt.Sprintf("executor_%s_worker", c.QueueName),
+		Name:                 fmt.Sprintf("executor_%s_worker", queueName),
 		NumHandlers:          c.MaximumNumJobs,
 		Interval:             c.QueuePollInterval,
 		HeartbeatInterval:    5 * time.Second,
 		CancelInterval:       c.QueuePollInterval,
-		Metrics:              makeWorkerMetrics(c.QueueName),
+		Metrics:              makeWorkerMetrics(queueName),
 		NumTotalJobs:         c.NumTotalJobs,
 		MaxActiveTime:        c.MaxActiveTime,
 		WorkerHostname:       c.WorkerHostname,
@@ -145,6 +175,18 @@ func firecrackerOptions(c *config.Config) command.FirecrackerOptions {
 	}
 }
 
+func kubernetesOptions(c *config.Config) command.KubernetesOptions {
+	// The node selector is validated when the config is loaded.
+	nodeSelector, _ := config.ParseKubernetesNodeSelector(c.KubernetesNodeSelector)
+
+	return command.KubernetesOptions{
+		Enabled:                    c.UseKubernetes,
+		Namespace:                  c.KubernetesNamespace,
+		PersistenceVolumeClaimName: c.KubernetesPersistenceVolumeClaimName,
+		NodeSelector:               nodeSelector,
+	}
+}
+
 func resourceOptions(c *config.Config) command.ResourceOptions {
 	return command.ResourceOptions{
 		NumCPUs:             c.JobNumCPUs,
@@ -191,17 +233,16 @@ func endpointOptions(c *config.Config, pathPrefix string) apiclient.EndpointOpti
 
 func makeWorkerMetrics(queueName string) workerutil.WorkerObservability {
 	observationContext := &observation.Context{
-		Logger:     log.Scoped("executor_processor", "executor worker processor"),
-		Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
-		Registerer: prometheus.DefaultRegisterer,
+		Logger: log.Scoped("executor_processor", "executor worker processor"),
+		Tracer: &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+		// The queue label is attached by the registerer rather than as a metric label,
+		// so that the metrics of the workers of multiple queues can be registered.
+		Registerer: prometheus.WrapRegistererWith(prometheus.Labels{"queue": queueName}, prometheus.DefaultRegisterer),
 	}
 
 	return workerutil.NewMetrics(observationContext, "executor_processor", workerutil.WithSampler(func(job workerutil.Record) bool { return true }),
 		// derived from historic data, ideally we will use spare high-res histograms once they're a reality
 		// 										 30s 1m	 2.5m 5m   7.5m 10m  15m  20m	30m	  45m	1hr
 		workerutil.WithDurationBuckets([]float64{30, 60, 150, 300, 450, 600, 900, 1200, 1800, 2700, 3600}),
-		workerutil.WithLabels(map[string]string{
-			"queue": queueName,
-		}),
 	)
 }
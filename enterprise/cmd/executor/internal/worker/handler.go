@@ -25,12 +25,18 @@ type handler struct {
 	filesStore    store.FilesStore
 	options       Options
 	operations    *command.Operations
-	runnerFactory func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner
+	runnerFactory runnerFactory
+
+	// queueName and scheduler are set when the executor serves multiple queues, in
+	// which case the handler returns the slot of each handled job to the scheduler.
+	queueName string
+	scheduler *scheduler
 }
 
 var (
 	_ workerutil.Handler        = &handler{}
 	_ workerutil.WithPreDequeue = &handler{}
+	_ workerutil.WithHooks      = &handler{}
 )
 
 // PreDequeue determines if the number of VMs with the current instance's VM Prefix is less than
@@ -59,6 +65,16 @@ func (h *handler) PreDequeue(ctx context.Context, logger log.Logger) (dequeueabl
 	return false, nil, nil
 }
 
+func (h *handler) PreHandle(ctx context.Context, logger log.Logger, record workerutil.Record) {}
+
+// PostHandle returns the slot of the handled job to the scheduler shared by the workers of
+// all queues served by this executor.
+func (h *handler) PostHandle(ctx context.Context, logger log.Logger, record workerutil.Record) {
+	if h.scheduler != nil {
+		h.scheduler.release(h.queueName)
+	}
+}
+
 // Handle clones the target code into a temporary directory, invokes the target indexer in a
 // fresh docker container, and uploads the results to the external frontend API.
 func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
@@ -120,6 +136,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record workerut
 	options := command.Options{
 		ExecutorName:       name,
 		FirecrackerOptions: h.options.FirecrackerOptions,
+		KubernetesOptions:  h.options.KubernetesOptions,
 		ResourceOptions:    h.options.ResourceOptions,
 	}
 	runner := h.runnerFactory(workspace.Path(), commandLogger, options, h.operations)
@@ -0,0 +1,169 @@
+package worker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// scheduler shares a budget of concurrently running jobs between the workers of several
+// queues. Each queue is entitled to a share of the budget proportional to its weight and
+// may borrow slots beyond its share that are not needed by other queues with pending work.
+// A queue never runs more jobs than its own maximum, if configured.
+//
+// The scheduler also enforces the maximum number of jobs dequeued in total, after which
+// the exhausted channel is closed.
+type scheduler struct {
+	mu           sync.Mutex
+	budget       int
+	totalWeight  int
+	running      int
+	queues       map[string]*queueState
+	numTotalJobs int
+	numDequeued  int
+	exhausted    chan struct{}
+}
+
+type queueState struct {
+	weight         int
+	maximumNumJobs int
+	running        int
+
+	// waiting is true if the queue is believed to have queued jobs. It is reset once a
+	// dequeue from the queue comes back empty.
+	waiting bool
+}
+
+func newScheduler(budget, numTotalJobs int, queues []Queue) *scheduler {
+	s := &scheduler{
+		budget:       budget,
+		queues:       make(map[string]*queueState, len(queues)),
+		numTotalJobs: numTotalJobs,
+		exhausted:    make(chan struct{}),
+	}
+	for _, queue := range queues {
+		s.totalWeight += queue.Weight
+		s.queues[queue.Name] = &queueState{
+			weight:         queue.Weight,
+			maximumNumJobs: queue.MaximumNumJobs,
+			waiting:        true,
+		}
+	}
+
+	return s
+}
+
+// reserve claims a slot of the budget for a job of the given queue. This method returns
+// false if the queue should not dequeue a job at this time.
+func (s *scheduler) reserve(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := s.queues[name]
+	if !s.canReserve(name, q) {
+		// Remember that this queue was held back, so that slots are set aside for it
+		// once they're freed up. If it turns out to be empty, the next dequeue resets
+		// this flag.
+		q.waiting = true
+		return false
+	}
+
+	q.running++
+	s.running++
+	return true
+}
+
+func (s *scheduler) canReserve(name string, q *queueState) bool {
+	if s.running >= s.budget {
+		return false
+	}
+	if s.numTotalJobs > 0 && s.numDequeued+s.running >= s.numTotalJobs {
+		return false
+	}
+	if q.maximumNumJobs > 0 && q.running >= q.maximumNumJobs {
+		return false
+	}
+	if s.entitlement(q) > q.running {
+		return true
+	}
+
+	// The queue is running at least its share, so it may only borrow slots that
+	// are not set aside for other queues waiting to reach their own share.
+	reserved := 0
+	for otherName, other := range s.queues {
+		if otherName == name || !other.waiting {
+			continue
+		}
+		if n := s.entitlement(other) - other.running; n > 0 {
+			reserved += n
+		}
+	}
+
+	return s.budget-s.running > reserved
+}
+
+// entitlement returns the number of slots of the budget the given queue is entitled to.
+func (s *scheduler) entitlement(q *queueState) int {
+	// Round up so that every queue is entitled to at least one slot.
+	n := (s.budget*q.weight + s.totalWeight - 1) / s.totalWeight
+	if q.maximumNumJobs > 0 && n > q.maximumNumJobs {
+		n = q.maximumNumJobs
+	}
+	return n
+}
+
+// dequeued records the outcome of a dequeue attempt made after a successful call to reserve.
+// If no job was dequeued, the reserved slot is returned to the budget. Otherwise, the slot is
+// held by the job until release is called.
+func (s *scheduler) dequeued(name string, dequeued bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q := s.queues[name]
+	if err == nil {
+		// A queue that had a job to hand out likely has more of them.
+		q.waiting = dequeued
+	}
+	if err != nil || !dequeued {
+		s.releaseLocked(q)
+	}
+}
+
+// release returns a slot held by a dequeued job to the budget once the job has been handled.
+// Jobs count towards the maximum number of jobs from the moment their slot is reserved.
+func (s *scheduler) release(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numDequeued++
+	s.releaseLocked(s.queues[name])
+}
+
+func (s *scheduler) releaseLocked(q *queueState) {
+	q.running--
+	s.running--
+
+	if s.numTotalJobs > 0 && s.numDequeued >= s.numTotalJobs && s.running == 0 {
+		close(s.exhausted)
+	}
+}
+
+// scheduledStore wraps the store of a single queue so that jobs are only dequeued when
+// the scheduler grants the queue a slot. The slot is released by the handler once the
+// job has been handled.
+type scheduledStore struct {
+	workerutil.Store
+	queueName string
+	scheduler *scheduler
+}
+
+func (s *scheduledStore) Dequeue(ctx context.Context, workerHostname string, extraArguments any) (workerutil.Record, bool, error) {
+	if !s.scheduler.reserve(s.queueName) {
+		return nil, false, nil
+	}
+
+	record, dequeued, err := s.Store.Dequeue(ctx, workerHostname, extraArguments)
+	s.scheduler.dequeued(s.queueName, dequeued, err)
+	return record, dequeued, err
+}
@@ -0,0 +1,111 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSchedulerWeights(t *testing.T) {
+	s := newScheduler(4, 0, []Queue{
+		{Name: "batches", Weight: 3},
+		{Name: "codeintel", Weight: 1},
+	})
+
+	// batches is entitled to three slots, and the fourth is set aside for codeintel
+	// which is assumed to have pending work until a dequeue comes back empty.
+	for i := 0; i < 3; i++ {
+		reserveAndDequeue(t, s, "batches")
+	}
+	if s.reserve("batches") {
+		t.Fatalf("expected slot of codeintel not to be borrowed")
+	}
+
+	// Once codeintel turns out to be empty, batches can use its slot.
+	if !s.reserve("codeintel") {
+		t.Fatalf("expected codeintel to reserve a slot")
+	}
+	s.dequeued("codeintel", false, nil)
+	reserveAndDequeue(t, s, "batches")
+
+	// The budget is used up, so codeintel is held back and marked as waiting.
+	if s.reserve("codeintel") {
+		t.Fatalf("expected budget to be exhausted")
+	}
+
+	// A released slot goes to codeintel rather than batches, which has exceeded its share.
+	s.release("batches")
+	if s.reserve("batches") {
+		t.Fatalf("expected released slot to be set aside for codeintel")
+	}
+	reserveAndDequeue(t, s, "codeintel")
+
+	if s.running != 4 || s.queues["batches"].running != 3 || s.queues["codeintel"].running != 1 {
+		t.Fatalf("unexpected running jobs. total=%d batches=%d codeintel=%d", s.running, s.queues["batches"].running, s.queues["codeintel"].running)
+	}
+}
+
+func TestSchedulerMaximumNumJobs(t *testing.T) {
+	s := newScheduler(4, 0, []Queue{
+		{Name: "batches", Weight: 1},
+		{Name: "codeintel", Weight: 1, MaximumNumJobs: 1},
+	})
+
+	reserveAndDequeue(t, s, "codeintel")
+	if s.reserve("codeintel") {
+		t.Fatalf("expected codeintel to be limited to one job")
+	}
+
+	// codeintel can't use more slots, so none are set aside for it.
+	for i := 0; i < 3; i++ {
+		reserveAndDequeue(t, s, "batches")
+	}
+}
+
+func TestSchedulerDequeueError(t *testing.T) {
+	s := newScheduler(1, 0, []Queue{{Name: "batches", Weight: 1}})
+
+	if !s.reserve("batches") {
+		t.Fatalf("expected batches to reserve a slot")
+	}
+	s.dequeued("batches", false, errors.New("oops"))
+	reserveAndDequeue(t, s, "batches")
+}
+
+func TestSchedulerNumTotalJobs(t *testing.T) {
+	s := newScheduler(2, 3, []Queue{
+		{Name: "batches", Weight: 1},
+		{Name: "codeintel", Weight: 1},
+	})
+
+	reserveAndDequeue(t, s, "batches")
+	reserveAndDequeue(t, s, "codeintel")
+	s.release("batches")
+	reserveAndDequeue(t, s, "batches")
+	s.release("codeintel")
+
+	if s.reserve("codeintel") {
+		t.Fatalf("expected total number of jobs to be reached")
+	}
+	select {
+	case <-s.exhausted:
+		t.Fatalf("expected scheduler not to be exhausted while jobs are running")
+	default:
+	}
+
+	s.release("batches")
+	select {
+	case <-s.exhausted:
+	default:
+		t.Fatalf("expected scheduler to be exhausted")
+	}
+}
+
+func reserveAndDequeue(t *testing.T, s *scheduler, name string) {
+	t.Helper()
+
+	if !s.reserve(name) {
+		t.Fatalf("expected %s to reserve a slot", name)
+	}
+	s.dequeued(name, true, nil)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/sourcegraph/log"
 
@@ -40,6 +44,11 @@ type Options struct {
 	// horizontal scaling factors while still uniformly processing events.
 	QueueName string
 
+	// Queues are the queues to process work from when a single executor should serve more
+	// than one queue. The handlers configured by WorkerOptions are shared between these
+	// queues according to their weights. This takes precedence over QueueName.
+	Queues []Queue
+
 	// GitServicePath is the path to the internal git service API proxy in the frontend.
 	// This path should contain the endpoints info/refs and git-upload-pack.
 	GitServicePath string
@@ -62,6 +71,13 @@ type Options struct {
 	// FirecrackerOptions configures the behavior of Firecracker virtual machine creation.
 	FirecrackerOptions command.FirecrackerOptions
 
+	// KubernetesOptions configures the behavior of Kubernetes jobs created for docker steps.
+	KubernetesOptions command.KubernetesOptions
+
+	// KubernetesConfigPath is the path to the kubeconfig used to connect to the cluster. The
+	// in-cluster configuration is used if empty.
+	KubernetesConfigPath string
+
 	// ResourceOptions configures the resource limits of docker container and Firecracker
 	// virtual machines running on the executor.
 	ResourceOptions command.ResourceOptions
@@ -75,6 +91,24 @@ type Options struct {
 	DockerRegistryNodeExporterEndpoint string
 }
 
+// Queue describes a queue processed by an executor serving multiple queues.
+type Queue struct {
+	// Name is the name of the queue.
+	Name string
+
+	// Weight is the share of the handlers the queue is entitled to, relative to the
+	// weights of the other queues. Handlers not needed by a queue can be used by others.
+	Weight int
+
+	// MaximumNumJobs is the maximum number of jobs of the queue that are handled at
+	// once. Zero means the queue can use all handlers.
+	MaximumNumJobs int
+
+	// WorkerOptions configures the worker processing this queue. The number of handlers
+	// and the total number of jobs are governed by the worker options of the executor.
+	WorkerOptions workerutil.WorkerOptions
+}
+
 // NewWorker creates a worker that polls a remote job queue API for work. The returned
 // routine contains both a worker that periodically polls for new work to perform, as well
 // as a heartbeat routine that will periodically hit the remote API with the work that is
@@ -91,24 +125,149 @@ func NewWorker(logger log.Logger, nameSet *janitor.NameSet, options Options, obs
 	if err != nil {
 		return nil, errors.Wrap(err, "building files store")
 	}
-	shim := &store.QueueShim{Name: options.QueueName, Store: queueStore}
+	if len(options.Queues) > 0 {
+		// Connectivity to the frontend is checked against the first queue.
+		options.QueueName = options.Queues[0].Name
+	}
 
 	if !connectToFrontend(logger, queueStore, options) {
 		os.Exit(1)
 	}
 
-	h := &handler{
+	runnerFactory := command.NewRunner
+	if options.KubernetesOptions.Enabled {
+		client, err := newKubernetesClient(options.KubernetesConfigPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "building kubernetes client")
+		}
+		runnerFactory = kubernetesRunnerFactory(client)
+	}
+
+	ctx := context.Background()
+
+	if len(options.Queues) == 0 {
+		shim := &store.QueueShim{Name: options.QueueName, Store: queueStore}
+		h := newHandler(nameSet, shim, filesStore, options, runnerFactory, observationContext)
+
+		return workerutil.NewWorker(ctx, shim, h, options.WorkerOptions), nil
+	}
+
+	s := newScheduler(options.WorkerOptions.NumHandlers, options.WorkerOptions.NumTotalJobs, options.Queues)
+	workers := make([]*workerutil.Worker, 0, len(options.Queues))
+	for _, queue := range options.Queues {
+		shim := &store.QueueShim{Name: queue.Name, Store: queueStore}
+		h := newHandler(nameSet, shim, filesStore, options, runnerFactory, observationContext)
+		h.queueName = queue.Name
+		h.scheduler = s
+
+		workerOptions := queue.WorkerOptions
+		workerOptions.NumHandlers = options.WorkerOptions.NumHandlers
+		if queue.MaximumNumJobs > 0 {
+			workerOptions.NumHandlers = queue.MaximumNumJobs
+		}
+		// The scheduler stops all workers once the total number of jobs is reached.
+		workerOptions.NumTotalJobs = 0
+
+		workers = append(workers, workerutil.NewWorker(ctx, &scheduledStore{Store: shim, queueName: queue.Name, scheduler: s}, h, workerOptions))
+	}
+
+	return newMultiQueueWorker(workers, s.exhausted), nil
+}
+
+func newHandler(nameSet *janitor.NameSet, store workerutil.Store, filesStore store.FilesStore, options Options, runnerFactory runnerFactory, observationContext *observation.Context) *handler {
+	return &handler{
 		nameSet:       nameSet,
-		store:         shim,
+		store:         store,
 		filesStore:    filesStore,
 		options:       options,
 		operations:    command.NewOperations(observationContext),
-		runnerFactory: command.NewRunner,
+		runnerFactory: runnerFactory,
 	}
+}
 
-	ctx := context.Background()
+type runnerFactory func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner
 
-	return workerutil.NewWorker(ctx, shim, h, options.WorkerOptions), nil
+// kubernetesRunnerFactory returns a runner factory that runs the steps of jobs as Kubernetes
+// jobs. The runners used to prepare workspaces run commands on the executor's host.
+func kubernetesRunnerFactory(client kubernetes.Interface) runnerFactory {
+	return func(dir string, logger command.Logger, options command.Options, operations *command.Operations) command.Runner {
+		if !options.KubernetesOptions.Enabled {
+			return command.NewRunner(dir, logger, options, operations)
+		}
+		return command.NewKubernetesRunner(client, dir, logger, options)
+	}
+}
+
+func newKubernetesClient(configPath string) (kubernetes.Interface, error) {
+	var (
+		config *rest.Config
+		err    error
+	)
+	if configPath == "" {
+		config, err = rest.InClusterConfig()
+	} else {
+		config, err = clientcmd.BuildConfigFromFlags("", configPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.NewForConfig(config)
+}
+
+// multiQueueWorker runs the workers of several queues as a single routine.
+type multiQueueWorker struct {
+	workers   []*workerutil.Worker
+	exhausted <-chan struct{}
+	stopOnce  sync.Once
+}
+
+var _ goroutine.WaitableBackgroundRoutine = &multiQueueWorker{}
+
+func newMultiQueueWorker(workers []*workerutil.Worker, exhausted <-chan struct{}) *multiQueueWorker {
+	return &multiQueueWorker{workers: workers, exhausted: exhausted}
+}
+
+func (w *multiQueueWorker) Start() {
+	for _, worker := range w.workers {
+		go worker.Start()
+	}
+
+	finished := make(chan struct{})
+	defer close(finished)
+
+	go func() {
+		select {
+		case <-w.exhausted:
+			w.Stop()
+		case <-finished:
+		}
+	}()
+
+	w.Wait()
+}
+
+// Stop stops the dequeue loops of all workers and blocks until all handlers have exited.
+func (w *multiQueueWorker) Stop() {
+	w.stopOnce.Do(func() {
+		var wg sync.WaitGroup
+		for _, worker := range w.workers {
+			wg.Add(1)
+			go func(worker *workerutil.Worker) {
+				defer wg.Done()
+				worker.Stop()
+			}(worker)
+		}
+		wg.Wait()
+	})
+}
+
+// Wait blocks until all workers have exited, which happens once they're stopped or each
+// of them has reached its maximum active time.
+func (w *multiQueueWorker) Wait() {
+	for _, worker := range w.workers {
+		worker.Wait()
+	}
 }
 
 // connectToFrontend will ping the configured Sourcegraph instance until it receives a 200 response.
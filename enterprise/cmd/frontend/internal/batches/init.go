@@ -7,9 +7,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
+	insightsstore "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -43,7 +46,19 @@ func Init(
 
 	// Register enterprise services.
 	gitserverClient := gitserver.NewClient(db)
-	enterpriseServices.BatchChangesResolver = resolvers.New(bstore, gitserverClient)
+
+	// Resolving repositoriesFromInsight: rules requires code insights.
+	var newWorkspaceResolver service.WorkspaceResolverBuilder = service.NewWorkspaceResolver
+	if insights.IsEnabled() {
+		insightsDB, err := insights.InitializeCodeInsightsDB("frontend-batches")
+		if err != nil {
+			return err
+		}
+		insightsStore := insightsstore.New(insightsDB, insightsstore.NewInsightPermissionStore(db))
+		newWorkspaceResolver = service.NewWorkspaceResolverBuilder(insightsStore)
+	}
+
+	enterpriseServices.BatchChangesResolver = resolvers.NewWithWorkspaceResolver(bstore, gitserverClient, newWorkspaceResolver)
 	enterpriseServices.GitHubWebhook = webhooks.NewGitHubWebhook(bstore, gitserverClient)
 	enterpriseServices.BitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(bstore, gitserverClient)
 	enterpriseServices.BitbucketCloudWebhook = webhooks.NewBitbucketCloudWebhook(bstore, gitserverClient)
@@ -170,6 +170,7 @@ func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.Ba
 			// See if we have a cache result for this step.
 			if cachedResult, ok := r.workspace.StepCacheResult(idx + 1); ok {
 				resolver.skipped = true
+				resolver.cachedResultFound = true
 				resolver.cachedResult = cachedResult.Value
 			} else if r.execution != nil {
 				e, ok := findExecutionLogEntry(r.execution, fmt.Sprintf("step.docker.step.%d.post", idx))
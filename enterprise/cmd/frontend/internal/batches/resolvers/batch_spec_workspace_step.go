@@ -47,7 +47,7 @@ func (r *batchSpecWorkspaceStepV1Resolver) IfCondition() *string {
 }
 
 func (r *batchSpecWorkspaceStepV1Resolver) CachedResultFound() bool {
-	return r.stepInfo.StartedAt.IsZero() && r.cachedResult != nil
+	return r.cachedResult != nil && (r.stepInfo.CacheHit || r.stepInfo.StartedAt.IsZero())
 }
 
 func (r *batchSpecWorkspaceStepV1Resolver) Skipped() bool {
@@ -30,7 +30,8 @@ func (c *batchChangesCodeHostResolver) RequiresSSH() bool {
 }
 
 func (c *batchChangesCodeHostResolver) RequiresUsername() bool {
-	return c.codeHost.ExternalServiceType == extsvc.TypeBitbucketCloud
+	return c.codeHost.ExternalServiceType == extsvc.TypeBitbucketCloud ||
+		c.codeHost.ExternalServiceType == extsvc.TypeAzureDevOps
 }
 
 func (c *batchChangesCodeHostResolver) HasWebhooks() bool {
@@ -38,6 +38,10 @@ import (
 type Resolver struct {
 	store           *store.Store
 	gitserverClient gitserver.Client
+
+	// newWorkspaceResolver is used to resolve the workspaces of batch specs.
+	// If nil, service.NewWorkspaceResolver is used.
+	newWorkspaceResolver service.WorkspaceResolverBuilder
 }
 
 // New returns a new Resolver whose store uses the given database
@@ -45,6 +49,12 @@ func New(store *store.Store, gitserverClient gitserver.Client) graphqlbackend.Ba
 	return &Resolver{store: store, gitserverClient: gitserverClient}
 }
 
+// NewWithWorkspaceResolver returns a new Resolver like New, which uses the
+// given WorkspaceResolverBuilder to resolve the workspaces of batch specs.
+func NewWithWorkspaceResolver(store *store.Store, gitserverClient gitserver.Client, newWorkspaceResolver service.WorkspaceResolverBuilder) graphqlbackend.BatchChangesResolver {
+	return &Resolver{store: store, gitserverClient: gitserverClient, newWorkspaceResolver: newWorkspaceResolver}
+}
+
 // batchChangesCreateAccess returns true if the current user has batch changes enabled for
 // them and can create batchChanges/changesetSpecs/batchSpecs.
 func batchChangesCreateAccess(ctx context.Context, db database.DB) error {
@@ -230,7 +240,11 @@ func (r *Resolver) ResolveWorkspacesForBatchSpec(ctx context.Context, args *grap
 	}
 
 	// Run the resolution.
-	resolver := service.NewWorkspaceResolver(r.store)
+	newWorkspaceResolver := r.newWorkspaceResolver
+	if newWorkspaceResolver == nil {
+		newWorkspaceResolver = service.NewWorkspaceResolver
+	}
+	resolver := newWorkspaceResolver(r.store)
 	workspaces, err := resolver.ResolveWorkspacesForBatchSpec(ctx, evaluatableSpec)
 	if err != nil {
 		return nil, err
@@ -1181,7 +1195,7 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeAzureDevOps {
 		a = &extsvcauth.BasicAuthWithSSH{
 			BasicAuth:  extsvcauth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1416,6 +1430,44 @@ func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.Mer
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) AutoMergeChangesets(ctx context.Context, args *graphqlbackend.AutoMergeChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.AutoMergeChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
+	svc := service.New(r.store)
+	published := btypes.ChangesetPublicationStatePublished
+	openState := btypes.ChangesetExternalStateOpen
+	bulkGroupID, err := svc.CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		changesetIDs,
+		btypes.ChangesetJobTypeAutoMerge,
+		&btypes.ChangesetJobAutoMergePayload{Squash: args.Squash},
+		store.ListChangesetsOpts{
+			PublicationState: &published,
+			ReconcilerStates: []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+			ExternalStates:   []btypes.ChangesetExternalState{openState},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
 func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.CloseChangesetsArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.CloseChangesets", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
 	defer func() {
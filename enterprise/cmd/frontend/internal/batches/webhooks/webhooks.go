@@ -9,6 +9,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
@@ -167,11 +168,20 @@ func (h Webhook) upsertChangesetEvent(
 	events, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{cs.ID},
 	})
+	wasMerged := cs.ExternalState == btypes.ChangesetExternalStateMerged
 	state.SetDerivedState(ctx, tx.Repos(), h.gitserverClient, cs, events)
 	if err := tx.UpdateChangesetCodeHostState(ctx, cs); err != nil {
 		return err
 	}
 
+	// Other changesets in the batch change may be waiting for this one to be
+	// merged before they can be published.
+	if !wasMerged && cs.ExternalState == btypes.ChangesetExternalStateMerged && !cs.IsImported() {
+		if err := tx.EnqueueChangesetsWaitingOnDependencies(ctx, cs.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
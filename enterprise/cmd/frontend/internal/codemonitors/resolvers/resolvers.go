@@ -14,9 +14,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -122,6 +125,10 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 		return nil, err
 	}
 
+	if err := codemonitors.ValidateQuery(args.Trigger.Query); err != nil {
+		return nil, err
+	}
+
 	// Start transaction.
 	tx, err := r.transact(ctx)
 	if err != nil {
@@ -227,6 +234,10 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 		return nil, err
 	}
 
+	if err := codemonitors.ValidateQuery(args.Trigger.Update.Query); err != nil {
+		return nil, err
+	}
+
 	// Get all action IDs of the monitor.
 	actionIDs, err := r.actionIDsForMonitorIDInt64(ctx, monitorID)
 	if err != nil {
@@ -290,15 +301,24 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.Issue != nil:
+			repoID, err := r.validateIssueRepository(ctx, a.Issue.Repository)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateIssueAction(ctx, monitorID, a.Issue.Enabled, a.Issue.IncludeResults, repoID)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or Issue must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, issue []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -313,8 +333,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionIssueKind:
+			issue = append(issue, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or issue")
 		}
 	}
 
@@ -330,6 +352,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteIssueActions(ctx, monitorID, issue...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -441,7 +467,11 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	issueActions, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(issueActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -451,6 +481,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, issueAction := range issueActions {
+		ids = append(ids, (&monitorIssue{IssueAction: issueAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -495,6 +528,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.Issue != nil:
+			if a.Issue.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Issue: a.Issue.Update})
+				continue
+			}
+			if _, ok := aMap[*a.Issue.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Issue.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.Issue.Id)
 		}
 	}
 
@@ -579,8 +622,10 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.Issue != nil:
+			err = r.updateIssueAction(ctx, *action.Issue)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or issue")
 		}
 		if err != nil {
 			return nil, err
@@ -636,6 +681,22 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateIssueAction(ctx context.Context, args graphqlbackend.EditActionIssueArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	repoID, err := r.validateIssueRepository(ctx, args.Update.Repository)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIssueAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, repoID)
+	return err
+}
+
 func (r *Resolver) transact(ctx context.Context) (*Resolver, error) {
 	tx, err := r.db.Transact(ctx)
 	if err != nil {
@@ -721,9 +782,11 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionIssueKind             = "CodeMonitorActionIssue"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionIssueEventKind        = "CodeMonitorActionIssueEvent"
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
@@ -816,7 +879,12 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	is, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(is))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -844,6 +912,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, i := range is {
+		actions = append(actions, &action{
+			issue: &monitorIssue{
+				Resolver:       r,
+				IssueAction:    i,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1020,6 +1097,7 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	issue        graphqlbackend.MonitorIssueResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1030,6 +1108,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.issue != nil:
+		return a.issue.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1047,6 +1127,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorIssue() (graphqlbackend.MonitorIssueResolver, bool) {
+	return a.issue, a.issue != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1262,6 +1346,70 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorIssue struct {
+	*Resolver
+	*edb.IssueAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIssue) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIssueKind, m.IssueAction.ID)
+}
+
+func (m *monitorIssue) Enabled() bool {
+	return m.IssueAction.Enabled
+}
+
+func (m *monitorIssue) IncludeResults() bool {
+	return m.IssueAction.IncludeResults
+}
+
+func (m *monitorIssue) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	repo, err := m.db.Repos().Get(ctx, m.IssueAction.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(m.db, gitserver.NewClient(m.db), repo), nil
+}
+
+func (m *monitorIssue) IssueURL() *string {
+	return m.IssueAction.IssueURL
+}
+
+func (m *monitorIssue) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          intPtr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        intPtr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtr(i int) *int { return &i }
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
@@ -1355,3 +1503,25 @@ func validateSlackURL(urlString string) error {
 	}
 	return nil
 }
+
+// validateIssueRepository checks that the repository with the given GraphQL ID
+// is visible to the current user and hosted on a code host that supports issue
+// actions.
+func (r *Resolver) validateIssueRepository(ctx context.Context, id graphql.ID) (api.RepoID, error) {
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(id)
+	if err != nil {
+		return 0, err
+	}
+
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return 0, err
+	}
+
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub, extsvc.TypeGitLab:
+		return repoID, nil
+	default:
+		return 0, errors.Errorf("issue actions are only supported for GitHub and GitLab repositories, %q is hosted on %s", repo.Name, repo.ExternalRepo.ServiceType)
+	}
+}
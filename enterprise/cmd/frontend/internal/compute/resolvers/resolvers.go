@@ -180,6 +180,8 @@ func pathAndCommitFromResult(m result.Match) (string, string) {
 		return "", string(v.Commit.ID)
 	case *result.RepoMatch:
 		return "", v.Rev
+	case *result.OwnerMatch:
+		return "", string(v.CommitID)
 	}
 	return "", ""
 }
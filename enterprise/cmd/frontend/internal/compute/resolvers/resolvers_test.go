@@ -55,3 +55,27 @@ func TestToResultResolverList(t *testing.T) {
 	producesNilResult := []result.Match{&result.CommitMatch{}}
 	autogold.Want("resolver ignores nil compute result", "[]").Equal(t, test("a|b", producesNilResult))
 }
+
+func TestToResultResolverListOwnerMatch(t *testing.T) {
+	computeQuery, err := compute.Parse("content:output(.+ -> $content) select:file.owners")
+	if err != nil {
+		t.Fatal(err)
+	}
+	matches := []result.Match{
+		&result.OwnerMatch{Handle: "alice", CommitID: "deadbeef"},
+		&result.OwnerMatch{Email: "bob@example.com", CommitID: "deadbeef"},
+	}
+	resolvers, err := toResultResolverList(context.Background(), computeQuery.Command, matches, database.NewMockDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results := make([]string, 0, len(resolvers))
+	for _, r := range resolvers {
+		if rr, ok := r.ToComputeText(); ok {
+			results = append(results, rr.Value())
+		}
+	}
+	v, _ := json.Marshal(results)
+	autogold.Want("resolver outputs owners", `["@alice","bob@example.com"]`).Equal(t, string(v))
+}
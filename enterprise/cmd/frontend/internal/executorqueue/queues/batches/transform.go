@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
@@ -141,6 +142,44 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		}
 	}
 
+	workspaceFiles, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecRandID: batchSpec.RandID})
+	if err != nil {
+		return apiclient.Job{}, errors.Wrap(err, "fetching workspace files")
+	}
+
+	skipped, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), workspace.FileMatches)
+	if err != nil {
+		return apiclient.Job{}, err
+	}
+
+	// batcheshelper stores the result of each step under the same cache key
+	// that is looked up when resolving workspaces, so that unchanged steps
+	// are skipped in later runs.
+	if job.Version == 2 {
+		stepCacheKeys, err := service.StepCacheKeysForWorkspace(
+			batchSpec.Spec,
+			batcheslib.Repository{
+				ID:          executionInput.Repository.ID,
+				Name:        executionInput.Repository.Name,
+				BaseRef:     workspace.Branch,
+				BaseRev:     workspace.Commit,
+				FileMatches: workspace.FileMatches,
+			},
+			workspace.Path,
+			workspace.OnlyFetchWorkspace,
+			secretEnvVars,
+			workspaceFiles,
+			skipped,
+		)
+		if err != nil {
+			return apiclient.Job{}, errors.Wrap(err, "computing step cache keys")
+		}
+		executionInput.StepCacheKeys = make(map[int]string, len(stepCacheKeys))
+		for _, ck := range stepCacheKeys {
+			executionInput.StepCacheKeys[ck.Index] = ck.Key
+		}
+	}
+
 	// Marshal the execution input into JSON and add it to the files passed to
 	// the VM.
 	marshaledInput, err := json.Marshal(executionInput)
@@ -153,10 +192,6 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		},
 	}
 
-	workspaceFiles, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecRandID: batchSpec.RandID})
-	if err != nil {
-		return apiclient.Job{}, errors.Wrap(err, "fetching workspace files")
-	}
 	for _, workspaceFile := range workspaceFiles {
 		files[filepath.Join(srcWorkspaceFilesDir, workspaceFile.Path, workspaceFile.FileName)] = apiclient.VirtualMachineFile{
 			Bucket:     fileStoreBucket,
@@ -223,11 +258,6 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 			}
 		}
 
-		skipped, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), workspace.FileMatches)
-		if err != nil {
-			return apiclient.Job{}, err
-		}
-
 		for i := startStep; i < len(batchSpec.Spec.Steps); i++ {
 			step := batchSpec.Spec.Steps[i]
 
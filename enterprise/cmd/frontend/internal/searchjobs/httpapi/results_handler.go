@@ -0,0 +1,130 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	sglog "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ResultsHandler serves the results of search jobs.
+type ResultsHandler struct {
+	logger      sglog.Logger
+	db          database.DB
+	store       searchjobs.Store
+	uploadStore uploadstore.Store
+}
+
+// NewResultsHandler creates a new ResultsHandler.
+func NewResultsHandler(db database.DB, store searchjobs.Store, uploadStore uploadstore.Store) *ResultsHandler {
+	return &ResultsHandler{
+		logger:      sglog.Scoped("ResultsHandler", "search jobs results REST API handler"),
+		db:          db,
+		store:       store,
+		uploadStore: uploadStore,
+	}
+}
+
+// ServeHTTP writes the results of all repository revisions of a search job
+// that have been searched so far, in the order they were enqueued.
+func (h *ResultsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	job, format, repoRevisionJobs, statusCode, err := h.get(r)
+	if err != nil {
+		http.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"search-job-%d.%s\"", job.ID, format))
+	w.WriteHeader(http.StatusOK)
+
+	if err := h.write(r.Context(), w, format, repoRevisionJobs); err != nil {
+		// The status code has already been written, so all we can do is
+		// to stop writing.
+		h.logger.Error("failed to write search job results", sglog.Int64("jobID", job.ID), sglog.Error(err))
+	}
+}
+
+func (h *ResultsHandler) get(r *http.Request) (_ *searchjobs.Job, _ searchjobs.Format, _ []*searchjobs.RepoRevisionJob, statusCode int, err error) {
+	ctx := r.Context()
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		return nil, "", nil, http.StatusBadRequest, errors.Wrap(err, "parsing search job ID")
+	}
+	format, err := searchjobs.ParseFormat(vars["format"])
+	if err != nil {
+		return nil, "", nil, http.StatusBadRequest, err
+	}
+
+	job, err := h.store.GetJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, searchjobs.ErrJobNotFound) {
+			return nil, "", nil, http.StatusNotFound, err
+		}
+		return nil, "", nil, http.StatusInternalServerError, errors.Wrap(err, "retrieving search job")
+	}
+
+	// 🚨 SECURITY: Only the creator of a job and site admins can download its
+	// results.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, h.db, job.UserID); err != nil {
+		// Do not reveal that the job exists.
+		return nil, "", nil, http.StatusNotFound, searchjobs.ErrJobNotFound
+	}
+
+	repoRevisionJobs, err := h.store.ListRepoRevisionJobs(ctx, searchjobs.ListRepoRevisionJobsOpts{
+		JobID:  job.ID,
+		States: []searchjobs.State{searchjobs.StateCompleted},
+	})
+	if err != nil {
+		return nil, "", nil, http.StatusInternalServerError, errors.Wrap(err, "listing searched repository revisions")
+	}
+
+	return job, format, repoRevisionJobs, http.StatusOK, nil
+}
+
+func (h *ResultsHandler) write(ctx context.Context, w io.Writer, format searchjobs.Format, repoRevisionJobs []*searchjobs.RepoRevisionJob) error {
+	if format == searchjobs.FormatCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(searchjobs.CSVHeader); err != nil {
+			return err
+		}
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+	}
+
+	for _, repoRevisionJob := range repoRevisionJobs {
+		if repoRevisionJob.ResultCount == 0 {
+			continue
+		}
+		if err := h.copy(ctx, w, searchjobs.ResultsKey(repoRevisionJob, format)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *ResultsHandler) copy(ctx context.Context, w io.Writer, key string) error {
+	rc, err := h.uploadStore.Get(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "retrieving %s", key)
+	}
+	defer rc.Close()
+
+	_, err = io.Copy(w, rc)
+	return err
+}
@@ -0,0 +1,41 @@
+package searchjobs
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchjobs/httpapi"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/searchjobs/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+var uploadStoreConfigInst = &searchjobs.UploadStoreConfig{}
+
+// Init initializes the given enterpriseServices to include the resolvers for
+// search jobs and the handler serving their results.
+func Init(
+	ctx context.Context,
+	db database.DB,
+	_ codeintel.Services,
+	_ conftypes.UnifiedWatchable,
+	enterpriseServices *enterprise.Services,
+	observationContext *observation.Context,
+) error {
+	uploadStoreConfigInst.Load()
+	if err := uploadStoreConfigInst.Validate(); err != nil {
+		return err
+	}
+
+	uploadStore, err := searchjobs.NewUploadStore(ctx, uploadStoreConfigInst, observationContext)
+	if err != nil {
+		return err
+	}
+
+	enterpriseServices.SearchJobsResolver = resolvers.NewResolver(db)
+	enterpriseServices.SearchJobsResultsHandler = httpapi.NewResultsHandler(db, searchjobs.NewStore(db), uploadStore)
+	return nil
+}
@@ -0,0 +1,318 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func NewResolver(db database.DB) graphqlbackend.SearchJobsResolver {
+	return &Resolver{db: db, store: searchjobs.NewStore(db)}
+}
+
+type Resolver struct {
+	db    database.DB
+	store searchjobs.Store
+}
+
+func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
+	return map[string]graphqlbackend.NodeByIDFunc{
+		searchJobIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.searchJobByID(ctx, id)
+		},
+	}
+}
+
+const searchJobIDKind = "SearchJob"
+
+func marshalSearchJobID(id int64) graphql.ID {
+	return relay.MarshalID(searchJobIDKind, id)
+}
+
+func unmarshalSearchJobID(id graphql.ID) (jobID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != searchJobIDKind {
+		err = errors.Errorf("expected graphql ID to have kind %q; got %q", searchJobIDKind, kind)
+		return
+	}
+	err = relay.UnmarshalSpec(id, &jobID)
+	return
+}
+
+func marshalSearchJobCursor(cursor int64) string {
+	return string(relay.MarshalID("SearchJobCursor", cursor))
+}
+
+func unmarshalSearchJobCursor(cursor *string) (int64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+	var after int64
+	if err := relay.UnmarshalSpec(graphql.ID(*cursor), &after); err != nil {
+		return 0, err
+	}
+	return after, nil
+}
+
+// getJob returns the search job with the given ID.
+//
+// 🚨 SECURITY: Only the creator of a job and site admins can access it.
+func (r *Resolver) getJob(ctx context.Context, id graphql.ID) (*searchjobs.Job, error) {
+	jobID, err := unmarshalSearchJobID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := r.store.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, job.UserID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (r *Resolver) searchJobByID(ctx context.Context, id graphql.ID) (graphqlbackend.SearchJobResolver, error) {
+	job, err := r.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: r.db, store: r.store, job: job}, nil
+}
+
+func (r *Resolver) CreateSearchJob(ctx context.Context, args graphqlbackend.CreateSearchJobArgs) (graphqlbackend.SearchJobResolver, error) {
+	user, err := auth.CurrentUser(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, auth.ErrNotAuthenticated
+	}
+
+	if strings.TrimSpace(args.Query) == "" {
+		return nil, errors.New("search job query must not be empty")
+	}
+
+	job, err := r.store.CreateJob(ctx, user.ID, args.Query)
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: r.db, store: r.store, job: job}, nil
+}
+
+func (r *Resolver) CancelSearchJob(ctx context.Context, args graphqlbackend.CancelSearchJobArgs) (*graphqlbackend.EmptyResponse, error) {
+	job, err := r.getJob(ctx, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.store.CancelJob(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) SearchJobs(ctx context.Context, args graphqlbackend.ListSearchJobsArgs) (graphqlbackend.SearchJobConnectionResolver, error) {
+	var userID int32
+	if args.UserID != nil {
+		id, err := graphqlbackend.UnmarshalUserID(*args.UserID)
+		if err != nil {
+			return nil, err
+		}
+		userID = id
+	} else {
+		user, err := auth.CurrentUser(ctx, r.db)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, auth.ErrNotAuthenticated
+		}
+		userID = user.ID
+	}
+
+	// 🚨 SECURITY: Only site admins can list the search jobs of other users.
+	if err := auth.CheckSiteAdminOrSameUser(ctx, r.db, userID); err != nil {
+		return nil, err
+	}
+
+	after, err := unmarshalSearchJobCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	return &searchJobConnectionResolver{
+		db:    r.db,
+		store: r.store,
+		opts: searchjobs.ListJobsOpts{
+			UserID: userID,
+			First:  int(args.First),
+			After:  after,
+		},
+	}, nil
+}
+
+type searchJobConnectionResolver struct {
+	db    database.DB
+	store searchjobs.Store
+	opts  searchjobs.ListJobsOpts
+
+	once sync.Once
+	jobs []*searchjobs.Job
+	next int64
+	err  error
+}
+
+func (r *searchJobConnectionResolver) compute(ctx context.Context) ([]*searchjobs.Job, int64, error) {
+	r.once.Do(func() {
+		opts := r.opts
+		if opts.First > 0 {
+			// Fetch one more job to know whether there is a next page.
+			opts.First++
+		}
+		r.jobs, r.err = r.store.ListJobs(ctx, opts)
+		if r.err == nil && r.opts.First > 0 && len(r.jobs) > r.opts.First {
+			r.jobs = r.jobs[:r.opts.First]
+			r.next = r.jobs[len(r.jobs)-1].ID
+		}
+	})
+	return r.jobs, r.next, r.err
+}
+
+func (r *searchJobConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.SearchJobResolver, error) {
+	jobs, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.SearchJobResolver, 0, len(jobs))
+	for _, job := range jobs {
+		resolvers = append(resolvers, &searchJobResolver{db: r.db, store: r.store, job: job})
+	}
+	return resolvers, nil
+}
+
+func (r *searchJobConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	return r.store.CountJobs(ctx, r.opts)
+}
+
+func (r *searchJobConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next == 0 {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(marshalSearchJobCursor(next)), nil
+}
+
+type searchJobResolver struct {
+	db    database.DB
+	store searchjobs.Store
+	job   *searchjobs.Job
+
+	once  sync.Once
+	stats searchjobs.JobStats
+	err   error
+}
+
+func (r *searchJobResolver) computeStats(ctx context.Context) (searchjobs.JobStats, error) {
+	r.once.Do(func() {
+		r.stats, r.err = r.store.GetJobStats(ctx, r.job.ID)
+	})
+	return r.stats, r.err
+}
+
+func (r *searchJobResolver) ID() graphql.ID {
+	return marshalSearchJobID(r.job.ID)
+}
+
+func (r *searchJobResolver) Query() string {
+	return r.job.Query
+}
+
+func (r *searchJobResolver) State(ctx context.Context) (string, error) {
+	stats, err := r.computeStats(ctx)
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(string(searchjobs.AggregateState(r.job, stats))), nil
+}
+
+func (r *searchJobResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.job.UserID)
+	if err != nil {
+		// Handle soft-deleted users
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *searchJobResolver) CreatedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.job.CreatedAt}
+}
+
+func (r *searchJobResolver) StartedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.job.StartedAt)
+}
+
+func (r *searchJobResolver) FinishedAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.job.FinishedAt)
+}
+
+func (r *searchJobResolver) FailureMessage() *string {
+	return r.job.FailureMessage
+}
+
+func (r *searchJobResolver) Stats(ctx context.Context) (graphqlbackend.SearchJobStatsResolver, error) {
+	stats, err := r.computeStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobStatsResolver{stats}, nil
+}
+
+func (r *searchJobResolver) ResultsURL(args graphqlbackend.SearchJobResultsURLArgs) (string, error) {
+	format, err := searchjobs.ParseFormat(strings.ToLower(args.Format))
+	if err != nil {
+		return "", err
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse site config external URL")
+	}
+	externalURL.Path = fmt.Sprintf(".api/search/jobs/%d/results.%s", r.job.ID, format)
+	return externalURL.String(), nil
+}
+
+type searchJobStatsResolver struct {
+	stats searchjobs.JobStats
+}
+
+func (r *searchJobStatsResolver) Total() int32       { return r.stats.Total }
+func (r *searchJobStatsResolver) InProgress() int32  { return r.stats.Queued + r.stats.Processing }
+func (r *searchJobStatsResolver) Completed() int32   { return r.stats.Completed }
+func (r *searchJobStatsResolver) Failed() int32      { return r.stats.Failed }
+func (r *searchJobStatsResolver) Canceled() int32    { return r.stats.Canceled }
+func (r *searchJobStatsResolver) ResultCount() int32 { return r.stats.ResultCount }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -14,16 +15,19 @@ import (
 )
 
 // NewBatchSpecResolutionWorker creates a dbworker.newWorker that fetches BatchSpecResolutionJobs
-// specs and passes them to the batchSpecWorkspaceCreator.
+// specs and passes them to the batchSpecWorkspaceCreator, which uses
+// newResolver to resolve the workspaces.
 func NewBatchSpecResolutionWorker(
 	ctx context.Context,
 	s *store.Store,
 	workerStore dbworkerstore.Store,
+	newResolver service.WorkspaceResolverBuilder,
 	observationContext *observation.Context,
 ) *workerutil.Worker {
 	e := &batchSpecWorkspaceCreator{
-		store:  s,
-		logger: log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
+		store:       s,
+		newResolver: newResolver,
+		logger:      log.Scoped("batch-spec-workspace-creator", "The background worker running workspace resolutions for batch changes"),
 	}
 
 	options := workerutil.WorkerOptions{
@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
-	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -23,15 +21,15 @@ import (
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
-	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // batchSpecWorkspaceCreator takes in BatchSpecs, resolves them into
 // RepoWorkspaces and then persists those as pending BatchSpecWorkspaces.
 type batchSpecWorkspaceCreator struct {
-	store  *store.Store
-	logger log.Logger
+	store       *store.Store
+	newResolver service.WorkspaceResolverBuilder
+	logger      log.Logger
 }
 
 // HandlerFunc returns a workerutil.HandlerFunc that can be passed to a
@@ -44,19 +42,14 @@ func (r *batchSpecWorkspaceCreator) HandlerFunc() workerutil.HandlerFunc {
 		// that are visible to the user are returned.
 		ctx = actor.WithActor(ctx, actor.FromUser(job.InitiatorID))
 
-		return r.process(ctx, service.NewWorkspaceResolver, job)
+		return r.process(ctx, r.newResolver, job)
 	}
 }
 
-type stepCacheKey struct {
-	index int
-	key   string
-}
-
 type workspaceCacheKey struct {
 	dbWorkspace   *btypes.BatchSpecWorkspace
 	repo          batcheslib.Repository
-	stepCacheKeys []stepCacheKey
+	stepCacheKeys []service.StepCacheKey
 	skippedSteps  map[int]struct{}
 }
 
@@ -115,15 +108,17 @@ func (r *batchSpecWorkspaceCreator) process(
 
 	// Build DB workspaces and check for cache entries.
 	ws := make([]*btypes.BatchSpecWorkspace, 0, len(workspaces))
-	// Collect all cache keys so we can look them up in a single query.
+	// Collect all cache keys so we can look them up at once.
 	cacheKeyWorkspaces := make([]workspaceCacheKey, 0, len(workspaces))
 	allStepCacheKeys := make([]string, 0, len(workspaces))
+	// Cache keys of steps whose results are only reused for the same user, and
+	// of steps whose results are shared with other users.
+	var ownStepCacheKeys, sharedStepCacheKeys []string
 	// load the mounts from the DB up front to avoid duplicate calls with no difference in data
 	mounts, err := listBatchSpecMounts(ctx, r.store, spec.ID)
 	if err != nil {
 		return err
 	}
-	retriever := &remoteFileMetadataRetriever{mounts: mounts}
 
 	// Build workspaces DB objects.
 	for _, w := range workspaces {
@@ -167,34 +162,18 @@ func (r *batchSpecWorkspaceCreator) process(
 			return err
 		}
 
-		stepCacheKeys := make([]stepCacheKey, 0, len(spec.Spec.Steps))
 		// Generate cache keys for all the steps.
-		for i := 0; i < len(spec.Spec.Steps); i++ {
-			if _, ok := skippedSteps[i]; ok {
-				continue
-			}
-
-			key := cache.KeyForWorkspace(
-				&template.BatchChangeAttributes{
-					Name:        spec.Spec.Name,
-					Description: spec.Spec.Description,
-				},
-				repo,
-				w.Path,
-				envVars,
-				w.OnlyFetchWorkspace,
-				spec.Spec.Steps,
-				i,
-				retriever,
-			)
-
-			rawStepKey, err := key.Key()
-			if err != nil {
-				return err
+		stepCacheKeys, err := service.StepCacheKeysForWorkspace(spec.Spec, repo, w.Path, w.OnlyFetchWorkspace, envVars, mounts, skippedSteps)
+		if err != nil {
+			return err
+		}
+		for _, ck := range stepCacheKeys {
+			allStepCacheKeys = append(allStepCacheKeys, ck.Key)
+			if ck.Shared {
+				sharedStepCacheKeys = append(sharedStepCacheKeys, ck.Key)
+			} else {
+				ownStepCacheKeys = append(ownStepCacheKeys, ck.Key)
 			}
-
-			stepCacheKeys = append(stepCacheKeys, stepCacheKey{index: i, key: rawStepKey})
-			allStepCacheKeys = append(allStepCacheKeys, rawStepKey)
 		}
 
 		cacheKeyWorkspaces = append(cacheKeyWorkspaces, workspaceCacheKey{
@@ -206,11 +185,17 @@ func (r *batchSpecWorkspaceCreator) process(
 	}
 
 	stepEntriesByCacheKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry, len(allStepCacheKeys))
-	if len(allStepCacheKeys) > 0 {
-		entries, err := r.store.ListBatchSpecExecutionCacheEntries(ctx, store.ListBatchSpecExecutionCacheEntriesOpts{
-			UserID: spec.UserID,
-			Keys:   allStepCacheKeys,
-		})
+	for _, opts := range []store.ListBatchSpecExecutionCacheEntriesOpts{
+		{UserID: spec.UserID, Keys: ownStepCacheKeys},
+		// Step results are shared across users, since the cache keys cover
+		// the repository, the steps, the secrets they use and the digests of
+		// their images.
+		{UserID: spec.UserID, Keys: sharedStepCacheKeys, IncludeShared: true},
+	} {
+		if len(opts.Keys) == 0 {
+			continue
+		}
+		entries, err := r.store.ListBatchSpecExecutionCacheEntries(ctx, opts)
 		if err != nil {
 			return err
 		}
@@ -228,8 +213,8 @@ func (r *batchSpecWorkspaceCreator) process(
 	// Check for an existing cache entry for each of the workspaces.
 	for _, workspace := range cacheKeyWorkspaces {
 		for _, ck := range workspace.stepCacheKeys {
-			key := ck.key
-			idx := ck.index
+			key := ck.Key
+			idx := ck.Index
 			if c, ok := stepEntriesByCacheKey[key]; ok {
 				var res execution.AfterStepResult
 				if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
@@ -343,42 +328,6 @@ func listBatchSpecMounts(ctx context.Context, s *store.Store, batchSpecID int64)
 	return mounts, nil
 }
 
-type remoteFileMetadataRetriever struct {
-	mounts []*btypes.BatchSpecWorkspaceFile
-}
-
-func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.MountMetadata, error) {
-	var mountsMetadata []cache.MountMetadata
-	for _, step := range steps {
-		for _, stepMount := range step.Mount {
-			metadata, err := getMountMetadata(r.mounts, stepMount.Path)
-			if err != nil {
-				return nil, err
-			}
-			mountsMetadata = append(mountsMetadata, metadata)
-		}
-	}
-	return mountsMetadata, nil
-}
-
-func getMountMetadata(mounts []*btypes.BatchSpecWorkspaceFile, path string) (metadata cache.MountMetadata, err error) {
-	dir, file := filepath.Split(path)
-	dir = strings.TrimSuffix(dir, string(filepath.Separator))
-	dir = strings.TrimPrefix(dir, fmt.Sprintf(".%s", string(filepath.Separator)))
-	mountPath := filepath.Join(dir, file)
-
-	for _, mount := range mounts {
-		if filepath.Join(mount.Path, mount.FileName) == mountPath {
-			return cache.MountMetadata{
-				Path:     mountPath,
-				Size:     mount.Size,
-				Modified: mount.ModifiedAt,
-			}, nil
-		}
-	}
-	return metadata, errors.New("could not find a matching mount entry")
-}
-
 func changesetSpecsForImports(ctx context.Context, s *store.Store, importChangesets []batcheslib.ImportChangeset, batchSpecID int64, userID int32) ([]*btypes.ChangesetSpec, error) {
 	cs := []*btypes.ChangesetSpec{}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -266,7 +267,7 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 			workspace.OnlyFetchWorkspace,
 			batchSpec.Spec.Steps,
 			result.StepIndex,
-			&remoteFileMetadataRetriever{mounts: mounts},
+			service.NewMountMetadataRetriever(mounts),
 		)
 		rawKey, err := key.Key()
 		if err != nil {
@@ -351,6 +352,87 @@ func TestBatchSpecWorkspaceCreatorProcess_Caching(t *testing.T) {
 		}
 	})
 
+	t.Run("cache entry of another user", func(t *testing.T) {
+		workspace := buildWorkspace("cache-entry-of-another-user")
+
+		// Only results of images pinned to a digest are shared.
+		pinnedSpec := strings.Replace(bt.TestRawBatchSpecYAML, "container: alpine\n", "container: alpine@sha256:8b29bc1\n", 1)
+		batchSpec := createBatchSpec(t, false, pinnedSpec)
+		otherUser := bt.CreateTestUser(t, db, false)
+		otherBatchSpec := *batchSpec
+		otherBatchSpec.UserID = otherUser.ID
+		entry := createCacheEntry(t, &otherBatchSpec, workspace, executionResult, secretValue, nil)
+
+		resolver := &dummyWorkspaceResolver{workspaces: []*service.RepoWorkspace{workspace}}
+		job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID}
+		if err := creator.process(userCtx, resolver.DummyBuilder, job); err != nil {
+			t.Fatalf("proces failed: %s", err)
+		}
+
+		have, _, err := s.ListBatchSpecWorkspaces(context.Background(), store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+		if err != nil {
+			t.Fatalf("listing workspaces failed: %s", err)
+		}
+
+		// Step results are shared across users.
+		assertWorkspacesEqual(t, have, []*btypes.BatchSpecWorkspace{
+			{
+				RepoID:             repos[0].ID,
+				BatchSpecID:        batchSpec.ID,
+				ChangesetSpecIDs:   have[0].ChangesetSpecIDs,
+				Branch:             "refs/heads/main",
+				Commit:             "cache-entry-of-another-user",
+				FileMatches:        []string{},
+				Path:               "",
+				OnlyFetchWorkspace: true,
+				CachedResultFound:  true,
+				StepCacheResults: map[int]btypes.StepCacheResult{
+					1: {
+						Key:   entry.Key,
+						Value: executionResult,
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("cache entry of another user with image tag", func(t *testing.T) {
+		workspace := buildWorkspace("cache-entry-of-another-user-tag")
+
+		batchSpec := createBatchSpec(t, false, bt.TestRawBatchSpecYAML)
+		otherUser := bt.CreateTestUser(t, db, false)
+		otherBatchSpec := *batchSpec
+		otherBatchSpec.UserID = otherUser.ID
+		createCacheEntry(t, &otherBatchSpec, workspace, executionResult, secretValue, nil)
+
+		resolver := &dummyWorkspaceResolver{workspaces: []*service.RepoWorkspace{workspace}}
+		job := &btypes.BatchSpecResolutionJob{BatchSpecID: batchSpec.ID}
+		if err := creator.process(userCtx, resolver.DummyBuilder, job); err != nil {
+			t.Fatalf("proces failed: %s", err)
+		}
+
+		have, _, err := s.ListBatchSpecWorkspaces(context.Background(), store.ListBatchSpecWorkspacesOpts{BatchSpecID: batchSpec.ID})
+		if err != nil {
+			t.Fatalf("listing workspaces failed: %s", err)
+		}
+
+		// The tag may have moved to another image since the other user ran
+		// the step.
+		assertWorkspacesEqual(t, have, []*btypes.BatchSpecWorkspace{
+			{
+				RepoID:             repos[0].ID,
+				BatchSpecID:        batchSpec.ID,
+				ChangesetSpecIDs:   []int64{},
+				Branch:             "refs/heads/main",
+				Commit:             "cache-entry-of-another-user-tag",
+				FileMatches:        []string{},
+				Path:               "",
+				OnlyFetchWorkspace: true,
+				CachedResultFound:  false,
+			},
+		})
+	})
+
 	t.Run("secret value changed", func(t *testing.T) {
 		workspace := buildWorkspace("secret-value-changed")
 
@@ -10,6 +10,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/batches/workers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
+	insightsstore "github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
@@ -49,10 +52,23 @@ func (j *workspaceResolverJob) Routines(_ context.Context, logger log.Logger) ([
 		return nil, err
 	}
 
+	// Code insights are optional, so repositoriesFromInsight: rules can only
+	// be resolved if they are enabled.
+	var newResolver service.WorkspaceResolverBuilder = service.NewWorkspaceResolver
+	if insights.IsEnabled() {
+		insightsDB, err := insights.InitializeCodeInsightsDB("batches-workspace-resolver")
+		if err != nil {
+			return nil, err
+		}
+		permStore := insightsstore.NewInsightPermissionStore(bstore.DatabaseDB())
+		newResolver = service.NewWorkspaceResolverBuilder(insightsstore.New(insightsDB, permStore))
+	}
+
 	resolverWorker := workers.NewBatchSpecResolutionWorker(
 		workCtx,
 		bstore,
 		resStore,
+		newResolver,
 		observationContext,
 	)
 
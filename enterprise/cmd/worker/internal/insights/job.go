@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
 	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/shared/init/codeintel"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -47,7 +48,13 @@ func (s *insightsJob) Routines(startupCtx context.Context, logger log.Logger) ([
 		return nil, err
 	}
 
-	return background.GetBackgroundJobs(context.Background(), logger, db, insightsDB), nil
+	// Code intelligence services are used to count precise references to symbols.
+	services, err := codeintel.InitServices()
+	if err != nil {
+		return nil, err
+	}
+
+	return background.GetBackgroundJobs(context.Background(), logger, db, insightsDB, services.CodenavService), nil
 }
 
 func NewInsightsJob() job.Job {
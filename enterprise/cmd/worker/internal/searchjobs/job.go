@@ -0,0 +1,55 @@
+package searchjobs
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs/background"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+type searchJobsJob struct{}
+
+var uploadStoreConfigInst = &searchjobs.UploadStoreConfig{}
+
+func NewSearchJobsJob() job.Job {
+	return &searchJobsJob{}
+}
+
+func (j *searchJobsJob) Description() string {
+	return "Runs search jobs over all matching repository revisions and stores their results."
+}
+
+func (j *searchJobsJob) Config() []env.Config {
+	return []env.Config{uploadStoreConfigInst}
+}
+
+func (j *searchJobsJob) Routines(startupCtx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     logger.Scoped("routines", "search jobs routines"),
+		Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	db, err := workerdb.InitDBWithLogger(logger)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := searchjobs.NewUploadStore(startupCtx, uploadStoreConfigInst, observationContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return background.NewBackgroundJobs(logger, db, uploadStore), nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitea"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitlab"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/perforce"
@@ -64,6 +65,7 @@ func ProvidersFromConfig(
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
 			extsvc.KindPerforce,
+			extsvc.KindGitea,
 		},
 		LimitOffset: &database.LimitOffset{
 			Limit: 500, // The number is randomly chosen
@@ -75,6 +77,7 @@ func ProvidersFromConfig(
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
 		perforceConns        []*types.PerforceConnection
+		giteaConns           []*types.GiteaConnection
 	)
 	for {
 		svcs, err := store.List(ctx, opt)
@@ -124,6 +127,11 @@ func ProvidersFromConfig(
 					URN:                svc.URN(),
 					PerforceConnection: c,
 				})
+			case *schema.GiteaConnection:
+				giteaConns = append(giteaConns, &types.GiteaConnection{
+					URN:             svc.URN(),
+					GiteaConnection: c,
+				})
 			default:
 				log15.Error("ProvidersFromConfig", "error", errors.Errorf("unexpected connection type: %T", cfg))
 				continue
@@ -173,6 +181,14 @@ func ProvidersFromConfig(
 		invalidConnections = append(invalidConnections, pfInvalidConnections...)
 	}
 
+	if len(giteaConns) > 0 {
+		gtProviders, gtProblems, gtWarnings, gtInvalidConnections := gitea.NewAuthzProviders(giteaConns)
+		providers = append(providers, gtProviders...)
+		seriousProblems = append(seriousProblems, gtProblems...)
+		warnings = append(warnings, gtWarnings...)
+		invalidConnections = append(invalidConnections, gtInvalidConnections...)
+	}
+
 	// 🚨 SECURITY: Warn the admin when both code host authz provider and the permissions user mapping are configured.
 	if cfg.SiteConfig().PermissionsUserMapping != nil &&
 		cfg.SiteConfig().PermissionsUserMapping.Enabled {
@@ -274,6 +290,15 @@ func ProviderFromExternalService(
 			},
 			db,
 		)
+	case *schema.GiteaConnection:
+		providers, problems, _, _ = gitea.NewAuthzProviders(
+			[]*types.GiteaConnection{
+				{
+					URN:             svc.URN(),
+					GiteaConnection: c,
+				},
+			},
+		)
 	default:
 		return nil, errors.Errorf("unsupported connection type %T", cfg)
 	}
@@ -0,0 +1,59 @@
+package gitea
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+)
+
+type client interface {
+	GetUser(ctx context.Context, login string) (*gitea.User, error)
+	GetAuthenticatedUser(ctx context.Context) (*gitea.User, error)
+	ListUserRepositories(ctx context.Context, cursor *gitea.Pagination) ([]*gitea.Repository, bool, error)
+	WithSudo(login string) client
+}
+
+var _ client = (*ClientAdapter)(nil)
+
+// ClientAdapter is an adapter for Gitea API client.
+type ClientAdapter struct {
+	*gitea.Client
+}
+
+func (c *ClientAdapter) WithSudo(login string) client {
+	return &ClientAdapter{Client: c.Client.WithSudo(login)}
+}
+
+type mockClient struct {
+	sudo                     string
+	mockGetUser              func(ctx context.Context, login string) (*gitea.User, error)
+	mockGetAuthenticatedUser func(ctx context.Context) (*gitea.User, error)
+	mockListUserRepositories func(ctx context.Context, sudo string, cursor *gitea.Pagination) ([]*gitea.Repository, bool, error)
+}
+
+func (m *mockClient) GetUser(ctx context.Context, login string) (*gitea.User, error) {
+	if m.mockGetUser != nil {
+		return m.mockGetUser(ctx, login)
+	}
+	return nil, nil
+}
+
+func (m *mockClient) GetAuthenticatedUser(ctx context.Context) (*gitea.User, error) {
+	if m.mockGetAuthenticatedUser != nil {
+		return m.mockGetAuthenticatedUser(ctx)
+	}
+	return &gitea.User{}, nil
+}
+
+func (m *mockClient) ListUserRepositories(ctx context.Context, cursor *gitea.Pagination) ([]*gitea.Repository, bool, error) {
+	if m.mockListUserRepositories != nil {
+		return m.mockListUserRepositories(ctx, m.sudo, cursor)
+	}
+	return nil, false, nil
+}
+
+func (m *mockClient) WithSudo(login string) client {
+	mc := *m
+	mc.sudo = login
+	return &mc
+}
@@ -0,0 +1,173 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider implements authz.Provider for Gitea (and Forgejo). Users are matched
+// by username and verified email, and the repositories visible to them are
+// listed by impersonating them with a site administrator token.
+type Provider struct {
+	urn      string
+	client   client
+	codeHost *extsvc.CodeHost
+}
+
+func NewProvider(conn *types.GiteaConnection) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, err
+	}
+	gClient, err := gitea.NewClient(conn.URN, conn.GiteaConnection, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Provider{
+		urn:      conn.URN,
+		client:   &ClientAdapter{Client: gClient},
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeGitea),
+	}, nil
+}
+
+// FetchAccount looks up the Gitea user with the same username as the Sourcegraph
+// user. The Gitea user is only returned if their email is one of the verified
+// emails of the Sourcegraph user.
+func (p *Provider) FetchAccount(ctx context.Context, user *types.User, _ []*extsvc.Account, verifiedEmails []string) (*extsvc.Account, error) {
+	if user == nil {
+		return nil, nil
+	}
+
+	giteaUser, err := p.client.GetUser(ctx, user.Username)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if giteaUser == nil || !containsEmail(verifiedEmails, giteaUser.Email) {
+		return nil, nil
+	}
+
+	accountData, err := json.Marshal(gitea.AccountData{
+		ID:    giteaUser.ID,
+		Login: giteaUser.Login,
+		Email: giteaUser.Email,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling account data")
+	}
+
+	return &extsvc.Account{
+		UserID: user.ID,
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: p.codeHost.ServiceType,
+			ServiceID:   p.codeHost.ServiceID,
+			AccountID:   strconv.FormatInt(giteaUser.ID, 10),
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData(accountData),
+		},
+	}, nil
+}
+
+func containsEmail(emails []string, email string) bool {
+	if email == "" {
+		return false
+	}
+	for _, e := range emails {
+		if strings.EqualFold(e, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchUserPerms returns the IDs of all repositories the given account can read
+// on the Gitea instance. The repository ID has the same value as it would be used
+// as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is up to
+// callers to decide whether to discard.
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	switch {
+	case account == nil:
+		return nil, errors.New("no account provided")
+	case account.Data == nil:
+		return nil, errors.New("no account data provided")
+	case !extsvc.IsHostOfAccount(p.codeHost, account):
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			p.codeHost.ServiceID, account.AccountSpec.ServiceID)
+	}
+
+	var data gitea.AccountData
+	if err := encryption.DecryptJSON(ctx, account.Data, &data); err != nil {
+		return nil, errors.Wrap(err, "unmarshaling account data")
+	}
+
+	cli := p.client.WithSudo(data.Login)
+	perms := &authz.ExternalUserPermissions{}
+	cursor := &gitea.Pagination{Page: 1}
+	for {
+		repos, hasNextPage, err := cli.ListUserRepositories(ctx, cursor)
+		if err != nil {
+			return perms, err
+		}
+
+		for _, r := range repos {
+			perms.Exacts = append(perms.Exacts, extsvc.RepoID(strconv.FormatInt(r.ID, 10)))
+		}
+
+		if !hasNextPage || len(repos) == 0 {
+			return perms, nil
+		}
+		cursor.Page++
+	}
+}
+
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	return nil, &authz.ErrUnimplemented{Feature: "gitea.FetchRepoPerms"}
+}
+
+func (p *Provider) ServiceType() string {
+	return p.codeHost.ServiceType
+}
+
+func (p *Provider) ServiceID() string {
+	return p.codeHost.ServiceID
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ValidateConnection validates that the configured token belongs to a Gitea site
+// administrator, which is required to list repositories on behalf of other users.
+func (p *Provider) ValidateConnection(ctx context.Context) (warnings []string) {
+	user, err := p.client.GetAuthenticatedUser(ctx)
+	if err != nil {
+		return []string{
+			fmt.Sprintf("Unable to get authenticated Gitea user: %v", err),
+		}
+	}
+
+	if !user.IsAdmin {
+		return []string{
+			fmt.Sprintf("Gitea user %q is not a site administrator and cannot fetch permissions of other users", user.Login),
+		}
+	}
+
+	return []string{}
+}
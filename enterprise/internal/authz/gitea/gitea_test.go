@@ -0,0 +1,177 @@
+package gitea
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestProvider_FetchAccount(t *testing.T) {
+	user := &types.User{ID: 1, Username: "alice"}
+
+	testCases := []struct {
+		name           string
+		client         mockClient
+		verifiedEmails []string
+		wantAccountID  string
+	}{
+		{
+			name: "username and verified email match",
+			client: mockClient{
+				mockGetUser: func(ctx context.Context, login string) (*gitea.User, error) {
+					return &gitea.User{ID: 42, Login: login, Email: "Alice@example.com"}, nil
+				},
+			},
+			verifiedEmails: []string{"alice@example.com"},
+			wantAccountID:  "42",
+		},
+		{
+			name: "email not verified",
+			client: mockClient{
+				mockGetUser: func(ctx context.Context, login string) (*gitea.User, error) {
+					return &gitea.User{ID: 42, Login: login, Email: "alice@example.com"}, nil
+				},
+			},
+			verifiedEmails: []string{"alice@other.com"},
+		},
+		{
+			name: "no such user",
+			client: mockClient{
+				mockGetUser: func(ctx context.Context, login string) (*gitea.User, error) {
+					return nil, notFoundError{}
+				},
+			},
+			verifiedEmails: []string{"alice@example.com"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestProvider(&tc.client)
+			acct, err := p.FetchAccount(context.Background(), user, nil, tc.verifiedEmails)
+			if err != nil {
+				t.Fatalf("error fetching account: %s", err)
+			}
+
+			var accountID string
+			if acct != nil {
+				accountID = acct.AccountID
+				if acct.ServiceType != extsvc.TypeGitea || acct.ServiceID != "https://gitea.sgdev.org/" {
+					t.Errorf("unexpected account spec: %+v", acct.AccountSpec)
+				}
+			}
+			if accountID != tc.wantAccountID {
+				t.Fatalf("unexpected account ID. want=%q have=%q", tc.wantAccountID, accountID)
+			}
+		})
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	client := &mockClient{
+		mockListUserRepositories: func(ctx context.Context, sudo string, cursor *gitea.Pagination) ([]*gitea.Repository, bool, error) {
+			if sudo != "alice" {
+				t.Fatalf("unexpected sudo user %q", sudo)
+			}
+			switch cursor.Page {
+			case 1:
+				return []*gitea.Repository{{ID: 1}, {ID: 2}}, true, nil
+			case 2:
+				return []*gitea.Repository{{ID: 3}}, false, nil
+			}
+			t.Fatalf("unexpected page %d", cursor.Page)
+			return nil, false, nil
+		},
+	}
+	p := NewTestProvider(client)
+
+	account := &extsvc.Account{
+		AccountSpec: extsvc.AccountSpec{
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   "https://gitea.sgdev.org/",
+			AccountID:   "42",
+		},
+		AccountData: extsvc.AccountData{
+			Data: extsvc.NewUnencryptedData([]byte(`{"id":42,"login":"alice","email":"alice@example.com"}`)),
+		},
+	}
+
+	perms, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]extsvc.RepoID{"1", "2", "3"}, perms.Exacts); diff != "" {
+		t.Fatalf("unexpected repo IDs (-want +got):\n%s", diff)
+	}
+
+	account.ServiceID = "https://gitea.example.com/"
+	if _, err := p.FetchUserPerms(context.Background(), account, authz.FetchPermsOptions{}); err == nil {
+		t.Fatal("expected error for account of another code host")
+	}
+}
+
+func TestProvider_ValidateConnection(t *testing.T) {
+	testCases := []struct {
+		name     string
+		client   mockClient
+		warnings []string
+	}{
+		{
+			name: "GetAuthenticatedUser fails",
+			client: mockClient{
+				mockGetAuthenticatedUser: func(ctx context.Context) (*gitea.User, error) {
+					return nil, errors.New("fake error")
+				},
+			},
+			warnings: []string{"Unable to get authenticated Gitea user: fake error"},
+		},
+		{
+			name: "not an admin",
+			client: mockClient{
+				mockGetAuthenticatedUser: func(ctx context.Context) (*gitea.User, error) {
+					return &gitea.User{Login: "bot"}, nil
+				},
+			},
+			warnings: []string{`Gitea user "bot" is not a site administrator and cannot fetch permissions of other users`},
+		},
+		{
+			name: "admin",
+			client: mockClient{
+				mockGetAuthenticatedUser: func(ctx context.Context) (*gitea.User, error) {
+					return &gitea.User{Login: "admin", IsAdmin: true}, nil
+				},
+			},
+			warnings: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewTestProvider(&tc.client)
+			warnings := p.ValidateConnection(context.Background())
+			if diff := cmp.Diff(tc.warnings, warnings); diff != "" {
+				t.Fatalf("warnings did not match: %s", diff)
+			}
+		})
+	}
+}
+
+type notFoundError struct{}
+
+func (notFoundError) Error() string  { return "not found" }
+func (notFoundError) NotFound() bool { return true }
+
+func NewTestProvider(client client) *Provider {
+	baseURL, _ := url.Parse("https://gitea.sgdev.org")
+	return &Provider{
+		urn:      "Gitea",
+		client:   client,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeGitea),
+	}
+}
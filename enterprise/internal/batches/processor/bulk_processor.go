@@ -98,6 +98,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
 		return b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeAutoMerge:
+		return b.autoMergeChangeset(ctx, job)
 
 	default:
 		return &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -196,7 +198,7 @@ func (b *bulkProcessor) mergeChangeset(ctx context.Context, job *btypes.Changese
 		return errcode.MakeNonRetryable(err)
 	}
 
-	return nil
+	return b.enqueueDependentChangesets(ctx)
 }
 
 func (b *bulkProcessor) closeChangeset(ctx context.Context) (err error) {
@@ -282,3 +284,78 @@ func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.Change
 
 	return nil
 }
+
+func (b *bulkProcessor) autoMergeChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
+	typedPayload, ok := job.Payload.(*btypes.ChangesetJobAutoMergePayload)
+	if !ok {
+		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobAutoMergePayload{}, job.Payload)
+	}
+
+	if !b.ch.Published() || b.ch.ExternalState != btypes.ChangesetExternalStateOpen {
+		return errcode.MakeNonRetryable(errors.New("cannot auto-merge a changeset that is not open"))
+	}
+
+	remoteRepo, err := sources.GetRemoteRepo(ctx, b.css, b.repo, b.ch, nil)
+	if err != nil {
+		return errors.Wrap(err, "loading remote repo")
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+		RemoteRepo: remoteRepo,
+	}
+	if err := b.css.AutoMergeChangeset(ctx, cs, typedPayload.Squash); err != nil {
+		if !errors.HasType(err, sources.ChangesetNotMergeableError{}) {
+			return err
+		}
+
+		// The changeset can't be merged yet, so we mark it for auto-merge.
+		// The syncer enqueues it once it has been approved and its checks
+		// have passed, and the reconciler then merges it.
+		b.ch.AutoMerge = true
+		b.ch.AutoMergeSquash = typedPayload.Squash
+		if err := b.tx.UpdateChangesetAutoMerge(ctx, b.ch); err != nil {
+			log15.Error("UpdateChangesetAutoMerge", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+		return nil
+	}
+
+	if b.ch.AutoMerge {
+		b.ch.AutoMerge = false
+		if err := b.tx.UpdateChangesetAutoMerge(ctx, b.ch); err != nil {
+			log15.Error("UpdateChangesetAutoMerge", "err", err)
+			return errcode.MakeNonRetryable(err)
+		}
+	}
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		log15.Error("Events", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, b.tx.Repos(), gitserver.NewClient(b.tx.DatabaseDB()), cs.Changeset, events)
+
+	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		log15.Error("UpsertChangesetEvents", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		log15.Error("UpdateChangeset", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	return b.enqueueDependentChangesets(ctx)
+}
+
+// enqueueDependentChangesets enqueues the changesets in the batch change that
+// may have been waiting for the processed changeset to be merged before they
+// can be published.
+func (b *bulkProcessor) enqueueDependentChangesets(ctx context.Context) error {
+	if b.ch.IsImported() || b.ch.ExternalState != btypes.ChangesetExternalStateMerged {
+		return nil
+	}
+	return b.tx.EnqueueChangesetsWaitingOnDependencies(ctx, b.ch.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState())
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	stesting "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/testing"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	bt "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
@@ -199,6 +200,93 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Auto-merge job", func(t *testing.T) {
+		openChangeset := bt.CreateChangeset(t, ctx, bstore, bt.TestChangesetOpts{
+			Repo:                repo.ID,
+			BatchChanges:        []types.BatchChangeAssoc{{BatchChangeID: batchChange.ID}},
+			Metadata:            &github.PullRequest{},
+			ExternalServiceType: extsvc.TypeGitHub,
+			CurrentSpec:         changesetSpec.ID,
+			PublicationState:    btypes.ChangesetPublicationStatePublished,
+			ExternalState:       btypes.ChangesetExternalStateOpen,
+			ReconcilerState:     btypes.ReconcilerStateCompleted,
+		})
+
+		t.Run("unpublished changeset", func(t *testing.T) {
+			fake := &stesting.FakeChangesetSource{}
+			bp := &bulkProcessor{
+				tx:      bstore,
+				sourcer: stesting.NewFakeSourcer(nil, fake),
+			}
+			job := &types.ChangesetJob{
+				JobType:     types.ChangesetJobTypeAutoMerge,
+				ChangesetID: changeset.ID,
+				UserID:      user.ID,
+				Payload:     &btypes.ChangesetJobAutoMergePayload{},
+			}
+			err := bp.Process(ctx, job)
+			if err == nil || !errcode.IsNonRetryable(err) {
+				t.Fatalf("unexpected error. want non-retryable error, have=%v", err)
+			}
+			if fake.AutoMergeChangesetCalled {
+				t.Fatal("expected AutoMergeChangeset not to be called but was")
+			}
+		})
+
+		t.Run("merged by code host", func(t *testing.T) {
+			fake := &stesting.FakeChangesetSource{}
+			bp := &bulkProcessor{
+				tx:      bstore,
+				sourcer: stesting.NewFakeSourcer(nil, fake),
+			}
+			job := &types.ChangesetJob{
+				JobType:     types.ChangesetJobTypeAutoMerge,
+				ChangesetID: openChangeset.ID,
+				UserID:      user.ID,
+				Payload:     &btypes.ChangesetJobAutoMergePayload{},
+			}
+			if err := bp.Process(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+			if !fake.AutoMergeChangesetCalled {
+				t.Fatal("expected AutoMergeChangeset to be called but wasn't")
+			}
+
+			have, err := bstore.GetChangesetByID(ctx, openChangeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have.AutoMerge {
+				t.Fatal("expected changeset not to be marked for auto-merge")
+			}
+		})
+
+		t.Run("not mergeable yet", func(t *testing.T) {
+			fake := &stesting.FakeChangesetSource{Err: sources.ChangesetNotMergeableError{ErrorMsg: "not approved"}}
+			bp := &bulkProcessor{
+				tx:      bstore,
+				sourcer: stesting.NewFakeSourcer(nil, fake),
+			}
+			job := &types.ChangesetJob{
+				JobType:     types.ChangesetJobTypeAutoMerge,
+				ChangesetID: openChangeset.ID,
+				UserID:      user.ID,
+				Payload:     &btypes.ChangesetJobAutoMergePayload{Squash: true},
+			}
+			if err := bp.Process(ctx, job); err != nil {
+				t.Fatal(err)
+			}
+
+			have, err := bstore.GetChangesetByID(ctx, openChangeset.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !have.AutoMerge || !have.AutoMergeSquash {
+				t.Fatalf("expected changeset to be marked for squash auto-merge, have AutoMerge=%t AutoMergeSquash=%t", have.AutoMerge, have.AutoMergeSquash)
+			}
+		})
+	})
+
 	t.Run("Close job", func(t *testing.T) {
 		fake := &stesting.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
@@ -0,0 +1,143 @@
+package reconciler
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// dependencyGatedOperations are the operations that are held back while the
+// changesets a changeset depends on haven't been merged yet. Publishing a
+// changeset as a draft is still allowed, so that it can be reviewed early.
+var dependencyGatedOperations = map[btypes.ReconcilerOperation]struct{}{
+	btypes.ReconcilerOperationPublish:   {},
+	btypes.ReconcilerOperationUndraft:   {},
+	btypes.ReconcilerOperationAutoMerge: {},
+}
+
+// hasDependencyGatedOperations returns whether the given operations contain any
+// operation that needs the dependencies of a changeset to be merged first.
+func hasDependencyGatedOperations(ops Operations) bool {
+	for _, op := range ops {
+		if _, ok := dependencyGatedOperations[op]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutDependencyGatedOperations removes the operations that need the
+// dependencies of a changeset to be merged first. If the changeset was about
+// to be published, the push that goes along with it is removed as well.
+func withoutDependencyGatedOperations(ops Operations) Operations {
+	publishing := false
+	for _, op := range ops {
+		if op == btypes.ReconcilerOperationPublish {
+			publishing = true
+		}
+	}
+
+	filtered := Operations{}
+	for _, op := range ops {
+		if _, ok := dependencyGatedOperations[op]; ok {
+			continue
+		}
+		if publishing && op == btypes.ReconcilerOperationPush {
+			continue
+		}
+		filtered = append(filtered, op)
+	}
+	return filtered
+}
+
+// applyDependencies holds back the operations of the given plan that would
+// publish, undraft, or merge the changeset while the changesets it depends on
+// in the same batch change haven't been merged yet. Dependencies that will
+// never be merged, because they were closed, archived, or are kept
+// unpublished, don't hold the changeset back. The reason is recorded in the
+// WaitingReason of the changeset.
+func applyDependencies(ctx context.Context, tx *store.Store, plan *Plan) error {
+	ch := plan.Changeset
+
+	var reason string
+	if plan.ChangesetSpec != nil && len(plan.ChangesetSpec.DependsOn) > 0 && hasDependencyGatedOperations(plan.Ops) {
+		var err error
+		reason, err = computeWaitingReason(ctx, tx, ch, plan.ChangesetSpec.DependsOn)
+		if err != nil {
+			return err
+		}
+	}
+
+	if reason != "" {
+		plan.Ops = withoutDependencyGatedOperations(plan.Ops)
+	}
+
+	if reason == ch.WaitingReason {
+		return nil
+	}
+	ch.WaitingReason = reason
+
+	// If there is nothing left to do, the executor won't update the changeset,
+	// so we need to persist the waiting reason ourselves.
+	if plan.Ops.IsNone() {
+		return tx.UpdateChangesetWaitingReason(ctx, ch)
+	}
+	return nil
+}
+
+func computeWaitingReason(ctx context.Context, tx *store.Store, ch *btypes.Changeset, dependsOn []string) (string, error) {
+	cs, _, err := tx.ListChangesets(ctx, store.ListChangesetsOpts{OwnedByBatchChangeID: ch.OwnedByBatchChangeID})
+	if err != nil {
+		return "", err
+	}
+
+	var unpublishedSpecIDs []int64
+	for _, c := range cs {
+		if c.ID != ch.ID && c.Unpublished() && c.CurrentSpecID != 0 {
+			unpublishedSpecIDs = append(unpublishedSpecIDs, c.CurrentSpecID)
+		}
+	}
+	unpublishedSpecs := make(map[int64]*btypes.ChangesetSpec, len(unpublishedSpecIDs))
+	if len(unpublishedSpecIDs) > 0 {
+		specs, _, err := tx.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{IDs: unpublishedSpecIDs})
+		if err != nil {
+			return "", err
+		}
+		for _, spec := range specs {
+			unpublishedSpecs[spec.ID] = spec
+		}
+	}
+
+	candidates := make([]*btypes.Changeset, 0, len(cs))
+	repoIDs := make([]api.RepoID, 0, len(cs))
+	for _, c := range cs {
+		if c.ID == ch.ID {
+			continue
+		}
+		// Changesets that stay unpublished will never be merged, so they
+		// don't hold back the changesets depending on them.
+		if spec, ok := unpublishedSpecs[c.CurrentSpecID]; ok && c.Unpublished() &&
+			calculatePublicationState(spec.Published, c.UiPublicationState).IsUnpublished() {
+			continue
+		}
+		candidates = append(candidates, c)
+		repoIDs = append(repoIDs, c.RepoID)
+	}
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	repos, err := tx.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return "", err
+	}
+	repoNames := make(map[api.RepoID]api.RepoName, len(repos))
+	for id, repo := range repos {
+		repoNames[id] = repo.Name
+	}
+
+	return state.ComputeWaitingReason(ch.RepoID, dependsOn, candidates, repoNames)
+}
@@ -0,0 +1,45 @@
+package reconciler
+
+import (
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func TestWithoutDependencyGatedOperations(t *testing.T) {
+	tcs := []struct {
+		name string
+		ops  Operations
+		want Operations
+	}{
+		{
+			name: "publish",
+			ops:  Operations{btypes.ReconcilerOperationPublish, btypes.ReconcilerOperationPush},
+			want: Operations{},
+		},
+		{
+			name: "publish as draft",
+			ops:  Operations{btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush},
+			want: Operations{btypes.ReconcilerOperationPublishDraft, btypes.ReconcilerOperationPush},
+		},
+		{
+			name: "undraft and update",
+			ops:  Operations{btypes.ReconcilerOperationUndraft, btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+			want: Operations{btypes.ReconcilerOperationPush, btypes.ReconcilerOperationUpdate},
+		},
+		{
+			name: "auto-merge",
+			ops:  Operations{btypes.ReconcilerOperationSync, btypes.ReconcilerOperationAutoMerge},
+			want: Operations{btypes.ReconcilerOperationSync},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			have := withoutDependencyGatedOperations(tc.ops)
+			if !have.Equal(tc.want) {
+				t.Fatalf("wrong operations. want=%s, have=%s", tc.want, have)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -74,6 +75,8 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return nil
 	}
 
+	wasMerged := e.ch.ExternalState == btypes.ChangesetExternalStateMerged
+
 	// Load the target repo.
 	//
 	// Note that the remote repo is lazily set when a changeset source is
@@ -124,6 +127,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationReattach:
 			e.reattachChangeset()
 
+		case btypes.ReconcilerOperationAutoMerge:
+			err = e.autoMergeChangeset(ctx)
+
 		default:
 			err = errors.Errorf("executor operation %q not implemented", op)
 		}
@@ -145,7 +151,17 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		return err
 	}
 
-	return e.tx.UpdateChangeset(ctx, e.ch)
+	if err := e.tx.UpdateChangeset(ctx, e.ch); err != nil {
+		return err
+	}
+
+	// Other changesets in the batch change may be waiting for this one to be
+	// merged before they can be published.
+	if !wasMerged && e.ch.ExternalState == btypes.ChangesetExternalStateMerged && !e.ch.IsImported() {
+		return e.tx.EnqueueChangesetsWaitingOnDependencies(ctx, e.ch.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState())
+	}
+
+	return nil
 }
 
 var errCannotPushToArchivedRepo = errcode.MakeNonRetryable(errors.New("cannot push to an archived repo"))
@@ -455,6 +471,38 @@ func (e *executor) closeChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// autoMergeChangeset asks the code host to merge the changeset once it has
+// been approved and its checks have passed. If the changeset cannot be merged
+// yet, it stays marked for auto-merge and the syncer enqueues it again once
+// it is ready to be merged.
+func (e *executor) autoMergeChangeset(ctx context.Context) (err error) {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	remoteRepo, err := e.remoteRepo(ctx)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  e.ch,
+		RemoteRepo: remoteRepo,
+		TargetRepo: e.targetRepo,
+	}
+
+	if err := css.AutoMergeChangeset(ctx, cs, e.ch.AutoMergeSquash); err != nil {
+		if errors.HasType(err, sources.ChangesetNotMergeableError{}) {
+			return nil
+		}
+		return errors.Wrap(err, "auto-merging changeset")
+	}
+
+	e.ch.AutoMerge = false
+	return nil
+}
+
 // undraftChangeset marks the given changeset on its code host as ready for review.
 func (e *executor) undraftChangeset(ctx context.Context) (err error) {
 	css, err := e.changesetSource(ctx)
@@ -24,6 +24,7 @@ var operationPrecedence = map[btypes.ReconcilerOperation]int{
 	btypes.ReconcilerOperationUpdate:       4,
 	btypes.ReconcilerOperationSleep:        5,
 	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationAutoMerge:    7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -169,6 +170,9 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 		} else if isReattach && !wantDetach {
 			pl.AddOp(btypes.ReconcilerOperationReattach)
 		}
+		if wantAutoMerge(wantedChangeset) {
+			pl.AddOp(btypes.ReconcilerOperationAutoMerge)
+		}
 		return pl, nil
 	}
 
@@ -248,6 +252,10 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 			}
 		}
 
+		if wantAutoMerge(wantedChangeset) {
+			pl.AddOp(btypes.ReconcilerOperationAutoMerge)
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", wantedChangeset.PublicationState)
 	}
@@ -255,6 +263,12 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, currentChang
 	return pl, nil
 }
 
+// wantAutoMerge returns whether the changeset has been marked to be merged
+// automatically and can still be merged.
+func wantAutoMerge(ch *btypes.Changeset) bool {
+	return ch.AutoMerge && ch.Published() && ch.ExternalState == btypes.ChangesetExternalStateOpen
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed ||
 		ch.ExternalState == btypes.ChangesetExternalStateReadOnly
@@ -280,6 +280,45 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationReopen,
 			},
 		},
+		{
+			name:         "auto-merge",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				AutoMerge:        true,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationAutoMerge,
+			},
+		},
+		{
+			name:         "auto-merge with updated title",
+			previousSpec: &bt.TestSpecOpts{Published: true, Title: "Before"},
+			currentSpec:  &bt.TestSpecOpts{Published: true, Title: "After"},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				AutoMerge:        true,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationUpdate,
+				btypes.ReconcilerOperationAutoMerge,
+			},
+		},
+		{
+			name:         "auto-merge on merged changeset",
+			previousSpec: &bt.TestSpecOpts{Published: true},
+			currentSpec:  &bt.TestSpecOpts{Published: true},
+			changeset: bt.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateMerged,
+				AutoMerge:        true,
+			},
+			// should be a noop
+			wantOperations: Operations{},
+		},
 		{
 			name:         "closing",
 			previousSpec: &bt.TestSpecOpts{Published: true},
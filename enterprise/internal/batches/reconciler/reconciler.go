@@ -80,6 +80,11 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return err
 	}
 
+	// Hold back publishing the changeset until its dependencies are merged.
+	if err := applyDependencies(ctx, tx, plan); err != nil {
+		return err
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
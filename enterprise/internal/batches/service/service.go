@@ -14,6 +14,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -1287,7 +1288,12 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 	}
 
 	if len(conflicts) == 0 {
-		return nil
+		var cycleErr error
+		cycleErr, nonValidationErr = s.validateChangesetSpecDependencies(ctx, batchSpecID)
+		if nonValidationErr != nil {
+			return nonValidationErr
+		}
+		return cycleErr
 	}
 
 	repoIDs := make([]api.RepoID, 0, len(conflicts))
@@ -1315,6 +1321,50 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 	return errs
 }
 
+// validateChangesetSpecDependencies returns a validation error if the changeset
+// specs of the batch spec depend on each other in a cycle, since none of the
+// changesets in the cycle could ever be published.
+func (s *Service) validateChangesetSpecDependencies(ctx context.Context, batchSpecID int64) (validationErr, err error) {
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(specs))
+	hasDependencies := false
+	for _, spec := range specs {
+		repoIDs = append(repoIDs, spec.BaseRepoID)
+		if len(spec.DependsOn) > 0 {
+			hasDependencies = true
+		}
+	}
+	if !hasDependencies {
+		return nil, nil
+	}
+
+	repos, err := s.store.Repos().GetReposSetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	repoNames := make(map[api.RepoID]api.RepoName, len(repos))
+	for id, repo := range repos {
+		repoNames[id] = repo.Name
+	}
+
+	cycle, err := state.FindDependencyCycle(specs, repoNames)
+	if err != nil {
+		return nil, err
+	}
+	if cycle == nil {
+		return nil, nil
+	}
+	names := make([]string, 0, len(cycle))
+	for _, name := range cycle {
+		names = append(names, string(name))
+	}
+	return batcheslib.NewValidationError(errors.Newf("the changesets depend on each other in a cycle: %s", strings.Join(names, " -> "))), nil
+}
+
 type changesetSpecHeadRefConflict struct {
 	repo    *types.Repo
 	count   int
@@ -1576,6 +1626,7 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		btypes.ChangesetJobTypeMerge:     0,
 		btypes.ChangesetJobTypePublish:   0,
 		btypes.ChangesetJobTypeReenqueue: 0,
+		btypes.ChangesetJobTypeAutoMerge: 0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1633,6 +1684,11 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 			bulkOperationsCounter[btypes.ChangesetJobTypeMerge] += 1
 		}
 
+		// AUTO_MERGE
+		if !isChangesetArchived && !isChangesetJobFailed && isChangesetOpen && !changeset.AutoMerge {
+			bulkOperationsCounter[btypes.ChangesetJobTypeAutoMerge] += 1
+		}
+
 		// COMMENT
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
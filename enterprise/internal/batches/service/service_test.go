@@ -2572,7 +2572,7 @@ changesetTemplate:
 				t.Fatal(err)
 			}
 
-			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "PUBLISH"}
+			expectedBulkOperations := []string{"CLOSE", "COMMENT", "MERGE", "AUTO_MERGE", "PUBLISH"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
@@ -2666,7 +2666,7 @@ changesetTemplate:
 			})
 
 			assert.NoError(t, err)
-			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE"}
+			expectedBulkOperations := []string{"COMMENT", "CLOSE", "MERGE", "AUTO_MERGE"}
 			if !assert.ElementsMatch(t, expectedBulkOperations, bulkOperations) {
 				t.Errorf("wrong bulk operation type returned. want=%q, have=%q", expectedBulkOperations, bulkOperations)
 			}
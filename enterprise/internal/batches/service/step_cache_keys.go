@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StepCacheKey is the cache key of the result of a single step in a
+// workspace.
+type StepCacheKey struct {
+	Index int
+	Key   string
+	// Shared is true if the result of the step can be shared with other
+	// users. Images referenced by a tag can't be resolved to a digest without
+	// Docker, so their tag may have moved to another image since the result
+	// was cached by another user. Only steps whose images, and the ones of all
+	// steps before them, are pinned to a digest are shared.
+	Shared bool
+}
+
+// StepCacheKeysForWorkspace computes the cache keys of the steps of the given
+// batch spec in a workspace. Statically skipped steps don't have a cache key.
+//
+// envVars are the secrets available to the steps and mounts are the workspace
+// files uploaded for the batch spec. Since the key of a step covers all
+// previous steps, unchanged prefixes of the steps keep their keys when later
+// steps are changed.
+func StepCacheKeysForWorkspace(
+	spec *batcheslib.BatchSpec,
+	repo batcheslib.Repository,
+	path string,
+	onlyFetchWorkspace bool,
+	envVars []string,
+	mounts []*btypes.BatchSpecWorkspaceFile,
+	skippedSteps map[int]struct{},
+) ([]StepCacheKey, error) {
+	retriever := NewMountMetadataRetriever(mounts)
+
+	keys := make([]StepCacheKey, 0, len(spec.Steps))
+	pinned := true
+	for i := 0; i < len(spec.Steps); i++ {
+		pinned = pinned && cache.ImagePinned(spec.Steps[i].Container)
+		if _, ok := skippedSteps[i]; ok {
+			continue
+		}
+
+		key := cache.KeyForWorkspace(
+			&template.BatchChangeAttributes{
+				Name:        spec.Name,
+				Description: spec.Description,
+			},
+			repo,
+			path,
+			envVars,
+			onlyFetchWorkspace,
+			spec.Steps,
+			i,
+			retriever,
+		)
+
+		rawKey, err := key.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, StepCacheKey{Index: i, Key: rawKey, Shared: pinned})
+	}
+
+	return keys, nil
+}
+
+// NewMountMetadataRetriever returns a cache.MetadataRetriever that looks up the
+// metadata of the files mounted by steps in the given workspace files.
+func NewMountMetadataRetriever(mounts []*btypes.BatchSpecWorkspaceFile) cache.MetadataRetriever {
+	return &remoteFileMetadataRetriever{mounts: mounts}
+}
+
+type remoteFileMetadataRetriever struct {
+	mounts []*btypes.BatchSpecWorkspaceFile
+}
+
+func (r *remoteFileMetadataRetriever) Get(steps []batcheslib.Step) ([]cache.MountMetadata, error) {
+	var mountsMetadata []cache.MountMetadata
+	for _, step := range steps {
+		for _, stepMount := range step.Mount {
+			metadata, err := getMountMetadata(r.mounts, stepMount.Path)
+			if err != nil {
+				return nil, err
+			}
+			mountsMetadata = append(mountsMetadata, metadata)
+		}
+	}
+	return mountsMetadata, nil
+}
+
+func getMountMetadata(mounts []*btypes.BatchSpecWorkspaceFile, path string) (metadata cache.MountMetadata, err error) {
+	dir, file := filepath.Split(path)
+	dir = strings.TrimSuffix(dir, string(filepath.Separator))
+	dir = strings.TrimPrefix(dir, fmt.Sprintf(".%s", string(filepath.Separator)))
+	mountPath := filepath.Join(dir, file)
+
+	for _, mount := range mounts {
+		if filepath.Join(mount.Path, mount.FileName) == mountPath {
+			return cache.MountMetadata{
+				Path:     mountPath,
+				Size:     mount.Size,
+				Modified: mount.ModifiedAt,
+			}, nil
+		}
+	}
+	return metadata, errors.New("could not find a matching mount entry")
+}
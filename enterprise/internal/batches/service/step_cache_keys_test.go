@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestStepCacheKeysForWorkspace_Shared(t *testing.T) {
+	spec := &batcheslib.BatchSpec{
+		Name: "test",
+		Steps: []batcheslib.Step{
+			{Run: "echo 1", Container: "alpine@sha256:8b29bc1"},
+			{Run: "echo 2", Container: "alpine:3"},
+			{Run: "echo 3", Container: "alpine@sha256:8b29bc1"},
+		},
+	}
+	repo := batcheslib.Repository{ID: "repo", Name: "github.com/sourcegraph/sourcegraph", BaseRev: "deadbeef"}
+
+	keys, err := StepCacheKeysForWorkspace(spec, repo, "", false, nil, nil, map[int]struct{}{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var shared []bool
+	for _, k := range keys {
+		shared = append(shared, k.Shared)
+	}
+	// The result of the last step depends on the image of the second step,
+	// which may have changed.
+	if diff := cmp.Diff([]bool{true, false, false}, shared); diff != "" {
+		t.Fatalf("unexpected shared keys (-want +got):\n%s", diff)
+	}
+}
@@ -11,6 +11,8 @@ import (
 
 	"github.com/gobwas/glob"
 	"github.com/grafana/regexp"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/log"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -72,9 +75,30 @@ type WorkspaceResolver interface {
 
 type WorkspaceResolverBuilder func(tx *store.Store) WorkspaceResolver
 
+// InsightSeriesRepoLister lists the repositories in the breakdown of a code
+// insight series. It is used to resolve repositoriesFromInsight: rules.
+type InsightSeriesRepoLister interface {
+	SeriesRepoIDs(ctx context.Context, seriesID string) ([]api.RepoID, error)
+}
+
 func NewWorkspaceResolver(s *store.Store) WorkspaceResolver {
+	return newWorkspaceResolver(s, nil)
+}
+
+// NewWorkspaceResolverBuilder returns a WorkspaceResolverBuilder whose
+// resolvers use the given InsightSeriesRepoLister to resolve
+// repositoriesFromInsight: rules. insights is nil if code insights are
+// disabled.
+func NewWorkspaceResolverBuilder(insights InsightSeriesRepoLister) WorkspaceResolverBuilder {
+	return func(s *store.Store) WorkspaceResolver {
+		return newWorkspaceResolver(s, insights)
+	}
+}
+
+func newWorkspaceResolver(s *store.Store, insights InsightSeriesRepoLister) *workspaceResolver {
 	return &workspaceResolver{
 		store:               s,
+		insights:            insights,
 		logger:              log.Scoped("batches.workspaceResolver", "The batch changes execution workspace resolver"),
 		gitserverClient:     gitserver.NewClient(s.DatabaseDB()),
 		frontendInternalURL: internalapi.Client.URL + "/.internal",
@@ -84,6 +108,7 @@ func NewWorkspaceResolver(s *store.Store) WorkspaceResolver {
 type workspaceResolver struct {
 	logger              log.Logger
 	store               *store.Store
+	insights            InsightSeriesRepoLister
 	gitserverClient     gitserver.Client
 	frontendInternalURL string
 }
@@ -231,6 +256,8 @@ func findIgnoredRepositories(ctx context.Context, gitserverClient gitserver.Clie
 
 var ErrMalformedOnQueryOrRepository = batcheslib.NewValidationError(errors.New("malformed 'on' field; missing either a repository name or a query"))
 
+var ErrInsightsDisabled = batcheslib.NewValidationError(errors.New("repositoriesFromInsight cannot be used, because code insights are disabled"))
+
 // resolveRepositoriesOn resolves a single on: entry in a batch spec.
 func (wr *workspaceResolver) resolveRepositoriesOn(ctx context.Context, on *batcheslib.OnQueryOrRepository) (_ []*RepoRevision, _ onlib.RepositoryRuleType, err error) {
 	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositoriesOn", "")
@@ -244,11 +271,50 @@ func (wr *workspaceResolver) resolveRepositoriesOn(ctx context.Context, on *batc
 		return revs, onlib.RepositoryRuleTypeQuery, err
 	}
 
+	if on.RepositoriesFromSavedSearch != "" {
+		revs, err := wr.resolveRepositoriesFromSavedSearch(ctx, on.RepositoriesFromSavedSearch)
+		return revs, onlib.RepositoryRuleTypeQuery, err
+	}
+
+	if on.RepositoriesFromInsight != "" {
+		revs, err := wr.resolveRepositoriesFromInsight(ctx, on.RepositoriesFromInsight)
+		return revs, onlib.RepositoryRuleTypeQuery, err
+	}
+
+	if on.RepositoriesFromCSV != "" {
+		rows, err := on.CSVRepositories()
+		if err != nil {
+			return nil, onlib.RepositoryRuleTypeExplicit, err
+		}
+
+		revs := []*RepoRevision{}
+		for i := range rows {
+			rowRevs, _, err := wr.resolveRepositoriesOn(ctx, &rows[i])
+			if err != nil {
+				return nil, onlib.RepositoryRuleTypeExplicit, errors.Wrapf(err, "resolving %q", rows[i].String())
+			}
+			revs = append(revs, rowRevs...)
+		}
+		return revs, onlib.RepositoryRuleTypeExplicit, nil
+	}
+
 	branches, err := on.GetBranches()
 	if err != nil {
 		return nil, onlib.RepositoryRuleTypeExplicit, err
 	}
 
+	if on.Repository != "" && on.Revision != "" {
+		if len(on.Branches) > 0 {
+			return nil, onlib.RepositoryRuleTypeExplicit, batcheslib.ErrConflictingRevision
+		}
+
+		repo, err := wr.resolveRepositoryNameAndRevision(ctx, on.Repository, on.Branch, on.Revision)
+		if err != nil {
+			return nil, onlib.RepositoryRuleTypeExplicit, err
+		}
+		return []*RepoRevision{repo}, onlib.RepositoryRuleTypeExplicit, nil
+	}
+
 	if on.Repository != "" && len(branches) > 0 {
 		revs := make([]*RepoRevision, len(branches))
 		for i, branch := range branches {
@@ -324,6 +390,132 @@ func (wr *workspaceResolver) resolveRepositoryNameAndBranch(ctx context.Context,
 	}, nil
 }
 
+// resolveRepositoryNameAndRevision resolves the repository pinned to the given
+// revision. If no branch is given, the default branch of the repository is
+// used.
+func (wr *workspaceResolver) resolveRepositoryNameAndRevision(ctx context.Context, name, branch, revision string) (_ *RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositoryNameAndRevision", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	repo, err := wr.store.Repos().GetByName(ctx, api.RepoName(name))
+	if err != nil {
+		return nil, err
+	}
+
+	if branch == "" {
+		branch, _, err = wr.gitserverClient.GetDefaultBranch(ctx, repo.Name, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	commit, err := wr.gitserverClient.ResolveRevision(ctx, repo.Name, revision, gitserver.ResolveRevisionOptions{
+		NoEnsureRevision: true,
+	})
+	if err != nil {
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			return nil, errors.Newf("no revision matching %q found for repository %s", revision, name)
+		}
+		return nil, err
+	}
+
+	return &RepoRevision{
+		Repo:   repo,
+		Branch: branch,
+		Commit: commit,
+		// Directly resolved repos don't have any file matches.
+		FileMatches: []string{},
+	}, nil
+}
+
+// resolveRepositoriesFromSavedSearch resolves the repositories matching the
+// query of the saved search with the given GraphQL ID.
+func (wr *workspaceResolver) resolveRepositoriesFromSavedSearch(ctx context.Context, id string) (_ []*RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositoriesFromSavedSearch", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	var savedSearchID int32
+	if err := relay.UnmarshalSpec(graphql.ID(id), &savedSearchID); err != nil {
+		return nil, batcheslib.NewValidationError(errors.Newf("invalid saved search ID %q", id))
+	}
+
+	db := wr.store.DatabaseDB()
+	ss, err := db.SavedSearches().GetByID(ctx, savedSearchID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Make sure the current user has permission to use the saved
+	// search.
+	if ss.Config.UserID != nil {
+		if *ss.Config.UserID != actor.FromContext(ctx).UID {
+			return nil, &auth.InsufficientAuthorizationError{
+				Message: "current user has insufficient privileges to use saved search",
+			}
+		}
+	} else if ss.Config.OrgID != nil {
+		if err := auth.CheckOrgAccess(ctx, db, *ss.Config.OrgID); err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, errors.New("saved search has no Org ID or User ID associated with it")
+	}
+
+	return wr.resolveRepositoriesMatchingQuery(ctx, ss.Config.Query)
+}
+
+// resolveRepositoriesFromInsight resolves the repositories in the breakdown of
+// the code insight series with the given series ID on their default branch.
+func (wr *workspaceResolver) resolveRepositoriesFromInsight(ctx context.Context, seriesID string) (_ []*RepoRevision, err error) {
+	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositoriesFromInsight", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if wr.insights == nil {
+		return nil, ErrInsightsDisabled
+	}
+
+	repoIDs, err := wr.insights.SeriesRepoIDs(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if len(repoIDs) == 0 {
+		return []*RepoRevision{}, nil
+	}
+
+	// 🚨 SECURITY: We use database.Repos.List to check whether the user has access to
+	// the repositories or not.
+	accessibleRepos, err := wr.store.Repos().List(ctx, database.ReposListOptions{IDs: repoIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	revs := make([]*RepoRevision, 0, len(accessibleRepos))
+	for _, repo := range accessibleRepos {
+		// Repositories from insights don't have any file matches.
+		rev, err := repoToRepoRevisionWithDefaultBranch(ctx, wr.gitserverClient, repo, []string{})
+		if err != nil {
+			// The repository may have been removed from gitserver since the
+			// insight was recorded.
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		revs = append(revs, rev)
+	}
+
+	return revs, nil
+}
+
 func (wr *workspaceResolver) resolveRepositoriesMatchingQuery(ctx context.Context, query string) (_ []*RepoRevision, err error) {
 	tr, ctx := trace.New(ctx, "workspaceResolver.resolveRepositorySearch", "")
 	defer func() {
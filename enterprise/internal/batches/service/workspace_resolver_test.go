@@ -379,6 +379,37 @@ func TestService_ResolveWorkspacesForBatchSpec(t *testing.T) {
 		resolveWorkspacesAndCompare(t, s, gs, u, searchMatches, batchSpec, want)
 	})
 
+	t.Run("repositoriesFromCSV with pinned revisions", func(t *testing.T) {
+		batchSpec := &batcheslib.BatchSpec{
+			On: []batcheslib.OnQueryOrRepository{
+				{RepositoriesFromCSV: fmt.Sprintf("repository,branch,revision\n%s,,v1.0\n%s,non-default-branch\n", rs[0].Name, rs[1].Name)},
+				{Repository: string(rs[2].Name), Branch: "other-non-default-branch", Revision: "c0ff33"},
+			},
+			Steps: steps,
+		}
+
+		gs := newGitserverClient(
+			map[api.CommitID]bool{
+				api.CommitID("b33a"):     false,
+				api.CommitID("d34db33f"): false,
+				api.CommitID("c0ff33"):   false,
+			},
+			map[string]api.CommitID{
+				"v1.0":               api.CommitID("b33a"),
+				"non-default-branch": api.CommitID("d34db33f"),
+				"c0ff33":             api.CommitID("c0ff33"),
+			},
+		)
+
+		want := []*RepoWorkspace{
+			buildRepoWorkspace(rs[0], "", "b33a", []string{}),
+			buildRepoWorkspace(rs[1], "non-default-branch", "d34db33f", []string{}),
+			buildRepoWorkspace(rs[2], "other-non-default-branch", "c0ff33", []string{}),
+		}
+
+		resolveWorkspacesAndCompare(t, s, gs, u, map[string][]streamhttp.EventMatch{}, batchSpec, want)
+	})
+
 	t.Run("workspaces with skipped steps", func(t *testing.T) {
 		conditionalSteps := []batcheslib.Step{
 			// Step should only execute in rs[1]
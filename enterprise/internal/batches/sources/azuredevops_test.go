@@ -0,0 +1,190 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_WithAuthenticator(t *testing.T) {
+	s := newTestAzureDevOpsSource(t, "https://dev.azure.com")
+
+	for name, a := range map[string]auth.Authenticator{
+		"BasicAuth":        &auth.BasicAuth{},
+		"BasicAuthWithSSH": &auth.BasicAuthWithSSH{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			src, err := s.WithAuthenticator(a)
+			assert.Nil(t, err)
+			assert.NotNil(t, src)
+		})
+	}
+
+	_, err := s.WithAuthenticator(&auth.OAuthBearerToken{})
+	assert.NotNil(t, err)
+	assert.ErrorAs(t, err, &UnsupportedAuthenticatorError{})
+}
+
+func TestAzureDevOpsSource_LoadChangeset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/myorg/p1/_apis/git/repositories/r1/pullrequests/42":
+			_ = json.NewEncoder(w).Encode(azuredevops.PullRequest{
+				ID:            42,
+				Status:        azuredevops.PullRequestStatusActive,
+				Title:         "My change",
+				SourceRefName: "refs/heads/my-branch",
+				TargetRefName: "refs/heads/main",
+				Reviewers:     []azuredevops.Reviewer{{IdentityRef: azuredevops.IdentityRef{ID: "u1"}, Vote: azuredevops.ReviewerVoteApproved}},
+			})
+		case "/myorg/p1/_apis/git/repositories/r1/pullRequests/42/statuses":
+			_, _ = io.WriteString(w, `{"count": 1, "value": [{"id": 1, "state": "succeeded", "context": {"name": "build"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	s := newTestAzureDevOpsSource(t, srv.URL)
+
+	t.Run("success", func(t *testing.T) {
+		cs := newTestAzureDevOpsChangeset("42")
+
+		assert.Nil(t, s.LoadChangeset(context.Background(), cs))
+		assert.Equal(t, "42", cs.ExternalID)
+		assert.Equal(t, extsvc.TypeAzureDevOps, cs.ExternalServiceType)
+		assert.Equal(t, "refs/heads/my-branch", cs.ExternalBranch)
+
+		apr := cs.Metadata.(*adobatches.AnnotatedPullRequest)
+		assert.Len(t, apr.Statuses, 1)
+		// The repository of the changeset is used to build the web URL.
+		url, err := cs.URL()
+		assert.Nil(t, err)
+		assert.Equal(t, "https://dev.azure.com/myorg/p1/_git/myrepo/pullrequest/42", url)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cs := newTestAzureDevOpsChangeset("43")
+
+		err := s.LoadChangeset(context.Background(), cs)
+		target := ChangesetNotFoundError{}
+		assert.ErrorAs(t, err, &target)
+		assert.Same(t, target.Changeset, cs)
+	})
+}
+
+func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
+	for name, tc := range map[string]struct {
+		conflict   bool
+		wantExists bool
+	}{
+		"created":        {},
+		"already exists": {conflict: true, wantExists: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/myorg/p1/_apis/git/repositories/r1/pullrequests":
+					var input azuredevops.CreatePullRequestInput
+					_ = json.NewDecoder(r.Body).Decode(&input)
+					assert.Equal(t, "refs/heads/my-branch", input.SourceRefName)
+					assert.Equal(t, "refs/heads/main", input.TargetRefName)
+					assert.True(t, input.IsDraft)
+
+					if tc.conflict {
+						w.WriteHeader(http.StatusConflict)
+						return
+					}
+					_ = json.NewEncoder(w).Encode(azuredevops.PullRequest{ID: 42, IsDraft: true, SourceRefName: input.SourceRefName})
+				case r.Method == "GET" && r.URL.Path == "/myorg/p1/_apis/git/repositories/r1/pullrequests":
+					_, _ = io.WriteString(w, `{"count": 1, "value": [{"pullRequestId": 7, "sourceRefName": "refs/heads/my-branch"}]}`)
+				case r.URL.Path == "/myorg/p1/_apis/git/repositories/r1/pullRequests/42/statuses",
+					r.URL.Path == "/myorg/p1/_apis/git/repositories/r1/pullRequests/7/statuses":
+					_, _ = io.WriteString(w, `{"count": 0, "value": []}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+
+			s := newTestAzureDevOpsSource(t, srv.URL)
+			cs := newTestAzureDevOpsChangeset("")
+
+			exists, err := s.CreateDraftChangeset(context.Background(), cs)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantExists, exists)
+			if tc.wantExists {
+				assert.Equal(t, "7", cs.ExternalID)
+			} else {
+				assert.Equal(t, "42", cs.ExternalID)
+			}
+		})
+	}
+}
+
+func TestAzureDevOpsSource_MergeChangeset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PATCH" && r.URL.Path == "/myorg/p1/_apis/git/repositories/r1/pullrequests/42":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = io.WriteString(w, `{"message": "The pull request has unresolved policies."}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	s := newTestAzureDevOpsSource(t, srv.URL)
+	cs := newTestAzureDevOpsChangeset("42")
+	cs.Metadata = &adobatches.AnnotatedPullRequest{
+		PullRequest: &azuredevops.PullRequest{
+			ID:                    42,
+			LastMergeSourceCommit: &azuredevops.GitCommitRef{CommitID: "abc"},
+		},
+	}
+
+	err := s.MergeChangeset(context.Background(), cs, true)
+	assert.ErrorAs(t, err, &ChangesetNotMergeableError{})
+}
+
+func newTestAzureDevOpsSource(t *testing.T, url string) *AzureDevOpsSource {
+	t.Helper()
+
+	client, err := azuredevops.NewClient("urn", &schema.AzureDevOpsConnection{Url: url, Username: "user", Token: "token"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AzureDevOpsSource{client: client}
+}
+
+func newTestAzureDevOpsChangeset(externalID string) *Changeset {
+	repo := &types.Repo{Metadata: &azuredevops.Repository{
+		ID:      "r1",
+		Name:    "myrepo",
+		URL:     "https://dev.azure.com/myorg/p1/_apis/git/repositories/r1",
+		WebURL:  "https://dev.azure.com/myorg/p1/_git/myrepo",
+		Project: azuredevops.Project{ID: "p1", Name: "p1"},
+	}}
+
+	return &Changeset{
+		Title:      "My change",
+		HeadRef:    "refs/heads/my-branch",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{ExternalID: externalID},
+	}
+}
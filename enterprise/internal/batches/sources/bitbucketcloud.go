@@ -210,6 +210,13 @@ func (s BitbucketCloudSource) MergeChangeset(ctx context.Context, cs *Changeset,
 	return s.setChangesetMetadata(ctx, repo, updated, cs)
 }
 
+// AutoMergeChangeset merges the Changeset if it has been approved and its
+// checks have passed, since Bitbucket Cloud can't merge pull requests
+// automatically.
+func (s BitbucketCloudSource) AutoMergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	return mergeWhenReady(ctx, s, cs, squash)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
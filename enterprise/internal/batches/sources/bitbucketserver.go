@@ -268,7 +268,7 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	merged, err := s.callAndRetryIfOutdated(ctx, c, s.client.MergePullRequest)
 	if err != nil {
 		if bitbucketserver.IsMergePreconditionFailedException(err) {
-			return &ChangesetNotMergeableError{ErrorMsg: err.Error()}
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
 		}
 		return err
 	}
@@ -276,6 +276,13 @@ func (s BitbucketServerSource) MergeChangeset(ctx context.Context, c *Changeset,
 	return c.Changeset.SetMetadata(merged)
 }
 
+// AutoMergeChangeset merges the Changeset if it has been approved and its
+// checks have passed, since Bitbucket Server can't merge pull requests
+// automatically. The squash parameter is ignored.
+func (s BitbucketServerSource) AutoMergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	return mergeWhenReady(ctx, s, c, squash)
+}
+
 type bitbucketClientFunc func(context.Context, *bitbucketserver.PullRequest) error
 
 func (s BitbucketServerSource) callAndRetryIfOutdated(ctx context.Context, c *Changeset, fn bitbucketClientFunc) (*bitbucketserver.PullRequest, error) {
@@ -98,6 +98,13 @@ type ChangesetSource interface {
 	// merge. If the changeset cannot be merged, because it is in an unmergeable
 	// state, ChangesetNotMergeableError must be returned.
 	MergeChangeset(ctx context.Context, ch *Changeset, squash bool) error
+	// AutoMergeChangeset merges a Changeset on the code host once it has been
+	// approved and its checks have passed. Code hosts with native support,
+	// such as GitHub auto-merge or GitLab's merge when pipeline succeeds, are
+	// asked to merge the changeset themselves. Other sources merge the
+	// changeset right away if it is ready to be merged. If the changeset
+	// cannot be merged yet, ChangesetNotMergeableError must be returned.
+	AutoMergeChangeset(ctx context.Context, ch *Changeset, squash bool) error
 }
 
 // ChangesetNotMergeableError is returned by MergeChangeset if the changeset
@@ -114,6 +121,20 @@ func (e ChangesetNotMergeableError) Error() string {
 
 func (e ChangesetNotMergeableError) NonRetryable() bool { return true }
 
+// errNotReadyToMerge is returned by mergeWhenReady if the changeset has not
+// been approved or its checks have not passed yet.
+var errNotReadyToMerge = ChangesetNotMergeableError{ErrorMsg: "changeset has not been approved or its checks have not passed yet"}
+
+// mergeWhenReady merges the changeset if it has been approved and its checks
+// have passed. It implements AutoMergeChangeset for code hosts that cannot
+// merge changesets automatically.
+func mergeWhenReady(ctx context.Context, s ChangesetSource, c *Changeset, squash bool) error {
+	if !c.Changeset.ReadyToMerge() {
+		return errNotReadyToMerge
+	}
+	return s.MergeChangeset(ctx, c, squash)
+}
+
 // A Changeset of an existing Repo.
 type Changeset struct {
 	Title   string
@@ -293,6 +293,27 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// AutoMergeChangeset enables auto-merge for the pull request on GitHub. Pull
+// requests that can already be merged are merged right away.
+func (s GithubSource) AutoMergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	if err := s.client.EnablePullRequestAutoMerge(ctx, pr, squash); err != nil {
+		if github.IsPullRequestInCleanStatus(err) {
+			return s.MergeChangeset(ctx, c, squash)
+		}
+		if github.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return err
+	}
+
+	return c.Changeset.SetMetadata(pr)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
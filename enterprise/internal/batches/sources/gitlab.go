@@ -544,6 +544,31 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// AutoMergeChangeset sets the merge request to be merged once its pipeline
+// succeeds. GitLab enforces the approval rules of the project when merging.
+func (s *GitLabSource) AutoMergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	updated, err := s.client.MergeMergeRequestWhenPipelineSucceeds(ctx, project, mr, squash)
+	if err != nil {
+		if errors.Is(err, gitlab.ErrNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging GitLab merge request when pipeline succeeds")
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", updated.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
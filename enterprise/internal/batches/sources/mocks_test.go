@@ -24,6 +24,9 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources)
 // used for unit testing.
 type MockChangesetSource struct {
+	// AutoMergeChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method AutoMergeChangeset.
+	AutoMergeChangesetFunc *ChangesetSourceAutoMergeChangesetFunc
 	// CloseChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method CloseChangeset.
 	CloseChangesetFunc *ChangesetSourceCloseChangesetFunc
@@ -61,6 +64,11 @@ type MockChangesetSource struct {
 // overwritten.
 func NewMockChangesetSource() *MockChangesetSource {
 	return &MockChangesetSource{
+		AutoMergeChangesetFunc: &ChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) (r0 error) {
+				return
+			},
+		},
 		CloseChangesetFunc: &ChangesetSourceCloseChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) (r0 error) {
 				return
@@ -118,6 +126,11 @@ func NewMockChangesetSource() *MockChangesetSource {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockChangesetSource() *MockChangesetSource {
 	return &MockChangesetSource{
+		AutoMergeChangesetFunc: &ChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) error {
+				panic("unexpected invocation of MockChangesetSource.AutoMergeChangeset")
+			},
+		},
 		CloseChangesetFunc: &ChangesetSourceCloseChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) error {
 				panic("unexpected invocation of MockChangesetSource.CloseChangeset")
@@ -176,6 +189,9 @@ func NewStrictMockChangesetSource() *MockChangesetSource {
 // overwritten.
 func NewMockChangesetSourceFrom(i ChangesetSource) *MockChangesetSource {
 	return &MockChangesetSource{
+		AutoMergeChangesetFunc: &ChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: i.AutoMergeChangeset,
+		},
 		CloseChangesetFunc: &ChangesetSourceCloseChangesetFunc{
 			defaultHook: i.CloseChangeset,
 		},
@@ -209,6 +225,117 @@ func NewMockChangesetSourceFrom(i ChangesetSource) *MockChangesetSource {
 	}
 }
 
+// ChangesetSourceAutoMergeChangesetFunc describes the behavior when the
+// AutoMergeChangeset method of the parent MockChangesetSource instance is
+// invoked.
+type ChangesetSourceAutoMergeChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset, bool) error
+	hooks       []func(context.Context, *Changeset, bool) error
+	history     []ChangesetSourceAutoMergeChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// AutoMergeChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockChangesetSource) AutoMergeChangeset(v0 context.Context, v1 *Changeset, v2 bool) error {
+	r0 := m.AutoMergeChangesetFunc.nextHook()(v0, v1, v2)
+	m.AutoMergeChangesetFunc.appendCall(ChangesetSourceAutoMergeChangesetFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AutoMergeChangeset
+// method of the parent MockChangesetSource instance is invoked and the hook
+// queue is empty.
+func (f *ChangesetSourceAutoMergeChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AutoMergeChangeset method of the parent MockChangesetSource instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ChangesetSourceAutoMergeChangesetFunc) PushHook(hook func(context.Context, *Changeset, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ChangesetSourceAutoMergeChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ChangesetSourceAutoMergeChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+func (f *ChangesetSourceAutoMergeChangesetFunc) nextHook() func(context.Context, *Changeset, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ChangesetSourceAutoMergeChangesetFunc) appendCall(r0 ChangesetSourceAutoMergeChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ChangesetSourceAutoMergeChangesetFuncCall
+// objects describing the invocations of this function.
+func (f *ChangesetSourceAutoMergeChangesetFunc) History() []ChangesetSourceAutoMergeChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]ChangesetSourceAutoMergeChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ChangesetSourceAutoMergeChangesetFuncCall is an object that describes an
+// invocation of method AutoMergeChangeset on an instance of
+// MockChangesetSource.
+type ChangesetSourceAutoMergeChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ChangesetSourceAutoMergeChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ChangesetSourceAutoMergeChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ChangesetSourceCloseChangesetFunc describes the behavior when the
 // CloseChangeset method of the parent MockChangesetSource instance is
 // invoked.
@@ -1292,6 +1419,9 @@ func (c ChangesetSourceWithAuthenticatorFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources)
 // used for unit testing.
 type MockForkableChangesetSource struct {
+	// AutoMergeChangesetFunc is an instance of a mock function object
+	// controlling the behavior of the method AutoMergeChangeset.
+	AutoMergeChangesetFunc *ForkableChangesetSourceAutoMergeChangesetFunc
 	// CloseChangesetFunc is an instance of a mock function object
 	// controlling the behavior of the method CloseChangeset.
 	CloseChangesetFunc *ForkableChangesetSourceCloseChangesetFunc
@@ -1335,6 +1465,11 @@ type MockForkableChangesetSource struct {
 // results, unless overwritten.
 func NewMockForkableChangesetSource() *MockForkableChangesetSource {
 	return &MockForkableChangesetSource{
+		AutoMergeChangesetFunc: &ForkableChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) (r0 error) {
+				return
+			},
+		},
 		CloseChangesetFunc: &ForkableChangesetSourceCloseChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) (r0 error) {
 				return
@@ -1403,6 +1538,11 @@ func NewMockForkableChangesetSource() *MockForkableChangesetSource {
 // unless overwritten.
 func NewStrictMockForkableChangesetSource() *MockForkableChangesetSource {
 	return &MockForkableChangesetSource{
+		AutoMergeChangesetFunc: &ForkableChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: func(context.Context, *Changeset, bool) error {
+				panic("unexpected invocation of MockForkableChangesetSource.AutoMergeChangeset")
+			},
+		},
 		CloseChangesetFunc: &ForkableChangesetSourceCloseChangesetFunc{
 			defaultHook: func(context.Context, *Changeset) error {
 				panic("unexpected invocation of MockForkableChangesetSource.CloseChangeset")
@@ -1471,6 +1611,9 @@ func NewStrictMockForkableChangesetSource() *MockForkableChangesetSource {
 // implementation, unless overwritten.
 func NewMockForkableChangesetSourceFrom(i ForkableChangesetSource) *MockForkableChangesetSource {
 	return &MockForkableChangesetSource{
+		AutoMergeChangesetFunc: &ForkableChangesetSourceAutoMergeChangesetFunc{
+			defaultHook: i.AutoMergeChangeset,
+		},
 		CloseChangesetFunc: &ForkableChangesetSourceCloseChangesetFunc{
 			defaultHook: i.CloseChangeset,
 		},
@@ -1510,6 +1653,118 @@ func NewMockForkableChangesetSourceFrom(i ForkableChangesetSource) *MockForkable
 	}
 }
 
+// ForkableChangesetSourceAutoMergeChangesetFunc describes the behavior when
+// the AutoMergeChangeset method of the parent MockForkableChangesetSource
+// instance is invoked.
+type ForkableChangesetSourceAutoMergeChangesetFunc struct {
+	defaultHook func(context.Context, *Changeset, bool) error
+	hooks       []func(context.Context, *Changeset, bool) error
+	history     []ForkableChangesetSourceAutoMergeChangesetFuncCall
+	mutex       sync.Mutex
+}
+
+// AutoMergeChangeset delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockForkableChangesetSource) AutoMergeChangeset(v0 context.Context, v1 *Changeset, v2 bool) error {
+	r0 := m.AutoMergeChangesetFunc.nextHook()(v0, v1, v2)
+	m.AutoMergeChangesetFunc.appendCall(ForkableChangesetSourceAutoMergeChangesetFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the AutoMergeChangeset
+// method of the parent MockForkableChangesetSource instance is invoked and
+// the hook queue is empty.
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) SetDefaultHook(hook func(context.Context, *Changeset, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// AutoMergeChangeset method of the parent MockForkableChangesetSource
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) PushHook(hook func(context.Context, *Changeset, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, *Changeset, bool) error {
+		return r0
+	})
+}
+
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) nextHook() func(context.Context, *Changeset, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) appendCall(r0 ForkableChangesetSourceAutoMergeChangesetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// ForkableChangesetSourceAutoMergeChangesetFuncCall objects describing the
+// invocations of this function.
+func (f *ForkableChangesetSourceAutoMergeChangesetFunc) History() []ForkableChangesetSourceAutoMergeChangesetFuncCall {
+	f.mutex.Lock()
+	history := make([]ForkableChangesetSourceAutoMergeChangesetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ForkableChangesetSourceAutoMergeChangesetFuncCall is an object that
+// describes an invocation of method AutoMergeChangeset on an instance of
+// MockForkableChangesetSource.
+type ForkableChangesetSourceAutoMergeChangesetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 *Changeset
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ForkableChangesetSourceAutoMergeChangesetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ForkableChangesetSourceAutoMergeChangesetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ForkableChangesetSourceCloseChangesetFunc describes the behavior when the
 // CloseChangeset method of the parent MockForkableChangesetSource instance
 // is invoked.
@@ -58,6 +58,7 @@ type FakeChangesetSource struct {
 	AuthenticatedUsernameCalled bool
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
+	AutoMergeChangesetCalled    bool
 	IsArchivedPushErrorCalled   bool
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
@@ -323,6 +324,11 @@ func (s *FakeChangesetSource) MergeChangeset(ctx context.Context, c *sources.Cha
 	return s.Err
 }
 
+func (s *FakeChangesetSource) AutoMergeChangeset(ctx context.Context, c *sources.Changeset, squash bool) error {
+	s.AutoMergeChangesetCalled = true
+	return s.Err
+}
+
 func (s *FakeChangesetSource) IsArchivedPushError(output string) bool {
 	s.IsArchivedPushErrorCalled = true
 	return s.IsArchivedPushErrorTrue
@@ -0,0 +1,166 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// ComputeWaitingReason returns the reason why a changeset depending on the
+// repositories matched by the given patterns cannot be published or undrafted
+// yet. The candidates are the other changesets in the same batch change and
+// repoNames maps their repo IDs to names.
+//
+// An empty string is returned if every candidate in a matching repository has
+// been resolved, see dependencyResolved. Patterns that don't match any
+// candidate are considered satisfied, and changesets never wait on other
+// changesets in their own repository.
+func ComputeWaitingReason(repoID api.RepoID, dependsOn []string, candidates []*btypes.Changeset, repoNames map[api.RepoID]api.RepoName) (string, error) {
+	if len(dependsOn) == 0 {
+		return "", nil
+	}
+
+	waitingOn := map[api.RepoName]struct{}{}
+	for _, c := range candidates {
+		if c.RepoID == repoID || dependencyResolved(c) {
+			continue
+		}
+
+		name, ok := repoNames[c.RepoID]
+		if !ok {
+			continue
+		}
+
+		match, err := matchAnyRepositoryPattern(dependsOn, name)
+		if err != nil {
+			return "", err
+		}
+		if match {
+			waitingOn[name] = struct{}{}
+		}
+	}
+
+	if len(waitingOn) == 0 {
+		return "", nil
+	}
+
+	names := make([]string, 0, len(waitingOn))
+	for name := range waitingOn {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf("Waiting for changesets in %s to be merged", strings.Join(names, ", ")), nil
+}
+
+// dependencyResolved returns whether the changeset no longer holds back the
+// changesets depending on it, because it will never be merged: it has been
+// merged, closed or deleted, the code host is read-only, or it has been
+// archived or detached from the batch change that owns it.
+func dependencyResolved(c *btypes.Changeset) bool {
+	switch c.ExternalState {
+	case btypes.ChangesetExternalStateMerged,
+		btypes.ChangesetExternalStateClosed,
+		btypes.ChangesetExternalStateDeleted,
+		btypes.ChangesetExternalStateReadOnly:
+		return true
+	}
+
+	for _, assoc := range c.BatchChanges {
+		if assoc.BatchChangeID == c.OwnedByBatchChangeID {
+			return assoc.Detach || assoc.Archive || assoc.IsArchived
+		}
+	}
+	return true
+}
+
+// FindDependencyCycle returns the names of the repositories of a cycle in the
+// dependencies between the given changeset specs, or nil if there is none.
+// The changesets in such a cycle would wait for each other forever.
+func FindDependencyCycle(specs []*btypes.ChangesetSpec, repoNames map[api.RepoID]api.RepoName) ([]api.RepoName, error) {
+	dependsOn := map[api.RepoName][]string{}
+	var names []api.RepoName
+	for _, spec := range specs {
+		name, ok := repoNames[spec.BaseRepoID]
+		if !ok {
+			continue
+		}
+		if _, ok := dependsOn[name]; !ok {
+			names = append(names, name)
+		}
+		dependsOn[name] = append(dependsOn[name], spec.DependsOn...)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	edges := make(map[api.RepoName][]api.RepoName, len(names))
+	for _, from := range names {
+		if len(dependsOn[from]) == 0 {
+			continue
+		}
+		for _, to := range names {
+			if to == from {
+				continue
+			}
+			match, err := matchAnyRepositoryPattern(dependsOn[from], to)
+			if err != nil {
+				return nil, err
+			}
+			if match {
+				edges[from] = append(edges[from], to)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	visits := make(map[api.RepoName]int, len(names))
+	var path []api.RepoName
+	var visit func(name api.RepoName) []api.RepoName
+	visit = func(name api.RepoName) []api.RepoName {
+		visits[name] = visiting
+		path = append(path, name)
+		for _, next := range edges[name] {
+			switch visits[next] {
+			case visiting:
+				for i, n := range path {
+					if n == next {
+						return append(append([]api.RepoName{}, path[i:]...), next)
+					}
+				}
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		visits[name] = visited
+		return nil
+	}
+
+	for _, name := range names {
+		if visits[name] == unvisited {
+			if cycle := visit(name); cycle != nil {
+				return cycle, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func matchAnyRepositoryPattern(patterns []string, name api.RepoName) (bool, error) {
+	for _, pattern := range patterns {
+		match, err := batcheslib.MatchRepositoryPattern(pattern, string(name))
+		if err != nil || match {
+			return match, err
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,166 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestComputeWaitingReason(t *testing.T) {
+	repoNames := map[api.RepoID]api.RepoName{
+		1: "github.com/sourcegraph/go-lib",
+		2: "github.com/sourcegraph/batches-lib",
+		3: "github.com/sourcegraph/batches-cli",
+		4: "github.com/sourcegraph/src-cli",
+	}
+
+	changeset := func(repoID api.RepoID, state btypes.ChangesetExternalState) *btypes.Changeset {
+		return &btypes.Changeset{
+			RepoID:               repoID,
+			ExternalState:        state,
+			OwnedByBatchChangeID: 1,
+			BatchChanges:         []btypes.BatchChangeAssoc{{BatchChangeID: 1}},
+		}
+	}
+	archived := func(c *btypes.Changeset) *btypes.Changeset {
+		c.BatchChanges[0].IsArchived = true
+		return c
+	}
+
+	tests := []struct {
+		name       string
+		dependsOn  []string
+		candidates []*btypes.Changeset
+		want       string
+	}{
+		{
+			name:       "no dependencies",
+			candidates: []*btypes.Changeset{changeset(1, btypes.ChangesetExternalStateOpen)},
+			want:       "",
+		},
+		{
+			name:       "no matching changesets",
+			dependsOn:  []string{"github.com/sourcegraph/other"},
+			candidates: []*btypes.Changeset{changeset(1, btypes.ChangesetExternalStateOpen)},
+			want:       "",
+		},
+		{
+			name:      "all dependencies merged",
+			dependsOn: []string{"github.com/sourcegraph/go-lib", "github.com/sourcegraph/batches-*"},
+			candidates: []*btypes.Changeset{
+				changeset(1, btypes.ChangesetExternalStateMerged),
+				changeset(2, btypes.ChangesetExternalStateMerged),
+				changeset(3, btypes.ChangesetExternalStateMerged),
+			},
+			want: "",
+		},
+		{
+			name:      "unmerged dependencies",
+			dependsOn: []string{"github.com/sourcegraph/go-lib", "github.com/sourcegraph/batches-*"},
+			candidates: []*btypes.Changeset{
+				changeset(1, btypes.ChangesetExternalStateMerged),
+				changeset(3, btypes.ChangesetExternalStateDraft),
+				changeset(2, ""),
+			},
+			want: "Waiting for changesets in github.com/sourcegraph/batches-cli, github.com/sourcegraph/batches-lib to be merged",
+		},
+		{
+			name:      "closed, deleted and archived dependencies",
+			dependsOn: []string{"github.com/sourcegraph/go-lib", "github.com/sourcegraph/batches-*"},
+			candidates: []*btypes.Changeset{
+				changeset(1, btypes.ChangesetExternalStateClosed),
+				changeset(2, btypes.ChangesetExternalStateDeleted),
+				archived(changeset(3, btypes.ChangesetExternalStateOpen)),
+			},
+			want: "",
+		},
+		{
+			name:       "dependency in the same repository",
+			dependsOn:  []string{"github.com/sourcegraph/*"},
+			candidates: []*btypes.Changeset{changeset(4, btypes.ChangesetExternalStateOpen)},
+			want:       "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := ComputeWaitingReason(4, tc.dependsOn, tc.candidates, repoNames)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong waiting reason. want=%q, have=%q", tc.want, have)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := ComputeWaitingReason(4, []string{"github.com/[lib"}, []*btypes.Changeset{changeset(1, "")}, repoNames)
+		if err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestFindDependencyCycle(t *testing.T) {
+	repoNames := map[api.RepoID]api.RepoName{
+		1: "github.com/sourcegraph/go-lib",
+		2: "github.com/sourcegraph/batches-lib",
+		3: "github.com/sourcegraph/src-cli",
+	}
+
+	spec := func(repoID api.RepoID, dependsOn ...string) *btypes.ChangesetSpec {
+		return &btypes.ChangesetSpec{BaseRepoID: repoID, DependsOn: dependsOn}
+	}
+
+	tests := []struct {
+		name  string
+		specs []*btypes.ChangesetSpec
+		want  []api.RepoName
+	}{
+		{
+			name: "no cycle",
+			specs: []*btypes.ChangesetSpec{
+				spec(1),
+				spec(2, "github.com/sourcegraph/go-lib"),
+				spec(3, "github.com/sourcegraph/*-lib"),
+			},
+		},
+		{
+			name: "dependency on own repository",
+			specs: []*btypes.ChangesetSpec{
+				spec(1, "github.com/sourcegraph/*"),
+				spec(1, "github.com/sourcegraph/go-lib"),
+			},
+		},
+		{
+			name: "cycle",
+			specs: []*btypes.ChangesetSpec{
+				spec(1, "github.com/sourcegraph/src-cli"),
+				spec(2, "github.com/sourcegraph/go-lib"),
+				spec(3, "github.com/sourcegraph/batches-*"),
+			},
+			want: []api.RepoName{
+				"github.com/sourcegraph/batches-lib",
+				"github.com/sourcegraph/go-lib",
+				"github.com/sourcegraph/src-cli",
+				"github.com/sourcegraph/batches-lib",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := FindDependencyCycle(tc.specs, repoNames)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Errorf("wrong cycle (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package state
 import (
 	"time"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -14,6 +15,8 @@ func setDraft(c *btypes.Changeset) *btypes.Changeset {
 		m.IsDraft = true
 	case *gitlab.MergeRequest:
 		m.WorkInProgress = true
+	case *adobatches.AnnotatedPullRequest:
+		m.IsDraft = true
 	}
 	return c
 }
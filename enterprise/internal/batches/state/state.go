@@ -11,6 +11,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -18,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -112,6 +114,9 @@ func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.Chang
 
 	case *bbcs.AnnotatedPullRequest:
 		return computeBitbucketCloudBuildState(c.UpdatedAt, m, events)
+
+	case *adobatches.AnnotatedPullRequest:
+		return computeAzureDevOpsBuildState(m, events)
 	}
 
 	return btypes.ChangesetCheckStateUnknown
@@ -251,6 +256,60 @@ func parseBitbucketCloudBuildState(s bitbucketcloud.PullRequestStatusState) btyp
 	}
 }
 
+func computeAzureDevOpsBuildState(apr *adobatches.AnnotatedPullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+	// Azure DevOps keeps every status ever posted to a pull request, so we only
+	// consider the most recent status of each context.
+	latest := make(map[string]*azuredevops.PullRequestBuildStatus)
+	addStatus := func(status *azuredevops.PullRequestBuildStatus) {
+		key := status.ContextKey()
+		if current, ok := latest[key]; !ok || isNewerAzureDevOpsBuildStatus(status, current) {
+			latest[key] = status
+		}
+	}
+
+	for _, status := range apr.Statuses {
+		addStatus(status)
+	}
+	for _, e := range events {
+		if m, ok := e.Metadata.(*azuredevops.PullRequestBuildStatus); ok {
+			addStatus(m)
+		}
+	}
+
+	states := make([]btypes.ChangesetCheckState, 0, len(latest))
+	for _, status := range latest {
+		// Statuses that don't apply to the pull request don't count towards
+		// its overall state.
+		if status.State == azuredevops.PullRequestStatusStateNotApplicable {
+			continue
+		}
+		states = append(states, parseAzureDevOpsBuildState(status.State))
+	}
+
+	return combineCheckStates(states)
+}
+
+func isNewerAzureDevOpsBuildStatus(a, b *azuredevops.PullRequestBuildStatus) bool {
+	if !a.UpdatedDate.Equal(b.UpdatedDate) {
+		return a.UpdatedDate.After(b.UpdatedDate)
+	}
+	// Status IDs are assigned sequentially within a pull request.
+	return a.ID > b.ID
+}
+
+func parseAzureDevOpsBuildState(s azuredevops.PullRequestStatusState) btypes.ChangesetCheckState {
+	switch s {
+	case azuredevops.PullRequestStatusStateError, azuredevops.PullRequestStatusStateFailed:
+		return btypes.ChangesetCheckStateFailed
+	case azuredevops.PullRequestStatusStatePending:
+		return btypes.ChangesetCheckStatePending
+	case azuredevops.PullRequestStatusStateSucceeded:
+		return btypes.ChangesetCheckStatePassed
+	default:
+		return btypes.ChangesetCheckStateUnknown
+	}
+}
+
 func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
@@ -515,6 +574,21 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *adobatches.AnnotatedPullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = btypes.ChangesetExternalStateMerged
+		case azuredevops.PullRequestStatusActive:
+			if m.IsDraft {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -584,6 +658,18 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *adobatches.AnnotatedPullRequest:
+		for _, reviewer := range m.Reviewers {
+			switch reviewer.Vote {
+			case azuredevops.ReviewerVoteApproved, azuredevops.ReviewerVoteApprovedWithSuggestions:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case azuredevops.ReviewerVoteWaitingForAuthor, azuredevops.ReviewerVoteRejected:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
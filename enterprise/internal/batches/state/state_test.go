@@ -7,8 +7,10 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -277,6 +279,102 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 	}
 }
 
+func TestComputeAzureDevOpsBuildState(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	nextID := 0
+	status := func(name string, state azuredevops.PullRequestStatusState, updated time.Time) *azuredevops.PullRequestBuildStatus {
+		nextID++
+		return &azuredevops.PullRequestBuildStatus{
+			ID:          nextID,
+			State:       state,
+			Context:     azuredevops.StatusContext{Name: name, Genre: "ci"},
+			UpdatedDate: updated,
+		}
+	}
+	statusEvent := func(s *azuredevops.PullRequestBuildStatus) *btypes.ChangesetEvent {
+		return &btypes.ChangesetEvent{
+			Kind:     btypes.ChangesetEventKindAzureDevOpsBuildStatus,
+			Metadata: s,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []*azuredevops.PullRequestBuildStatus
+		events   []*btypes.ChangesetEvent
+		want     btypes.ChangesetCheckState
+	}{
+		{
+			name: "no statuses",
+			want: btypes.ChangesetCheckStateUnknown,
+		},
+		{
+			name: "single success",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStateSucceeded, now),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "success + error",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStateSucceeded, now),
+				status("lint", azuredevops.PullRequestStatusStateError, now),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "pending + success",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStatePending, now),
+				status("lint", azuredevops.PullRequestStatusStateSucceeded, now),
+			},
+			want: btypes.ChangesetCheckStatePending,
+		},
+		{
+			name: "not applicable is ignored",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStateSucceeded, now),
+				status("lint", azuredevops.PullRequestStatusStateNotApplicable, now),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "only the latest status of a context counts",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStateFailed, now.Add(-time.Hour)),
+				status("build", azuredevops.PullRequestStatusStateSucceeded, now),
+			},
+			want: btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "events are taken into account",
+			statuses: []*azuredevops.PullRequestBuildStatus{
+				status("build", azuredevops.PullRequestStatusStatePending, now.Add(-time.Hour)),
+			},
+			events: []*btypes.ChangesetEvent{
+				statusEvent(status("build", azuredevops.PullRequestStatusStateFailed, now)),
+			},
+			want: btypes.ChangesetCheckStateFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			apr := &adobatches.AnnotatedPullRequest{
+				PullRequest: &azuredevops.PullRequest{},
+				Statuses:    tc.statuses,
+			}
+			have := computeAzureDevOpsBuildState(apr, tc.events)
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
 func TestComputeGitLabCheckState(t *testing.T) {
 	t.Parallel()
 
@@ -536,6 +634,24 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "azuredevops - no events, no votes",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, azuredevops.ReviewerVoteNoVote),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name:      "azuredevops - no events, approved with suggestions",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, azuredevops.ReviewerVoteApprovedWithSuggestions),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name:      "azuredevops - no events, rejection wins",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive, azuredevops.ReviewerVoteApproved, azuredevops.ReviewerVoteWaitingForAuthor),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStateChangesRequested,
+		},
 	}
 
 	for i, tc := range tests {
@@ -744,6 +860,30 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateReadOnly,
 		},
+		{
+			name:      "azuredevops - no events, active",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "azuredevops - no events, abandoned",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusAbandoned),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "azuredevops - no events, completed",
+			changeset: azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusCompleted),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
+		{
+			name:      "azuredevops draft - no events",
+			changeset: setDraft(azureDevOpsChangeset(daysAgo(0), azuredevops.PullRequestStatusActive)),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateDraft,
+		},
 	}
 
 	for i, tc := range tests {
@@ -881,6 +1021,24 @@ func bitbucketChangeset(updatedAt time.Time, state, reviewStatus string) *btypes
 	}
 }
 
+func azureDevOpsChangeset(updatedAt time.Time, status azuredevops.PullRequestStatus, votes ...azuredevops.ReviewerVote) *btypes.Changeset {
+	reviewers := make([]azuredevops.Reviewer, 0, len(votes))
+	for _, vote := range votes {
+		reviewers = append(reviewers, azuredevops.Reviewer{Vote: vote})
+	}
+
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAzureDevOps,
+		UpdatedAt:           updatedAt,
+		Metadata: &adobatches.AnnotatedPullRequest{
+			PullRequest: &azuredevops.PullRequest{
+				Status:    status,
+				Reviewers: reviewers,
+			},
+		},
+	}
+}
+
 func githubChangeset(updatedAt time.Time, state string) *btypes.Changeset {
 	return &btypes.Changeset{
 		ExternalServiceType: extsvc.TypeGitHub,
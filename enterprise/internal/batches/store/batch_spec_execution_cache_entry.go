@@ -95,6 +95,11 @@ RETURNING %s
 type ListBatchSpecExecutionCacheEntriesOpts struct {
 	Keys   []string
 	UserID int32
+
+	// IncludeShared also returns the entries other users created for the
+	// given Keys. At most one entry is returned per key, preferring the entry
+	// of UserID over the most recent entry of another user.
+	IncludeShared bool
 }
 
 // ListBatchSpecExecutionCacheEntries gets the BatchSpecExecutionCacheEntries matching the given options.
@@ -132,14 +137,33 @@ SELECT %s FROM batch_spec_execution_cache_entries
 WHERE %s
 `
 
+var listSharedBatchSpecExecutionCacheEntriesQueryFmtstr = `
+SELECT DISTINCT ON (batch_spec_execution_cache_entries.key) %s FROM batch_spec_execution_cache_entries
+WHERE %s
+ORDER BY
+	batch_spec_execution_cache_entries.key,
+	batch_spec_execution_cache_entries.user_id = %s DESC,
+	batch_spec_execution_cache_entries.created_at DESC
+`
+
 func listBatchSpecExecutionCacheEntriesQuery(opts *ListBatchSpecExecutionCacheEntriesOpts) *sqlf.Query {
 	preds := []*sqlf.Query{
 		sqlf.Sprintf("batch_spec_execution_cache_entries.key = ANY (%s)", pq.Array(opts.Keys)),
 		// Only consider records that are in the current cache version.
 		sqlf.Sprintf("batch_spec_execution_cache_entries.version = %s", btypes.CurrentCacheVersion),
-		sqlf.Sprintf("batch_spec_execution_cache_entries.user_id = %s", opts.UserID),
 	}
 
+	if opts.IncludeShared {
+		return sqlf.Sprintf(
+			listSharedBatchSpecExecutionCacheEntriesQueryFmtstr,
+			sqlf.Join(BatchSpecExecutionCacheEntryColums.ToSqlf(), ", "),
+			sqlf.Join(preds, "\n AND "),
+			opts.UserID,
+		)
+	}
+
+	preds = append(preds, sqlf.Sprintf("batch_spec_execution_cache_entries.user_id = %s", opts.UserID))
+
 	return sqlf.Sprintf(
 		listBatchSpecExecutionCacheEntriesQueryFmtstr,
 		sqlf.Join(BatchSpecExecutionCacheEntryColums.ToSqlf(), ", "),
@@ -78,6 +78,43 @@ func testStoreBatchSpecExecutionCacheEntries(t *testing.T, ctx context.Context,
 		})
 	})
 
+	t.Run("ListShared", func(t *testing.T) {
+		own := &btypes.BatchSpecExecutionCacheEntry{
+			UserID: 950,
+			Key:    entries[1].Key,
+			Value:  "my-own-cache-value",
+		}
+		if err := s.CreateBatchSpecExecutionCacheEntry(ctx, own); err != nil {
+			t.Fatal(err)
+		}
+
+		cs, err := s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID:        own.UserID,
+			Keys:          []string{entries[0].Key, entries[1].Key, "unknown-cache-key"},
+			IncludeShared: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The entry of the other user is returned for the first key, but the
+		// own entry is preferred for the second key.
+		if diff := cmp.Diff([]*btypes.BatchSpecExecutionCacheEntry{entries[0], own}, cs); diff != "" {
+			t.Fatal(diff)
+		}
+
+		cs, err = s.ListBatchSpecExecutionCacheEntries(ctx, ListBatchSpecExecutionCacheEntriesOpts{
+			UserID: own.UserID,
+			Keys:   []string{entries[0].Key},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cs) != 0 {
+			t.Fatalf("unexpected shared cache entries: %+v", cs)
+		}
+	})
+
 	t.Run("CreateWithConflictingKey", func(t *testing.T) {
 		clock.Add(1 * time.Minute)
 
@@ -202,6 +202,8 @@ func scanChangesetJob(c *btypes.ChangesetJob, s dbutil.Scanner) error {
 		c.Payload = new(btypes.ChangesetJobClosePayload)
 	case btypes.ChangesetJobTypePublish:
 		c.Payload = new(btypes.ChangesetJobPublishPayload)
+	case btypes.ChangesetJobTypeAutoMerge:
+		c.Payload = new(btypes.ChangesetJobAutoMergePayload)
 	default:
 		return errors.Errorf("unknown job type %q", c.JobType)
 	}
@@ -76,6 +76,7 @@ func testStoreChangesetSpecs(t *testing.T, ctx context.Context, s *Store, clock
 			c.Diff = testDiff
 			c.CommitAuthorName = "name"
 			c.CommitAuthorEmail = "email"
+			c.DependsOn = []string{"github.com/sourcegraph/go-lib"}
 			c.Type = btypes.ChangesetSpecTypeBranch
 		} else {
 			c.ExternalID = "123456"
@@ -15,6 +15,7 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/search"
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -22,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -68,6 +70,9 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
 	sqlf.Sprintf("changesets.detached_at"),
+	sqlf.Sprintf("changesets.auto_merge"),
+	sqlf.Sprintf("changesets.auto_merge_squash"),
+	sqlf.Sprintf("changesets.waiting_reason"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -105,6 +110,9 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("auto_merge"),
+	sqlf.Sprintf("auto_merge_squash"),
+	sqlf.Sprintf("waiting_reason"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -187,6 +195,9 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		c.AutoMerge,
+		c.AutoMergeSquash,
+		dbutil.NullStringColumn(c.WaitingReason),
 		dbutil.NullStringColumn(title),
 	}
 
@@ -230,7 +241,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 
 var createChangesetQueryFmtstr = `
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -734,7 +745,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 
 var updateChangesetQueryFmtstr = `
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -768,6 +779,82 @@ func (s *Store) UpdateChangesetUiPublicationState(ctx context.Context, cs *btype
 	return s.updateChangesetColumn(ctx, cs, "ui_publication_state", uiPublicationState)
 }
 
+// UpdateChangesetAutoMerge updates only the `auto_merge`, `auto_merge_squash`
+// & `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetAutoMerge(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetAutoMerge.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cs.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateChangesetAutoMergeQueryFmtstr,
+		cs.UpdatedAt,
+		cs.AutoMerge,
+		cs.AutoMergeSquash,
+		cs.ID,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var updateChangesetAutoMergeQueryFmtstr = `
+UPDATE changesets
+SET (updated_at, auto_merge, auto_merge_squash) = (%s, %s, %s)
+WHERE id = %s
+RETURNING
+  %s
+`
+
+// UpdateChangesetWaitingReason updates only the `waiting_reason` &
+// `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetWaitingReason(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetWaitingReason.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.updateChangesetColumn(ctx, cs, "waiting_reason", dbutil.NullStringColumn(cs.WaitingReason))
+}
+
+// EnqueueChangesetsWaitingOnDependencies re-enqueues all changesets owned by
+// the given batch change that have been held back by the reconciler because
+// of unmerged dependencies, so that the reconciler can re-evaluate them.
+func (s *Store) EnqueueChangesetsWaitingOnDependencies(ctx context.Context, batchChangeID int64, resetState btypes.ReconcilerState) (err error) {
+	ctx, _, endObservation := s.operations.enqueueChangesetsWaitingOnDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		enqueueChangesetsWaitingOnDependenciesFmtstr,
+		resetState.ToDB(),
+		s.now(),
+		batchChangeID,
+		btypes.ReconcilerStateCompleted.ToDB(),
+	)
+	return s.Exec(ctx, q)
+}
+
+var enqueueChangesetsWaitingOnDependenciesFmtstr = `
+UPDATE changesets
+SET
+	reconciler_state = %s,
+	num_resets = 0,
+	num_failures = 0,
+	failure_message = NULL,
+	updated_at = %s
+WHERE
+	owned_by_batch_change_id = %s
+	AND waiting_reason IS NOT NULL
+	AND reconciler_state = %s
+`
+
 // updateChangesetColumn updates the column with the given name, setting it to
 // the given value, and updating the updated_at column.
 func (s *Store) updateChangesetColumn(ctx context.Context, cs *btypes.Changeset, name string, val any) error {
@@ -1143,6 +1230,9 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
 		&dbutil.NullTime{Time: &t.DetachedAt},
+		&t.AutoMerge,
+		&t.AutoMergeSquash,
+		&dbutil.NullString{S: &t.WaitingReason},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1171,6 +1261,11 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		// Ensure the inner PR is initialized, it should never be nil.
 		m.PullRequest = &bitbucketcloud.PullRequest{}
 		t.Metadata = m
+	case extsvc.TypeAzureDevOps:
+		m := new(adobatches.AnnotatedPullRequest)
+		// Ensure the inner PR is initialized, it should never be nil.
+		m.PullRequest = &azuredevops.PullRequest{}
+		t.Metadata = m
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1599,6 +1599,114 @@ func testStoreChangesets(t *testing.T, ctx context.Context, s *Store, clock bt.C
 			t.Fatalf("invalid changeset: %s", diff)
 		}
 	})
+
+	t.Run("UpdateChangesetAutoMerge", func(t *testing.T) {
+		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			ReconcilerState:  btypes.ReconcilerStateCompleted,
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ExternalState:    btypes.ChangesetExternalStateOpen,
+			Repo:             repo.ID,
+		})
+
+		c1.AutoMerge = true
+		c1.AutoMergeSquash = true
+
+		// This is what we expect after the update
+		want := c1.Clone()
+
+		// Other columns should not be updated in the DB
+		c1.ReconcilerState = btypes.ReconcilerStateErrored
+		c1.ExternalServiceType = "external-service-type"
+
+		if err := s.UpdateChangesetAutoMerge(ctx, c1); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		have := c1
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changeset: %s", diff)
+		}
+	})
+
+	t.Run("UpdateChangesetWaitingReason", func(t *testing.T) {
+		c1 := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			ReconcilerState:  btypes.ReconcilerStateCompleted,
+			PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			Repo:             repo.ID,
+		})
+
+		c1.WaitingReason = "Waiting for changesets in github.com/sourcegraph/go-lib to be merged"
+
+		// This is what we expect after the update
+		want := c1.Clone()
+
+		// Other columns should not be updated in the DB
+		c1.ReconcilerState = btypes.ReconcilerStateErrored
+		c1.ExternalServiceType = "external-service-type"
+
+		if err := s.UpdateChangesetWaitingReason(ctx, c1); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		have := c1
+		if diff := cmp.Diff(have, want); diff != "" {
+			t.Fatalf("invalid changeset: %s", diff)
+		}
+	})
+
+	t.Run("EnqueueChangesetsWaitingOnDependencies", func(t *testing.T) {
+		var batchChangeID int64 = 9876
+		waitingReason := "Waiting for changesets in github.com/sourcegraph/go-lib to be merged"
+
+		waiting := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			WaitingReason:      waitingReason,
+		})
+		notWaiting := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+		})
+		otherBatchChange := bt.CreateChangeset(t, ctx, s, bt.TestChangesetOpts{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID + 1,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			WaitingReason:      waitingReason,
+		})
+
+		if err := s.EnqueueChangesetsWaitingOnDependencies(ctx, batchChangeID, btypes.ReconcilerStateQueued); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		bt.ReloadAndAssertChangeset(t, ctx, s, waiting, bt.ChangesetAssertions{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID,
+			ReconcilerState:    btypes.ReconcilerStateQueued,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ExternalState:      waiting.ExternalState,
+			WaitingReason:      waitingReason,
+		})
+		bt.ReloadAndAssertChangeset(t, ctx, s, notWaiting, bt.ChangesetAssertions{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ExternalState:      notWaiting.ExternalState,
+		})
+		bt.ReloadAndAssertChangeset(t, ctx, s, otherBatchChange, bt.ChangesetAssertions{
+			Repo:               repo.ID,
+			OwnedByBatchChange: batchChangeID + 1,
+			ReconcilerState:    btypes.ReconcilerStateCompleted,
+			PublicationState:   btypes.ChangesetPublicationStateUnpublished,
+			ExternalState:      otherBatchChange.ExternalState,
+			WaitingReason:      waitingReason,
+		})
+	})
 }
 
 func testStoreListChangesetSyncData(t *testing.T, ctx context.Context, s *Store, clock bt.Clock) {
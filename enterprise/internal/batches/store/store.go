@@ -227,26 +227,29 @@ type operations struct {
 	listChangesetSpecsWithConflictingHeadRef *observation.Operation
 	deleteChangesetSpecs                     *observation.Operation
 
-	createChangeset                   *observation.Operation
-	deleteChangeset                   *observation.Operation
-	countChangesets                   *observation.Operation
-	getChangeset                      *observation.Operation
-	listChangesetSyncData             *observation.Operation
-	listChangesets                    *observation.Operation
-	enqueueChangeset                  *observation.Operation
-	updateChangeset                   *observation.Operation
-	updateChangesetBatchChanges       *observation.Operation
-	updateChangesetUIPublicationState *observation.Operation
-	updateChangesetCodeHostState      *observation.Operation
-	getChangesetExternalIDs           *observation.Operation
-	cancelQueuedBatchChangeChangesets *observation.Operation
-	enqueueChangesetsToClose          *observation.Operation
-	getChangesetsStats                *observation.Operation
-	getRepoChangesetsStats            *observation.Operation
-	getGlobalChangesetsStats          *observation.Operation
-	enqueueNextScheduledChangeset     *observation.Operation
-	getChangesetPlaceInSchedulerQueue *observation.Operation
-	cleanDetachedChangesets           *observation.Operation
+	createChangeset                        *observation.Operation
+	deleteChangeset                        *observation.Operation
+	countChangesets                        *observation.Operation
+	getChangeset                           *observation.Operation
+	listChangesetSyncData                  *observation.Operation
+	listChangesets                         *observation.Operation
+	enqueueChangeset                       *observation.Operation
+	updateChangeset                        *observation.Operation
+	updateChangesetBatchChanges            *observation.Operation
+	updateChangesetUIPublicationState      *observation.Operation
+	updateChangesetAutoMerge               *observation.Operation
+	updateChangesetWaitingReason           *observation.Operation
+	updateChangesetCodeHostState           *observation.Operation
+	getChangesetExternalIDs                *observation.Operation
+	cancelQueuedBatchChangeChangesets      *observation.Operation
+	enqueueChangesetsToClose               *observation.Operation
+	enqueueChangesetsWaitingOnDependencies *observation.Operation
+	getChangesetsStats                     *observation.Operation
+	getRepoChangesetsStats                 *observation.Operation
+	getGlobalChangesetsStats               *observation.Operation
+	enqueueNextScheduledChangeset          *observation.Operation
+	getChangesetPlaceInSchedulerQueue      *observation.Operation
+	cleanDetachedChangesets                *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -370,26 +373,29 @@ func newOperations(observationContext *observation.Context) *operations {
 			getRewirerMappings:                       op("GetRewirerMappings"),
 			listChangesetSpecsWithConflictingHeadRef: op("ListChangesetSpecsWithConflictingHeadRef"),
 
-			createChangeset:                   op("CreateChangeset"),
-			deleteChangeset:                   op("DeleteChangeset"),
-			countChangesets:                   op("CountChangesets"),
-			getChangeset:                      op("GetChangeset"),
-			listChangesetSyncData:             op("ListChangesetSyncData"),
-			listChangesets:                    op("ListChangesets"),
-			enqueueChangeset:                  op("EnqueueChangeset"),
-			updateChangeset:                   op("UpdateChangeset"),
-			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
-			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
-			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
-			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
-			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
-			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
-			getChangesetsStats:                op("GetChangesetsStats"),
-			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
-			getGlobalChangesetsStats:          op("GetGlobalChangesetsStats"),
-			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
-			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
-			cleanDetachedChangesets:           op("CleanDetachedChangesets"),
+			createChangeset:                        op("CreateChangeset"),
+			deleteChangeset:                        op("DeleteChangeset"),
+			countChangesets:                        op("CountChangesets"),
+			getChangeset:                           op("GetChangeset"),
+			listChangesetSyncData:                  op("ListChangesetSyncData"),
+			listChangesets:                         op("ListChangesets"),
+			enqueueChangeset:                       op("EnqueueChangeset"),
+			updateChangeset:                        op("UpdateChangeset"),
+			updateChangesetBatchChanges:            op("UpdateChangesetBatchChanges"),
+			updateChangesetUIPublicationState:      op("UpdateChangesetUIPublicationState"),
+			updateChangesetAutoMerge:               op("UpdateChangesetAutoMerge"),
+			updateChangesetWaitingReason:           op("UpdateChangesetWaitingReason"),
+			updateChangesetCodeHostState:           op("UpdateChangesetCodeHostState"),
+			getChangesetExternalIDs:                op("GetChangesetExternalIDs"),
+			cancelQueuedBatchChangeChangesets:      op("CancelQueuedBatchChangeChangesets"),
+			enqueueChangesetsToClose:               op("EnqueueChangesetsToClose"),
+			enqueueChangesetsWaitingOnDependencies: op("EnqueueChangesetsWaitingOnDependencies"),
+			getChangesetsStats:                     op("GetChangesetsStats"),
+			getRepoChangesetsStats:                 op("GetRepoChangesetsStats"),
+			getGlobalChangesetsStats:               op("GetGlobalChangesetsStats"),
+			enqueueNextScheduledChangeset:          op("EnqueueNextScheduledChangeset"),
+			getChangesetPlaceInSchedulerQueue:      op("GetChangesetPlaceInSchedulerQueue"),
+			cleanDetachedChangesets:                op("CleanDetachedChangesets"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -10,6 +10,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/state"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
@@ -500,6 +501,9 @@ func (s *changesetSyncer) SyncChangeset(ctx context.Context, id int64) error {
 // SyncChangeset refreshes the metadata of the given changeset and
 // updates them in the database.
 func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Client, source sources.ChangesetSource, repo *types.Repo, c *btypes.Changeset) (err error) {
+	wasReadyToMerge := c.ReadyToMerge()
+	wasMerged := c.ExternalState == btypes.ChangesetExternalStateMerged
+
 	repoChangeset := &sources.Changeset{TargetRepo: repo, Changeset: c}
 	if err := source.LoadChangeset(ctx, repoChangeset); err != nil {
 		if !errors.HasType(err, sources.ChangesetNotFoundError{}) {
@@ -537,5 +541,22 @@ func SyncChangeset(ctx context.Context, syncStore SyncStore, client gitserver.Cl
 		return err
 	}
 
+	// Changesets marked for auto-merge on code hosts that can't merge them
+	// automatically are merged by the reconciler, so we enqueue them as soon
+	// as they become ready to be merged.
+	if c.AutoMerge && !wasReadyToMerge && c.ReadyToMerge() {
+		if err := tx.EnqueueChangeset(ctx, c, global.DefaultReconcilerEnqueueState(), ""); err != nil {
+			return err
+		}
+	}
+
+	// Other changesets in the batch change may be waiting for this one to be
+	// merged before they can be published.
+	if !wasMerged && c.ExternalState == btypes.ChangesetExternalStateMerged && !c.IsImported() {
+		if err := tx.EnqueueChangesetsWaitingOnDependencies(ctx, c.OwnedByBatchChangeID, global.DefaultReconcilerEnqueueState()); err != nil {
+			return err
+		}
+	}
+
 	return tx.UpsertChangesetEvents(ctx, events...)
 }
@@ -48,6 +48,10 @@ type TestChangesetOpts struct {
 	IsArchived bool
 	Archive    bool
 
+	AutoMerge bool
+
+	WaitingReason string
+
 	Metadata any
 }
 
@@ -94,7 +98,9 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 
 		OwnedByBatchChangeID: opts.OwnedByBatchChange,
 
-		Closing: opts.Closing,
+		Closing:       opts.Closing,
+		AutoMerge:     opts.AutoMerge,
+		WaitingReason: opts.WaitingReason,
 
 		ReconcilerState: opts.ReconcilerState,
 		NumFailures:     opts.NumFailures,
@@ -147,6 +153,7 @@ type ChangesetAssertions struct {
 	ExternalForkNamespace string
 	DiffStat              *diff.Stat
 	Closing               bool
+	WaitingReason         string
 
 	Title string
 	Body  string
@@ -226,6 +233,10 @@ func AssertChangeset(t *testing.T, c *btypes.Changeset, a ChangesetAssertions) {
 		t.Fatalf("changeset Closing wrong. (-want +got):\n%s", diff)
 	}
 
+	if have, want := c.WaitingReason, a.WaitingReason; have != want {
+		t.Fatalf("changeset WaitingReason wrong. want=%q, have=%q", want, have)
+	}
+
 	toDetach := []int64{}
 	for _, assoc := range c.BatchChanges {
 		if assoc.Detach {
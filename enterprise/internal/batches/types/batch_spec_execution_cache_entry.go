@@ -5,7 +5,10 @@ import (
 	"time"
 )
 
-const CurrentCacheVersion = 2
+// CurrentCacheVersion is the version of the cache keys of new cache entries.
+// Entries of other versions are never read and are cleaned up first. Version 3
+// keys images pinned to a digest by the digest only.
+const CurrentCacheVersion = 3
 
 type BatchSpecExecutionCacheEntry struct {
 	ID int64
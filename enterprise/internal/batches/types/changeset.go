@@ -8,9 +8,11 @@ import (
 	"github.com/inconshreveable/log15"
 	"github.com/sourcegraph/go-diff/diff"
 
+	adobatches "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/azuredevops"
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -295,6 +297,20 @@ type Changeset struct {
 	// reconciler should close the changeset.
 	Closing bool
 
+	// AutoMerge is set to true (along with the ReconcilerState) when the
+	// reconciler should merge the changeset once it has been approved and its
+	// checks have passed. It is reset once the code host has been asked to
+	// merge the changeset automatically, or the changeset has been merged.
+	AutoMerge bool
+	// AutoMergeSquash is true if the changeset should be squash merged when
+	// it is merged automatically.
+	AutoMergeSquash bool
+
+	// WaitingReason is set when the reconciler holds back publishing or
+	// undrafting the changeset, because the changesets it depends on haven't
+	// been merged yet.
+	WaitingReason string
+
 	// DetachedAt is the time when the changeset became "detached".
 	DetachedAt time.Time
 }
@@ -317,6 +333,15 @@ func (c *Changeset) Closeable() bool {
 		c.ExternalState != ChangesetExternalStateReadOnly
 }
 
+// ReadyToMerge returns whether the Changeset is open, has been approved, and
+// none of its checks are pending or failed.
+func (c *Changeset) ReadyToMerge() bool {
+	return c.ExternalState == ChangesetExternalStateOpen &&
+		c.ExternalReviewState == ChangesetReviewStateApproved &&
+		c.ExternalCheckState != ChangesetCheckStatePending &&
+		c.ExternalCheckState != ChangesetCheckStateFailed
+}
+
 // Complete returns whether the Changeset has been published and its
 // ExternalState is in a final state.
 func (c *Changeset) Complete() bool {
@@ -420,6 +445,23 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *adobatches.AnnotatedPullRequest:
+		c.Metadata = pr
+		c.ExternalID = strconv.Itoa(pr.ID)
+		c.ExternalServiceType = extsvc.TypeAzureDevOps
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceRefName)
+		// Azure DevOps doesn't track when a pull request was last updated, so
+		// we use the most recent timestamp we know of.
+		c.ExternalUpdatedAt = pr.CreationDate
+		if pr.ClosedDate.After(c.ExternalUpdatedAt) {
+			c.ExternalUpdatedAt = pr.ClosedDate
+		}
+		for _, s := range pr.Statuses {
+			if s.UpdatedDate.After(c.ExternalUpdatedAt) {
+				c.ExternalUpdatedAt = s.UpdatedDate
+			}
+		}
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -447,6 +489,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -466,6 +510,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreatedBy.UniqueName, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -493,6 +539,13 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *adobatches.AnnotatedPullRequest:
+		// The unique name of Azure Active Directory and Microsoft accounts is
+		// their e-mail address, but not all identities have one.
+		if strings.Contains(m.CreatedBy.UniqueName, "@") {
+			return m.CreatedBy.UniqueName, nil
+		}
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -511,6 +564,8 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *adobatches.AnnotatedPullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
@@ -527,6 +582,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *adobatches.AnnotatedPullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -572,6 +629,11 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *adobatches.AnnotatedPullRequest:
+		if u := m.WebURL(); u != "" {
+			return u, nil
+		}
+		return "", errors.New("Azure DevOps pull request does not have a web URL")
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -733,6 +795,39 @@ func (c *Changeset) Events() (events []*ChangesetEvent, err error) {
 			})
 		}
 
+		for _, status := range m.Statuses {
+			if kind, err = ChangesetEventKindFor(status); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				Key:         status.Key(),
+				Kind:        kind,
+				Metadata:    status,
+			})
+		}
+
+	case *adobatches.AnnotatedPullRequest:
+		// As with Bitbucket Cloud, we create review events from the current
+		// votes of the reviewers and check events from the statuses of the pull
+		// request.
+		var kind ChangesetEventKind
+
+		for i := range m.Reviewers {
+			reviewer := &m.Reviewers[i]
+			if kind, err = ChangesetEventKindFor(reviewer); err != nil {
+				return
+			}
+			appendEvent(&ChangesetEvent{
+				ChangesetID: c.ID,
+				// Reviewers have no ID of their own, but a reviewer can only
+				// vote once on each pull request.
+				Key:      m.Repository.ID + ":" + strconv.Itoa(m.ID) + ":" + reviewer.ID,
+				Kind:     kind,
+				Metadata: reviewer,
+			})
+		}
+
 		for _, status := range m.Statuses {
 			if kind, err = ChangesetEventKindFor(status); err != nil {
 				return
@@ -761,6 +856,11 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeSourceCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeSourceCommit.CommitID, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -778,6 +878,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.SourceRefName), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -796,6 +898,11 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *adobatches.AnnotatedPullRequest:
+		if m.LastMergeTargetCommit == nil {
+			return "", nil
+		}
+		return m.LastMergeTargetCommit.CommitID, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -813,6 +920,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *adobatches.AnnotatedPullRequest:
+		return gitdomain.EnsureRefPrefix(m.TargetRefName), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -1135,6 +1244,22 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusCreated, nil
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		return ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated, nil
+
+	case *azuredevops.Reviewer:
+		switch e.Vote {
+		case azuredevops.ReviewerVoteApproved:
+			return ChangesetEventKindAzureDevOpsApproved, nil
+		case azuredevops.ReviewerVoteApprovedWithSuggestions:
+			return ChangesetEventKindAzureDevOpsApprovedWithSuggestions, nil
+		case azuredevops.ReviewerVoteWaitingForAuthor:
+			return ChangesetEventKindAzureDevOpsWaitingForAuthor, nil
+		case azuredevops.ReviewerVoteRejected:
+			return ChangesetEventKindAzureDevOpsRejected, nil
+		default:
+			return ChangesetEventKindAzureDevOpsNoVote, nil
+		}
+	case *azuredevops.PullRequestBuildStatus:
+		return ChangesetEventKindAzureDevOpsBuildStatus, nil
 	}
 
 	return ChangesetEventKindInvalid, errors.Errorf("unknown changeset event kind for %T", e)
@@ -1144,6 +1269,17 @@ func ChangesetEventKindFor(e any) (ChangesetEventKind, error) {
 // ChangesetEventKind.
 func NewChangesetEventMetadata(k ChangesetEventKind) (any, error) {
 	switch {
+	case strings.HasPrefix(string(k), "azuredevops"):
+		switch k {
+		case ChangesetEventKindAzureDevOpsApproved,
+			ChangesetEventKindAzureDevOpsApprovedWithSuggestions,
+			ChangesetEventKindAzureDevOpsNoVote,
+			ChangesetEventKindAzureDevOpsRejected,
+			ChangesetEventKindAzureDevOpsWaitingForAuthor:
+			return new(azuredevops.Reviewer), nil
+		case ChangesetEventKindAzureDevOpsBuildStatus:
+			return new(azuredevops.PullRequestBuildStatus), nil
+		}
 	case strings.HasPrefix(string(k), "bitbucketcloud"):
 		switch k {
 		case ChangesetEventKindBitbucketCloudApproved,
@@ -8,6 +8,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -102,6 +103,15 @@ const (
 	ChangesetEventKindBitbucketCloudRepoCommitStatusCreated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_created"          // RepoCommitStatusCreatedEvent
 	ChangesetEventKindBitbucketCloudRepoCommitStatusUpdated          ChangesetEventKind = "bitbucketcloud:repo:commit_status_updated"          // RepoCommitStatusUpdatedEvent
 
+	// These changeset events are created as the result of regular syncs with
+	// Azure DevOps. Reviewer events reflect the current vote of each reviewer.
+	ChangesetEventKindAzureDevOpsApproved                ChangesetEventKind = "azuredevops:approved"
+	ChangesetEventKindAzureDevOpsApprovedWithSuggestions ChangesetEventKind = "azuredevops:approved_with_suggestions"
+	ChangesetEventKindAzureDevOpsBuildStatus             ChangesetEventKind = "azuredevops:build_status"
+	ChangesetEventKindAzureDevOpsNoVote                  ChangesetEventKind = "azuredevops:no_vote"
+	ChangesetEventKindAzureDevOpsRejected                ChangesetEventKind = "azuredevops:rejected"
+	ChangesetEventKindAzureDevOpsWaitingForAuthor        ChangesetEventKind = "azuredevops:waiting_for_author"
+
 	ChangesetEventKindInvalid ChangesetEventKind = "invalid"
 )
 
@@ -163,6 +173,9 @@ func (e *ChangesetEvent) ReviewAuthor() string {
 	case *bitbucketcloud.PullRequestChangesRequestRemovedEvent:
 		return meta.ChangesRequest.User.UUID
 
+	case *azuredevops.Reviewer:
+		return meta.ID
+
 	default:
 		return ""
 	}
@@ -174,14 +187,18 @@ func (e *ChangesetEvent) ReviewState() (ChangesetReviewState, error) {
 	case ChangesetEventKindBitbucketServerApproved,
 		ChangesetEventKindGitLabApproved,
 		ChangesetEventKindBitbucketCloudApproved,
-		ChangesetEventKindBitbucketCloudPullRequestApproved:
+		ChangesetEventKindBitbucketCloudPullRequestApproved,
+		ChangesetEventKindAzureDevOpsApproved,
+		ChangesetEventKindAzureDevOpsApprovedWithSuggestions:
 		return ChangesetReviewStateApproved, nil
 
 	// BitbucketServer's "REVIEWED" activity is created when someone clicks
 	// the "Needs work" button in the UI, which is why we map it to "Changes Requested"
 	case ChangesetEventKindBitbucketServerReviewed,
 		ChangesetEventKindBitbucketCloudChangesRequested,
-		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated:
+		ChangesetEventKindBitbucketCloudPullRequestChangesRequestCreated,
+		ChangesetEventKindAzureDevOpsWaitingForAuthor,
+		ChangesetEventKindAzureDevOpsRejected:
 		return ChangesetReviewStateChangesRequested, nil
 
 	case ChangesetEventKindGitHubReviewed:
@@ -316,6 +333,8 @@ func (e *ChangesetEvent) Timestamp() time.Time {
 		t = ev.CommitStatus.CreatedOn
 	case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
 		t = ev.CommitStatus.UpdatedOn
+	case *azuredevops.PullRequestBuildStatus:
+		t = ev.UpdatedDate
 	}
 
 	return t
@@ -854,6 +873,14 @@ func (e *ChangesetEvent) Update(o *ChangesetEvent) error {
 		o := o.Metadata.(*bitbucketcloud.RepoCommitStatusUpdatedEvent)
 		*e = *o
 
+	case *azuredevops.Reviewer:
+		o := o.Metadata.(*azuredevops.Reviewer)
+		*e = *o
+
+	case *azuredevops.PullRequestBuildStatus:
+		o := o.Metadata.(*azuredevops.PullRequestBuildStatus)
+		*e = *o
+
 	default:
 		return errors.Errorf("unknown changeset event metadata %T", e)
 	}
@@ -47,6 +47,7 @@ var (
 	ChangesetJobTypeMerge     ChangesetJobType = "merge"
 	ChangesetJobTypeClose     ChangesetJobType = "close"
 	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeAutoMerge ChangesetJobType = "auto_merge"
 )
 
 type ChangesetJobCommentPayload struct {
@@ -61,6 +62,10 @@ type ChangesetJobMergePayload struct {
 	Squash bool `json:"squash,omitempty"`
 }
 
+type ChangesetJobAutoMergePayload struct {
+	Squash bool `json:"squash,omitempty"`
+}
+
 type ChangesetJobClosePayload struct{}
 
 type ChangesetJobPublishPayload struct {
@@ -35,6 +35,7 @@ func NewChangesetSpecFromSpec(spec *batcheslib.ChangesetSpec) (*ChangesetSpec, e
 		Title:      spec.Title,
 		Body:       spec.Body,
 		Published:  spec.Published,
+		DependsOn:  spec.DependsOn,
 	}
 
 	if spec.IsImportingExisting() {
@@ -113,6 +114,11 @@ type ChangesetSpec struct {
 	CommitAuthorName  string
 	CommitAuthorEmail string
 
+	// DependsOn are glob patterns of the repositories whose changesets in the
+	// same batch change have to be merged before the changeset is published
+	// or undrafted.
+	DependsOn []string
+
 	ForkNamespace *string
 }
 
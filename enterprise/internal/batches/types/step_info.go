@@ -44,6 +44,13 @@ type StepInfo struct {
 	OutputVariables map[string]any
 	Diff            *string
 	ExitCode        *int
+
+	// CacheHit is true if the step didn't run because its result was found in
+	// the cache.
+	CacheHit bool
+	// CacheKey is the key the result of the step was cached under after it
+	// ran. It is empty if the step didn't run.
+	CacheKey string
 }
 
 // ParseLogLines looks at all given log lines and determines the derived *StepInfo
@@ -72,8 +79,13 @@ func ParseLogLines(entry workerutil.ExecutionLogEntry, lines []*batcheslib.LogEv
 			for i := 1; i < m.StartStep; i++ {
 				setSafe(i, func(si *StepInfo) {
 					si.Skipped = true
+					si.CacheHit = true
 				})
 			}
+		case *batcheslib.CacheAfterStepResultMetadata:
+			setSafe(m.Value.StepIndex+1, func(si *StepInfo) {
+				si.CacheKey = m.Key
+			})
 		case *batcheslib.TaskStepSkippedMetadata:
 			setSafe(m.Step, func(si *StepInfo) {
 				si.Skipped = true
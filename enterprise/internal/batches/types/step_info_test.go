@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 )
 
 func TestParseJSONLogsFromOutput(t *testing.T) {
@@ -164,8 +165,31 @@ func TestParseLogLines(t *testing.T) {
 				}},
 			},
 			want: map[int]*StepInfo{
-				1: {Skipped: true},
-				2: {Skipped: true},
+				1: {Skipped: true, CacheHit: true},
+				2: {Skipped: true, CacheHit: true},
+			},
+		},
+		{
+			name: "Cached after step result",
+			lines: []*batcheslib.LogEvent{
+				{
+					Timestamp: time1,
+					Status:    batcheslib.LogEventStatusStarted,
+					Metadata: &batcheslib.TaskPreparingStepMetadata{
+						Step: 1,
+					},
+				},
+				{
+					Timestamp: time2,
+					Status:    batcheslib.LogEventStatusSuccess,
+					Metadata: &batcheslib.CacheAfterStepResultMetadata{
+						Key:   "cache-key-step-0",
+						Value: execution.AfterStepResult{StepIndex: 0},
+					},
+				},
+			},
+			want: map[int]*StepInfo{
+				1: {StartedAt: time1, CacheKey: "cache-key-step-0"},
 			},
 		},
 		{
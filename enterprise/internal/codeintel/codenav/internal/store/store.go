@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
@@ -11,12 +12,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
 // Store provides the interface for codenav storage.
 type Store interface {
 	GetUnsafeDB() database.DB
 	GetUploadsForRanking(ctx context.Context, graphKey, objectPrefix string, batchSize int) ([]ExportedUpload, error)
+	GetUploadsReferencingMoniker(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) ([]ReferencingUpload, error)
 
 	ProcessStaleExportedUploads(
 		ctx context.Context,
@@ -120,6 +123,76 @@ WHERE c.id IN (SELECT id FROM inserted)
 ORDER BY c.id
 `
 
+type ReferencingUpload struct {
+	ID             int
+	RepositoryID   int
+	RepositoryName string
+}
+
+var scanReferencingUploads = basestore.NewSliceScanner(func(s dbutil.Scanner) (u ReferencingUpload, _ error) {
+	err := s.Scan(&u.ID, &u.RepositoryID, &u.RepositoryName)
+	return u, err
+})
+
+// GetUploadsReferencingMoniker returns the uploads with a reference to the package of the given moniker. If asOf
+// is nil, the uploads visible at the tip of the default branch of each repository are returned. Otherwise, the
+// most recent completed upload of each root and indexer for a commit made at or before asOf is returned. An empty
+// package version matches every version of the package.
+func (s *store) GetUploadsReferencingMoniker(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) (_ []ReferencingUpload, err error) {
+	candidates := sqlf.Sprintf(visibleAtTipUploadsQuery)
+	if asOf != nil {
+		candidates = sqlf.Sprintf(mostRecentUploadsQuery, *asOf)
+	}
+
+	versionCond := sqlf.Sprintf("TRUE")
+	if moniker.Version != "" {
+		versionCond = sqlf.Sprintf("ref.version = %s", moniker.Version)
+	}
+
+	return scanReferencingUploads(s.db.Query(ctx, sqlf.Sprintf(
+		getUploadsReferencingMonikerQuery,
+		candidates,
+		moniker.Scheme,
+		moniker.Name,
+		versionCond,
+	)))
+}
+
+const visibleAtTipUploadsQuery = `
+SELECT uvt.upload_id AS id, uvt.repository_id
+FROM lsif_uploads_visible_at_tip uvt
+WHERE uvt.is_default_branch
+`
+
+const mostRecentUploadsQuery = `
+SELECT DISTINCT ON (u.repository_id, u.root, u.indexer) u.id, u.repository_id
+FROM lsif_uploads u
+WHERE u.state = 'completed' AND u.committed_at <= %s
+ORDER BY u.repository_id, u.root, u.indexer, u.committed_at DESC, u.id DESC
+`
+
+const getUploadsReferencingMonikerQuery = `
+SELECT
+	c.id,
+	c.repository_id,
+	r.name
+FROM (%s) c
+JOIN repo r ON r.id = c.repository_id
+WHERE
+	EXISTS (
+		SELECT 1
+		FROM lsif_references ref
+		WHERE
+			ref.dump_id = c.id AND
+			ref.scheme = %s AND
+			ref.name = %s AND
+			%s
+	) AND
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL
+ORDER BY c.repository_id, c.id
+`
+
 func (s *store) ProcessStaleExportedUploads(
 	ctx context.Context,
 	graphKey string,
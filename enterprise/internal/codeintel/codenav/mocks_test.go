@@ -9,6 +9,7 @@ package codenav
 import (
 	"context"
 	"sync"
+	"time"
 
 	diff "github.com/sourcegraph/go-diff/diff"
 	lsifstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/internal/lsifstore"
@@ -33,6 +34,10 @@ type MockStore struct {
 	// GetUploadsForRankingFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsForRanking.
 	GetUploadsForRankingFunc *StoreGetUploadsForRankingFunc
+	// GetUploadsReferencingMonikerFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// GetUploadsReferencingMoniker.
+	GetUploadsReferencingMonikerFunc *StoreGetUploadsReferencingMonikerFunc
 	// ProcessStaleExportedUploadsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// ProcessStaleExportedUploads.
@@ -53,6 +58,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetUploadsReferencingMonikerFunc: &StoreGetUploadsReferencingMonikerFunc{
+			defaultHook: func(context.Context, precise.QualifiedMonikerData, *time.Time) (r0 []store.ReferencingUpload, r1 error) {
+				return
+			},
+		},
 		ProcessStaleExportedUploadsFunc: &StoreProcessStaleExportedUploadsFunc{
 			defaultHook: func(context.Context, string, int, func(ctx context.Context, objectPrefix string) error) (r0 int, r1 error) {
 				return
@@ -75,6 +85,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadsForRanking")
 			},
 		},
+		GetUploadsReferencingMonikerFunc: &StoreGetUploadsReferencingMonikerFunc{
+			defaultHook: func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error) {
+				panic("unexpected invocation of MockStore.GetUploadsReferencingMoniker")
+			},
+		},
 		ProcessStaleExportedUploadsFunc: &StoreProcessStaleExportedUploadsFunc{
 			defaultHook: func(context.Context, string, int, func(ctx context.Context, objectPrefix string) error) (int, error) {
 				panic("unexpected invocation of MockStore.ProcessStaleExportedUploads")
@@ -93,6 +108,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsForRankingFunc: &StoreGetUploadsForRankingFunc{
 			defaultHook: i.GetUploadsForRanking,
 		},
+		GetUploadsReferencingMonikerFunc: &StoreGetUploadsReferencingMonikerFunc{
+			defaultHook: i.GetUploadsReferencingMoniker,
+		},
 		ProcessStaleExportedUploadsFunc: &StoreProcessStaleExportedUploadsFunc{
 			defaultHook: i.ProcessStaleExportedUploads,
 		},
@@ -311,6 +329,117 @@ func (c StoreGetUploadsForRankingFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetUploadsReferencingMonikerFunc describes the behavior when the
+// GetUploadsReferencingMoniker method of the parent MockStore instance is
+// invoked.
+type StoreGetUploadsReferencingMonikerFunc struct {
+	defaultHook func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error)
+	hooks       []func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error)
+	history     []StoreGetUploadsReferencingMonikerFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsReferencingMoniker delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockStore) GetUploadsReferencingMoniker(v0 context.Context, v1 precise.QualifiedMonikerData, v2 *time.Time) ([]store.ReferencingUpload, error) {
+	r0, r1 := m.GetUploadsReferencingMonikerFunc.nextHook()(v0, v1, v2)
+	m.GetUploadsReferencingMonikerFunc.appendCall(StoreGetUploadsReferencingMonikerFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadsReferencingMoniker method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreGetUploadsReferencingMonikerFunc) SetDefaultHook(hook func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsReferencingMoniker method of the parent MockStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *StoreGetUploadsReferencingMonikerFunc) PushHook(hook func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetUploadsReferencingMonikerFunc) SetDefaultReturn(r0 []store.ReferencingUpload, r1 error) {
+	f.SetDefaultHook(func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetUploadsReferencingMonikerFunc) PushReturn(r0 []store.ReferencingUpload, r1 error) {
+	f.PushHook(func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetUploadsReferencingMonikerFunc) nextHook() func(context.Context, precise.QualifiedMonikerData, *time.Time) ([]store.ReferencingUpload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetUploadsReferencingMonikerFunc) appendCall(r0 StoreGetUploadsReferencingMonikerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetUploadsReferencingMonikerFuncCall
+// objects describing the invocations of this function.
+func (f *StoreGetUploadsReferencingMonikerFunc) History() []StoreGetUploadsReferencingMonikerFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetUploadsReferencingMonikerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetUploadsReferencingMonikerFuncCall is an object that describes an
+// invocation of method GetUploadsReferencingMoniker on an instance of
+// MockStore.
+type StoreGetUploadsReferencingMonikerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 precise.QualifiedMonikerData
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 *time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []store.ReferencingUpload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetUploadsReferencingMonikerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetUploadsReferencingMonikerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreProcessStaleExportedUploadsFunc describes the behavior when the
 // ProcessStaleExportedUploads method of the parent MockStore instance is
 // invoked.
@@ -23,6 +23,8 @@ type operations struct {
 	getDumpsByIDs          *observation.Operation
 	getClosestDumpsForBlob *observation.Operation
 
+	getReferenceCountsForMoniker *observation.Operation
+
 	numUploadsRead         prometheus.Counter
 	numBytesUploaded       prometheus.Counter
 	numStaleRecordsDeleted prometheus.Counter
@@ -83,6 +85,8 @@ func newOperations(observationContext *observation.Context) *operations {
 		getDumpsByIDs:          op("GetDumpsByIDs"),
 		getClosestDumpsForBlob: op("GetClosestDumpsForBlob"),
 
+		getReferenceCountsForMoniker: op("GetReferenceCountsForMoniker"),
+
 		numUploadsRead:         numUploadsRead,
 		numBytesUploaded:       numBytesUploaded,
 		numStaleRecordsDeleted: numStaleRecordsDeleted,
@@ -3,6 +3,7 @@ package codenav
 import (
 	"context"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
 	traceLog "github.com/opentracing/opentracing-go/log"
@@ -1144,6 +1145,53 @@ func (s *Service) GetDumpsByIDs(ctx context.Context, ids []int) (_ []types.Dump,
 	return s.uploadSvc.GetDumpsByIDs(ctx, ids)
 }
 
+// GetReferenceCountsForMoniker returns the number of references to the given moniker in each repository. If asOf
+// is nil, the uploads visible at the tip of the default branch are counted. Otherwise, the most recent uploads for
+// commits made at or before asOf are counted, as long as they haven't expired. Repositories without any reference
+// are omitted.
+func (s *Service) GetReferenceCountsForMoniker(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) (_ []shared.RepositoryReferenceCount, err error) {
+	ctx, trace, endObservation := s.operations.getReferenceCountsForMoniker.With(ctx, &err, observation.Args{
+		LogFields: []traceLog.Field{
+			traceLog.String("scheme", moniker.Scheme),
+			traceLog.String("identifier", moniker.Identifier),
+			traceLog.String("packageName", moniker.Name),
+			traceLog.String("packageVersion", moniker.Version),
+		},
+	})
+	defer endObservation(1, observation.Args{})
+
+	uploads, err := s.store.GetUploadsReferencingMoniker(ctx, moniker, asOf)
+	if err != nil {
+		return nil, errors.Wrap(err, "store.GetUploadsReferencingMoniker")
+	}
+	trace.Log(traceLog.Int("numUploads", len(uploads)))
+
+	// Uploads are ordered by repository, so the uploads of each repository are adjacent.
+	var counts []shared.RepositoryReferenceCount
+	for i := 0; i < len(uploads); {
+		j := i
+		ids := []int{}
+		for ; j < len(uploads) && uploads[j].RepositoryID == uploads[i].RepositoryID; j++ {
+			ids = append(ids, uploads[j].ID)
+		}
+
+		_, count, err := s.lsifstore.GetBulkMonikerLocations(ctx, "references", ids, []precise.MonikerData{moniker.MonikerData}, 0, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "lsifStore.GetBulkMonikerLocations")
+		}
+		if count > 0 {
+			counts = append(counts, shared.RepositoryReferenceCount{
+				RepositoryID:   uploads[i].RepositoryID,
+				RepositoryName: uploads[i].RepositoryName,
+				Count:          count,
+			})
+		}
+		i = j
+	}
+
+	return counts, nil
+}
+
 func (s *Service) GetClosestDumpsForBlob(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) (_ []types.Dump, err error) {
 	ctx, trace, endObservation := s.operations.getClosestDumpsForBlob.With(ctx, &err, observation.Args{
 		LogFields: []traceLog.Field{
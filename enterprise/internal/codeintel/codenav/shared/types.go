@@ -12,6 +12,13 @@ type Location struct {
 	Range  types.Range
 }
 
+// RepositoryReferenceCount is the number of references to a moniker in the uploads of a repository.
+type RepositoryReferenceCount struct {
+	RepositoryID   int
+	RepositoryName string
+	Count          int
+}
+
 type RequestArgs struct {
 	RepositoryID int
 	Commit       string
@@ -8,10 +8,11 @@ import (
 )
 
 type operations struct {
-	getRepoRank       *observation.Operation
-	getDocumentRanks  *observation.Operation
-	indexRepositories *observation.Operation
-	indexRepository   *observation.Operation
+	getRepoRank                *observation.Operation
+	getDocumentRanks           *observation.Operation
+	getDocumentReferenceCounts *observation.Operation
+	indexRepositories          *observation.Operation
+	indexRepository            *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -31,9 +32,10 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		getRepoRank:       op("GetRepoRank"),
-		getDocumentRanks:  op("GetDocumentRanks"),
-		indexRepositories: op("IndexRepositories"),
-		indexRepository:   op("indexRepository"),
+		getRepoRank:                op("GetRepoRank"),
+		getDocumentRanks:           op("GetDocumentRanks"),
+		getDocumentReferenceCounts: op("GetDocumentReferenceCounts"),
+		indexRepositories:          op("IndexRepositories"),
+		indexRepository:            op("indexRepository"),
 	}
 }
@@ -153,6 +153,27 @@ func (s *Service) GetDocumentRanks(ctx context.Context, repoName api.RepoName) (
 	return ranks, nil
 }
 
+// GetDocumentReferenceCounts returns a map from paths within the given repo to the number of
+// precise references to that document. Unlike GetDocumentRanks, paths without precise data are
+// omitted and the repository's file tree is not consulted, which makes this method cheap enough
+// to call while serving search requests.
+func (s *Service) GetDocumentReferenceCounts(ctx context.Context, repoName api.RepoName) (_ map[string]float64, err error) {
+	_, _, endObservation := s.operations.getDocumentReferenceCounts.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	documentRanks, ok, err := s.store.GetDocumentRanks(ctx, repoName)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	counts := make(map[string]float64, len(documentRanks))
+	for path, rank := range documentRanks {
+		counts[path] = rank[1]
+	}
+
+	return counts, nil
+}
+
 func (s *Service) LastUpdatedAt(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]time.Time, error) {
 	return s.store.LastUpdatedAt(ctx, repoIDs)
 }
@@ -25,8 +25,17 @@ type Upload struct {
 	UncompressedSize  *int64
 	Rank              *int
 	AssociatedIndexID *int
+	ContentType       string
 }
 
+const (
+	// LSIFContentType is the content type of newline-delimited LSIF JSON uploads.
+	LSIFContentType = "application/x-ndjson+lsif"
+
+	// SCIPContentType is the content type of SCIP protobuf uploads.
+	SCIPContentType = "application/x-protobuf+scip"
+)
+
 func (u Upload) RecordID() int {
 	return u.ID
 }
@@ -0,0 +1,486 @@
+// Package scipconversion translates SCIP indexes into the LSIF element stream understood by
+// the conversion package so that SCIP uploads are stored in the same precise bundle format
+// as LSIF uploads, and can be queried by code navigation without changes.
+package scipconversion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol/reader"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Correlate reads a SCIP index from the given reader and returns a correlation state object with
+// the same data canonicalized and pruned for storage. See conversion.Correlate.
+func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var index scip.Index
+	if err := proto.Unmarshal(content, &index); err != nil {
+		return nil, errors.Wrap(err, "proto.Unmarshal")
+	}
+
+	return conversion.CorrelateElements(ctx, Convert(ctx, &index, root), root, getChildren)
+}
+
+// lsifVersion is the version reported in the synthetic metaData vertex.
+const lsifVersion = "0.4.3"
+
+// hoverSeparator separates the documentation sections of a SCIP symbol in the hover text.
+const hoverSeparator = "\n\n---\n\n"
+
+// Convert returns a channel of LSIF elements equivalent to the given SCIP index. Each symbol
+// is translated into a result set with definition, reference, implementation, and hover results.
+// Non-local symbols additionally receive a moniker so that they can be resolved across indexes.
+// Document paths are interpreted relative to the given upload root.
+func Convert(ctx context.Context, index *scip.Index, root string) <-chan conversion.Pair {
+	elements := make(chan conversion.Pair)
+
+	go func() {
+		defer close(elements)
+
+		c := newConverter(ctx, index, elements)
+		if err := c.convert(root); err != nil {
+			c.send(conversion.Pair{Err: err})
+		}
+	}()
+
+	return elements
+}
+
+type converter struct {
+	ctx      context.Context
+	index    *scip.Index
+	elements chan<- conversion.Pair
+	id       int
+
+	// symbolInformation holds the documentation and relationships of each symbol.
+	symbolInformation map[string]*scip.SymbolInformation
+
+	// definedSymbols holds the non-local symbols defined by this index.
+	definedSymbols map[string]struct{}
+
+	// implementations maps a symbol to the symbols that implement it.
+	implementations map[string][]string
+
+	// symbols holds the result set data of each symbol. Local symbols are keyed by their
+	// document as well. The order of keys is retained in symbolKeys so that the output is
+	// deterministic.
+	symbols    map[string]*symbolData
+	symbolKeys []string
+
+	// packages maps package information to the identifier of its vertex.
+	packages map[reader.PackageInformation]int
+}
+
+type symbolData struct {
+	symbol                 string
+	resultSetID            int
+	definitionResultID     int
+	referenceResultID      int
+	implementationResultID int
+
+	// definitions and references map document identifiers to the ranges of the
+	// symbol's occurrences in that document. The order in which documents were
+	// first seen is retained in definitionDocuments and referenceDocuments.
+	definitions         map[int][]int
+	references          map[int][]int
+	definitionDocuments []int
+	referenceDocuments  []int
+}
+
+func newConverter(ctx context.Context, index *scip.Index, elements chan<- conversion.Pair) *converter {
+	return &converter{
+		ctx:               ctx,
+		index:             index,
+		elements:          elements,
+		symbolInformation: map[string]*scip.SymbolInformation{},
+		definedSymbols:    map[string]struct{}{},
+		implementations:   map[string][]string{},
+		symbols:           map[string]*symbolData{},
+		packages:          map[reader.PackageInformation]int{},
+	}
+}
+
+func (c *converter) convert(root string) error {
+	c.indexSymbols()
+
+	projectRoot := "file:///" + root
+	if !c.emitVertex("metaData", conversion.MetaData{Version: lsifVersion, ProjectRoot: projectRoot}) {
+		return nil
+	}
+
+	for _, document := range c.index.Documents {
+		if err := c.convertDocument(projectRoot, document); err != nil {
+			return err
+		}
+		if c.ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	c.emitResults()
+	return nil
+}
+
+// indexSymbols collects symbol information, defined symbols, and implementation relationships
+// from the entire index before any elements are emitted.
+func (c *converter) indexSymbols() {
+	addSymbols := func(symbols []*scip.SymbolInformation) {
+		for _, info := range symbols {
+			c.symbolInformation[info.Symbol] = info
+
+			for _, relationship := range info.Relationships {
+				if relationship.IsImplementation {
+					c.implementations[relationship.Symbol] = append(c.implementations[relationship.Symbol], info.Symbol)
+				}
+			}
+		}
+	}
+
+	for _, document := range c.index.Documents {
+		addSymbols(document.Symbols)
+
+		for _, occurrence := range document.Occurrences {
+			if isDefinition(occurrence) && !isLocal(occurrence.Symbol) {
+				c.definedSymbols[occurrence.Symbol] = struct{}{}
+			}
+		}
+	}
+	addSymbols(c.index.ExternalSymbols)
+}
+
+func (c *converter) convertDocument(projectRoot string, document *scip.Document) error {
+	documentID, ok := c.emitVertexID("document", projectRoot+document.RelativePath)
+	if !ok {
+		return nil
+	}
+
+	var rangeIDs []int
+	var diagnostics []conversion.Diagnostic
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" && len(occurrence.Diagnostics) == 0 {
+			// Syntax highlighting information only
+			continue
+		}
+
+		rangeData, err := convertRange(occurrence.Range)
+		if err != nil {
+			return errors.Wrapf(err, "document %q", document.RelativePath)
+		}
+
+		for _, diagnostic := range occurrence.Diagnostics {
+			diagnostics = append(diagnostics, conversion.Diagnostic{
+				Severity:       int(diagnostic.Severity),
+				Code:           diagnostic.Code,
+				Message:        diagnostic.Message,
+				Source:         diagnostic.Source,
+				StartLine:      rangeData.Start.Line,
+				StartCharacter: rangeData.Start.Character,
+				EndLine:        rangeData.End.Line,
+				EndCharacter:   rangeData.End.Character,
+			})
+		}
+
+		if occurrence.Symbol == "" {
+			continue
+		}
+
+		rangeID, ok := c.emitVertexID("range", conversion.Range{Range: reader.Range{RangeData: rangeData}})
+		if !ok {
+			return nil
+		}
+		rangeIDs = append(rangeIDs, rangeID)
+
+		data, ok := c.symbolData(documentID, occurrence.Symbol)
+		if !ok {
+			return nil
+		}
+		if !c.emitEdge("next", conversion.Edge{OutV: rangeID, InV: data.resultSetID}) {
+			return nil
+		}
+
+		if len(occurrence.OverrideDocumentation) > 0 {
+			hoverResultID, ok := c.emitVertexID("hoverResult", strings.Join(occurrence.OverrideDocumentation, hoverSeparator))
+			if !ok {
+				return nil
+			}
+			if !c.emitEdge("textDocument/hover", conversion.Edge{OutV: rangeID, InV: hoverResultID}) {
+				return nil
+			}
+		}
+
+		if _, ok := data.references[documentID]; !ok {
+			data.referenceDocuments = append(data.referenceDocuments, documentID)
+		}
+		// Definitions are also listed as references, as is conventional for LSIF indexers
+		data.references[documentID] = append(data.references[documentID], rangeID)
+
+		if isDefinition(occurrence) {
+			if _, ok := data.definitions[documentID]; !ok {
+				data.definitionDocuments = append(data.definitionDocuments, documentID)
+			}
+			data.definitions[documentID] = append(data.definitions[documentID], rangeID)
+		}
+	}
+
+	if len(rangeIDs) > 0 {
+		if !c.emitEdge("contains", conversion.Edge{OutV: documentID, InVs: rangeIDs}) {
+			return nil
+		}
+	}
+
+	if len(diagnostics) > 0 {
+		diagnosticResultID, ok := c.emitVertexID("diagnosticResult", diagnostics)
+		if !ok {
+			return nil
+		}
+		if !c.emitEdge("textDocument/diagnostic", conversion.Edge{OutV: documentID, InV: diagnosticResultID}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// symbolData returns the result set data for the given symbol occurring in the given document.
+// The result set and its attached results are emitted the first time a symbol is seen.
+func (c *converter) symbolData(documentID int, symbol string) (*symbolData, bool) {
+	key := symbol
+	if isLocal(symbol) {
+		// Local symbols are only unique within a document
+		key = fmt.Sprintf("%d:%s", documentID, symbol)
+	}
+	if data, ok := c.symbols[key]; ok {
+		return data, true
+	}
+
+	data := &symbolData{
+		symbol:      symbol,
+		definitions: map[int][]int{},
+		references:  map[int][]int{},
+	}
+
+	var ok bool
+	if data.resultSetID, ok = c.emitVertexID("resultSet", conversion.ResultSet{}); !ok {
+		return nil, false
+	}
+	if data.definitionResultID, ok = c.emitVertexID("definitionResult", nil); !ok {
+		return nil, false
+	}
+	if !c.emitEdge("textDocument/definition", conversion.Edge{OutV: data.resultSetID, InV: data.definitionResultID}) {
+		return nil, false
+	}
+	if data.referenceResultID, ok = c.emitVertexID("referenceResult", nil); !ok {
+		return nil, false
+	}
+	if !c.emitEdge("textDocument/references", conversion.Edge{OutV: data.resultSetID, InV: data.referenceResultID}) {
+		return nil, false
+	}
+
+	if len(c.implementations[symbol]) > 0 {
+		if data.implementationResultID, ok = c.emitVertexID("implementationResult", nil); !ok {
+			return nil, false
+		}
+		if !c.emitEdge("textDocument/implementation", conversion.Edge{OutV: data.resultSetID, InV: data.implementationResultID}) {
+			return nil, false
+		}
+	}
+
+	if info, exists := c.symbolInformation[symbol]; exists && len(info.Documentation) > 0 {
+		hoverResultID, ok := c.emitVertexID("hoverResult", strings.Join(info.Documentation, hoverSeparator))
+		if !ok {
+			return nil, false
+		}
+		if !c.emitEdge("textDocument/hover", conversion.Edge{OutV: data.resultSetID, InV: hoverResultID}) {
+			return nil, false
+		}
+	}
+
+	if !isLocal(symbol) {
+		if !c.emitMoniker(data.resultSetID, symbol) {
+			return nil, false
+		}
+	}
+
+	c.symbols[key] = data
+	c.symbolKeys = append(c.symbolKeys, key)
+	return data, true
+}
+
+// emitMoniker attaches a moniker identifying the given symbol to the given result set. Symbols
+// defined in this index are exported; all other symbols are imported.
+func (c *converter) emitMoniker(resultSetID int, symbol string) bool {
+	scheme, packageInformation, ok := parsePackage(symbol)
+	if !ok {
+		// Malformed symbol; the result set is still usable within this index
+		return true
+	}
+
+	kind := "import"
+	if _, ok := c.definedSymbols[symbol]; ok {
+		kind = "export"
+	}
+
+	monikerID, ok := c.emitVertexID("moniker", conversion.Moniker{Moniker: reader.Moniker{
+		Kind:       kind,
+		Scheme:     scheme,
+		Identifier: symbol,
+	}})
+	if !ok {
+		return false
+	}
+	if !c.emitEdge("moniker", conversion.Edge{OutV: resultSetID, InV: monikerID}) {
+		return false
+	}
+
+	if packageInformation.Name == "" {
+		return true
+	}
+
+	packageInformationID, exists := c.packages[packageInformation]
+	if !exists {
+		if packageInformationID, ok = c.emitVertexID("packageInformation", conversion.PackageInformation(packageInformation)); !ok {
+			return false
+		}
+		c.packages[packageInformation] = packageInformationID
+	}
+
+	return c.emitEdge("packageInformation", conversion.Edge{OutV: monikerID, InV: packageInformationID})
+}
+
+// emitResults links the definition, reference, and implementation results of every symbol
+// to the ranges collected while converting documents.
+func (c *converter) emitResults() {
+	for _, key := range c.symbolKeys {
+		data := c.symbols[key]
+
+		for _, documentID := range data.definitionDocuments {
+			if !c.emitEdge("item", conversion.Edge{OutV: data.definitionResultID, InVs: data.definitions[documentID], Document: documentID}) {
+				return
+			}
+		}
+		for _, documentID := range data.referenceDocuments {
+			if !c.emitEdge("item", conversion.Edge{OutV: data.referenceResultID, InVs: data.references[documentID], Document: documentID}) {
+				return
+			}
+		}
+
+		if data.implementationResultID == 0 {
+			continue
+		}
+		for _, implementation := range c.implementations[data.symbol] {
+			implementationData, ok := c.symbols[implementation]
+			if !ok {
+				continue
+			}
+
+			for _, documentID := range implementationData.definitionDocuments {
+				if !c.emitEdge("item", conversion.Edge{OutV: data.implementationResultID, InVs: implementationData.definitions[documentID], Document: documentID}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *converter) emitVertex(label string, payload any) bool {
+	_, ok := c.emitVertexID(label, payload)
+	return ok
+}
+
+func (c *converter) emitVertexID(label string, payload any) (int, bool) {
+	c.id++
+	return c.id, c.send(conversion.Pair{Element: conversion.Element{ID: c.id, Type: "vertex", Label: label, Payload: payload}})
+}
+
+func (c *converter) emitEdge(label string, edge conversion.Edge) bool {
+	c.id++
+	return c.send(conversion.Pair{Element: conversion.Element{ID: c.id, Type: "edge", Label: label, Payload: edge}})
+}
+
+// send writes the given pair to the output channel. This method returns false if the context
+// has been canceled before the pair could be consumed.
+func (c *converter) send(pair conversion.Pair) bool {
+	select {
+	case c.elements <- pair:
+		return true
+	case <-c.ctx.Done():
+		return false
+	}
+}
+
+// convertRange converts a SCIP range, which is either [startLine, startCharacter, endCharacter]
+// or [startLine, startCharacter, endLine, endCharacter], into an LSIF range.
+func convertRange(r []int32) (protocol.RangeData, error) {
+	switch len(r) {
+	case 3:
+		return protocol.RangeData{
+			Start: protocol.Pos{Line: int(r[0]), Character: int(r[1])},
+			End:   protocol.Pos{Line: int(r[0]), Character: int(r[2])},
+		}, nil
+	case 4:
+		return protocol.RangeData{
+			Start: protocol.Pos{Line: int(r[0]), Character: int(r[1])},
+			End:   protocol.Pos{Line: int(r[2]), Character: int(r[3])},
+		}, nil
+	}
+
+	return protocol.RangeData{}, errors.Newf("malformed range %v", r)
+}
+
+func isDefinition(occurrence *scip.Occurrence) bool {
+	return occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0
+}
+
+func isLocal(symbol string) bool {
+	return strings.HasPrefix(symbol, "local ")
+}
+
+// parsePackage extracts the scheme and package of the given non-local symbol. A symbol has the
+// form `<scheme> <manager> <name> <version> <descriptors>`, where spaces within the leading
+// fields are escaped by doubling them and empty fields are written as a single dot.
+func parsePackage(symbol string) (scheme string, _ reader.PackageInformation, _ bool) {
+	fields := make([]string, 0, 4)
+	var field strings.Builder
+	for i := 0; i < len(symbol) && len(fields) < 4; i++ {
+		if symbol[i] != ' ' {
+			field.WriteByte(symbol[i])
+			continue
+		}
+		if i+1 < len(symbol) && symbol[i+1] == ' ' {
+			field.WriteByte(' ')
+			i++
+			continue
+		}
+
+		value := field.String()
+		if value == "." {
+			value = ""
+		}
+		fields = append(fields, value)
+		field.Reset()
+	}
+	if len(fields) < 4 {
+		return "", reader.PackageInformation{}, false
+	}
+
+	return fields[0], reader.PackageInformation{
+		Manager: fields[1],
+		Name:    fields[2],
+		Version: fields[3],
+	}, true
+}
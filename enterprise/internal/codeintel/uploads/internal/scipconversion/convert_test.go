@@ -0,0 +1,210 @@
+package scipconversion
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+const (
+	fooSymbol   = "scip-go gomod github.com/test/test v1.0.0 `github.com/test/test`/Foo()."
+	implSymbol  = "scip-go gomod github.com/test/test v1.0.0 `github.com/test/test`/impl#Foo()."
+	printSymbol = "scip-go gomod github.com/golang/go go1.19 fmt/Println()."
+)
+
+var testIndex = &scip.Index{
+	Metadata: &scip.Metadata{ProjectRoot: "file:///test/root"},
+	Documents: []*scip.Document{
+		{
+			RelativePath: "foo.go",
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{2, 5, 8}, Symbol: fooSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{3, 1, 3, 8}, Symbol: printSymbol},
+				{Range: []int32{4, 1, 2}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{5, 1, 2}, Symbol: "local 0"},
+				{Range: []int32{6, 1, 4}, SyntaxKind: scip.SyntaxKind_IdentifierKeyword},
+			},
+			Symbols: []*scip.SymbolInformation{
+				{Symbol: fooSymbol, Documentation: []string{"```go\nfunc Foo()\n```", "Foo does things."}},
+			},
+		},
+		{
+			RelativePath: "bar.go",
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{1, 2, 5}, Symbol: fooSymbol, OverrideDocumentation: []string{"overridden"}},
+				{Range: []int32{4, 1, 2}, Symbol: "local 0"},
+				{Range: []int32{7, 10, 13}, Symbol: implSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+			Symbols: []*scip.SymbolInformation{
+				{Symbol: implSymbol, Relationships: []*scip.Relationship{{Symbol: fooSymbol, IsImplementation: true}}},
+			},
+		},
+	},
+}
+
+func TestConvert(t *testing.T) {
+	ctx := context.Background()
+	chans, err := conversion.CorrelateElements(ctx, Convert(ctx, testIndex, "sub/"), "sub/", nil)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+	bundle := precise.GroupedBundleDataChansToMaps(chans)
+
+	var paths []string
+	for path := range bundle.Documents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if diff := cmp.Diff([]string{"bar.go", "foo.go"}, paths); diff != "" {
+		t.Fatalf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	fooDefinition := precise.LocationData{URI: "foo.go", StartLine: 2, StartCharacter: 5, EndLine: 2, EndCharacter: 8}
+	fooReference := precise.LocationData{URI: "bar.go", StartLine: 1, StartCharacter: 2, EndLine: 1, EndCharacter: 5}
+
+	t.Run("definition", func(t *testing.T) {
+		result := query(t, bundle, "foo.go", 2, 6)
+		if diff := cmp.Diff([]precise.LocationData{fooDefinition}, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]precise.LocationData{fooReference, fooDefinition}, sortLocations(result.References)); diff != "" {
+			t.Errorf("unexpected references (-want +got):\n%s", diff)
+		}
+		if want := "```go\nfunc Foo()\n```\n\n---\n\nFoo does things."; result.Hover != want {
+			t.Errorf("unexpected hover. want=%q have=%q", want, result.Hover)
+		}
+	})
+
+	t.Run("reference with override documentation", func(t *testing.T) {
+		result := query(t, bundle, "bar.go", 1, 3)
+		if diff := cmp.Diff([]precise.LocationData{fooDefinition}, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+		if result.Hover != "overridden" {
+			t.Errorf("unexpected hover. want=%q have=%q", "overridden", result.Hover)
+		}
+	})
+
+	t.Run("local symbols are scoped to their document", func(t *testing.T) {
+		result := query(t, bundle, "foo.go", 5, 1)
+		want := []precise.LocationData{{URI: "foo.go", StartLine: 4, StartCharacter: 1, EndLine: 4, EndCharacter: 2}}
+		if diff := cmp.Diff(want, result.Definitions); diff != "" {
+			t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+		}
+
+		if result := query(t, bundle, "bar.go", 4, 1); len(result.Definitions) != 0 {
+			t.Errorf("unexpected definitions: %v", result.Definitions)
+		}
+	})
+
+	t.Run("monikers", func(t *testing.T) {
+		result := query(t, bundle, "foo.go", 3, 2)
+		if len(result.Monikers) != 1 {
+			t.Fatalf("unexpected number of monikers. want=1 have=%d", len(result.Monikers))
+		}
+		moniker := result.Monikers[0]
+		if moniker.Kind != "import" || moniker.Scheme != "scip-go" || moniker.Identifier != printSymbol {
+			t.Errorf("unexpected moniker: %+v", moniker.MonikerData)
+		}
+		if moniker.Name != "github.com/golang/go" || moniker.Version != "go1.19" {
+			t.Errorf("unexpected package information: %+v", moniker.PackageInformationData)
+		}
+
+		wantPackages := []precise.Package{{Scheme: "scip-go", Name: "github.com/test/test", Version: "v1.0.0"}}
+		if diff := cmp.Diff(wantPackages, bundle.Packages); diff != "" {
+			t.Errorf("unexpected packages (-want +got):\n%s", diff)
+		}
+		wantReferences := []precise.PackageReference{{Package: precise.Package{Scheme: "scip-go", Name: "github.com/golang/go", Version: "go1.19"}}}
+		if diff := cmp.Diff(wantReferences, bundle.PackageReferences); diff != "" {
+			t.Errorf("unexpected package references (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("implementations", func(t *testing.T) {
+		document := bundle.Documents["foo.go"]
+		ranges := precise.FindRanges(document.Ranges, 2, 6)
+		if len(ranges) != 1 {
+			t.Fatalf("unexpected number of ranges. want=1 have=%d", len(ranges))
+		}
+		resultID := ranges[0].ImplementationResultID
+
+		chunk := bundle.ResultChunks[precise.HashKey(resultID, bundle.Meta.NumResultChunks)]
+		var locations []precise.LocationData
+		for _, documentIDRangeID := range chunk.DocumentIDRangeIDs[resultID] {
+			path := chunk.DocumentPaths[documentIDRangeID.DocumentID]
+			r := bundle.Documents[path].Ranges[documentIDRangeID.RangeID]
+			locations = append(locations, precise.LocationData{URI: path, StartLine: r.StartLine, StartCharacter: r.StartCharacter, EndLine: r.EndLine, EndCharacter: r.EndCharacter})
+		}
+
+		want := []precise.LocationData{{URI: "bar.go", StartLine: 7, StartCharacter: 10, EndLine: 7, EndCharacter: 13}}
+		if diff := cmp.Diff(want, locations); diff != "" {
+			t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestConvertMalformedRange(t *testing.T) {
+	index := &scip.Index{
+		Documents: []*scip.Document{
+			{
+				RelativePath: "foo.go",
+				Occurrences:  []*scip.Occurrence{{Range: []int32{1, 2}, Symbol: "local 0"}},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	if _, err := conversion.CorrelateElements(ctx, Convert(ctx, index, ""), "", nil); err == nil {
+		t.Fatal("expected error correlating index with malformed range")
+	}
+}
+
+func TestParsePackage(t *testing.T) {
+	tests := []struct {
+		symbol  string
+		scheme  string
+		manager string
+		name    string
+		version string
+		ok      bool
+	}{
+		{symbol: fooSymbol, scheme: "scip-go", manager: "gomod", name: "github.com/test/test", version: "v1.0.0", ok: true},
+		{symbol: "scip-java maven com.example  lib 1.0 Foo#", scheme: "scip-java", manager: "maven", name: "com.example lib", version: "1.0", ok: true},
+		{symbol: "scip-python . . . foo/", scheme: "scip-python", ok: true},
+		{symbol: "malformed", ok: false},
+	}
+
+	for _, tt := range tests {
+		scheme, pkg, ok := parsePackage(tt.symbol)
+		if ok != tt.ok || scheme != tt.scheme || pkg.Manager != tt.manager || pkg.Name != tt.name || pkg.Version != tt.version {
+			t.Errorf("unexpected result for %q: scheme=%q package=%+v ok=%v", tt.symbol, scheme, pkg, ok)
+		}
+	}
+}
+
+func query(t *testing.T, bundle *precise.GroupedBundleDataMaps, path string, line, character int) precise.QueryResult {
+	results, err := precise.Query(bundle, path, line, character)
+	if err != nil {
+		t.Fatalf("unexpected error querying bundle: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("unexpected number of results at %s:%d:%d. want=1 have=%d", path, line, character, len(results))
+	}
+	return results[0]
+}
+
+func sortLocations(locations []precise.LocationData) []precise.LocationData {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].URI != locations[j].URI {
+			return locations[i].URI < locations[j].URI
+		}
+		return locations[i].StartLine < locations[j].StartLine
+	})
+	return locations
+}
@@ -39,6 +39,7 @@ func scanCompleteUpload(s dbutil.Scanner) (upload types.Upload, _ error) {
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.ContentType,
 	); err != nil {
 		return upload, err
 	}
@@ -83,6 +84,7 @@ func scanUploadWithCount(s dbutil.Scanner) (upload types.Upload, count int, err
 		&upload.AssociatedIndexID,
 		&upload.Rank,
 		&upload.UncompressedSize,
+		&upload.ContentType,
 		&count,
 	); err != nil {
 		return upload, 0, err
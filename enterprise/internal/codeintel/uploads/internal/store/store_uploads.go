@@ -91,6 +91,7 @@ SELECT
 	u.associated_index_id,
 	s.rank,
 	u.uncompressed_size,
+	u.content_type,
 	COUNT(*) OVER() AS count
 FROM %s
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -130,7 +131,8 @@ SELECT
 	NULL::integer[] as uploaded_parts,
 	au.upload_size, au.associated_index_id,
 	COALESCE((snapshot->'expired')::boolean, false) AS expired,
-	NULL::bigint AS uncompressed_size
+	NULL::bigint AS uncompressed_size,
+	'application/x-ndjson+lsif' AS content_type
 FROM (
 	SELECT upload_id, snapshot_transition_columns(transition_columns ORDER BY sequence ASC) AS snapshot
 	FROM lsif_uploads_audit_logs
@@ -234,7 +236,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_type
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -290,7 +293,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_type
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -392,7 +396,8 @@ SELECT
 	u.upload_size,
 	u.associated_index_id,
 	s.rank,
-	u.uncompressed_size
+	u.uncompressed_size,
+	u.content_type
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
 ON u.id = s.id
@@ -1837,6 +1842,7 @@ func (s *store) InsertUpload(ctx context.Context, upload types.Upload) (id int,
 			upload.UploadSize,
 			upload.AssociatedIndexID,
 			upload.UncompressedSize,
+			upload.ContentType,
 		),
 	))
 
@@ -1855,8 +1861,9 @@ INSERT INTO lsif_uploads (
 	uploaded_parts,
 	upload_size,
 	associated_index_id,
-	uncompressed_size
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	uncompressed_size,
+	content_type
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -2034,7 +2041,8 @@ func buildGetConditionsAndCte(opts shared.GetUploadsOptions) (*sqlf.Query, []*sq
 				upload_size,
 				associated_index_id,
 				expired,
-				uncompressed_size
+				uncompressed_size,
+				content_type
 			FROM lsif_uploads
 			UNION ALL
 			SELECT *
@@ -13,6 +13,7 @@ import (
 
 	codeinteltypes "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/scipconversion"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -21,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -54,9 +56,9 @@ func (s *Service) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 	}
 
 	return false, withUploadData(ctx, logger, uploadStore, upload.ID, trace, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		groupedBundleData, err := correlate(ctx, upload, r, getChildren)
 		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+			return err
 		}
 
 		// Find the commit date for the commit attached to this upload record and insert it into the
@@ -140,6 +142,24 @@ func (s *Service) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 	})
 }
 
+// correlate converts the raw index data of the given upload into the format we send to the
+// writer. SCIP indexes are translated into the same precise bundle format as LSIF indexes.
+func correlate(ctx context.Context, upload codeinteltypes.Upload, r io.Reader, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	if upload.ContentType == codeinteltypes.SCIPContentType {
+		groupedBundleData, err := scipconversion.Correlate(ctx, r, upload.Root, getChildren)
+		if err != nil {
+			return nil, errors.Wrap(err, "scipconversion.Correlate")
+		}
+		return groupedBundleData, nil
+	}
+
+	groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+	if err != nil {
+		return nil, errors.Wrap(err, "conversion.Correlate")
+	}
+	return groupedBundleData, nil
+}
+
 func inTransaction(ctx context.Context, dbStore store.Store, fn func(tx store.Store) error) (err error) {
 	tx, err := dbStore.Transact(ctx)
 	if err != nil {
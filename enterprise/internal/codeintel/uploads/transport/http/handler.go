@@ -48,6 +48,7 @@ func newHandler(
 			Indexer:           getQuery(r, "indexerName"),
 			IndexerVersion:    getQuery(r, "indexerVersion"),
 			AssociatedIndexID: getQueryInt(r, "associatedIndexId"),
+			ContentType:       getContentType(r),
 		}, 0, nil
 	}
 
@@ -1,9 +1,12 @@
 package http
 
 import (
+	"mime"
 	"net/http"
 	"strconv"
 	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/types"
 )
 
 func getQuery(r *http.Request, name string) string {
@@ -24,3 +27,14 @@ func sanitizeRoot(s string) string {
 	}
 	return s
 }
+
+// getContentType returns the index format of the upload described by the request's
+// Content-Type header. Uploads without a recognized content type are assumed to be
+// LSIF, which is what older versions of src-cli send implicitly.
+func getContentType(r *http.Request) string {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == types.SCIPContentType {
+		return types.SCIPContentType
+	}
+	return types.LSIFContentType
+}
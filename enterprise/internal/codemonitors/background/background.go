@@ -5,8 +5,11 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	bstore "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
 func NewBackgroundJobs(logger log.Logger, db edb.EnterpriseDB) []goroutine.BackgroundRoutine {
@@ -17,6 +20,10 @@ func NewBackgroundJobs(logger log.Logger, db edb.EnterpriseDB) []goroutine.Backg
 	triggerMetrics := newMetricsForTriggerQueries(logger)
 	actionMetrics := newActionMetrics(logger)
 
+	// Issue actions authenticate with the batch changes credentials of the
+	// monitor owner or the site.
+	issueCredentials := bstore.New(db, observation.ContextWithLogger(logger), keyring.Default().BatchChangesCredentialKey)
+
 	// Create a new context. Each background routine will wrap this with
 	// a cancellable context that is canceled when Stop() is called.
 	ctx := context.Background()
@@ -25,7 +32,7 @@ func NewBackgroundJobs(logger log.Logger, db edb.EnterpriseDB) []goroutine.Backg
 		newTriggerJobsLogDeleter(ctx, codeMonitorsStore),
 		newTriggerQueryRunner(ctx, logger.Scoped("TriggerQueryRunner", ""), db, triggerMetrics),
 		newTriggerQueryResetter(ctx, logger.Scoped("TriggerQueryResetter", ""), codeMonitorsStore, triggerMetrics),
-		newActionRunner(ctx, logger.Scoped("ActionRunner", ""), codeMonitorsStore, actionMetrics),
+		newActionRunner(ctx, logger.Scoped("ActionRunner", ""), codeMonitorsStore, issueCredentials, actionMetrics),
 		newActionJobResetter(ctx, logger.Scoped("ActionJobResetter", ""), codeMonitorsStore, actionMetrics),
 	}
 }
@@ -0,0 +1,187 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/slack-go/slack"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+)
+
+// To avoid a circular dependency with the insights/resolvers package we have
+// to redeclare the insight view kind.
+const insightViewKind = "insight_view"
+const utmSourceInsightAlert = "code-insights-alert"
+
+// InsightAlert describes a code insights series whose latest recording
+// triggered an alert rule. Alerts are delivered through the same email, Slack
+// and webhook actions as code monitor notifications.
+type InsightAlert struct {
+	// InsightUniqueID and InsightTitle identify the insight view the series is
+	// shown on.
+	InsightUniqueID string
+	InsightTitle    string
+	SeriesLabel     string
+	Query           string
+
+	// Reason is a human readable description of the alert rule that was
+	// triggered, for example "rose above 100".
+	Reason        string
+	Value         float64
+	PreviousValue *float64
+	RecordingTime time.Time
+}
+
+var MockSendEmailForInsightAlert func(ctx context.Context, db database.DB, userID int32, alert InsightAlert) error
+
+// SendEmailForInsightAlert sends an email about the given alert to the
+// primary email address of the user.
+func SendEmailForInsightAlert(ctx context.Context, db database.DB, userID int32, alert InsightAlert) error {
+	if MockSendEmailForInsightAlert != nil {
+		return MockSendEmailForInsightAlert(ctx, db, userID, alert)
+	}
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	return sendEmail(ctx, db, userID, insightAlertEmailTemplates, newTemplateDataForInsightAlert(externalURL, alert))
+}
+
+// SendSlackNotificationForInsightAlert posts the given alert to a Slack
+// incoming webhook.
+func SendSlackNotificationForInsightAlert(ctx context.Context, url string, alert InsightAlert) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	return postSlackWebhook(ctx, httpcli.ExternalDoer, url, insightAlertSlackPayload(externalURL, alert))
+}
+
+// SendWebhookNotificationForInsightAlert posts the given alert as JSON to a
+// generic webhook.
+func SendWebhookNotificationForInsightAlert(ctx context.Context, url string, alert InsightAlert) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, httpcli.ExternalDoer, url, generateInsightAlertWebhookPayload(externalURL, alert))
+}
+
+var insightAlertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight {{.InsightTitle}}: {{.SeriesLabel}} {{.Reason}}`,
+	Text: `
+The series {{.SeriesLabel}} of your Sourcegraph code insight, {{.InsightTitle}}, {{.Reason}}.
+
+Latest value: {{.Value}}{{ if .HasPreviousValue }} (previously {{.PreviousValue}}){{ end }}
+Recorded at: {{.RecordingTime}}
+
+View code insight: {{.InsightURL}}
+
+__
+You are receiving this notification because you are a recipient of an alert on a code insight.
+`,
+	HTML: `
+<p>The series <strong>{{.SeriesLabel}}</strong> of your Sourcegraph code insight, <strong>{{.InsightTitle}}</strong>, {{.Reason}}.</p>
+
+<p>
+  Latest value: <strong>{{.Value}}</strong>{{ if .HasPreviousValue }} (previously {{.PreviousValue}}){{ end }}<br>
+  Recorded at: {{.RecordingTime}}
+</p>
+
+<p><a href="{{.InsightURL}}">View code insight</a></p>
+
+<p style="color: #5e6e8c">You are receiving this notification because you are a recipient of an alert on a code insight.</p>
+`,
+})
+
+type templateDataInsightAlert struct {
+	InsightTitle     string
+	InsightURL       string
+	SeriesLabel      string
+	Reason           string
+	Value            string
+	PreviousValue    string
+	HasPreviousValue bool
+	RecordingTime    string
+}
+
+func newTemplateDataForInsightAlert(externalURL *url.URL, alert InsightAlert) *templateDataInsightAlert {
+	data := &templateDataInsightAlert{
+		InsightTitle:  alert.InsightTitle,
+		InsightURL:    getInsightURL(externalURL, alert.InsightUniqueID, utmSourceInsightAlert),
+		SeriesLabel:   alert.SeriesLabel,
+		Reason:        alert.Reason,
+		Value:         formatInsightValue(alert.Value),
+		RecordingTime: alert.RecordingTime.UTC().Format(time.RFC3339),
+	}
+	if alert.PreviousValue != nil {
+		data.PreviousValue = formatInsightValue(*alert.PreviousValue)
+		data.HasPreviousValue = true
+	}
+	return data
+}
+
+func insightAlertSlackPayload(externalURL *url.URL, alert InsightAlert) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+
+	value := fmt.Sprintf("Latest value: *%s*", formatInsightValue(alert.Value))
+	if alert.PreviousValue != nil {
+		value += fmt.Sprintf(" (previously %s)", formatInsightValue(*alert.PreviousValue))
+	}
+
+	blocks := []slack.Block{
+		newMarkdownSection(fmt.Sprintf(
+			"The series *%s* of the Sourcegraph code insight, *%s*, %s.",
+			alert.SeriesLabel,
+			alert.InsightTitle,
+			alert.Reason,
+		)),
+		newMarkdownSection(value),
+		newMarkdownSection(fmt.Sprintf(
+			"<%s|View code insight>",
+			getInsightURL(externalURL, alert.InsightUniqueID, utmSourceInsightAlert),
+		)),
+	}
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+type insightAlertWebhookPayload struct {
+	InsightTitle  string    `json:"insightTitle"`
+	InsightURL    string    `json:"insightURL"`
+	SeriesLabel   string    `json:"seriesLabel"`
+	Query         string    `json:"query"`
+	Reason        string    `json:"reason"`
+	Value         float64   `json:"value"`
+	PreviousValue *float64  `json:"previousValue,omitempty"`
+	RecordingTime time.Time `json:"recordingTime"`
+}
+
+func generateInsightAlertWebhookPayload(externalURL *url.URL, alert InsightAlert) insightAlertWebhookPayload {
+	return insightAlertWebhookPayload{
+		InsightTitle:  alert.InsightTitle,
+		InsightURL:    getInsightURL(externalURL, alert.InsightUniqueID, utmSourceInsightAlert),
+		SeriesLabel:   alert.SeriesLabel,
+		Query:         alert.Query,
+		Reason:        alert.Reason,
+		Value:         alert.Value,
+		PreviousValue: alert.PreviousValue,
+		RecordingTime: alert.RecordingTime,
+	}
+}
+
+func getInsightURL(externalURL *url.URL, insightUniqueID, utmSource string) string {
+	return sourcegraphURL(externalURL, fmt.Sprintf("insights/insight/%s", relay.MarshalID(insightViewKind, insightUniqueID)), "", utmSource)
+}
+
+func formatInsightValue(value float64) string {
+	return fmt.Sprintf("%g", value)
+}
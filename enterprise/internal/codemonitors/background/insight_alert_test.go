@@ -0,0 +1,86 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsightAlertWebhook(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	previous := 90.0
+	alert := InsightAlert{
+		InsightUniqueID: "todos",
+		InsightTitle:    "TODOs",
+		SeriesLabel:     "TODO",
+		Query:           "TODO",
+		Reason:          "rose above 100",
+		Value:           120,
+		PreviousValue:   &previous,
+		RecordingTime:   time.Date(2022, 11, 23, 0, 0, 0, 0, time.UTC),
+	}
+
+	want := `{
+		"insightTitle": "TODOs",
+		"insightURL": "https://sourcegraph.com/insights/insight/aW5zaWdodF92aWV3OiJ0b2RvcyI=?utm_source=code-insights-alert",
+		"seriesLabel": "TODO",
+		"query": "TODO",
+		"reason": "rose above 100",
+		"value": 120,
+		"previousValue": 90,
+		"recordingTime": "2022-11-23T00:00:00Z"
+	}`
+
+	t.Run("payload", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.JSONEq(t, want, string(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		err := postWebhook(context.Background(), s.Client(), s.URL, generateInsightAlertWebhookPayload(eu, alert))
+		require.NoError(t, err)
+	})
+
+	t.Run("without previous value", func(t *testing.T) {
+		alertCopy := alert
+		alertCopy.PreviousValue = nil
+
+		j, err := json.Marshal(generateInsightAlertWebhookPayload(eu, alertCopy))
+		require.NoError(t, err)
+		require.NotContains(t, string(j), "previousValue")
+	})
+}
+
+func TestInsightAlertSlack(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	alert := InsightAlert{
+		InsightUniqueID: "todos",
+		InsightTitle:    "TODOs",
+		SeriesLabel:     "TODO",
+		Reason:          "fell below 10",
+		Value:           5.5,
+	}
+
+	payload := insightAlertSlackPayload(eu, alert)
+	require.Len(t, payload.Blocks.BlockSet, 3)
+
+	j, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.Contains(t, string(j), "The series *TODO* of the Sourcegraph code insight, *TODOs*, fell below 10.")
+	require.Contains(t, string(j), "Latest value: *5.5*")
+	require.Contains(t, string(j), "https://sourcegraph.com/insights/insight/aW5zaWdodF92aWV3OiJ0b2RvcyI=?utm_source=code-insights-alert|View code insight")
+}
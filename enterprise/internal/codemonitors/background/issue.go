@@ -0,0 +1,247 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	bstore "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// issueClient opens and comments on issues in a single repository on a code
+// host.
+type issueClient interface {
+	CreateIssue(ctx context.Context, title, body string) (number int32, url string, err error)
+	CommentOnIssue(ctx context.Context, number int32, body string) error
+}
+
+// issueCredentialStore loads the batch changes credentials that code monitor
+// issues are opened with.
+type issueCredentialStore interface {
+	UserCredentials() database.UserCredentialsStore
+	GetSiteCredential(ctx context.Context, opts bstore.GetSiteCredentialOpts) (*btypes.SiteCredential, error)
+}
+
+// newIssueClient returns an issueClient for the given repository, which is
+// looked up with the actor in ctx. The client authenticates with the batch
+// changes credential of the monitor owner for the code host of the
+// repository, or else with its site credential. The token of the code host
+// connection is never used, so that a monitor can't act on the code host as
+// anyone but its owner or the identity chosen by site admins.
+func newIssueClient(ctx context.Context, db database.DB, credentials issueCredentialStore, userID int32, repoID api.RepoID) (issueClient, error) {
+	repo, err := db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repository")
+	}
+
+	a, err := loadIssueAuthenticator(ctx, credentials, userID, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := db.ExternalServices().List(ctx, database.ExternalServicesListOptions{IDs: repo.ExternalServiceIDs()})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing external services")
+	}
+
+	// Prefer site-level connections over user-owned ones, newest first.
+	sort.SliceStable(es, func(i, j int) bool {
+		if (es[i].NamespaceUserID == 0) != (es[j].NamespaceUserID == 0) {
+			return es[i].NamespaceUserID == 0
+		}
+		return es[i].ID > es[j].ID
+	})
+
+	for _, e := range es {
+		cfg, err := e.Configuration(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := cfg.(type) {
+		case *schema.GitHubConnection:
+			return newGitHubIssueClient(e, c, repo, a)
+		case *schema.GitLabConnection:
+			return newGitLabIssueClient(e, c, repo, a)
+		}
+	}
+
+	return nil, errors.Errorf("no GitHub or GitLab code host connection found for repository %q", repo.Name)
+}
+
+// loadIssueAuthenticator returns the batch changes credential of the user for
+// the code host of repo, or else the site credential of the code host.
+func loadIssueAuthenticator(ctx context.Context, credentials issueCredentialStore, userID int32, repo *types.Repo) (auth.Authenticator, error) {
+	cred, err := credentials.UserCredentials().GetByScope(ctx, database.UserCredentialScope{
+		Domain:              database.UserCredentialDomainBatches,
+		UserID:              userID,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err != nil && !errcode.IsNotFound(err) {
+		return nil, errors.Wrap(err, "loading user credential")
+	}
+	if cred != nil {
+		return cred.Authenticator(ctx)
+	}
+
+	siteCred, err := credentials.GetSiteCredential(ctx, bstore.GetSiteCredentialOpts{
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err != nil && err != bstore.ErrNoResults {
+		return nil, errors.Wrap(err, "loading site credential")
+	}
+	if siteCred != nil {
+		return siteCred.Authenticator(ctx)
+	}
+
+	return nil, errors.Errorf("no credential found to open issues on %s: the monitor owner has to add a batch changes access token for the code host, or a site admin has to add a global one", repo.ExternalRepo.ServiceID)
+}
+
+type githubIssueClient struct {
+	client      *github.V3Client
+	owner, name string
+}
+
+func newGitHubIssueClient(svc *types.ExternalService, c *schema.GitHubConnection, repo *types.Repo, a auth.Authenticator) (*githubIssueClient, error) {
+	meta, ok := repo.Metadata.(*github.Repository)
+	if !ok {
+		return nil, errors.Errorf("repository %q is not a GitHub repository", repo.Name)
+	}
+	owner, name, err := github.SplitRepositoryNameWithOwner(meta.NameWithOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	apiURL, _ := github.APIRoot(extsvc.NormalizeBaseURL(baseURL))
+
+	return &githubIssueClient{
+		client: github.NewV3Client(log.Scoped("codemonitors.issue", "GitHub issue client for code monitors"), svc.URN(), apiURL, a, httpcli.ExternalDoer),
+		owner:  owner,
+		name:   name,
+	}, nil
+}
+
+func (c *githubIssueClient) CreateIssue(ctx context.Context, title, body string) (int32, string, error) {
+	issue, err := c.client.CreateIssue(ctx, c.owner, c.name, title, body)
+	if err != nil {
+		return 0, "", err
+	}
+	return int32(issue.Number), issue.HTMLURL, nil
+}
+
+func (c *githubIssueClient) CommentOnIssue(ctx context.Context, number int32, body string) error {
+	return c.client.CreateIssueComment(ctx, c.owner, c.name, int(number), body)
+}
+
+type gitlabIssueClient struct {
+	client  *gitlab.Client
+	project *gitlab.Project
+}
+
+func newGitLabIssueClient(svc *types.ExternalService, c *schema.GitLabConnection, repo *types.Repo, a auth.Authenticator) (*gitlabIssueClient, error) {
+	project, ok := repo.Metadata.(*gitlab.Project)
+	if !ok {
+		return nil, errors.Errorf("repository %q is not a GitLab project", repo.Name)
+	}
+
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := gitlab.NewClientProvider(svc.URN(), extsvc.NormalizeBaseURL(baseURL), httpcli.ExternalDoer)
+	return &gitlabIssueClient{client: provider.GetAuthenticatorClient(a), project: project}, nil
+}
+
+func (c *gitlabIssueClient) CreateIssue(ctx context.Context, title, body string) (int32, string, error) {
+	issue, err := c.client.CreateIssue(ctx, c.project, gitlab.CreateIssueOpts{Title: title, Description: body})
+	if err != nil {
+		return 0, "", err
+	}
+	return int32(issue.IID), issue.WebURL, nil
+}
+
+func (c *gitlabIssueClient) CommentOnIssue(ctx context.Context, number int32, body string) error {
+	return c.client.CreateIssueNote(ctx, c.project, gitlab.ID(number), body)
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor %q detected new matches", args.MonitorDescription)
+}
+
+// issueBody renders the Markdown body of the issue or comment posted for a
+// code monitor event.
+func issueBody(args actionArgs) string {
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		"%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n\n",
+		args.MonitorOwnerName,
+		args.MonitorDescription,
+		totalCount,
+	)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			lang := ""
+			content := result.MessagePreview
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+				lang = "diff"
+				content = result.DiffPreview
+			}
+			fmt.Fprintf(&b,
+				"%s match: [%s@%s](%s)\n\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			var contentRaw string
+			if content != nil {
+				contentRaw = truncateString(content.Content, 10)
+			}
+			fmt.Fprintf(&b, "````%s\n%s\n````\n\n", lang, strings.TrimSuffix(contentRaw, "\n"))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b,
+				"...and [%d more matches](%s).\n\n",
+				truncatedCount,
+				getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+			)
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b,
+		"If you are %s, you can [edit your code monitor](%s).\n",
+		args.MonitorOwnerName,
+		getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+	)
+	return b.String()
+}
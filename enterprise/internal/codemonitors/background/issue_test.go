@@ -0,0 +1,119 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/hexops/autogold"
+	"github.com/stretchr/testify/require"
+
+	bstore "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIssueBody(t *testing.T) {
+	t.Parallel()
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		ExternalURL:        eu,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+		IncludeResults:     false,
+	}
+
+	t.Run("title", func(t *testing.T) {
+		require.Equal(t, `Sourcegraph code monitor "My test monitor" detected new matches`, issueTitle(action))
+	})
+
+	t.Run("golden with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+
+	t.Run("golden with truncated results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		// quadruple the number of results
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		actionCopy.Results = append(actionCopy.Results, actionCopy.Results...)
+		autogold.Equal(t, autogold.Raw(issueBody(actionCopy)))
+	})
+
+	t.Run("golden without results", func(t *testing.T) {
+		autogold.Equal(t, autogold.Raw(issueBody(action)))
+	})
+}
+
+type fakeIssueCredentialStore struct {
+	userCredentials *database.MockUserCredentialsStore
+	siteCredential  *btypes.SiteCredential
+}
+
+func (f *fakeIssueCredentialStore) UserCredentials() database.UserCredentialsStore {
+	return f.userCredentials
+}
+
+func (f *fakeIssueCredentialStore) GetSiteCredential(context.Context, bstore.GetSiteCredentialOpts) (*btypes.SiteCredential, error) {
+	if f.siteCredential == nil {
+		return nil, bstore.ErrNoResults
+	}
+	return f.siteCredential, nil
+}
+
+func TestLoadIssueAuthenticator(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{
+		Name:         "github.com/sourcegraph/sourcegraph",
+		ExternalRepo: api.ExternalRepoSpec{ServiceType: extsvc.TypeGitHub, ServiceID: "https://github.com/"},
+	}
+
+	userToken := &auth.OAuthBearerToken{Token: "user"}
+	userCredential := &database.UserCredential{}
+	require.NoError(t, userCredential.SetAuthenticator(ctx, userToken))
+	siteToken := &auth.OAuthBearerToken{Token: "site"}
+	siteCredential := &btypes.SiteCredential{}
+	require.NoError(t, siteCredential.SetAuthenticator(ctx, siteToken))
+
+	newStore := func(user *database.UserCredential, site *btypes.SiteCredential) *fakeIssueCredentialStore {
+		userCredentials := database.NewMockUserCredentialsStore()
+		userCredentials.GetByScopeFunc.SetDefaultHook(func(_ context.Context, scope database.UserCredentialScope) (*database.UserCredential, error) {
+			require.Equal(t, database.UserCredentialDomainBatches, scope.Domain)
+			require.Equal(t, int32(42), scope.UserID)
+			if user == nil {
+				return nil, &errcode.Mock{IsNotFound: true}
+			}
+			return user, nil
+		})
+		return &fakeIssueCredentialStore{userCredentials: userCredentials, siteCredential: site}
+	}
+
+	t.Run("user credential", func(t *testing.T) {
+		a, err := loadIssueAuthenticator(ctx, newStore(userCredential, siteCredential), 42, repo)
+		require.NoError(t, err)
+		require.Equal(t, userToken, a)
+	})
+
+	t.Run("site credential", func(t *testing.T) {
+		a, err := loadIssueAuthenticator(ctx, newStore(nil, siteCredential), 42, repo)
+		require.NoError(t, err)
+		require.Equal(t, siteToken, a)
+	})
+
+	t.Run("no credential", func(t *testing.T) {
+		_, err := loadIssueAuthenticator(ctx, newStore(nil, nil), 42, repo)
+		require.ErrorContains(t, err, "no credential found to open issues on https://github.com/")
+	})
+}
@@ -75,7 +75,7 @@ func newTriggerJobsLogDeleter(ctx context.Context, store edb.CodeMonitorStore) g
 	return goroutine.NewPeriodicGoroutine(ctx, 60*time.Minute, deleteLogs)
 }
 
-func newActionRunner(ctx context.Context, logger log.Logger, s edb.CodeMonitorStore, metrics codeMonitorsMetrics) *workerutil.Worker {
+func newActionRunner(ctx context.Context, logger log.Logger, s edb.CodeMonitorStore, issueCredentials issueCredentialStore, metrics codeMonitorsMetrics) *workerutil.Worker {
 	options := workerutil.WorkerOptions{
 		Name:              "code_monitors_action_jobs_worker",
 		NumHandlers:       1,
@@ -83,7 +83,7 @@ func newActionRunner(ctx context.Context, logger log.Logger, s edb.CodeMonitorSt
 		HeartbeatInterval: 15 * time.Second,
 		Metrics:           metrics.workerMetrics,
 	}
-	worker := dbworker.NewWorker(ctx, createDBWorkerStoreForActionJobs(logger, s), &actionRunner{s}, options)
+	worker := dbworker.NewWorker(ctx, createDBWorkerStoreForActionJobs(logger, s), &actionRunner{CodeMonitorStore: s, issueCredentials: issueCredentials}, options)
 	return worker
 }
 
@@ -207,6 +207,8 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, record work
 
 type actionRunner struct {
 	edb.CodeMonitorStore
+	// issueCredentials loads the credentials issue actions authenticate with.
+	issueCredentials issueCredentialStore
 }
 
 func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
@@ -229,8 +231,10 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, record wor
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.Issue != nil:
+		return r.handleIssue(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, or issue")
 	}
 }
 
@@ -364,6 +368,66 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+func (r *actionRunner) handleIssue(ctx context.Context, j *edb.ActionJob) error {
+	// Unlike the other actions, the issue action records state on the code
+	// host, so we don't run it in a transaction: the issue number has to be
+	// stored as soon as the issue is created, and a transaction must not be
+	// held open across calls to the code host.
+	s := r.CodeMonitorStore
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	w, err := s.GetIssueAction(ctx, *j.Issue)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	monitor, err := s.GetMonitor(ctx, w.Monitor)
+	if err != nil {
+		return errors.Wrap(err, "GetMonitor")
+	}
+
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          w.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-issue",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		IncludeResults:     w.IncludeResults,
+	}
+
+	// Look up the repository and credential as the monitor owner, so that a
+	// monitor can only open issues in repositories its owner has access to.
+	ownerCtx := actor.WithActor(ctx, actor.FromUser(monitor.UserID))
+	client, err := newIssueClient(ownerCtx, database.NewDBWith(log.Scoped("handleIssue", ""), s), r.issueCredentials, monitor.UserID, w.RepoID)
+	if err != nil {
+		return err
+	}
+
+	body := issueBody(args)
+	if w.IssueNumber != nil {
+		return client.CommentOnIssue(ctx, *w.IssueNumber, body)
+	}
+
+	number, url, err := client.CreateIssue(ctx, issueTitle(args), body)
+	if err != nil {
+		return err
+	}
+	// Record the issue right away so that retries and later jobs comment on
+	// it instead of opening another one.
+	return errors.Wrap(s.SetIssueActionIssue(ctx, w.ID, number, url), "SetIssueActionIssue")
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -0,0 +1,231 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// unsearchedRepoStatus is the set of statuses of repositories that were not
+// searched, so we can't tell whether their matches went away.
+const unsearchedRepoStatus = search.RepoStatusCloning | search.RepoStatusMissing | search.RepoStatusTimedout
+
+// searchResultSet runs a search for file contents, paths, or symbols and
+// returns the matches that were not found on the previous run.
+//
+// Unlike commit and diff searches, these searches can't be restricted to what
+// changed since the last run. Instead, every match is reduced to a key, the
+// keys are stored per repository, and a match is new if its key wasn't stored
+// on the previous run. New matches are returned as commit matches with a diff
+// preview that adds the matched lines, so actions render them like diff
+// matches.
+func searchResultSet(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*result.CommitMatch, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+
+	fileMatches := make(map[api.RepoID][]*result.FileMatch)
+	for _, res := range agg.Results {
+		fm, ok := res.(*result.FileMatch)
+		if !ok {
+			// Queries without a type: filter also match repositories and
+			// commits, which have no file contents to compare.
+			continue
+		}
+		fileMatches[fm.Repo.ID] = append(fileMatches[fm.Repo.ID], fm)
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	lastMatched, err := cm.ListLastMatched(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(fileMatches))
+	for repoID := range fileMatches {
+		repoIDs = append(repoIDs, repoID)
+	}
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	var results []*result.CommitMatch
+	for _, repoID := range repoIDs {
+		seen := make(map[string]struct{}, len(lastMatched[repoID]))
+		for _, key := range lastMatched[repoID] {
+			seen[key] = struct{}{}
+		}
+
+		matched := make(map[string]struct{})
+		if agg.Stats.IsLimitHit {
+			// Not every match was returned, so keep the keys we didn't see
+			// this time around rather than reporting them as new later.
+			for key := range seen {
+				matched[key] = struct{}{}
+			}
+		}
+
+		for _, fm := range fileMatches[repoID] {
+			if match := diffFileMatch(fm, seen, matched); match != nil {
+				results = append(results, match)
+			}
+		}
+
+		keys := make([]string, 0, len(matched))
+		for key := range matched {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if err := cm.UpsertLastMatched(ctx, monitorID, repoID, keys); err != nil {
+			return nil, err
+		}
+	}
+
+	if !agg.Stats.IsLimitHit {
+		// Forget the matches of repositories that no longer match, so that
+		// matches reintroduced later are reported again.
+		var unmatched []api.RepoID
+		for repoID := range lastMatched {
+			if _, ok := fileMatches[repoID]; ok {
+				continue
+			}
+			if agg.Stats.Status.Get(repoID)&unsearchedRepoStatus != 0 {
+				continue
+			}
+			unmatched = append(unmatched, repoID)
+		}
+		if err := cm.DeleteLastMatched(ctx, monitorID, unmatched...); err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// diffFileMatch adds the keys of the lines, symbols, or path matched by fm to
+// matched, and returns a commit match for those that are not in seen. It
+// returns nil if nothing in fm is new.
+//
+// Keys don't include line numbers, so edits that only move a match within a
+// file don't report it again.
+func diffFileMatch(fm *result.FileMatch, seen, matched map[string]struct{}) *result.CommitMatch {
+	isNew := func(key string) bool {
+		matched[key] = struct{}{}
+		_, ok := seen[key]
+		return !ok
+	}
+
+	var b diffPreviewBuilder
+	b.writeHeader(fm.Path)
+
+	for _, lm := range fm.ChunkMatches.AsLineMatches() {
+		if len(lm.OffsetAndLengths) == 0 {
+			// Context line of a multiline chunk
+			continue
+		}
+		if isNew(matchKey("line", fm.Path, lm.Preview)) {
+			b.writeAddedLine(int(lm.LineNumber)+1, lm.Preview, lm.OffsetAndLengths)
+		}
+	}
+
+	for _, sm := range fm.Symbols {
+		s := sm.Symbol
+		if isNew(matchKey("symbol", fm.Path, s.Kind, s.Parent, s.Name)) {
+			prefix := s.Kind + " "
+			if s.Parent != "" {
+				prefix += s.Parent + "."
+			}
+			highlight := [2]int32{int32(len([]rune(prefix))), int32(len([]rune(s.Name)))}
+			b.writeAddedLine(s.Line, prefix+s.Name, [][2]int32{highlight})
+		}
+	}
+
+	isNewPath := false
+	if len(fm.ChunkMatches) == 0 && len(fm.Symbols) == 0 {
+		isNewPath = isNew(matchKey("path", fm.Path))
+	}
+
+	if b.hunks == 0 && !isNewPath {
+		return nil
+	}
+
+	return &result.CommitMatch{
+		Commit: gitdomain.Commit{ID: fm.CommitID},
+		Repo:   fm.Repo,
+		DiffPreview: &result.MatchedString{
+			Content:       b.buf.String(),
+			MatchedRanges: b.ranges,
+		},
+		ModifiedFiles: []string{fm.Path},
+	}
+}
+
+// matchKey hashes the fields identifying a match so that keys have a fixed
+// size regardless of the length of the matched content.
+func matchKey(fields ...string) string {
+	h := sha256.New()
+	for _, f := range fields {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// diffPreviewBuilder renders matches as lines added to a single file, in the
+// format produced by result.FormatDiffFiles, while tracking the ranges of the
+// matches in the rendered content.
+type diffPreviewBuilder struct {
+	buf    strings.Builder
+	line   int
+	hunks  int
+	ranges result.Ranges
+}
+
+func (b *diffPreviewBuilder) writeLine(s string) {
+	b.buf.WriteString(s)
+	b.buf.WriteByte('\n')
+	b.line++
+}
+
+func (b *diffPreviewBuilder) writeHeader(path string) {
+	escaped := strings.ReplaceAll(path, " ", `\ `)
+	b.writeLine(escaped + " " + escaped)
+}
+
+// writeAddedLine writes a hunk that adds content at the 1-based lineNumber.
+// Each highlight is a pair of a rune offset into content and a rune length.
+func (b *diffPreviewBuilder) writeAddedLine(lineNumber int, content string, highlights [][2]int32) {
+	b.writeLine(fmt.Sprintf("@@ -%d,0 +%d,1 @@", lineNumber-1, lineNumber))
+
+	runes := []rune(content)
+	location := func(column int) result.Location {
+		return result.Location{
+			// Account for the leading "+"
+			Offset: b.buf.Len() + 1 + len(string(runes[:column])),
+			Line:   b.line,
+			Column: 1 + column,
+		}
+	}
+	for _, h := range highlights {
+		start, end := int(h[0]), int(h[0]+h[1])
+		if start < 0 || end > len(runes) || start >= end {
+			continue
+		}
+		b.ranges = append(b.ranges, result.Range{Start: location(start), End: location(end)})
+	}
+
+	b.writeLine("+" + content)
+	b.hunks++
+}
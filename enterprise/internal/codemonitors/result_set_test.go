@@ -0,0 +1,92 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiffFileMatch(t *testing.T) {
+	t.Parallel()
+
+	file := result.File{
+		Repo:     types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/test"},
+		CommitID: "abc123",
+		Path:     "cmd/main.go",
+	}
+
+	// matchedContent returns the content of the ranges of the diff preview.
+	matchedContent := func(cm *result.CommitMatch) []string {
+		var res []string
+		for _, r := range cm.DiffPreview.MatchedRanges {
+			res = append(res, cm.DiffPreview.Content[r.Start.Offset:r.End.Offset])
+		}
+		return res
+	}
+
+	t.Run("content", func(t *testing.T) {
+		fm := &result.FileMatch{
+			File: file,
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "// TODO(security): check input\nfunc main() {}",
+				ContentStart: result.Location{Offset: 100, Line: 9, Column: 0},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 103, Line: 9, Column: 3},
+					End:   result.Location{Offset: 117, Line: 9, Column: 17},
+				}},
+			}},
+		}
+
+		seen, matched := map[string]struct{}{}, map[string]struct{}{}
+		cm := diffFileMatch(fm, seen, matched)
+		require.NotNil(t, cm)
+		require.Equal(t, file.Repo, cm.Repo)
+		require.Equal(t, file.CommitID, cm.Commit.ID)
+		require.Equal(t, "cmd/main.go cmd/main.go\n@@ -9,0 +10,1 @@\n+// TODO(security): check input\n", cm.DiffPreview.Content)
+		require.Equal(t, []string{"TODO(security)"}, matchedContent(cm))
+		require.Equal(t, 2, cm.DiffPreview.MatchedRanges[0].Start.Line)
+		require.Equal(t, 1, cm.ResultCount())
+		require.Len(t, matched, 1)
+
+		// The same line is not new on the next run, even if it moved.
+		fm.ChunkMatches[0].ContentStart.Line = 20
+		require.Nil(t, diffFileMatch(fm, matched, map[string]struct{}{}))
+	})
+
+	t.Run("symbols", func(t *testing.T) {
+		fm := &result.FileMatch{
+			File: file,
+			Symbols: []*result.SymbolMatch{
+				{Symbol: result.Symbol{Name: "Handle", Kind: "method", Parent: "Server", Line: 12}, File: &file},
+				{Symbol: result.Symbol{Name: "NewServer", Kind: "function", Line: 40}, File: &file},
+			},
+		}
+
+		matched := map[string]struct{}{}
+		cm := diffFileMatch(fm, map[string]struct{}{}, matched)
+		require.NotNil(t, cm)
+		require.Equal(t, "cmd/main.go cmd/main.go\n@@ -11,0 +12,1 @@\n+method Server.Handle\n@@ -39,0 +40,1 @@\n+function NewServer\n", cm.DiffPreview.Content)
+		require.Equal(t, []string{"Handle", "NewServer"}, matchedContent(cm))
+
+		// Only the symbol that wasn't seen before is reported.
+		fm.Symbols = append(fm.Symbols, &result.SymbolMatch{Symbol: result.Symbol{Name: "Close", Kind: "method", Parent: "Server", Line: 50}, File: &file})
+		cm = diffFileMatch(fm, matched, map[string]struct{}{})
+		require.NotNil(t, cm)
+		require.Equal(t, []string{"Close"}, matchedContent(cm))
+	})
+
+	t.Run("path", func(t *testing.T) {
+		fm := &result.FileMatch{File: result.File{Repo: file.Repo, CommitID: file.CommitID, Path: "has space.go"}}
+
+		matched := map[string]struct{}{}
+		cm := diffFileMatch(fm, map[string]struct{}{}, matched)
+		require.NotNil(t, cm)
+		require.Equal(t, "has\\ space.go has\\ space.go\n", cm.DiffPreview.Content)
+		require.Equal(t, 1, cm.ResultCount())
+
+		require.Nil(t, diffFileMatch(fm, matched, map[string]struct{}{}))
+	})
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/commit"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	searchquery "github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/repos"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
@@ -107,6 +108,49 @@ func Settings(ctx context.Context) (_ *schema.Settings, err error) {
 	return &unmarshaledSettings, nil
 }
 
+const mixedQueryTypesMessage = "code monitors cannot combine type:commit or type:diff with type:file, type:path, or type:symbol"
+
+// ValidateQuery returns an error if the query of a code monitor trigger
+// searches result types that code monitors can't compare between runs, or
+// mixes commit and diff searches with file content, path, or symbol searches,
+// which are compared differently.
+func ValidateQuery(query string) error {
+	plan, err := searchquery.Pipeline(searchquery.Init(query, searchquery.SearchTypeStandard))
+	if err != nil {
+		return err
+	}
+
+	var commitSearches int
+	for _, basic := range plan {
+		types, _ := basic.IncludeExcludeValues(searchquery.FieldType)
+		if len(types) == 0 {
+			// Without a type: filter, file contents and paths are searched.
+			types = []string{"file", "path"}
+		}
+		var commits, files bool
+		for _, t := range types {
+			switch t {
+			case "commit", "diff":
+				commits = true
+			case "file", "path", "symbol":
+				files = true
+			default:
+				return errors.Errorf("code monitors do not support type:%s, only type:commit, type:diff, type:file, type:path, and type:symbol", t)
+			}
+		}
+		if commits {
+			commitSearches++
+		}
+		if commits && files {
+			return errors.New(mixedQueryTypesMessage)
+		}
+	}
+	if commitSearches > 0 && commitSearches < len(plan) {
+		return errors.New(mixedQueryTypesMessage)
+	}
+	return nil
+}
+
 func Search(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) (_ []*result.CommitMatch, err error) {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -130,6 +174,12 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 		return nil, errcode.MakeNonRetryable(err)
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		// File content, path, and symbol searches don't search commits, so
+		// we diff their results against the previous run instead.
+		return searchResultSet(ctx, db, clients, planJob, monitorID)
+	}
+
 	if featureflag.FromContext(ctx).GetBoolOr("cc-repo-aware-monitors", true) {
 		hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
 			return hookWithID(ctx, db, gs, monitorID, repoID, args, doSearch)
@@ -187,6 +237,8 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, query string
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
 // be searched from the beginning.
+// For file content, path, and symbol searches, it saves the current matches instead, so that only matches
+// introduced afterwards are reported.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query string, monitorID int64, settings *schema.Settings) error {
 	searchClient := client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs())
 	inputs, err := searchClient.Plan(
@@ -209,6 +261,13 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, query stri
 		return err
 	}
 
+	if !job.HasDescendent[*commit.SearchJob](planJob) {
+		// Record the current matches so that only matches introduced from
+		// now on are reported.
+		_, err = searchResultSet(ctx, db, clients, planJob, monitorID)
+		return err
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -158,3 +158,31 @@ func TestCodeMonitorHook(t *testing.T) {
 	err = hookWithID(ctx, db, gs, fixtures.Monitor.ID, fixtures.Repo.ID, &gitprotocol.SearchRequest{}, doSearch)
 	require.NoError(t, err)
 }
+
+func TestValidateQuery(t *testing.T) {
+	valid := []string{
+		"repo:foo type:commit",
+		"repo:foo type:diff bar",
+		"repo:foo type:file bar",
+		"repo:foo type:symbol bar",
+		"repo:foo type:file type:path bar",
+		"repo:foo bar",
+		"(repo:a type:diff b) or (repo:c type:commit d)",
+	}
+	for _, q := range valid {
+		t.Run(q, func(t *testing.T) {
+			require.NoError(t, ValidateQuery(q))
+		})
+	}
+
+	invalid := []string{
+		"repo:foo type:repo",
+		"repo:foo type:diff type:file bar",
+		"(repo:a type:diff b) or (repo:c d)",
+	}
+	for _, q := range invalid {
+		t.Run(q, func(t *testing.T) {
+			require.Error(t, ValidateQuery(q))
+		})
+	}
+}
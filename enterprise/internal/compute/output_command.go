@@ -100,6 +100,11 @@ func resultChunks(r result.Match, kind string, onlyPath bool) []string {
 			content = string(m.Commit.Message)
 		}
 		return []string{content}
+	case *result.OwnerMatch:
+		if m.Handle != "" {
+			return []string{"@" + m.Handle}
+		}
+		return []string{m.Email}
 	default:
 		panic("unsupported result kind in compute output command")
 	}
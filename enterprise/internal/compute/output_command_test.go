@@ -119,6 +119,11 @@ func TestRun(t *testing.T) {
 		"bob: (1)\nbob: (2)\nbob: (3)\n").
 		Equal(t, test(`content:output((\d) -> $author: ($1))`, commitMatch("a 1 b 2 c 3")))
 
+	autogold.Want(
+		"owner match content",
+		"@alice in my/awesome/repo").
+		Equal(t, test(`content:output(.+ -> $content in $repo) select:file.owners`, &result.OwnerMatch{Handle: "alice", Repo: types.MinimalRepo{Name: "my/awesome/repo"}}))
+
 	autogold.Want(
 		"works with boundary assertions",
 		"test\nstring\n").
@@ -300,6 +300,13 @@ func NewMetaEnvironment(r result.Match, content string) *MetaEnvironment {
 			Lang:    lang,
 			Content: content,
 		}
+	case *result.OwnerMatch:
+		return &MetaEnvironment{
+			Repo:    string(m.Repo.Name),
+			Commit:  string(m.CommitID),
+			Email:   m.Email,
+			Content: content,
+		}
 	}
 	return &MetaEnvironment{}
 }
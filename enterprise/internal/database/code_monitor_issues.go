@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// IssueAction is a code monitor action that opens an issue on the code host
+// of a repository and comments on it on subsequent events.
+type IssueAction struct {
+	ID             int64
+	Monitor        int64
+	Enabled        bool
+	IncludeResults bool
+	RepoID         api.RepoID
+
+	// IssueNumber and IssueURL are set once the issue has been opened on the
+	// code host.
+	IssueNumber *int32
+	IssueURL    *string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+// The issue is reset when the repository changes so that the next event opens
+// a new issue in the new repository.
+const updateIssueActionQuery = `
+UPDATE cm_issues
+SET enabled = %s,
+	include_results = %s,
+	issue_number = CASE WHEN repo_id = %s THEN issue_number ELSE NULL END,
+	issue_url = CASE WHEN repo_id = %s THEN issue_url ELSE NULL END,
+	repo_id = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_issues.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateIssueAction(ctx context.Context, id int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error) {
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateIssueActionQuery,
+		enabled,
+		includeResults,
+		repoID,
+		repoID,
+		repoID,
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const createIssueActionQuery = `
+INSERT INTO cm_issues
+(monitor, enabled, include_results, repo_id, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createIssueActionQuery,
+		monitorID,
+		enabled,
+		includeResults,
+		repoID,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const deleteIssueActionQuery = `
+DELETE FROM cm_issues
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteIssueActions(ctx context.Context, monitorID int64, issueIDs ...int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(issueIDs))
+	for _, ids := range issueIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteIssueActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countIssueActionsQuery = `
+SELECT COUNT(*)
+FROM cm_issues
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountIssueActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countIssueActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getIssueActionQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetIssueAction(ctx context.Context, id int64) (*IssueAction, error) {
+	q := sqlf.Sprintf(
+		getIssueActionQuery,
+		sqlf.Join(issueActionColumns, ","),
+		id,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const listIssueActionsQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListIssueActions(ctx context.Context, opts ListActionsOpts) ([]*IssueAction, error) {
+	q := sqlf.Sprintf(
+		listIssueActionsQuery,
+		sqlf.Join(issueActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanIssueActions(rows)
+}
+
+const setIssueActionIssueQuery = `
+UPDATE cm_issues
+SET issue_number = %s,
+	issue_url = %s
+WHERE id = %s
+`
+
+// SetIssueActionIssue records the issue that was opened on the code host for
+// the given action, so that subsequent events comment on it.
+func (s *codeMonitorStore) SetIssueActionIssue(ctx context.Context, id int64, number int32, url string) error {
+	return s.Exec(ctx, sqlf.Sprintf(setIssueActionIssueQuery, number, url, id))
+}
+
+// issueActionColumns is the set of columns in the cm_issues table
+// This must be kept in sync with scanIssueAction
+var issueActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_issues.id"),
+	sqlf.Sprintf("cm_issues.monitor"),
+	sqlf.Sprintf("cm_issues.enabled"),
+	sqlf.Sprintf("cm_issues.include_results"),
+	sqlf.Sprintf("cm_issues.repo_id"),
+	sqlf.Sprintf("cm_issues.issue_number"),
+	sqlf.Sprintf("cm_issues.issue_url"),
+	sqlf.Sprintf("cm_issues.created_by"),
+	sqlf.Sprintf("cm_issues.created_at"),
+	sqlf.Sprintf("cm_issues.changed_by"),
+	sqlf.Sprintf("cm_issues.changed_at"),
+}
+
+func scanIssueActions(rows *sql.Rows) ([]*IssueAction, error) {
+	var is []*IssueAction
+	for rows.Next() {
+		i, err := scanIssueAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+	return is, rows.Err()
+}
+
+// scanIssueAction scans an IssueAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with issueActionColumns.
+func scanIssueAction(scanner dbutil.Scanner) (*IssueAction, error) {
+	var i IssueAction
+	err := scanner.Scan(
+		&i.ID,
+		&i.Monitor,
+		&i.Enabled,
+		&i.IncludeResults,
+		&i.RepoID,
+		&i.IssueNumber,
+		&i.IssueURL,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ChangedBy,
+		&i.ChangedAt,
+	)
+	return &i, err
+}
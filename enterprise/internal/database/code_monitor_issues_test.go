@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeMonitorStoreIssues(t *testing.T) {
+	logger := logtest.Scoped(t)
+
+	setup := func(t *testing.T) (context.Context, CodeMonitorStore, codeMonitorTestFixtures) {
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		ctx := actor.WithActor(context.Background(), actor.FromUser(fixtures.User.ID))
+		return ctx, db.CodeMonitors(), fixtures
+	}
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Nil(t, action.IssueNumber)
+		require.Nil(t, action.IssueURL)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, action, got)
+	})
+
+	t.Run("SetIssueThenGet", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		err = s.SetIssueActionIssue(ctx, action.ID, 42, "https://github.com/sourcegraph/test/issues/42")
+		require.NoError(t, err)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, int32(42), *got.IssueNumber)
+		require.Equal(t, "https://github.com/sourcegraph/test/issues/42", *got.IssueURL)
+	})
+
+	t.Run("CreateUpdateGet", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		err = s.SetIssueActionIssue(ctx, action.ID, 42, "https://github.com/sourcegraph/test/issues/42")
+		require.NoError(t, err)
+
+		// Updating without changing the repository keeps the issue.
+		updated, err := s.UpdateIssueAction(ctx, action.ID, false, true, fixtures.Repo.ID)
+		require.NoError(t, err)
+		require.Equal(t, false, updated.Enabled)
+		require.Equal(t, true, updated.IncludeResults)
+		require.Equal(t, int32(42), *updated.IssueNumber)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+	})
+
+	t.Run("UpdateRepoResetsIssue", func(t *testing.T) {
+		t.Parallel()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		ctx := actor.WithActor(context.Background(), actor.FromUser(fixtures.User.ID))
+		s := db.CodeMonitors()
+
+		err := db.Repos().Create(ctx, &types.Repo{Name: "other"})
+		require.NoError(t, err)
+		other, err := db.Repos().GetByName(ctx, api.RepoName("other"))
+		require.NoError(t, err)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		err = s.SetIssueActionIssue(ctx, action.ID, 42, "https://github.com/sourcegraph/test/issues/42")
+		require.NoError(t, err)
+
+		updated, err := s.UpdateIssueAction(ctx, action.ID, true, false, other.ID)
+		require.NoError(t, err)
+		require.Equal(t, other.ID, updated.RepoID)
+		require.Nil(t, updated.IssueNumber)
+		require.Nil(t, updated.IssueURL)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		_, err := s.UpdateIssueAction(ctx, 383838, false, false, fixtures.Repo.ID)
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteGet", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		action2, err := s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		err = s.DeleteIssueActions(ctx, fixtures.Monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetIssueAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		_, err = s.GetIssueAction(ctx, action2.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("CountListCreate", func(t *testing.T) {
+		t.Parallel()
+		ctx, s, fixtures := setup(t)
+
+		count, err := s.CountIssueActions(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 0, count)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, false, fixtures.Repo.ID)
+		require.NoError(t, err)
+		_, err = s.CreateIssueAction(ctx, fixtures.Monitor.ID, true, true, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		count, err = s.CountIssueActions(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.Monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 2)
+
+		first := 1
+		actions, err = s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.Monitor.ID, First: &first})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+	})
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func (s *codeMonitorStore) ListLastMatched(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error) {
+	rawQuery := `
+	SELECT repo_id, match_keys
+	FROM cm_last_matched
+	WHERE monitor_id = %s
+	`
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(rawQuery, monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lastMatched := make(map[api.RepoID][]string)
+	for rows.Next() {
+		var (
+			repoID    api.RepoID
+			matchKeys []string
+		)
+		if err := rows.Scan(&repoID, (*pq.StringArray)(&matchKeys)); err != nil {
+			return nil, err
+		}
+		lastMatched[repoID] = matchKeys
+	}
+	return lastMatched, rows.Err()
+}
+
+func (s *codeMonitorStore) UpsertLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID, matchKeys []string) error {
+	rawQuery := `
+	INSERT INTO cm_last_matched (monitor_id, repo_id, match_keys)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id, repo_id) DO UPDATE
+	SET match_keys = %s
+	`
+
+	// Appease non-null constraint on column
+	if matchKeys == nil {
+		matchKeys = []string{}
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, int64(repoID), pq.StringArray(matchKeys), pq.StringArray(matchKeys))
+	return s.Exec(ctx, q)
+}
+
+func (s *codeMonitorStore) DeleteLastMatched(ctx context.Context, monitorID int64, repoIDs ...api.RepoID) error {
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	rawQuery := `
+	DELETE FROM cm_last_matched
+	WHERE monitor_id = %s
+		AND repo_id = ANY(%s)
+	`
+
+	ids := make([]int64, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		ids = append(ids, int64(id))
+	}
+	q := sqlf.Sprintf(rawQuery, monitorID, pq.Int64Array(ids))
+	return s.Exec(ctx, q)
+}
@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeMonitorStoreLastMatched(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("upsert list upsert list", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		err := cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"key1", "key2"})
+		require.NoError(t, err)
+
+		// List
+		lastMatched, err := cm.ListLastMatched(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {"key1", "key2"}}, lastMatched)
+
+		// Update
+		err = cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"key3"})
+		require.NoError(t, err)
+
+		// List
+		lastMatched, err = cm.ListLastMatched(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID][]string{fixtures.Repo.ID: {"key3"}}, lastMatched)
+	})
+
+	t.Run("no error for missing list", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		lastMatched, err := cm.ListLastMatched(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Empty(t, lastMatched)
+	})
+
+	t.Run("upsert nil", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, nil)
+		require.NoError(t, err)
+
+		lastMatched, err := cm.ListLastMatched(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Len(t, lastMatched, 1)
+		require.Empty(t, lastMatched[fixtures.Repo.ID])
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := db.Repos().Create(ctx, &types.Repo{Name: "other"})
+		require.NoError(t, err)
+		other, err := db.Repos().GetByName(ctx, api.RepoName("other"))
+		require.NoError(t, err)
+
+		err = cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID, []string{"key1"})
+		require.NoError(t, err)
+		err = cm.UpsertLastMatched(ctx, fixtures.Monitor.ID, other.ID, []string{"key2"})
+		require.NoError(t, err)
+
+		err = cm.DeleteLastMatched(ctx, fixtures.Monitor.ID, fixtures.Repo.ID)
+		require.NoError(t, err)
+
+		lastMatched, err := cm.ListLastMatched(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, map[api.RepoID][]string{other.ID: {"key2"}}, lastMatched)
+	})
+}
@@ -72,6 +72,14 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateIssueAction(_ context.Context, id int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	CreateIssueAction(ctx context.Context, monitorID int64, enabled, includeResults bool, repoID api.RepoID) (*IssueAction, error)
+	DeleteIssueActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountIssueActions(ctx context.Context, monitorID int64) (int, error)
+	GetIssueAction(ctx context.Context, id int64) (*IssueAction, error)
+	ListIssueActions(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	SetIssueActionIssue(ctx context.Context, id int64, number int32, url string) error
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -90,6 +98,13 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// ListLastMatched returns the match keys recorded per repository on the
+	// last run of a code monitor that diffs file, path, or symbol results
+	// between runs rather than searching new commits.
+	ListLastMatched(ctx context.Context, monitorID int64) (map[api.RepoID][]string, error)
+	UpsertLastMatched(ctx context.Context, monitorID int64, repoID api.RepoID, matchKeys []string) error
+	DeleteLastMatched(ctx context.Context, monitorID int64, repoIDs ...api.RepoID) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -34,6 +34,9 @@ type MockCodeMonitorStore struct {
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountIssueActions.
+	CountIssueActionsFunc *CodeMonitorStoreCountIssueActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -52,6 +55,9 @@ type MockCodeMonitorStore struct {
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIssueAction.
+	CreateIssueActionFunc *CodeMonitorStoreCreateIssueActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -70,6 +76,12 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueActions.
+	DeleteIssueActionsFunc *CodeMonitorStoreDeleteIssueActionsFunc
+	// DeleteLastMatchedFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteLastMatched.
+	DeleteLastMatchedFunc *CodeMonitorStoreDeleteLastMatchedFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -108,6 +120,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIssueAction.
+	GetIssueActionFunc *CodeMonitorStoreGetIssueActionFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -139,6 +154,12 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIssueActions.
+	ListIssueActionsFunc *CodeMonitorStoreListIssueActionsFunc
+	// ListLastMatchedFunc is an instance of a mock function object
+	// controlling the behavior of the method ListLastMatched.
+	ListLastMatchedFunc *CodeMonitorStoreListLastMatchedFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -161,6 +182,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
 	ResetQueryTriggerTimestampsFunc *CodeMonitorStoreResetQueryTriggerTimestampsFunc
+	// SetIssueActionIssueFunc is an instance of a mock function object
+	// controlling the behavior of the method SetIssueActionIssue.
+	SetIssueActionIssueFunc *CodeMonitorStoreSetIssueActionIssueFunc
 	// SetQueryTriggerNextRunFunc is an instance of a mock function object
 	// controlling the behavior of the method SetQueryTriggerNextRun.
 	SetQueryTriggerNextRunFunc *CodeMonitorStoreSetQueryTriggerNextRunFunc
@@ -170,6 +194,9 @@ type MockCodeMonitorStore struct {
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIssueAction.
+	UpdateIssueActionFunc *CodeMonitorStoreUpdateIssueActionFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -189,6 +216,9 @@ type MockCodeMonitorStore struct {
 	// UpdateWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateWebhookAction.
 	UpdateWebhookActionFunc *CodeMonitorStoreUpdateWebhookActionFunc
+	// UpsertLastMatchedFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertLastMatched.
+	UpsertLastMatchedFunc *CodeMonitorStoreUpsertLastMatchedFunc
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
@@ -209,6 +239,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (r0 int32, r1 error) {
 				return
@@ -239,6 +274,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -269,6 +309,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
+		DeleteLastMatchedFunc: &CodeMonitorStoreDeleteLastMatchedFunc{
+			defaultHook: func(context.Context, int64, ...api.RepoID) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -329,6 +379,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -379,6 +434,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*IssueAction, r1 error) {
+				return
+			},
+		},
+		ListLastMatchedFunc: &CodeMonitorStoreListLastMatchedFunc{
+			defaultHook: func(context.Context, int64) (r0 map[api.RepoID][]string, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) (r0 []*Monitor, r1 error) {
 				return
@@ -414,6 +479,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		SetIssueActionIssueFunc: &CodeMonitorStoreSetIssueActionIssueFunc{
+			defaultHook: func(context.Context, int64, int32, string) (r0 error) {
+				return
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) (r0 error) {
 				return
@@ -429,6 +499,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -459,6 +534,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
+				return
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) (r0 error) {
 				return
@@ -481,6 +561,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIssueActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -511,6 +596,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIssueAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
@@ -541,6 +631,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueActions")
+			},
+		},
+		DeleteLastMatchedFunc: &CodeMonitorStoreDeleteLastMatchedFunc{
+			defaultHook: func(context.Context, int64, ...api.RepoID) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteLastMatched")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -601,6 +701,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIssueAction")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -651,6 +756,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIssueActions")
+			},
+		},
+		ListLastMatchedFunc: &CodeMonitorStoreListLastMatchedFunc{
+			defaultHook: func(context.Context, int64) (map[api.RepoID][]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListLastMatched")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -686,6 +801,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
 			},
 		},
+		SetIssueActionIssueFunc: &CodeMonitorStoreSetIssueActionIssueFunc{
+			defaultHook: func(context.Context, int64, int32, string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.SetIssueActionIssue")
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) error {
 				panic("unexpected invocation of MockCodeMonitorStore.SetQueryTriggerNextRun")
@@ -701,6 +821,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueAction")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -731,6 +856,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastMatched")
+			},
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID, []string) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
@@ -750,6 +880,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: i.CountIssueActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -768,6 +901,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: i.CreateIssueAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -786,6 +922,12 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: i.DeleteIssueActions,
+		},
+		DeleteLastMatchedFunc: &CodeMonitorStoreDeleteLastMatchedFunc{
+			defaultHook: i.DeleteLastMatched,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -822,6 +964,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: i.GetIssueAction,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -852,6 +997,12 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: i.ListIssueActions,
+		},
+		ListLastMatchedFunc: &CodeMonitorStoreListLastMatchedFunc{
+			defaultHook: i.ListLastMatched,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -873,6 +1024,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
+		SetIssueActionIssueFunc: &CodeMonitorStoreSetIssueActionIssueFunc{
+			defaultHook: i.SetIssueActionIssue,
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: i.SetQueryTriggerNextRun,
 		},
@@ -882,6 +1036,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: i.UpdateIssueAction,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -900,6 +1057,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
 			defaultHook: i.UpdateWebhookAction,
 		},
+		UpsertLastMatchedFunc: &CodeMonitorStoreUpsertLastMatchedFunc{
+			defaultHook: i.UpsertLastMatched,
+		},
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
@@ -1116,6 +1276,117 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountIssueActionsFunc describes the behavior when the
+// CountIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCountIssueActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIssueActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIssueActionsFunc.nextHook()(v0, v1)
+	m.CountIssueActionsFunc.appendCall(CodeMonitorStoreCountIssueActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) appendCall(r0 CodeMonitorStoreCountIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountIssueActionsFunc) History() []CodeMonitorStoreCountIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountIssueActionsFuncCall is an object that describes an
+// invocation of method CountIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountMonitorsFunc describes the behavior when the
 // CountMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -1786,6 +2057,126 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateIssueActionFunc describes the behavior when the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreCreateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.CreateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.CreateIssueActionFunc.appendCall(CodeMonitorStoreCreateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) appendCall(r0 CodeMonitorStoreCreateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateIssueActionFunc) History() []CodeMonitorStoreCreateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateIssueActionFuncCall is an object that describes an
+// invocation of method CreateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateMonitorFunc describes the behavior when the
 // CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2479,21 +2870,257 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
-// DeleteMonitor method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreDeleteIssueActionsFunc describes the behavior when the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreDeleteMonitorFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []CodeMonitorStoreDeleteMonitorFuncCall
+type CodeMonitorStoreDeleteIssueActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteMonitor delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteMonitor(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteMonitorFunc.nextHook()(v0, v1)
-	m.DeleteMonitorFunc.appendCall(CodeMonitorStoreDeleteMonitorFuncCall{v0, v1, r0})
+// DeleteIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIssueActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueActionsFunc.appendCall(CodeMonitorStoreDeleteIssueActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) History() []CodeMonitorStoreDeleteIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIssueActionsFuncCall is an object that describes an
+// invocation of method DeleteIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteLastMatchedFunc describes the behavior when the
+// DeleteLastMatched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteLastMatchedFunc struct {
+	defaultHook func(context.Context, int64, ...api.RepoID) error
+	hooks       []func(context.Context, int64, ...api.RepoID) error
+	history     []CodeMonitorStoreDeleteLastMatchedFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteLastMatched delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteLastMatched(v0 context.Context, v1 int64, v2 ...api.RepoID) error {
+	r0 := m.DeleteLastMatchedFunc.nextHook()(v0, v1, v2...)
+	m.DeleteLastMatchedFunc.appendCall(CodeMonitorStoreDeleteLastMatchedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteLastMatched
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) SetDefaultHook(hook func(context.Context, int64, ...api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteLastMatched method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) PushHook(hook func(context.Context, int64, ...api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) nextHook() func(context.Context, int64, ...api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) appendCall(r0 CodeMonitorStoreDeleteLastMatchedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteLastMatchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteLastMatchedFunc) History() []CodeMonitorStoreDeleteLastMatchedFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteLastMatchedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteLastMatchedFuncCall is an object that describes an
+// invocation of method DeleteLastMatched on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteLastMatchedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteLastMatchedFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteLastMatchedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
+// DeleteMonitor method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteMonitorFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteMonitorFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteMonitor delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteMonitor(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteMonitorFunc.nextHook()(v0, v1)
+	m.DeleteMonitorFunc.appendCall(CodeMonitorStoreDeleteMonitorFuncCall{v0, v1, r0})
 	return r0
 }
 
@@ -3803,37 +4430,36 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
-// GetLastSearched method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreGetIssueActionFunc describes the behavior when the
+// GetIssueAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreGetLastSearchedFunc struct {
-	defaultHook func(context.Context, int64, api.RepoID) ([]string, error)
-	hooks       []func(context.Context, int64, api.RepoID) ([]string, error)
-	history     []CodeMonitorStoreGetLastSearchedFuncCall
+type CodeMonitorStoreGetIssueActionFunc struct {
+	defaultHook func(context.Context, int64) (*IssueAction, error)
+	hooks       []func(context.Context, int64) (*IssueAction, error)
+	history     []CodeMonitorStoreGetIssueActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetLastSearched delegates to the next hook function in the queue and
+// GetIssueAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetLastSearched(v0 context.Context, v1 int64, v2 api.RepoID) ([]string, error) {
-	r0, r1 := m.GetLastSearchedFunc.nextHook()(v0, v1, v2)
-	m.GetLastSearchedFunc.appendCall(CodeMonitorStoreGetLastSearchedFuncCall{v0, v1, v2, r0, r1})
+func (m *MockCodeMonitorStore) GetIssueAction(v0 context.Context, v1 int64) (*IssueAction, error) {
+	r0, r1 := m.GetIssueActionFunc.nextHook()(v0, v1)
+	m.GetIssueActionFunc.appendCall(CodeMonitorStoreGetIssueActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetLastSearched
+// SetDefaultHook sets function that is called when the GetIssueAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreGetLastSearchedFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetLastSearched method of the parent MockCodeMonitorStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *CodeMonitorStoreGetLastSearchedFunc) PushHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+// GetIssueAction method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetIssueActionFunc) PushHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3841,20 +4467,20 @@ func (f *CodeMonitorStoreGetLastSearchedFunc) PushHook(hook func(context.Context
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetLastSearchedFunc) SetDefaultReturn(r0 []string, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetLastSearchedFunc) PushReturn(r0 []string, r1 error) {
-	f.PushHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetLastSearchedFunc) nextHook() func(context.Context, int64, api.RepoID) ([]string, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) nextHook() func(context.Context, int64) (*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3867,39 +4493,36 @@ func (f *CodeMonitorStoreGetLastSearchedFunc) nextHook() func(context.Context, i
 	return hook
 }
 
-func (f *CodeMonitorStoreGetLastSearchedFunc) appendCall(r0 CodeMonitorStoreGetLastSearchedFuncCall) {
+func (f *CodeMonitorStoreGetIssueActionFunc) appendCall(r0 CodeMonitorStoreGetIssueActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetLastSearchedFuncCall
+// History returns a sequence of CodeMonitorStoreGetIssueActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetLastSearchedFunc) History() []CodeMonitorStoreGetLastSearchedFuncCall {
+func (f *CodeMonitorStoreGetIssueActionFunc) History() []CodeMonitorStoreGetIssueActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetLastSearchedFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetIssueActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetLastSearchedFuncCall is an object that describes an
-// invocation of method GetLastSearched on an instance of
+// CodeMonitorStoreGetIssueActionFuncCall is an object that describes an
+// invocation of method GetIssueAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetLastSearchedFuncCall struct {
+type CodeMonitorStoreGetIssueActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 api.RepoID
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []string
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3907,35 +4530,149 @@ type CodeMonitorStoreGetLastSearchedFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetLastSearchedFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+func (c CodeMonitorStoreGetIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetLastSearchedFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetIssueActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetMonitorFunc describes the behavior when the GetMonitor
-// method of the parent MockCodeMonitorStore instance is invoked.
-type CodeMonitorStoreGetMonitorFunc struct {
-	defaultHook func(context.Context, int64) (*Monitor, error)
-	hooks       []func(context.Context, int64) (*Monitor, error)
-	history     []CodeMonitorStoreGetMonitorFuncCall
+// CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
+// GetLastSearched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetLastSearchedFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID) ([]string, error)
+	hooks       []func(context.Context, int64, api.RepoID) ([]string, error)
+	history     []CodeMonitorStoreGetLastSearchedFuncCall
 	mutex       sync.Mutex
 }
 
-// GetMonitor delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetMonitor(v0 context.Context, v1 int64) (*Monitor, error) {
-	r0, r1 := m.GetMonitorFunc.nextHook()(v0, v1)
-	m.GetMonitorFunc.appendCall(CodeMonitorStoreGetMonitorFuncCall{v0, v1, r0, r1})
+// GetLastSearched delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetLastSearched(v0 context.Context, v1 int64, v2 api.RepoID) ([]string, error) {
+	r0, r1 := m.GetLastSearchedFunc.nextHook()(v0, v1, v2)
+	m.GetLastSearchedFunc.appendCall(CodeMonitorStoreGetLastSearchedFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetMonitor method of
-// the parent MockCodeMonitorStore instance is invoked and the hook queue is
+// SetDefaultHook sets function that is called when the GetLastSearched
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetLastSearchedFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetLastSearched method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetLastSearchedFunc) PushHook(hook func(context.Context, int64, api.RepoID) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetLastSearchedFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetLastSearchedFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetLastSearchedFunc) nextHook() func(context.Context, int64, api.RepoID) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetLastSearchedFunc) appendCall(r0 CodeMonitorStoreGetLastSearchedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetLastSearchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetLastSearchedFunc) History() []CodeMonitorStoreGetLastSearchedFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetLastSearchedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetLastSearchedFuncCall is an object that describes an
+// invocation of method GetLastSearched on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetLastSearchedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetLastSearchedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetLastSearchedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetMonitorFunc describes the behavior when the GetMonitor
+// method of the parent MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreGetMonitorFunc struct {
+	defaultHook func(context.Context, int64) (*Monitor, error)
+	hooks       []func(context.Context, int64) (*Monitor, error)
+	history     []CodeMonitorStoreGetMonitorFuncCall
+	mutex       sync.Mutex
+}
+
+// GetMonitor delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetMonitor(v0 context.Context, v1 int64) (*Monitor, error) {
+	r0, r1 := m.GetMonitorFunc.nextHook()(v0, v1)
+	m.GetMonitorFunc.appendCall(CodeMonitorStoreGetMonitorFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetMonitor method of
+// the parent MockCodeMonitorStore instance is invoked and the hook queue is
 // empty.
 func (f *CodeMonitorStoreGetMonitorFunc) SetDefaultHook(hook func(context.Context, int64) (*Monitor, error)) {
 	f.defaultHook = hook
@@ -4903,36 +5640,37 @@ func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListMonitorsFunc describes the behavior when the
-// ListMonitors method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListIssueActionsFunc describes the behavior when the
+// ListIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreListMonitorsFunc struct {
-	defaultHook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
-	hooks       []func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
-	history     []CodeMonitorStoreListMonitorsFuncCall
+type CodeMonitorStoreListIssueActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	history     []CodeMonitorStoreListIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListMonitors delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListMonitors(v0 context.Context, v1 ListMonitorsOpts) ([]*Monitor, error) {
-	r0, r1 := m.ListMonitorsFunc.nextHook()(v0, v1)
-	m.ListMonitorsFunc.appendCall(CodeMonitorStoreListMonitorsFuncCall{v0, v1, r0, r1})
+// ListIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListIssueActions(v0 context.Context, v1 ListActionsOpts) ([]*IssueAction, error) {
+	r0, r1 := m.ListIssueActionsFunc.nextHook()(v0, v1)
+	m.ListIssueActionsFunc.appendCall(CodeMonitorStoreListIssueActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListMonitors method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+// SetDefaultHook sets function that is called when the ListIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListMonitors method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
+// ListIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListIssueActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4940,20 +5678,20 @@ func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, L
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultReturn(r0 []*Monitor, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultReturn(r0 []*IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListMonitorsFunc) PushReturn(r0 []*Monitor, r1 error) {
-	f.PushHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) PushReturn(r0 []*IssueAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4966,35 +5704,36 @@ func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, List
 	return hook
 }
 
-func (f *CodeMonitorStoreListMonitorsFunc) appendCall(r0 CodeMonitorStoreListMonitorsFuncCall) {
+func (f *CodeMonitorStoreListIssueActionsFunc) appendCall(r0 CodeMonitorStoreListIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListMonitorsFuncCall
+// History returns a sequence of CodeMonitorStoreListIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListMonitorsFunc) History() []CodeMonitorStoreListMonitorsFuncCall {
+func (f *CodeMonitorStoreListIssueActionsFunc) History() []CodeMonitorStoreListIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListMonitorsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListMonitorsFuncCall is an object that describes an
-// invocation of method ListMonitors on an instance of MockCodeMonitorStore.
-type CodeMonitorStoreListMonitorsFuncCall struct {
+// CodeMonitorStoreListIssueActionsFuncCall is an object that describes an
+// invocation of method ListIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListMonitorsOpts
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*Monitor
+	Result0 []*IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5002,47 +5741,47 @@ type CodeMonitorStoreListMonitorsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListMonitorsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListMonitorsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListQueryTriggerJobsFunc describes the behavior when the
-// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreListQueryTriggerJobsFunc struct {
-	defaultHook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
-	hooks       []func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
-	history     []CodeMonitorStoreListQueryTriggerJobsFuncCall
+// CodeMonitorStoreListLastMatchedFunc describes the behavior when the
+// ListLastMatched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListLastMatchedFunc struct {
+	defaultHook func(context.Context, int64) (map[api.RepoID][]string, error)
+	hooks       []func(context.Context, int64) (map[api.RepoID][]string, error)
+	history     []CodeMonitorStoreListLastMatchedFuncCall
 	mutex       sync.Mutex
 }
 
-// ListQueryTriggerJobs delegates to the next hook function in the queue and
+// ListLastMatched delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListQueryTriggerJobs(v0 context.Context, v1 ListTriggerJobsOpts) ([]*TriggerJob, error) {
-	r0, r1 := m.ListQueryTriggerJobsFunc.nextHook()(v0, v1)
-	m.ListQueryTriggerJobsFunc.appendCall(CodeMonitorStoreListQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) ListLastMatched(v0 context.Context, v1 int64) (map[api.RepoID][]string, error) {
+	r0, r1 := m.ListLastMatchedFunc.nextHook()(v0, v1)
+	m.ListLastMatchedFunc.appendCall(CodeMonitorStoreListLastMatchedFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListQueryTriggerJobs
+// SetDefaultHook sets function that is called when the ListLastMatched
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) SetDefaultHook(hook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)) {
+func (f *CodeMonitorStoreListLastMatchedFunc) SetDefaultHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// ListLastMatched method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) PushHook(hook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)) {
+func (f *CodeMonitorStoreListLastMatchedFunc) PushHook(hook func(context.Context, int64) (map[api.RepoID][]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5050,20 +5789,20 @@ func (f *CodeMonitorStoreListQueryTriggerJobsFunc) PushHook(hook func(context.Co
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) SetDefaultReturn(r0 []*TriggerJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+func (f *CodeMonitorStoreListLastMatchedFunc) SetDefaultReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) PushReturn(r0 []*TriggerJob, r1 error) {
-	f.PushHook(func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+func (f *CodeMonitorStoreListLastMatchedFunc) PushReturn(r0 map[api.RepoID][]string, r1 error) {
+	f.PushHook(func(context.Context, int64) (map[api.RepoID][]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) nextHook() func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+func (f *CodeMonitorStoreListLastMatchedFunc) nextHook() func(context.Context, int64) (map[api.RepoID][]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5076,37 +5815,36 @@ func (f *CodeMonitorStoreListQueryTriggerJobsFunc) nextHook() func(context.Conte
 	return hook
 }
 
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) appendCall(r0 CodeMonitorStoreListQueryTriggerJobsFuncCall) {
+func (f *CodeMonitorStoreListLastMatchedFunc) appendCall(r0 CodeMonitorStoreListLastMatchedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of
-// CodeMonitorStoreListQueryTriggerJobsFuncCall objects describing the
-// invocations of this function.
-func (f *CodeMonitorStoreListQueryTriggerJobsFunc) History() []CodeMonitorStoreListQueryTriggerJobsFuncCall {
+// History returns a sequence of CodeMonitorStoreListLastMatchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListLastMatchedFunc) History() []CodeMonitorStoreListLastMatchedFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListQueryTriggerJobsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListLastMatchedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListQueryTriggerJobsFuncCall is an object that describes
-// an invocation of method ListQueryTriggerJobs on an instance of
+// CodeMonitorStoreListLastMatchedFuncCall is an object that describes an
+// invocation of method ListLastMatched on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListQueryTriggerJobsFuncCall struct {
+type CodeMonitorStoreListLastMatchedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListTriggerJobsOpts
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*TriggerJob
+	Result0 map[api.RepoID][]string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5114,46 +5852,46 @@ type CodeMonitorStoreListQueryTriggerJobsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListQueryTriggerJobsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListLastMatchedFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListQueryTriggerJobsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListLastMatchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListRecipientsFunc describes the behavior when the
-// ListRecipients method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListMonitorsFunc describes the behavior when the
+// ListMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreListRecipientsFunc struct {
-	defaultHook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)
-	hooks       []func(context.Context, ListRecipientsOpts) ([]*Recipient, error)
-	history     []CodeMonitorStoreListRecipientsFuncCall
+type CodeMonitorStoreListMonitorsFunc struct {
+	defaultHook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
+	hooks       []func(context.Context, ListMonitorsOpts) ([]*Monitor, error)
+	history     []CodeMonitorStoreListMonitorsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListRecipients delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListRecipients(v0 context.Context, v1 ListRecipientsOpts) ([]*Recipient, error) {
-	r0, r1 := m.ListRecipientsFunc.nextHook()(v0, v1)
-	m.ListRecipientsFunc.appendCall(CodeMonitorStoreListRecipientsFuncCall{v0, v1, r0, r1})
+// ListMonitors delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListMonitors(v0 context.Context, v1 ListMonitorsOpts) ([]*Monitor, error) {
+	r0, r1 := m.ListMonitorsFunc.nextHook()(v0, v1)
+	m.ListMonitorsFunc.appendCall(CodeMonitorStoreListMonitorsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListRecipients
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreListRecipientsFunc) SetDefaultHook(hook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)) {
+// SetDefaultHook sets function that is called when the ListMonitors method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListRecipients method of the parent MockCodeMonitorStore instance invokes
+// ListMonitors method of the parent MockCodeMonitorStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreListRecipientsFunc) PushHook(hook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)) {
+func (f *CodeMonitorStoreListMonitorsFunc) PushHook(hook func(context.Context, ListMonitorsOpts) ([]*Monitor, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5161,20 +5899,20 @@ func (f *CodeMonitorStoreListRecipientsFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListRecipientsFunc) SetDefaultReturn(r0 []*Recipient, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+func (f *CodeMonitorStoreListMonitorsFunc) SetDefaultReturn(r0 []*Monitor, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListRecipientsFunc) PushReturn(r0 []*Recipient, r1 error) {
-	f.PushHook(func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+func (f *CodeMonitorStoreListMonitorsFunc) PushReturn(r0 []*Monitor, r1 error) {
+	f.PushHook(func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListRecipientsFunc) nextHook() func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+func (f *CodeMonitorStoreListMonitorsFunc) nextHook() func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5187,36 +5925,257 @@ func (f *CodeMonitorStoreListRecipientsFunc) nextHook() func(context.Context, Li
 	return hook
 }
 
-func (f *CodeMonitorStoreListRecipientsFunc) appendCall(r0 CodeMonitorStoreListRecipientsFuncCall) {
+func (f *CodeMonitorStoreListMonitorsFunc) appendCall(r0 CodeMonitorStoreListMonitorsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListRecipientsFuncCall
+// History returns a sequence of CodeMonitorStoreListMonitorsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListRecipientsFunc) History() []CodeMonitorStoreListRecipientsFuncCall {
+func (f *CodeMonitorStoreListMonitorsFunc) History() []CodeMonitorStoreListMonitorsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListRecipientsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListMonitorsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListRecipientsFuncCall is an object that describes an
-// invocation of method ListRecipients on an instance of
-// MockCodeMonitorStore.
-type CodeMonitorStoreListRecipientsFuncCall struct {
+// CodeMonitorStoreListMonitorsFuncCall is an object that describes an
+// invocation of method ListMonitors on an instance of MockCodeMonitorStore.
+type CodeMonitorStoreListMonitorsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListRecipientsOpts
+	Arg1 ListMonitorsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*Recipient
+	Result0 []*Monitor
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListMonitorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListMonitorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListQueryTriggerJobsFunc describes the behavior when the
+// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreListQueryTriggerJobsFunc struct {
+	defaultHook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
+	hooks       []func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)
+	history     []CodeMonitorStoreListQueryTriggerJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListQueryTriggerJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListQueryTriggerJobs(v0 context.Context, v1 ListTriggerJobsOpts) ([]*TriggerJob, error) {
+	r0, r1 := m.ListQueryTriggerJobsFunc.nextHook()(v0, v1)
+	m.ListQueryTriggerJobsFunc.appendCall(CodeMonitorStoreListQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListQueryTriggerJobs
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) SetDefaultHook(hook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) PushHook(hook func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) SetDefaultReturn(r0 []*TriggerJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) PushReturn(r0 []*TriggerJob, r1 error) {
+	f.PushHook(func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) nextHook() func(context.Context, ListTriggerJobsOpts) ([]*TriggerJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) appendCall(r0 CodeMonitorStoreListQueryTriggerJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreListQueryTriggerJobsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListQueryTriggerJobsFunc) History() []CodeMonitorStoreListQueryTriggerJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListQueryTriggerJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListQueryTriggerJobsFuncCall is an object that describes
+// an invocation of method ListQueryTriggerJobs on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListQueryTriggerJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListTriggerJobsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*TriggerJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListQueryTriggerJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListQueryTriggerJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListRecipientsFunc describes the behavior when the
+// ListRecipients method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListRecipientsFunc struct {
+	defaultHook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)
+	hooks       []func(context.Context, ListRecipientsOpts) ([]*Recipient, error)
+	history     []CodeMonitorStoreListRecipientsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListRecipients delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListRecipients(v0 context.Context, v1 ListRecipientsOpts) ([]*Recipient, error) {
+	r0, r1 := m.ListRecipientsFunc.nextHook()(v0, v1)
+	m.ListRecipientsFunc.appendCall(CodeMonitorStoreListRecipientsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListRecipients
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListRecipientsFunc) SetDefaultHook(hook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListRecipients method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreListRecipientsFunc) PushHook(hook func(context.Context, ListRecipientsOpts) ([]*Recipient, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListRecipientsFunc) SetDefaultReturn(r0 []*Recipient, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListRecipientsFunc) PushReturn(r0 []*Recipient, r1 error) {
+	f.PushHook(func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListRecipientsFunc) nextHook() func(context.Context, ListRecipientsOpts) ([]*Recipient, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListRecipientsFunc) appendCall(r0 CodeMonitorStoreListRecipientsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListRecipientsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListRecipientsFunc) History() []CodeMonitorStoreListRecipientsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListRecipientsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListRecipientsFuncCall is an object that describes an
+// invocation of method ListRecipients on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListRecipientsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListRecipientsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*Recipient
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -5665,6 +6624,120 @@ func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interfa
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreSetIssueActionIssueFunc describes the behavior when the
+// SetIssueActionIssue method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreSetIssueActionIssueFunc struct {
+	defaultHook func(context.Context, int64, int32, string) error
+	hooks       []func(context.Context, int64, int32, string) error
+	history     []CodeMonitorStoreSetIssueActionIssueFuncCall
+	mutex       sync.Mutex
+}
+
+// SetIssueActionIssue delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) SetIssueActionIssue(v0 context.Context, v1 int64, v2 int32, v3 string) error {
+	r0 := m.SetIssueActionIssueFunc.nextHook()(v0, v1, v2, v3)
+	m.SetIssueActionIssueFunc.appendCall(CodeMonitorStoreSetIssueActionIssueFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetIssueActionIssue
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) SetDefaultHook(hook func(context.Context, int64, int32, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetIssueActionIssue method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) PushHook(hook func(context.Context, int64, int32, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int32, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int32, string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) nextHook() func(context.Context, int64, int32, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) appendCall(r0 CodeMonitorStoreSetIssueActionIssueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreSetIssueActionIssueFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreSetIssueActionIssueFunc) History() []CodeMonitorStoreSetIssueActionIssueFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreSetIssueActionIssueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreSetIssueActionIssueFuncCall is an object that describes
+// an invocation of method SetIssueActionIssue on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreSetIssueActionIssueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreSetIssueActionIssueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreSetIssueActionIssueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreSetQueryTriggerNextRunFunc describes the behavior when
 // the SetQueryTriggerNextRun method of the parent MockCodeMonitorStore
 // instance is invoked.
@@ -5999,6 +7072,126 @@ func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateIssueActionFunc describes the behavior when the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	hooks       []func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 api.RepoID) (*IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpdateIssueActionFunc.appendCall(CodeMonitorStoreUpdateIssueActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushHook(hook func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) nextHook() func(context.Context, int64, bool, bool, api.RepoID) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) History() []CodeMonitorStoreUpdateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateIssueActionFuncCall is an object that describes an
+// invocation of method UpdateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 bool
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateMonitorFunc describes the behavior when the
 // UpdateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6694,6 +7887,120 @@ func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpsertLastMatchedFunc describes the behavior when the
+// UpsertLastMatched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpsertLastMatchedFunc struct {
+	defaultHook func(context.Context, int64, api.RepoID, []string) error
+	hooks       []func(context.Context, int64, api.RepoID, []string) error
+	history     []CodeMonitorStoreUpsertLastMatchedFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertLastMatched delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertLastMatched(v0 context.Context, v1 int64, v2 api.RepoID, v3 []string) error {
+	r0 := m.UpsertLastMatchedFunc.nextHook()(v0, v1, v2, v3)
+	m.UpsertLastMatchedFunc.appendCall(CodeMonitorStoreUpsertLastMatchedFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpsertLastMatched
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) SetDefaultHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertLastMatched method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) PushHook(hook func(context.Context, int64, api.RepoID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, api.RepoID, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) nextHook() func(context.Context, int64, api.RepoID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) appendCall(r0 CodeMonitorStoreUpsertLastMatchedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpsertLastMatchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpsertLastMatchedFunc) History() []CodeMonitorStoreUpsertLastMatchedFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertLastMatchedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertLastMatchedFuncCall is an object that describes an
+// invocation of method UpsertLastMatched on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpsertLastMatchedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastMatchedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertLastMatchedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpsertLastSearchedFunc describes the behavior when the
 // UpsertLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
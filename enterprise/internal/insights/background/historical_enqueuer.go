@@ -362,10 +362,17 @@ func (h *historicalEnqueuer) Handler(ctx context.Context) error {
 
 	// Discover all global insights on the instance.
 	h.logger.Debug("Fetching data series for historical")
-	foundInsights, err := h.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{BackfillNotQueued: true, GlobalOnly: true})
+	allInsights, err := h.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{BackfillNotQueued: true, GlobalOnly: true})
 	if err != nil {
 		return errors.Wrap(err, "Discover")
 	}
+	// Series counting precise references are backfilled from historical uploads instead of searches.
+	foundInsights := make([]itypes.InsightSeries, 0, len(allInsights))
+	for _, series := range allInsights {
+		if series.GenerationMethod != itypes.PreciseReferences {
+			foundInsights = append(foundInsights, series)
+		}
+	}
 
 	for _, series := range foundInsights {
 		h.statistics[series.SeriesID] = &repoBackfillStatistics{}
@@ -141,15 +141,20 @@ func (ie *InsightEnqueuer) EnqueueSingle(
 	var modifiedQuery querybuilder.BasicQuery
 	var finalQuery string
 
-	if len(series.Repositories) > 0 {
-		modifiedQuery, err = querybuilder.MultiRepoQuery(basicQuery, series.Repositories, defaultQueryParams)
+	if series.GenerationMethod == types.PreciseReferences {
+		// Precise references queries aren't search queries, the handler reads the moniker from the series.
+		finalQuery = series.Query
 	} else {
-		modifiedQuery, err = querybuilder.GlobalQuery(basicQuery, defaultQueryParams)
-	}
-	if err != nil {
-		return errors.Wrapf(err, "GlobalQuery series_id:%s", seriesID)
+		if len(series.Repositories) > 0 {
+			modifiedQuery, err = querybuilder.MultiRepoQuery(basicQuery, series.Repositories, defaultQueryParams)
+		} else {
+			modifiedQuery, err = querybuilder.GlobalQuery(basicQuery, defaultQueryParams)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "GlobalQuery series_id:%s", seriesID)
+		}
+		finalQuery = modifiedQuery.String()
 	}
-	finalQuery = modifiedQuery.String()
 	if series.GroupBy != nil {
 		computeQuery, err := querybuilder.ComputeInsightCommandQuery(modifiedQuery, querybuilder.MapType(*series.GroupBy))
 		if err != nil {
@@ -0,0 +1,87 @@
+package background
+
+import (
+	"context"
+	"time"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background/queryrunner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/timeseries"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewPreciseReferencesBackfiller periodically backfills the series that count precise references. Instead of
+// searching historical commits, each point is computed from the most recent uploads for commits made before the
+// point, so data is only available as far back as uploads are retained.
+func NewPreciseReferencesBackfiller(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB, counter queryrunner.ReferenceCounter) goroutine.BackgroundRoutine {
+	interval := time.Minute * 5
+	insightStore := store.NewInsightStore(insightsdb)
+	timeseriesStore := store.New(insightsdb, store.NewInsightPermissionStore(postgres))
+
+	return goroutine.NewPeriodicGoroutine(ctx, interval,
+		goroutine.NewHandlerWithErrorMessage("insights_precise_references_backfiller", func(ctx context.Context) (err error) {
+			return backfillPreciseReferences(ctx, insightStore, timeseriesStore, counter)
+		}))
+}
+
+func backfillPreciseReferences(ctx context.Context, insightStore *store.InsightStore, timeseriesStore *store.Store, counter queryrunner.ReferenceCounter) (err error) {
+	// 🚨 SECURITY: References are counted in every repository on Sourcegraph - points will be filtered when users
+	// query for insight data based on the repositories they can see.
+	ctx = actor.WithInternalActor(ctx)
+
+	series, err := insightStore.GetDataSeries(ctx, store.GetDataSeriesArgs{BackfillNotQueued: true})
+	if err != nil {
+		return errors.Wrap(err, "GetDataSeries")
+	}
+	for _, s := range series {
+		if s.GenerationMethod != types.PreciseReferences {
+			continue
+		}
+		if seriesErr := backfillPreciseReferencesSeries(ctx, insightStore, timeseriesStore, counter, s); seriesErr != nil {
+			err = errors.Append(err, errors.Wrapf(seriesErr, "series_id: %s", s.SeriesID))
+		}
+	}
+	return err
+}
+
+func backfillPreciseReferencesSeries(ctx context.Context, insightStore *store.InsightStore, timeseriesStore *store.Store, counter queryrunner.ReferenceCounter, series types.InsightSeries) error {
+	frames := timeseries.BuildFrames(12, timeseries.TimeInterval{
+		Unit:  types.IntervalUnit(series.SampleIntervalUnit),
+		Value: series.SampleIntervalValue,
+	}, series.CreatedAt.Truncate(time.Hour*24))
+
+	var recordings []store.RecordSeriesPointArgs
+	for _, frame := range frames {
+		recordTime := frame.From
+		job := &queryrunner.SearchJob{
+			SeriesID:    series.SeriesID,
+			SearchQuery: series.Query,
+			RecordTime:  &recordTime,
+			PersistMode: string(store.RecordMode),
+		}
+		points, err := queryrunner.GeneratePreciseReferencesRecordings(ctx, counter, job, &series, recordTime, &recordTime)
+		if err != nil {
+			return errors.Wrapf(err, "recording %s", recordTime)
+		}
+		recordings = append(recordings, points...)
+	}
+
+	if err := timeseriesStore.RecordSeriesPointsAndRecordingTimes(ctx, recordings, types.InsightSeriesRecordingTimes{
+		InsightSeriesID: series.ID,
+		RecordingTimes:  timeseries.MakeRecordingsFromFrames(frames, false),
+	}); err != nil {
+		return errors.Wrap(err, "RecordSeriesPointsAndRecordingTimes")
+	}
+
+	// The backfill is complete as soon as it is queued since there is no search work left to do.
+	series, err := insightStore.StampBackfill(ctx, series)
+	if err != nil {
+		return errors.Wrap(err, "StampBackfill")
+	}
+	return insightStore.SetSeriesBackfillComplete(ctx, series.SeriesID, series.BackfillQueuedAt)
+}
@@ -0,0 +1,192 @@
+package queryrunner
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	defaultBreakdownSeriesLimit = 20
+
+	// otherBreakdownValue is the value results are recorded under once the series limit of a breakdown is reached.
+	otherBreakdownValue = "Other"
+	// unownedBreakdownValue is the value results in files without a code owner are recorded under. It can't be
+	// mistaken for an owner since owners are either handles starting with '@' or email addresses.
+	unownedBreakdownValue = "Unowned"
+)
+
+type streamFileProvider func(context.Context, string) (*streaming.FileTabulationResult, error)
+
+// breakdownValueStore fixes the values the results of a breakdown series are recorded under.
+type breakdownValueStore interface {
+	AddSeriesBreakdownValues(ctx context.Context, id int, values []string, limit int) ([]string, error)
+}
+
+func makeAuthorBreakdownHandler(provider streamComputeProvider, values breakdownValueStore) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		streamResults, err := provider(ctx, job.SearchQuery)
+		if err != nil {
+			return nil, errors.Wrap(err, "authorBreakdownHandler")
+		}
+		if len(streamResults.Errors) > 0 {
+			return nil, errors.Wrap(classifiedError(streamResults.Errors, types.Search), "authorBreakdownHandler")
+		}
+		if len(streamResults.Alerts) > 0 {
+			return nil, errors.Errorf("authorBreakdownHandler: streaming search: alerts: %v", streamResults.Alerts)
+		}
+		return generateBreakdownRecordings(ctx, job, series, recordTime, streamResults.RepoCounts, values, breakdownSeriesLimit())
+	}
+}
+
+func makeCodeOwnerBreakdownHandler(provider streamFileProvider, values breakdownValueStore, client gitserver.Client) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		streamResults, err := provider(ctx, job.SearchQuery)
+		if err != nil {
+			return nil, errors.Wrap(err, "codeOwnerBreakdownHandler")
+		}
+		if len(streamResults.Errors) > 0 {
+			return nil, errors.Wrap(classifiedError(streamResults.Errors, types.Search), "codeOwnerBreakdownHandler")
+		}
+		if len(streamResults.Alerts) > 0 {
+			return nil, errors.Errorf("codeOwnerBreakdownHandler: streaming search: alerts: %v", streamResults.Alerts)
+		}
+		repoCounts, err := countByCodeOwner(ctx, codeowners.NewRulesetCache(client), streamResults.Files)
+		if err != nil {
+			return nil, errors.Wrap(err, "codeOwnerBreakdownHandler")
+		}
+		return generateBreakdownRecordings(ctx, job, series, recordTime, repoCounts, values, breakdownSeriesLimit())
+	}
+}
+
+type rulesetGetter interface {
+	Get(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error)
+}
+
+// countByCodeOwner tabulates the match counts of files per repository and owner of the file. A file with many
+// owners counts towards each of them, and files without an owner are counted as unowned.
+func countByCodeOwner(ctx context.Context, rulesets rulesetGetter, files []streaming.FileMatch) (map[string]*streaming.ComputeMatch, error) {
+	repoCounts := make(map[string]*streaming.ComputeMatch)
+	for _, file := range files {
+		commit := api.CommitID(file.Commit)
+		if commit == "" {
+			commit = "HEAD"
+		}
+		rs, err := rulesets.Get(ctx, api.RepoName(file.RepositoryName), commit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving code owners of %s", file.RepositoryName)
+		}
+
+		current, ok := repoCounts[file.RepositoryName]
+		if !ok {
+			current = &streaming.ComputeMatch{
+				RepositoryID:   file.RepositoryID,
+				RepositoryName: file.RepositoryName,
+				ValueCounts:    make(map[string]int),
+			}
+			repoCounts[file.RepositoryName] = current
+		}
+
+		owners := rs.FindOwners(file.Path)
+		if len(owners) == 0 {
+			current.ValueCounts[unownedBreakdownValue] += file.MatchCount
+			continue
+		}
+		for _, owner := range owners {
+			current.ValueCounts[owner] += file.MatchCount
+		}
+	}
+	return repoCounts, nil
+}
+
+// generateBreakdownRecordings records one point per repository and broken down value. A series records at most limit
+// values, fixed as they are first recorded so that every point in time of the series, whether it was backfilled or
+// recorded later, is broken down the same way. The results of the remaining values are recorded as a single other
+// value.
+func generateBreakdownRecordings(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time, repoCounts map[string]*streaming.ComputeMatch, values breakdownValueStore, limit int) (_ []store.RecordSeriesPointArgs, err error) {
+	kept, err := values.AddSeriesBreakdownValues(ctx, series.ID, rankBreakdownValues(repoCounts), limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddSeriesBreakdownValues")
+	}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+	for _, match := range limitBreakdown(repoCounts, kept) {
+		// sub-repo permissions filtering. If the repo supports it, then it should be excluded from search results
+		var subRepoEnabled bool
+		repoID := api.RepoID(match.RepositoryID)
+		subRepoEnabled, err = checkSubRepoPermissions(ctx, checker, repoID, err)
+		if subRepoEnabled {
+			continue
+		}
+		for value, count := range match.ValueCounts {
+			value := value
+			recordings = append(recordings, toRecording(job, float64(count), recordTime, match.RepositoryName, repoID, &value)...)
+		}
+	}
+	return recordings, nil
+}
+
+// rankBreakdownValues returns the values of the given counts ordered by their results across all repositories, most
+// results first.
+func rankBreakdownValues(repoCounts map[string]*streaming.ComputeMatch) []string {
+	totals := make(map[string]int)
+	for _, match := range repoCounts {
+		for value, count := range match.ValueCounts {
+			totals[value] += count
+		}
+	}
+	ranked := make([]string, 0, len(totals))
+	for value := range totals {
+		ranked = append(ranked, value)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if totals[ranked[i]] != totals[ranked[j]] {
+			return totals[ranked[i]] > totals[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked
+}
+
+// limitBreakdown returns the given counts with all values but the kept ones merged into otherBreakdownValue.
+func limitBreakdown(repoCounts map[string]*streaming.ComputeMatch, kept []string) []*streaming.ComputeMatch {
+	keep := make(map[string]struct{}, len(kept))
+	for _, value := range kept {
+		keep[value] = struct{}{}
+	}
+
+	limited := make([]*streaming.ComputeMatch, 0, len(repoCounts))
+	for _, match := range repoCounts {
+		valueCounts := make(map[string]int, len(match.ValueCounts))
+		for value, count := range match.ValueCounts {
+			if _, ok := keep[value]; !ok {
+				value = otherBreakdownValue
+			}
+			valueCounts[value] += count
+		}
+		limited = append(limited, &streaming.ComputeMatch{
+			RepositoryID:   match.RepositoryID,
+			RepositoryName: match.RepositoryName,
+			ValueCounts:    valueCounts,
+		})
+	}
+	return limited
+}
+
+func breakdownSeriesLimit() int {
+	if limit := conf.Get().InsightsBreakdownsSeriesLimit; limit > 0 {
+		return limit
+	}
+	return defaultBreakdownSeriesLimit
+}
@@ -0,0 +1,183 @@
+package queryrunner
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+)
+
+func TestAuthorBreakdownHandler(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{SeriesID: "testseries1", SearchQuery: "type:diff TODO", RecordTime: &date, PersistMode: "record"}
+
+	provider := func(ctx context.Context, query string) (*streaming.ComputeTabulationResult, error) {
+		return &streaming.ComputeTabulationResult{
+			RepoCounts: map[string]*streaming.ComputeMatch{
+				"github.com/sourcegraph/sourcegraph": {
+					RepositoryID:   11,
+					RepositoryName: "github.com/sourcegraph/sourcegraph",
+					ValueCounts:    map[string]int{"alice": 3, "bob": 1},
+				},
+				"github.com/sourcegraph/zoekt": {
+					RepositoryID:   12,
+					RepositoryName: "github.com/sourcegraph/zoekt",
+					ValueCounts:    map[string]int{"bob": 2},
+				},
+			},
+		}, nil
+	}
+
+	handler := makeAuthorBreakdownHandler(provider, fakeBreakdownValueStore{})
+	recordings, err := handler(context.Background(), &job, &types.InsightSeries{ID: 1, SeriesID: "testseries1"}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("author breakdown recordings", []string{
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC alice 3.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC bob 1.000000",
+		"github.com/sourcegraph/zoekt 12 2021-12-01 00:00:00 +0000 UTC bob 2.000000",
+	}).Equal(t, stringify(recordings))
+}
+
+// fakeBreakdownValueStore keeps the breakdown values of series in memory, by series ID.
+type fakeBreakdownValueStore map[int][]string
+
+func (f fakeBreakdownValueStore) AddSeriesBreakdownValues(_ context.Context, id int, values []string, limit int) ([]string, error) {
+	current := f[id]
+	for _, value := range values {
+		if len(current) >= limit {
+			break
+		}
+		found := false
+		for _, c := range current {
+			found = found || c == value
+		}
+		if !found {
+			current = append(current, value)
+		}
+	}
+	f[id] = current
+	return current, nil
+}
+
+type rulesetGetterFunc func(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error)
+
+func (f rulesetGetterFunc) Get(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	return f(ctx, repo, commit)
+}
+
+func TestCountByCodeOwner(t *testing.T) {
+	rs, err := codeowners.Parse(strings.NewReader("* @sourcegraph/everyone\n/enterprise/ @sourcegraph/enterprise alice@example.com\n/docs/\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var commits []api.CommitID
+	rulesets := rulesetGetterFunc(func(_ context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+		commits = append(commits, commit)
+		if repo == "github.com/sourcegraph/zoekt" {
+			// Repositories without a CODEOWNERS file have a nil ruleset.
+			return nil, nil
+		}
+		return rs, nil
+	})
+
+	files := []streaming.FileMatch{
+		{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "deadbeef", Path: "cmd/main.go", MatchCount: 2},
+		{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "deadbeef", Path: "enterprise/main.go", MatchCount: 3},
+		{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Commit: "deadbeef", Path: "docs/index.md", MatchCount: 1},
+		{RepositoryID: 12, RepositoryName: "github.com/sourcegraph/zoekt", Path: "main.go", MatchCount: 4},
+	}
+	repoCounts, err := countByCodeOwner(context.Background(), rulesets, files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	autogold.Want("code owner counts", map[string]*streaming.ComputeMatch{
+		"github.com/sourcegraph/sourcegraph": {
+			RepositoryID:   11,
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			ValueCounts: map[string]int{
+				"@sourcegraph/enterprise": 3,
+				"@sourcegraph/everyone":   2,
+				"Unowned":                 1,
+				"alice@example.com":       3,
+			},
+		},
+		"github.com/sourcegraph/zoekt": {
+			RepositoryID:   12,
+			RepositoryName: "github.com/sourcegraph/zoekt",
+			ValueCounts:    map[string]int{"Unowned": 4},
+		},
+	}).Equal(t, repoCounts)
+	autogold.Want("code owner commits", []api.CommitID{"deadbeef", "deadbeef", "deadbeef", "HEAD"}).Equal(t, commits)
+}
+
+func TestGenerateBreakdownRecordingsLimit(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dependent := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{SeriesID: "testseries1", RecordTime: &date, PersistMode: "record", DependentFrames: []time.Time{dependent}}
+
+	repoCounts := map[string]*streaming.ComputeMatch{
+		"github.com/sourcegraph/sourcegraph": {
+			RepositoryID:   11,
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			ValueCounts:    map[string]int{"alice": 10, "bob": 1, "carol": 2},
+		},
+		"github.com/sourcegraph/zoekt": {
+			RepositoryID:   12,
+			RepositoryName: "github.com/sourcegraph/zoekt",
+			ValueCounts:    map[string]int{"bob": 5, "dave": 1},
+		},
+	}
+
+	// alice (10) and bob (6) are kept, carol and dave are merged into the other value.
+	series := &types.InsightSeries{ID: 1, SeriesID: "testseries1"}
+	values := fakeBreakdownValueStore{}
+	recordings, err := generateBreakdownRecordings(context.Background(), &job, series, date, repoCounts, values, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("limited breakdown recordings", []string{
+		"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC Other 2.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC alice 10.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC bob 1.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC Other 2.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC alice 10.000000",
+		"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC bob 1.000000",
+		"github.com/sourcegraph/zoekt 12 2021-11-01 00:00:00 +0000 UTC Other 1.000000",
+		"github.com/sourcegraph/zoekt 12 2021-11-01 00:00:00 +0000 UTC bob 5.000000",
+		"github.com/sourcegraph/zoekt 12 2021-12-01 00:00:00 +0000 UTC Other 1.000000",
+		"github.com/sourcegraph/zoekt 12 2021-12-01 00:00:00 +0000 UTC bob 5.000000",
+	}).Equal(t, stringify(recordings))
+
+	// The counts of the search are left untouched.
+	autogold.Want("unlimited counts", map[string]int{"alice": 10, "bob": 1, "carol": 2}).Equal(t, repoCounts["github.com/sourcegraph/sourcegraph"].ValueCounts)
+
+	// Later recordings keep the values of the first one, even once other values have more results.
+	later := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	laterJob := SearchJob{SeriesID: "testseries1", RecordTime: &later, PersistMode: "record"}
+	laterCounts := map[string]*streaming.ComputeMatch{
+		"github.com/sourcegraph/sourcegraph": {
+			RepositoryID:   11,
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			ValueCounts:    map[string]int{"alice": 1, "carol": 20},
+		},
+	}
+	recordings, err = generateBreakdownRecordings(context.Background(), &laterJob, series, later, laterCounts, values, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("later breakdown recordings", []string{
+		"github.com/sourcegraph/sourcegraph 11 2022-01-01 00:00:00 +0000 UTC Other 20.000000",
+		"github.com/sourcegraph/sourcegraph 11 2022-01-01 00:00:00 +0000 UTC alice 1.000000",
+	}).Equal(t, stringify(recordings))
+}
@@ -0,0 +1,84 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	codenavshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ReferenceCounter counts the precise references to a moniker in each repository. It is implemented by the
+// codenav service.
+type ReferenceCounter interface {
+	GetReferenceCountsForMoniker(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) ([]codenavshared.RepositoryReferenceCount, error)
+}
+
+func makePreciseReferencesHandler(counter ReferenceCounter) InsightsHandler {
+	return func(ctx context.Context, job *SearchJob, series *types.InsightSeries, recordTime time.Time) ([]store.RecordSeriesPointArgs, error) {
+		recordings, err := GeneratePreciseReferencesRecordings(ctx, counter, job, series, recordTime, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "preciseReferencesHandler")
+		}
+		return recordings, nil
+	}
+}
+
+// GeneratePreciseReferencesRecordings returns the number of precise references to the symbol of the series in
+// each repository. If asOf is nil, the uploads at the tip of the default branch of each repository are counted.
+// Otherwise, the most recent uploads for commits made at or before asOf are counted.
+func GeneratePreciseReferencesRecordings(ctx context.Context, counter ReferenceCounter, job *SearchJob, series *types.InsightSeries, recordTime time.Time, asOf *time.Time) (_ []store.RecordSeriesPointArgs, err error) {
+	q, err := types.ParsePreciseReferencesQuery(series.Query)
+	if err != nil {
+		return nil, err
+	}
+	moniker := precise.QualifiedMonikerData{
+		MonikerData: precise.MonikerData{
+			Scheme:     q.Scheme,
+			Identifier: q.Identifier,
+		},
+		PackageInformationData: precise.PackageInformationData{
+			Name:    q.PackageName,
+			Version: q.PackageVersion,
+		},
+	}
+
+	counts, err := counter.GetReferenceCountsForMoniker(ctx, moniker, asOf)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetReferenceCountsForMoniker")
+	}
+
+	// Repository scoped series only count the references in their repositories.
+	var scoped map[string]struct{}
+	if len(series.Repositories) > 0 {
+		scoped = make(map[string]struct{}, len(series.Repositories))
+		for _, repo := range series.Repositories {
+			scoped[repo] = struct{}{}
+		}
+	}
+
+	checker := authz.DefaultSubRepoPermsChecker
+	var recordings []store.RecordSeriesPointArgs
+	for _, count := range counts {
+		if scoped != nil {
+			if _, ok := scoped[count.RepositoryName]; !ok {
+				continue
+			}
+		}
+
+		// sub-repo permissions filtering. If the repo supports it, then it should be excluded from the counts
+		var subRepoEnabled bool
+		repoID := api.RepoID(count.RepositoryID)
+		subRepoEnabled, err = checkSubRepoPermissions(ctx, checker, repoID, err)
+		if subRepoEnabled {
+			continue
+		}
+		recordings = append(recordings, toRecording(job, float64(count.Count), recordTime, count.RepositoryName, repoID, nil)...)
+	}
+	return recordings, nil
+}
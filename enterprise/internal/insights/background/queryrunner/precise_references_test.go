@@ -0,0 +1,94 @@
+package queryrunner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold"
+
+	codenavshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+type referenceCounterFunc func(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) ([]codenavshared.RepositoryReferenceCount, error)
+
+func (f referenceCounterFunc) GetReferenceCountsForMoniker(ctx context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) ([]codenavshared.RepositoryReferenceCount, error) {
+	return f(ctx, moniker, asOf)
+}
+
+func TestGeneratePreciseReferencesRecordings(t *testing.T) {
+	date := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	dependent := time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC)
+	job := SearchJob{
+		SeriesID:        "testseries1",
+		RecordTime:      &date,
+		PersistMode:     "record",
+		DependentFrames: []time.Time{dependent},
+	}
+
+	var gotMoniker precise.QualifiedMonikerData
+	var gotAsOf *time.Time
+	counter := referenceCounterFunc(func(_ context.Context, moniker precise.QualifiedMonikerData, asOf *time.Time) ([]codenavshared.RepositoryReferenceCount, error) {
+		gotMoniker, gotAsOf = moniker, asOf
+		return []codenavshared.RepositoryReferenceCount{
+			{RepositoryID: 11, RepositoryName: "github.com/sourcegraph/sourcegraph", Count: 12},
+			{RepositoryID: 12, RepositoryName: "github.com/sourcegraph/zoekt", Count: 3},
+		}, nil
+	})
+
+	t.Run("global series", func(t *testing.T) {
+		series := types.InsightSeries{
+			SeriesID: "testseries1",
+			Query:    "scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap",
+		}
+		recordings, err := GeneratePreciseReferencesRecordings(context.Background(), counter, &job, &series, date, &date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("precise references global series", []string{
+			"github.com/sourcegraph/sourcegraph 11 2021-11-01 00:00:00 +0000 UTC  12.000000",
+			"github.com/sourcegraph/sourcegraph 11 2021-12-01 00:00:00 +0000 UTC  12.000000",
+			"github.com/sourcegraph/zoekt 12 2021-11-01 00:00:00 +0000 UTC  3.000000",
+			"github.com/sourcegraph/zoekt 12 2021-12-01 00:00:00 +0000 UTC  3.000000",
+		}).Equal(t, stringify(recordings))
+
+		autogold.Want("precise references moniker", precise.QualifiedMonikerData{
+			MonikerData: precise.MonikerData{
+				Scheme:     "gomod",
+				Identifier: "github.com/sourcegraph/sourcegraph/lib/errors:Wrap",
+			},
+			PackageInformationData: precise.PackageInformationData{Name: "github.com/sourcegraph/sourcegraph"},
+		}).Equal(t, gotMoniker)
+		if gotAsOf == nil || !gotAsOf.Equal(date) {
+			t.Fatalf("unexpected asOf, want=%s have=%v", date, gotAsOf)
+		}
+	})
+
+	t.Run("repository scoped series", func(t *testing.T) {
+		series := types.InsightSeries{
+			SeriesID:     "testseries1",
+			Query:        "scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap",
+			Repositories: []string{"github.com/sourcegraph/zoekt"},
+		}
+		recordings, err := GeneratePreciseReferencesRecordings(context.Background(), counter, &job, &series, date, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("precise references scoped series", []string{
+			"github.com/sourcegraph/zoekt 12 2021-11-01 00:00:00 +0000 UTC  3.000000",
+			"github.com/sourcegraph/zoekt 12 2021-12-01 00:00:00 +0000 UTC  3.000000",
+		}).Equal(t, stringify(recordings))
+		if gotAsOf != nil {
+			t.Fatalf("unexpected asOf, want=nil have=%v", gotAsOf)
+		}
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		series := types.InsightSeries{SeriesID: "testseries1", Query: "TODO"}
+		if _, err := GeneratePreciseReferencesRecordings(context.Background(), counter, &job, &series, date, nil); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
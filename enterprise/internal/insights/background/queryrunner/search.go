@@ -14,10 +14,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
+func GetSearchHandlers(insightStore *store.InsightStore, gitserverClient gitserver.Client) map[types.GenerationMethod]InsightsHandler {
 
 	searchStream := func(ctx context.Context, query string) (*streaming.TabulationResult, error) {
 		decoder, streamResults := streaming.TabulationDecoder()
@@ -46,10 +47,30 @@ func GetSearchHandlers() map[types.GenerationMethod]InsightsHandler {
 		return streamResults, nil
 	}
 
+	authorSearchStream := func(ctx context.Context, query string) (*streaming.ComputeTabulationResult, error) {
+		decoder, streamResults := streaming.AuthorTabulationDecoder()
+		err := streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		return streamResults, nil
+	}
+
+	fileSearchStream := func(ctx context.Context, query string) (*streaming.FileTabulationResult, error) {
+		decoder, streamResults := streaming.FileTabulationDecoder()
+		err := streaming.Search(ctx, query, nil, decoder)
+		if err != nil {
+			return nil, errors.Wrap(err, "streaming.Search")
+		}
+		return streamResults, nil
+	}
+
 	return map[types.GenerationMethod]InsightsHandler{
-		types.MappingCompute: makeMappingComputeHandler(computeTextExtraSearch),
-		types.SearchCompute:  makeComputeHandler(computeSearchStream),
-		types.Search:         makeSearchHandler(searchStream),
+		types.MappingCompute:           makeMappingComputeHandler(computeTextExtraSearch),
+		types.SearchCompute:            makeComputeHandler(computeSearchStream),
+		types.Search:                   makeSearchHandler(searchStream),
+		types.SearchAuthorBreakdown:    makeAuthorBreakdownHandler(authorSearchStream, insightStore),
+		types.SearchCodeOwnerBreakdown: makeCodeOwnerBreakdownHandler(fileSearchStream, insightStore, gitserverClient),
 	}
 
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -35,8 +36,9 @@ import (
 //
 
 // NewWorker returns a worker that will execute search queries and insert information about the
-// results into the code insights database.
-func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store, insightsStore *store.Store, repoStore discovery.RepoStore, metrics workerutil.WorkerObservability, limiter *ratelimit.InstrumentedLimiter) *workerutil.Worker {
+// results into the code insights database. Series counting precise references are only recorded
+// if a reference counter is given.
+func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore.Store, insightsStore *store.Store, repoStore discovery.RepoStore, gitserverClient gitserver.Client, referenceCounter ReferenceCounter, metrics workerutil.WorkerObservability, limiter *ratelimit.InstrumentedLimiter) *workerutil.Worker {
 	numHandlers := conf.Get().InsightsQueryWorkerConcurrency
 	if numHandlers <= 0 {
 		// Default concurrency is set to 5.
@@ -53,6 +55,11 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore
 
 	sharedCache := make(map[string]*types.InsightSeries)
 
+	searchHandlers := GetSearchHandlers(store.NewInsightStoreWith(insightsStore), gitserverClient)
+	if referenceCounter != nil {
+		searchHandlers[types.PreciseReferences] = makePreciseReferencesHandler(referenceCounter)
+	}
+
 	prometheus.DefaultRegisterer.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
 		Name: "src_query_runner_worker_total",
 		Help: "Total number of jobs in the queued state.",
@@ -72,7 +79,7 @@ func NewWorker(ctx context.Context, logger log.Logger, workerStore dbworkerstore
 		limiter:         limiter,
 		metadadataStore: store.NewInsightStoreWith(insightsStore),
 		seriesCache:     sharedCache,
-		searchHandlers:  GetSearchHandlers(),
+		searchHandlers:  searchHandlers,
 		logger:          log.Scoped("insights.queryRunner.Handler", ""),
 	}, options)
 }
@@ -0,0 +1,224 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewSeriesAlertJob periodically evaluates the alert rules of insight series against their most recent recordings.
+// Every recording is evaluated once per alert rule, after all queued search queries of the series are done.
+func NewSeriesAlertJob(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB) goroutine.BackgroundRoutine {
+	interval := time.Minute * 5
+
+	return goroutine.NewPeriodicGoroutine(ctx, interval,
+		goroutine.NewHandlerWithErrorMessage("insights_series_alerts", func(ctx context.Context) (err error) {
+			return checkSeriesAlerts(ctx, postgres, insightsdb, defaultAlertNotifier)
+		}))
+}
+
+// alertNotifier delivers triggered alerts through the code monitor actions.
+type alertNotifier struct {
+	email   func(ctx context.Context, db database.DB, userID int32, alert cmbackground.InsightAlert) error
+	slack   func(ctx context.Context, url string, alert cmbackground.InsightAlert) error
+	webhook func(ctx context.Context, url string, alert cmbackground.InsightAlert) error
+}
+
+var defaultAlertNotifier = alertNotifier{
+	email:   cmbackground.SendEmailForInsightAlert,
+	slack:   cmbackground.SendSlackNotificationForInsightAlert,
+	webhook: cmbackground.SendWebhookNotificationForInsightAlert,
+}
+
+// The channels alerts are delivered through.
+const (
+	alertChannelEmail   = "email"
+	alertChannelSlack   = "slack"
+	alertChannelWebhook = "webhook"
+)
+
+// alertChannels returns the channels the alert is configured to be delivered through.
+func alertChannels(alert types.SeriesAlert) []string {
+	var channels []string
+	if alert.EmailUserID != nil {
+		channels = append(channels, alertChannelEmail)
+	}
+	if alert.SlackWebhookURL != nil {
+		channels = append(channels, alertChannelSlack)
+	}
+	if alert.WebhookURL != nil {
+		channels = append(channels, alertChannelWebhook)
+	}
+	return channels
+}
+
+// notify delivers the alert through the given channels and returns the channels it couldn't be delivered through.
+//
+// 🚨 SECURITY: Alerts contain the total of a series over all repositories, like the results of the query runner.
+// Alert rules are only managed by site admins through insight definitions.
+func (n alertNotifier) notify(ctx context.Context, postgres database.DB, alert types.SeriesAlert, insightAlert cmbackground.InsightAlert, channels []string) (failed []string, err error) {
+	for _, channel := range channels {
+		var channelErr error
+		switch {
+		case channel == alertChannelEmail && alert.EmailUserID != nil:
+			channelErr = n.email(ctx, postgres, *alert.EmailUserID, insightAlert)
+		case channel == alertChannelSlack && alert.SlackWebhookURL != nil:
+			channelErr = n.slack(ctx, *alert.SlackWebhookURL, insightAlert)
+		case channel == alertChannelWebhook && alert.WebhookURL != nil:
+			channelErr = n.webhook(ctx, *alert.WebhookURL, insightAlert)
+		default:
+			// The channel was removed from the alert since it was triggered.
+			continue
+		}
+		if channelErr != nil {
+			failed = append(failed, channel)
+			err = errors.Append(err, errors.Wrap(channelErr, channel))
+		}
+	}
+	return failed, err
+}
+
+func checkSeriesAlerts(ctx context.Context, postgres database.DB, insightsdb edb.InsightsDB, notifier alertNotifier) (err error) {
+	insightStore := store.NewInsightStore(insightsdb)
+	timeseriesStore := store.New(insightsdb, store.NewInsightPermissionStore(postgres))
+
+	alerts, err := insightStore.GetSeriesAlerts(ctx, store.GetSeriesAlertsArgs{})
+	if err != nil {
+		return errors.Wrap(err, "GetSeriesAlerts")
+	}
+	alertsBySeries := make(map[int][]types.SeriesAlert)
+	for _, alert := range alerts {
+		alertsBySeries[alert.InsightSeriesID] = append(alertsBySeries[alert.InsightSeriesID], alert)
+	}
+
+	for seriesID, seriesAlerts := range alertsBySeries {
+		if seriesErr := checkSeriesAlertsForSeries(ctx, postgres, insightStore, timeseriesStore, notifier, seriesID, seriesAlerts); seriesErr != nil {
+			err = errors.Append(err, errors.Wrapf(seriesErr, "series %d", seriesID))
+		}
+	}
+	return err
+}
+
+func checkSeriesAlertsForSeries(
+	ctx context.Context,
+	postgres database.DB,
+	insightStore *store.InsightStore,
+	timeseriesStore *store.Store,
+	notifier alertNotifier,
+	seriesID int,
+	alerts []types.SeriesAlert,
+) (err error) {
+	series, err := insightStore.GetDataSeries(ctx, store.GetDataSeriesArgs{ID: seriesID})
+	if err != nil {
+		return errors.Wrap(err, "GetDataSeries")
+	}
+	if len(series) == 0 {
+		return nil
+	}
+
+	// Wait until all queries of the most recent recording have been recorded, so that the total is complete.
+	pending, _, err := basestore.ScanFirstInt(basestore.NewWithHandle(postgres.Handle()).Query(ctx, sqlf.Sprintf(countPendingRecordingJobsSql, series[0].SeriesID)))
+	if err != nil {
+		return errors.Wrap(err, "countPendingRecordingJobs")
+	}
+	if pending > 0 {
+		return nil
+	}
+
+	totals, err := timeseriesStore.LatestRecordingTotals(ctx, series[0].SeriesID, 2)
+	if err != nil {
+		return errors.Wrap(err, "LatestRecordingTotals")
+	}
+	if len(totals) == 0 {
+		return nil
+	}
+	latest := totals[0]
+	var previous *float64
+	if len(totals) > 1 {
+		previous = &totals[1].Value
+	}
+
+	views, err := insightStore.Get(ctx, store.InsightQueryArgs{SeriesID: series[0].SeriesID, WithoutAuthorization: true})
+	if err != nil {
+		return errors.Wrap(err, "Get")
+	}
+
+	for _, alert := range alerts {
+		// Once the latest recording was evaluated, the alert is only delivered again through the channels that failed,
+		// until the series is recorded again.
+		channels := alertChannels(alert)
+		evaluated := alert.LastEvaluatedAt != nil && !latest.Time.After(*alert.LastEvaluatedAt)
+		if evaluated {
+			if len(alert.UndeliveredChannels) == 0 {
+				continue
+			}
+			channels = alert.UndeliveredChannels
+		}
+
+		reason, triggered := evaluateSeriesAlert(alert, latest.Value, previous)
+		var undelivered []string
+		if triggered {
+			insightAlert := cmbackground.InsightAlert{
+				Query:         series[0].Query,
+				Reason:        reason,
+				Value:         latest.Value,
+				PreviousValue: previous,
+				RecordingTime: latest.Time,
+			}
+			if len(views) > 0 {
+				insightAlert.InsightUniqueID = views[0].UniqueID
+				insightAlert.InsightTitle = views[0].Title
+				insightAlert.SeriesLabel = views[0].Label
+			}
+			var notifyErr error
+			undelivered, notifyErr = notifier.notify(ctx, postgres, alert, insightAlert, channels)
+			if notifyErr != nil {
+				err = errors.Append(err, errors.Wrapf(notifyErr, "notifying alert %d", alert.ID))
+			}
+		}
+
+		if stampErr := insightStore.StampSeriesAlert(ctx, alert.ID, latest.Time, triggered && !evaluated, undelivered); stampErr != nil {
+			err = errors.Append(err, errors.Wrap(stampErr, "StampSeriesAlert"))
+		}
+	}
+	return err
+}
+
+// evaluateSeriesAlert returns whether the alert is triggered by the latest total of a series and a description of
+// the triggered condition. Thresholds only trigger when they are crossed, so that a series that stays above or below
+// a threshold doesn't trigger on every recording.
+func evaluateSeriesAlert(alert types.SeriesAlert, latest float64, previous *float64) (string, bool) {
+	switch alert.Kind {
+	case types.AlertAbove:
+		if latest > alert.Threshold && (previous == nil || *previous <= alert.Threshold) {
+			return fmt.Sprintf("rose above %g", alert.Threshold), true
+		}
+	case types.AlertBelow:
+		if latest < alert.Threshold && (previous == nil || *previous >= alert.Threshold) {
+			return fmt.Sprintf("fell below %g", alert.Threshold), true
+		}
+	case types.AlertDelta:
+		if previous != nil && math.Abs(latest-*previous) >= alert.Threshold {
+			return fmt.Sprintf("changed by %g since the previous recording", latest-*previous), true
+		}
+	}
+	return "", false
+}
+
+const countPendingRecordingJobsSql = `
+SELECT COUNT(*)
+FROM insights_query_runner_jobs
+WHERE series_id = %s AND persist_mode = 'record' AND state IN ('queued', 'processing', 'errored')
+`
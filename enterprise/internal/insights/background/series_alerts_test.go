@@ -0,0 +1,94 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	cmbackground "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestEvaluateSeriesAlert(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	testCases := []struct {
+		name      string
+		alert     types.SeriesAlert
+		latest    float64
+		previous  *float64
+		reason    string
+		triggered bool
+	}{
+		{name: "above crossed", alert: types.SeriesAlert{Kind: types.AlertAbove, Threshold: 100}, latest: 101, previous: float(100), reason: "rose above 100", triggered: true},
+		{name: "above first recording", alert: types.SeriesAlert{Kind: types.AlertAbove, Threshold: 100}, latest: 150, reason: "rose above 100", triggered: true},
+		{name: "above stays above", alert: types.SeriesAlert{Kind: types.AlertAbove, Threshold: 100}, latest: 150, previous: float(120)},
+		{name: "above equal", alert: types.SeriesAlert{Kind: types.AlertAbove, Threshold: 100}, latest: 100, previous: float(50)},
+		{name: "below crossed", alert: types.SeriesAlert{Kind: types.AlertBelow, Threshold: 10}, latest: 9, previous: float(12), reason: "fell below 10", triggered: true},
+		{name: "below stays below", alert: types.SeriesAlert{Kind: types.AlertBelow, Threshold: 10}, latest: 5, previous: float(9)},
+		{name: "delta increase", alert: types.SeriesAlert{Kind: types.AlertDelta, Threshold: 5}, latest: 15, previous: float(10), reason: "changed by 5 since the previous recording", triggered: true},
+		{name: "delta decrease", alert: types.SeriesAlert{Kind: types.AlertDelta, Threshold: 5}, latest: 2.5, previous: float(10), reason: "changed by -7.5 since the previous recording", triggered: true},
+		{name: "delta too small", alert: types.SeriesAlert{Kind: types.AlertDelta, Threshold: 5}, latest: 14, previous: float(10)},
+		{name: "delta first recording", alert: types.SeriesAlert{Kind: types.AlertDelta, Threshold: 5}, latest: 100},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, triggered := evaluateSeriesAlert(tc.alert, tc.latest, tc.previous)
+			if triggered != tc.triggered {
+				t.Errorf("unexpected triggered. want=%v have=%v", tc.triggered, triggered)
+			}
+			if reason != tc.reason {
+				t.Errorf("unexpected reason. want=%q have=%q", tc.reason, reason)
+			}
+		})
+	}
+}
+
+func TestAlertNotifierNotify(t *testing.T) {
+	url := "https://example.com"
+	userID := int32(1)
+	alert := types.SeriesAlert{ID: 1, EmailUserID: &userID, SlackWebhookURL: &url, WebhookURL: &url}
+
+	var delivered []string
+	deliver := func(channel string, err error) func() error {
+		return func() error {
+			delivered = append(delivered, channel)
+			return err
+		}
+	}
+	email, slack, webhook := deliver(alertChannelEmail, nil), deliver(alertChannelSlack, errors.New("slack is down")), deliver(alertChannelWebhook, nil)
+	notifier := alertNotifier{
+		email:   func(context.Context, database.DB, int32, cmbackground.InsightAlert) error { return email() },
+		slack:   func(context.Context, string, cmbackground.InsightAlert) error { return slack() },
+		webhook: func(context.Context, string, cmbackground.InsightAlert) error { return webhook() },
+	}
+
+	failed, err := notifier.notify(context.Background(), nil, alert, cmbackground.InsightAlert{}, alertChannels(alert))
+	if err == nil {
+		t.Fatal("expected error but got none")
+	}
+	if diff := cmp.Diff([]string{alertChannelEmail, alertChannelSlack, alertChannelWebhook}, delivered); diff != "" {
+		t.Fatalf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{alertChannelSlack}, failed); diff != "" {
+		t.Fatalf("unexpected failed channels (-want +got):\n%s", diff)
+	}
+
+	// Retrying only delivers the alert through the channels that failed.
+	delivered = nil
+	slack = deliver(alertChannelSlack, nil)
+	failed, err = notifier.notify(context.Background(), nil, alert, cmbackground.InsightAlert{}, failed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{alertChannelSlack}, delivered); diff != "" {
+		t.Fatalf("unexpected deliveries (-want +got):\n%s", diff)
+	}
+	if len(failed) != 0 {
+		t.Fatalf("unexpected failed channels %v", failed)
+	}
+}
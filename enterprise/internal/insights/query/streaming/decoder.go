@@ -113,6 +113,146 @@ func TabulationDecoder() (streamhttp.FrontendStreamDecoder, *TabulationResult) {
 	}, tr
 }
 
+type FileMatch struct {
+	RepositoryID   int32
+	RepositoryName string
+	Commit         string
+	Path           string
+	MatchCount     int
+}
+
+type FileTabulationResult struct {
+	StreamDecoderEvents
+	Files []FileMatch
+}
+
+// FileTabulationDecoder will tabulate the result counts per file. Results that are not files are ignored.
+func FileTabulationDecoder() (streamhttp.FrontendStreamDecoder, *FileTabulationResult) {
+	fr := &FileTabulationResult{}
+
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: func(progress *streamapi.Progress) {
+			if !progress.Done {
+				return
+			}
+			// Skipped elements are built progressively for a Progress update until it is Done, so
+			// we want to register its contents only once it is done.
+			for _, skipped := range progress.Skipped {
+				// ShardTimeout is a specific skipped event that we want to retry on. Currently
+				// we only retry on Alert events so this is why we add it there. This behaviour will
+				// be uniformised eventually.
+				if skipped.Reason == streamapi.ShardTimeout {
+					fr.Alerts = append(fr.Alerts, fmt.Sprintf("%s: %s", skipped.Reason, skipped.Message))
+				} else {
+					fr.SkippedReasons = append(fr.SkippedReasons, fmt.Sprintf("%s: %s", skipped.Reason, skipped.Message))
+				}
+			}
+		},
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				switch match := match.(type) {
+				case *streamhttp.EventContentMatch:
+					count := 0
+					for _, lineMatch := range match.LineMatches {
+						count += len(lineMatch.OffsetAndLengths)
+					}
+					fr.Files = append(fr.Files, FileMatch{
+						RepositoryID:   match.RepositoryID,
+						RepositoryName: match.Repository,
+						Commit:         match.Commit,
+						Path:           match.Path,
+						MatchCount:     count,
+					})
+				case *streamhttp.EventPathMatch:
+					fr.Files = append(fr.Files, FileMatch{
+						RepositoryID:   match.RepositoryID,
+						RepositoryName: match.Repository,
+						Commit:         match.Commit,
+						Path:           match.Path,
+						MatchCount:     1,
+					})
+				case *streamhttp.EventSymbolMatch:
+					fr.Files = append(fr.Files, FileMatch{
+						RepositoryID:   match.RepositoryID,
+						RepositoryName: match.Repository,
+						Commit:         match.Commit,
+						Path:           match.Path,
+						MatchCount:     len(match.Symbols),
+					})
+				}
+			}
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			if ea.Title == "No repositories found" {
+				// If we hit a case where we don't find a repository we don't want to error, just
+				// complete our search.
+			} else {
+				fr.Alerts = append(fr.Alerts, fmt.Sprintf("%s: %s", ea.Title, ea.Description))
+			}
+		},
+		OnError: func(eventError *streamhttp.EventError) {
+			fr.Errors = append(fr.Errors, eventError.Message)
+		},
+	}, fr
+}
+
+// AuthorTabulationDecoder will tabulate the number of commit and diff results per repository and commit author.
+// Results that are not commits are ignored.
+func AuthorTabulationDecoder() (streamhttp.FrontendStreamDecoder, *ComputeTabulationResult) {
+	ctr := &ComputeTabulationResult{
+		RepoCounts: make(map[string]*ComputeMatch),
+	}
+
+	return streamhttp.FrontendStreamDecoder{
+		OnProgress: func(progress *streamapi.Progress) {
+			if !progress.Done {
+				return
+			}
+			// Skipped elements are built progressively for a Progress update until it is Done, so
+			// we want to register its contents only once it is done.
+			for _, skipped := range progress.Skipped {
+				// ShardTimeout is a specific skipped event that we want to retry on. Currently
+				// we only retry on Alert events so this is why we add it there. This behaviour will
+				// be uniformised eventually.
+				if skipped.Reason == streamapi.ShardTimeout {
+					ctr.Alerts = append(ctr.Alerts, fmt.Sprintf("%s: %s", skipped.Reason, skipped.Message))
+				} else {
+					ctr.SkippedReasons = append(ctr.SkippedReasons, fmt.Sprintf("%s: %s", skipped.Reason, skipped.Message))
+				}
+			}
+		},
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			for _, match := range matches {
+				commit, ok := match.(*streamhttp.EventCommitMatch)
+				if !ok || commit.AuthorName == "" {
+					continue
+				}
+				current, ok := ctr.RepoCounts[commit.Repository]
+				if !ok {
+					current = newComputeMatch(commit.Repository, commit.RepositoryID)
+					ctr.RepoCounts[commit.Repository] = current
+				}
+				author := commit.AuthorName
+				if len(author) > capturedValueMaxLength {
+					author = author[:capturedValueMaxLength]
+				}
+				current.ValueCounts[author] += 1
+			}
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			if ea.Title == "No repositories found" {
+				// If we hit a case where we don't find a repository we don't want to error, just
+				// complete our search.
+			} else {
+				ctr.Alerts = append(ctr.Alerts, fmt.Sprintf("%s: %s", ea.Title, ea.Description))
+			}
+		},
+		OnError: func(eventError *streamhttp.EventError) {
+			ctr.Errors = append(ctr.Errors, eventError.Message)
+		},
+	}, ctr
+}
+
 // ComputeMatch is our internal representation of a match retrieved from a Compute Streaming Search.
 // It is internally different from the `ComputeMatch` returned by the Compute GraphQL query but they
 // serve the same end goal.
@@ -0,0 +1,75 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func (r *Resolver) ExportInsightDefinitions(ctx context.Context) (string, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return "", err
+	}
+
+	// 🚨 SECURITY: Exporting is restricted to admins only, so the insights and dashboards of every user are exported.
+	insights, err := r.insightStore.ExportInsights(ctx, store.InsightQueryArgs{WithoutAuthorization: true})
+	if err != nil {
+		return "", errors.Wrap(err, "ExportInsights")
+	}
+	dashboards, err := r.dashboardStore.ExportDashboards(ctx, store.DashboardQueryArgs{WithoutAuthorization: true})
+	if err != nil {
+		return "", errors.Wrap(err, "ExportDashboards")
+	}
+
+	out, err := types.MarshalDefinitions(types.Definitions{Insights: insights, Dashboards: dashboards})
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func (r *Resolver) ImportInsightDefinitions(ctx context.Context, args *graphqlbackend.ImportInsightDefinitionsArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	defs, err := types.ParseDefinitions([]byte(args.Definitions))
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.insightStore.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+	dashboardTx := r.dashboardStore.With(tx)
+	backfiller := background.NewScopedBackfiller(r.workerBaseStore, r.baseInsightResolver.timeSeriesStore.With(tx))
+	seriesFillStrategy := makeFillSeriesStrategy(ctx, tx, backfiller, r.scheduler, r.insightEnqueuer)
+
+	for _, def := range defs.Insights {
+		_, created, err := tx.ImportInsight(ctx, def)
+		if err != nil {
+			return nil, errors.Wrapf(err, "importing insight %q", def.ID)
+		}
+		for _, series := range created {
+			if err := seriesFillStrategy(ctx, series); err != nil {
+				return nil, errors.Wrapf(err, "filling series %q", series.SeriesID)
+			}
+		}
+	}
+	for _, def := range defs.Dashboards {
+		if _, err := dashboardTx.ImportDashboard(ctx, def); err != nil {
+			return nil, errors.Wrapf(err, "importing dashboard %q", def.ID)
+		}
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
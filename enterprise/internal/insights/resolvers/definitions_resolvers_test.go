@@ -0,0 +1,95 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestResolver_InsightDefinitions(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{InsightsBackfillerV2: true},
+	}})
+	defer conf.Mock(nil)
+
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Microsecond)
+	logger := logtest.Scoped(t)
+	clock := func() time.Time { return now }
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	resolver := newWithClock(insightsDB, postgres, clock)
+
+	newUser := func(name string, isAdmin bool) int32 {
+		u, err := postgres.Users().Create(context.Background(), database.NewUser{Username: name})
+		require.NoError(t, err)
+		require.NoError(t, postgres.Users().SetIsSiteAdmin(context.Background(), u.ID, isAdmin))
+		return u.ID
+	}
+	adminCtx := actor.WithActor(context.Background(), actor.FromUser(newUser("admin", true)))
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(newUser("user", false)))
+
+	definitions := `
+version: 1
+insights:
+  - id: todos
+    title: TODOs
+    series:
+      - id: todos-series
+        query: TODO
+        intervalUnit: WEEK
+        intervalValue: 2
+    grants:
+      global: true
+dashboards:
+  - id: team
+    title: Team dashboard
+    insights: [todos]
+    grants:
+      global: true
+`
+
+	t.Run("non-admins can't import or export", func(t *testing.T) {
+		_, err := resolver.ImportInsightDefinitions(userCtx, &graphqlbackend.ImportInsightDefinitionsArgs{Definitions: definitions})
+		require.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+		_, err = resolver.ExportInsightDefinitions(userCtx)
+		require.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+	})
+
+	t.Run("import backfills new series and is idempotent", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := resolver.ImportInsightDefinitions(adminCtx, &graphqlbackend.ImportInsightDefinitionsArgs{Definitions: definitions})
+			require.NoError(t, err)
+		}
+
+		series, err := resolver.insightStore.GetDataSeries(adminCtx, store.GetDataSeriesArgs{SeriesID: "todos-series"})
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		require.False(t, series[0].BackfillQueuedAt.IsZero(), "expected the imported series to be queued for backfill")
+
+		exported, err := resolver.ExportInsightDefinitions(adminCtx)
+		require.NoError(t, err)
+		defs, err := types.ParseDefinitions([]byte(exported))
+		require.NoError(t, err)
+		require.Len(t, defs.Insights, 1)
+		require.Equal(t, "todos", defs.Insights[0].ID)
+		require.Len(t, defs.Insights[0].Series, 1)
+		require.Equal(t, "todos-series", defs.Insights[0].Series[0].ID)
+		require.Len(t, defs.Dashboards, 1)
+		require.Equal(t, "team", defs.Dashboards[0].ID)
+		require.Equal(t, []string{"todos"}, defs.Dashboards[0].Insights)
+	})
+}
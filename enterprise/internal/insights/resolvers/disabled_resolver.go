@@ -86,3 +86,23 @@ func (r *disabledResolver) SearchQueryAggregate(ctx context.Context, args graphq
 func (r *disabledResolver) InsightViewDebug(ctx context.Context, args graphqlbackend.InsightViewDebugArgs) (graphqlbackend.InsightViewDebugResolver, error) {
 	return nil, errors.New(r.reason)
 }
+
+func (r *disabledResolver) ExportInsightDefinitions(ctx context.Context) (string, error) {
+	return "", errors.New(r.reason)
+}
+
+func (r *disabledResolver) ImportInsightDefinitions(ctx context.Context, args *graphqlbackend.ImportInsightDefinitionsArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	return nil, errors.New(r.reason)
+}
+
+func (r *disabledResolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	return nil, errors.New(r.reason)
+}
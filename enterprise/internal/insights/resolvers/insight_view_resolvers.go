@@ -1002,6 +1002,10 @@ func makeFillSeriesStrategy(ctx context.Context, tx *store.InsightStore, scopedB
 	v2BackfillEnabled := conf.Get().ExperimentalFeatures.InsightsBackfillerV2
 
 	return func(ctx context.Context, series types.InsightSeries) error {
+		if series.GenerationMethod == types.PreciseReferences {
+			// These series are backfilled from historical uploads by a background job instead of searches.
+			return nil
+		}
 		if series.GroupBy != nil {
 			return groupBySeriesFill(ctx, series, tx, insightEnqueuer)
 		}
@@ -1064,20 +1068,19 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	var foundSeries bool
 	var err error
 	var dynamic bool
+	generationMethod := inputGenerationMethod(series)
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
-		if _, err := querybuilder.ParseComputeQuery(series.Query); err != nil {
-			return nil, errors.Wrap(err, "query validation")
-		}
-	} else {
-		if _, err := querybuilder.ParseQuery(series.Query, "literal"); err != nil {
-			return nil, errors.Wrap(err, "query validation")
-		}
+	if err := validateSeriesQuery(series, generationMethod); err != nil {
+		return nil, errors.Wrap(err, "query validation")
 	}
 
 	if series.GeneratedFromCaptureGroups != nil {
 		dynamic = *series.GeneratedFromCaptureGroups
 	}
+	// Breakdown series are always expanded into one series per recorded value.
+	if generationMethod.IsBreakdown() {
+		dynamic = true
+	}
 
 	groupBy := lowercaseGroupBy(series.GroupBy)
 	var nextRecordingAfter time.Time
@@ -1097,6 +1100,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			StepIntervalValue:         int(series.TimeScope.StepInterval.Value),
 			GenerateFromCaptureGroups: dynamic,
 			GroupBy:                   groupBy,
+			GenerationMethod:          generationMethod,
 		})
 		if err != nil {
 			return nil, errors.Wrap(err, "FindMatchingSeries")
@@ -1117,7 +1121,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			SampleIntervalValue:        int(series.TimeScope.StepInterval.Value),
 			GeneratedFromCaptureGroups: dynamic,
 			JustInTime:                 len(repos) > 0 && !deprecateJustInTime,
-			GenerationMethod:           searchGenerationMethod(series),
+			GenerationMethod:           generationMethod,
 			GroupBy:                    groupBy,
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
@@ -1147,7 +1151,63 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	return &seriesToAdd, nil
 }
 
-func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+// validateSeriesQuery returns an error if the query of the series can't be recorded with the generation method.
+func validateSeriesQuery(series graphqlbackend.LineChartSearchInsightDataSeriesInput, generationMethod types.GenerationMethod) error {
+	fromCaptureGroups := series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups
+	switch generationMethod {
+	case types.PreciseReferences:
+		if series.GroupBy != nil || fromCaptureGroups {
+			return errors.New("precise references series can't be grouped or generated from capture groups")
+		}
+		_, err := types.ParsePreciseReferencesQuery(series.Query)
+		return err
+	case types.SearchAuthorBreakdown, types.SearchCodeOwnerBreakdown:
+		if series.GroupBy != nil || fromCaptureGroups {
+			return errors.New("breakdown series can't be grouped or generated from capture groups")
+		}
+		if generationMethod == types.SearchAuthorBreakdown {
+			// Commit authors are only known for diff and commit results.
+			ok, _, err := canAggregateByAuthor(series.Query, "literal")
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errors.New("author breakdown series require a type:diff or type:commit query")
+			}
+			return nil
+		}
+		// Code owners are only known for the files matched by content queries.
+		ok, _, err := canAggregateByPath(series.Query, "literal")
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("code owner breakdown series require a content query")
+		}
+		return nil
+	}
+
+	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+		_, err := querybuilder.ParseComputeQuery(series.Query)
+		return err
+	}
+	_, err := querybuilder.ParseQuery(series.Query, "literal")
+	return err
+}
+
+// seriesGenerationMethods maps the values of the InsightSeriesGenerationMethod GraphQL enum to generation methods.
+var seriesGenerationMethods = map[string]types.GenerationMethod{
+	"PRECISE_REFERENCES":   types.PreciseReferences,
+	"AUTHOR_BREAKDOWN":     types.SearchAuthorBreakdown,
+	"CODE_OWNER_BREAKDOWN": types.SearchCodeOwnerBreakdown,
+}
+
+func inputGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if series.GenerationMethod != nil {
+		if method, ok := seriesGenerationMethods[*series.GenerationMethod]; ok {
+			return method
+		}
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
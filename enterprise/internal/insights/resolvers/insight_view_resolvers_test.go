@@ -15,11 +15,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	internalTypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func addrStr(input string) *string {
@@ -384,6 +386,158 @@ func TestInsightViewDashboardConnections(t *testing.T) {
 	})
 }
 
+func TestCreateLineChartSearchInsight_GenerationMethod(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{InsightsBackfillerV2: true},
+	}})
+	defer conf.Mock(nil)
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgresDB := database.NewDB(logger, dbtest.NewDB(logger, t))
+	resolver := newWithClock(insightsDB, postgresDB, timeutil.Now)
+
+	createSeries := func(series graphqlbackend.LineChartSearchInsightDataSeriesInput) (types.InsightViewSeries, error) {
+		series.TimeScope = graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{Unit: string(types.Month), Value: 1}}
+		payload, err := resolver.CreateLineChartSearchInsight(ctx, &graphqlbackend.CreateLineChartSearchInsightArgs{
+			Input: graphqlbackend.CreateLineChartSearchInsightInput{DataSeries: []graphqlbackend.LineChartSearchInsightDataSeriesInput{series}},
+		})
+		if err != nil {
+			return types.InsightViewSeries{}, err
+		}
+		viewSeries, err := resolver.insightStore.Get(ctx, store.InsightQueryArgs{UniqueID: payload.(*insightPayloadResolver).viewId, WithoutAuthorization: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(viewSeries) != 1 {
+			t.Fatalf("expected 1 series, got %d", len(viewSeries))
+		}
+		return viewSeries[0], nil
+	}
+
+	preciseReferences := "PRECISE_REFERENCES"
+	authorBreakdown := "AUTHOR_BREAKDOWN"
+	codeOwnerBreakdown := "CODE_OWNER_BREAKDOWN"
+	preciseQuery := "scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap"
+
+	t.Run("search", func(t *testing.T) {
+		series, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "errors.Wrap"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if series.GenerationMethod != types.Search {
+			t.Errorf("expected generation method %q, got %q", types.Search, series.GenerationMethod)
+		}
+		if series.BackfillQueuedAt == nil {
+			t.Error("expected search series to be queued for backfill")
+		}
+	})
+
+	t.Run("precise references", func(t *testing.T) {
+		series, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: preciseQuery, GenerationMethod: &preciseReferences})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if series.GenerationMethod != types.PreciseReferences {
+			t.Errorf("expected generation method %q, got %q", types.PreciseReferences, series.GenerationMethod)
+		}
+		if series.BackfillQueuedAt != nil {
+			t.Error("expected precise references series not to be queued for a search backfill")
+		}
+	})
+
+	t.Run("invalid precise references query", func(t *testing.T) {
+		_, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "identifier:Wrap", GenerationMethod: &preciseReferences})
+		if err == nil {
+			t.Fatal("expected an error for a precise references query without scheme and package")
+		}
+	})
+
+	t.Run("author breakdown", func(t *testing.T) {
+		series, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:diff deprecated", GenerationMethod: &authorBreakdown})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if series.GenerationMethod != types.SearchAuthorBreakdown {
+			t.Errorf("expected generation method %q, got %q", types.SearchAuthorBreakdown, series.GenerationMethod)
+		}
+		if !series.GeneratedFromCaptureGroups {
+			t.Error("expected breakdown series to be generated dynamically")
+		}
+		if series.BackfillQueuedAt == nil {
+			t.Error("expected breakdown series to be queued for backfill")
+		}
+	})
+
+	t.Run("code owner breakdown", func(t *testing.T) {
+		series, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated", GenerationMethod: &codeOwnerBreakdown})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if series.GenerationMethod != types.SearchCodeOwnerBreakdown {
+			t.Errorf("expected generation method %q, got %q", types.SearchCodeOwnerBreakdown, series.GenerationMethod)
+		}
+		if !series.GeneratedFromCaptureGroups {
+			t.Error("expected breakdown series to be generated dynamically")
+		}
+	})
+
+	t.Run("author breakdown of a content query", func(t *testing.T) {
+		_, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated", GenerationMethod: &authorBreakdown})
+		if err == nil {
+			t.Fatal("expected an error for an author breakdown of a content query")
+		}
+	})
+
+	t.Run("code owner breakdown of a commit query", func(t *testing.T) {
+		_, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:commit deprecated", GenerationMethod: &codeOwnerBreakdown})
+		if err == nil {
+			t.Fatal("expected an error for a code owner breakdown of a commit query")
+		}
+	})
+
+	t.Run("grouped precise references", func(t *testing.T) {
+		groupBy := "REPO"
+		_, err := createSeries(graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: preciseQuery, GenerationMethod: &preciseReferences, GroupBy: &groupBy})
+		if err == nil {
+			t.Fatal("expected an error for a grouped precise references series")
+		}
+	})
+}
+
+func TestValidateSeriesQuery(t *testing.T) {
+	boolPtr := func(b bool) *bool { return &b }
+	groupBy := "REPO"
+
+	testCases := []struct {
+		name             string
+		series           graphqlbackend.LineChartSearchInsightDataSeriesInput
+		generationMethod types.GenerationMethod
+		wantErr          bool
+	}{
+		{name: "search", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated"}, generationMethod: types.Search},
+		{name: "invalid search", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "count:abc deprecated"}, generationMethod: types.Search, wantErr: true},
+		{name: "author breakdown of a diff query", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:diff deprecated"}, generationMethod: types.SearchAuthorBreakdown},
+		{name: "author breakdown of a commit query", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:commit deprecated"}, generationMethod: types.SearchAuthorBreakdown},
+		{name: "author breakdown of a content query", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated"}, generationMethod: types.SearchAuthorBreakdown, wantErr: true},
+		{name: "code owner breakdown of a content query", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated"}, generationMethod: types.SearchCodeOwnerBreakdown},
+		{name: "code owner breakdown of a diff query", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:diff deprecated"}, generationMethod: types.SearchCodeOwnerBreakdown, wantErr: true},
+		{name: "grouped breakdown", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "deprecated", GroupBy: &groupBy}, generationMethod: types.SearchCodeOwnerBreakdown, wantErr: true},
+		{name: "capture groups breakdown", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "type:diff (\\w+)", GeneratedFromCaptureGroups: boolPtr(true)}, generationMethod: types.SearchAuthorBreakdown, wantErr: true},
+		{name: "precise references", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:Wrap"}, generationMethod: types.PreciseReferences},
+		{name: "invalid precise references", series: graphqlbackend.LineChartSearchInsightDataSeriesInput{Query: "identifier:Wrap"}, generationMethod: types.PreciseReferences, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateSeriesQuery(tc.series, tc.generationMethod)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 type fakeSearchContextLoader struct {
 	mocks map[string]*internalTypes.SearchContext
 }
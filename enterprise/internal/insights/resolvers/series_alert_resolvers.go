@@ -0,0 +1,147 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.getAlertSeries(ctx, args.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := r.insightStore.GetSeriesAlerts(ctx, store.GetSeriesAlertsArgs{InsightSeriesID: series.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	// 🚨 SECURITY: Alert rules deliver the data of a series to arbitrary recipients, so only admins can create them.
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.getAlertSeries(ctx, args.Input.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+
+	alert := types.SeriesAlert{
+		InsightSeriesID: series.ID,
+		Kind:            types.SeriesAlertKind(args.Input.Kind),
+		Threshold:       args.Input.Threshold,
+		SlackWebhookURL: args.Input.SlackWebhookURL,
+		WebhookURL:      args.Input.WebhookURL,
+	}
+	if args.Input.EmailRecipient != nil {
+		userID, err := graphqlbackend.UnmarshalUserID(*args.Input.EmailRecipient)
+		if err != nil {
+			return nil, errors.Wrap(err, "UnmarshalUserID")
+		}
+		if _, err := r.postgresDB.Users().GetByID(ctx, userID); err != nil {
+			return nil, errors.Wrap(err, "fetching email recipient")
+		}
+		alert.EmailUserID = &userID
+	}
+
+	alert, err = r.insightStore.CreateSeriesAlert(ctx, alert)
+	if err != nil {
+		return nil, err
+	}
+	return &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the insight series alert id")
+	}
+	if err := r.insightStore.DeleteSeriesAlert(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) getAlertSeries(ctx context.Context, seriesID string) (types.InsightSeries, error) {
+	series, err := r.dataSeriesStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: seriesID})
+	if err != nil {
+		return types.InsightSeries{}, err
+	}
+	if len(series) == 0 {
+		return types.InsightSeries{}, errors.Newf("unable to fetch series with series_id: %v", seriesID)
+	}
+	return series[0], nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert    types.SeriesAlert
+	seriesID string
+}
+
+func (r *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, r.alert.ID)
+}
+
+func (r *insightSeriesAlertResolver) SeriesId() string {
+	return r.seriesID
+}
+
+func (r *insightSeriesAlertResolver) Kind() string {
+	return string(r.alert.Kind)
+}
+
+func (r *insightSeriesAlertResolver) Threshold() float64 {
+	return r.alert.Threshold
+}
+
+func (r *insightSeriesAlertResolver) EmailRecipient() *graphql.ID {
+	if r.alert.EmailUserID == nil {
+		return nil
+	}
+	id := graphqlbackend.MarshalUserID(*r.alert.EmailUserID)
+	return &id
+}
+
+func (r *insightSeriesAlertResolver) SlackWebhookURL() *string {
+	return r.alert.SlackWebhookURL
+}
+
+func (r *insightSeriesAlertResolver) WebhookURL() *string {
+	return r.alert.WebhookURL
+}
+
+func (r *insightSeriesAlertResolver) LastTriggeredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(r.alert.LastTriggeredAt)
+}
@@ -0,0 +1,90 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestResolver_InsightSeriesAlerts(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).Truncate(time.Microsecond)
+	logger := logtest.Scoped(t)
+	clock := func() time.Time { return now }
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	resolver := newWithClock(insightsDB, postgres, clock)
+
+	newUser := func(name string, isAdmin bool) int32 {
+		u, err := postgres.Users().Create(context.Background(), database.NewUser{Username: name})
+		require.NoError(t, err)
+		require.NoError(t, postgres.Users().SetIsSiteAdmin(context.Background(), u.ID, isAdmin))
+		return u.ID
+	}
+	adminID := newUser("admin", true)
+	adminCtx := actor.WithActor(context.Background(), actor.FromUser(adminID))
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(newUser("user", false)))
+
+	_, err := resolver.insightStore.CreateSeries(adminCtx, types.InsightSeries{
+		SeriesID:            "series1",
+		Query:               "deprecated",
+		SampleIntervalUnit:  string(types.Month),
+		SampleIntervalValue: 1,
+		GenerationMethod:    types.Search,
+	})
+	require.NoError(t, err)
+
+	emailRecipient := graphqlbackend.MarshalUserID(adminID)
+	input := graphqlbackend.CreateInsightSeriesAlertInput{
+		SeriesId:       "series1",
+		Kind:           string(types.AlertAbove),
+		Threshold:      10,
+		EmailRecipient: &emailRecipient,
+	}
+
+	t.Run("non-admins can't manage alert rules", func(t *testing.T) {
+		_, err := resolver.CreateInsightSeriesAlert(userCtx, &graphqlbackend.CreateInsightSeriesAlertArgs{Input: input})
+		require.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+		_, err = resolver.InsightSeriesAlerts(userCtx, &graphqlbackend.InsightSeriesAlertsArgs{SeriesId: "series1"})
+		require.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+		_, err = resolver.DeleteInsightSeriesAlert(userCtx, &graphqlbackend.DeleteInsightSeriesAlertArgs{Id: "unused"})
+		require.ErrorIs(t, err, auth.ErrMustBeSiteAdmin)
+	})
+
+	t.Run("unknown series", func(t *testing.T) {
+		missing := input
+		missing.SeriesId = "missing"
+		_, err := resolver.CreateInsightSeriesAlert(adminCtx, &graphqlbackend.CreateInsightSeriesAlertArgs{Input: missing})
+		require.Error(t, err)
+	})
+
+	t.Run("create, list and delete", func(t *testing.T) {
+		created, err := resolver.CreateInsightSeriesAlert(adminCtx, &graphqlbackend.CreateInsightSeriesAlertArgs{Input: input})
+		require.NoError(t, err)
+		require.Equal(t, "series1", created.SeriesId())
+		require.Equal(t, "ABOVE", created.Kind())
+		require.Equal(t, float64(10), created.Threshold())
+		require.Equal(t, &emailRecipient, created.EmailRecipient())
+
+		alerts, err := resolver.InsightSeriesAlerts(adminCtx, &graphqlbackend.InsightSeriesAlertsArgs{SeriesId: "series1"})
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		require.Equal(t, created.ID(), alerts[0].ID())
+
+		_, err = resolver.DeleteInsightSeriesAlert(adminCtx, &graphqlbackend.DeleteInsightSeriesAlertArgs{Id: created.ID()})
+		require.NoError(t, err)
+		alerts, err = resolver.InsightSeriesAlerts(adminCtx, &graphqlbackend.InsightSeriesAlertsArgs{SeriesId: "series1"})
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+	})
+}
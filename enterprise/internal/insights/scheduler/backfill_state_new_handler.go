@@ -172,7 +172,8 @@ func (h *newBackfillHandler) Handle(ctx context.Context, logger log.Logger, reco
 }
 
 func parseQuery(series types.InsightSeries) (query.Plan, error) {
-	if series.GeneratedFromCaptureGroups {
+	// Breakdown series are dynamic like capture group series, but their query is a plain search query.
+	if series.GeneratedFromCaptureGroups && !series.GenerationMethod.IsBreakdown() {
 		query, err := compute.Parse(series.Query)
 		if err != nil {
 			return nil, errors.Wrap(err, "compute.Parse")
@@ -8,6 +8,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
+	"github.com/segmentio/ksuid"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -145,6 +146,7 @@ func scanDashboard(rows *sql.Rows, queryErr error) (_ []*types.Dashboard, err er
 		var temp types.Dashboard
 		if err := rows.Scan(
 			&temp.ID,
+			&temp.UniqueID,
 			&temp.Title,
 			pq.Array(&temp.InsightIDs),
 			pq.Array(&temp.UserIdGrants),
@@ -159,7 +161,7 @@ func scanDashboard(rows *sql.Rows, queryErr error) (_ []*types.Dashboard, err er
 }
 
 const getDashboardsSql = `
-SELECT db.id, db.title, t.uuid_array as insight_view_unique_ids,
+SELECT db.id, db.unique_id, db.title, t.uuid_array as insight_view_unique_ids,
 	ARRAY_REMOVE(ARRAY_AGG(dg.user_id), NULL) AS granted_users,
 	ARRAY_REMOVE(ARRAY_AGG(dg.org_id), NULL)  AS granted_orgs,
 	BOOL_OR(dg.global IS TRUE)                AS granted_global
@@ -379,6 +381,111 @@ func (s *DBDashboardStore) EnsureLimitedAccessModeDashboard(ctx context.Context)
 	return id, nil
 }
 
+// ImportDashboard creates or updates the dashboard with the unique ID of the given definition. Importing the same
+// definition many times has only one effect. A previously deleted dashboard is restored, and the grants and insight
+// views of an existing dashboard are replaced by those of the definition.
+func (s *DBDashboardStore) ImportDashboard(ctx context.Context, def types.DashboardDefinition) (_ *types.Dashboard, err error) {
+	if def.ID == "" {
+		return nil, errors.New("unable to import dashboard invalid unique ID")
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	id, found, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(getDashboardIDByUniqueIDSql, def.ID)))
+	if err != nil {
+		return nil, errors.Wrap(err, "getDashboardIDByUniqueID")
+	}
+	if !found {
+		id, _, err = basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(insertDashboardWithUniqueIDSql, def.Title, def.ID, Standard)))
+		if err != nil {
+			return nil, errors.Wrap(err, "insertDashboard")
+		}
+	} else {
+		if err := tx.Exec(ctx, sqlf.Sprintf(importDashboardSql, def.Title, id)); err != nil {
+			return nil, errors.Wrap(err, "updating dashboard")
+		}
+		if err := tx.Exec(ctx, sqlf.Sprintf(removeDashboardGrants, id)); err != nil {
+			return nil, errors.Wrap(err, "removing existing dashboard grants")
+		}
+		if err := tx.Exec(ctx, sqlf.Sprintf(removeOtherDashboardInsightViewConnectionsSql, id, pq.Array(def.Insights))); err != nil {
+			return nil, errors.Wrap(err, "removing insight views from dashboard")
+		}
+	}
+
+	err = tx.AddViewsToDashboard(ctx, id, def.Insights)
+	if err != nil {
+		return nil, errors.Wrap(err, "AddViewsToDashboard")
+	}
+	err = tx.AddDashboardGrants(ctx, id, dashboardGrantsFromDefinition(def.Grants))
+	if err != nil {
+		return nil, errors.Wrap(err, "AddDashboardGrants")
+	}
+
+	dashboards, err := tx.GetDashboards(ctx, DashboardQueryArgs{ID: []int{id}, WithoutAuthorization: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDashboards")
+	}
+	if len(dashboards) > 0 {
+		return dashboards[0], nil
+	}
+	return nil, nil
+}
+
+// ExportDashboards returns the definitions of the dashboards matching the given arguments. Dashboards that have not
+// been imported are assigned a unique ID, so that importing the exported definitions updates them in place.
+func (s *DBDashboardStore) ExportDashboards(ctx context.Context, args DashboardQueryArgs) (_ []types.DashboardDefinition, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	dashboards, err := tx.GetDashboards(ctx, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetDashboards")
+	}
+
+	defs := make([]types.DashboardDefinition, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		if dashboard.UniqueID == nil {
+			uniqueID, _, err := basestore.ScanFirstString(tx.Query(ctx, sqlf.Sprintf(assignDashboardUniqueIDSql, ksuid.New().String(), dashboard.ID)))
+			if err != nil {
+				return nil, errors.Wrap(err, "assigning dashboard unique ID")
+			}
+			dashboard.UniqueID = &uniqueID
+		}
+		defs = append(defs, types.NewDashboardDefinition(*dashboard))
+	}
+	return defs, nil
+}
+
+const getDashboardIDByUniqueIDSql = `
+SELECT id FROM dashboard WHERE unique_id = %s;
+`
+
+const insertDashboardWithUniqueIDSql = `
+INSERT INTO dashboard (title, unique_id, type) VALUES (%s, %s, %s) RETURNING id;
+`
+
+const importDashboardSql = `
+UPDATE dashboard SET title = %s, deleted_at = NULL, last_updated_at = NOW() WHERE id = %s;
+`
+
+const removeOtherDashboardInsightViewConnectionsSql = `
+DELETE
+FROM dashboard_insight_view
+WHERE dashboard_id = %s
+  AND insight_view_id NOT IN (SELECT id FROM insight_view WHERE unique_id = ANY(%s));
+`
+
+const assignDashboardUniqueIDSql = `
+UPDATE dashboard SET unique_id = COALESCE(unique_id, %s) WHERE id = %s RETURNING unique_id;
+`
+
 const insertDashboardSql = `
 INSERT INTO dashboard (title, save, type) VALUES (%s, %s, %s) RETURNING id;
 `
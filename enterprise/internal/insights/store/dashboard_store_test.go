@@ -669,3 +669,130 @@ func TestHasDashboardPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestImportDashboard(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	ctx := context.Background()
+	store := NewDashboardStore(insightsDB)
+
+	_, err := insightsDB.ExecContext(context.Background(), `INSERT INTO insight_view (id, title, description, unique_id)
+									VALUES
+										(1, 'my view', 'my description', 'unique1234'),
+										(2, 'other view', 'other description', 'other1234')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := types.DashboardDefinition{
+		ID:       "team-dashboard",
+		Title:    "team dashboard",
+		Insights: []string{"unique1234", "other1234"},
+		Grants:   types.GrantsDefinition{Orgs: []int64{1}},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		got, err := store.ImportDashboard(ctx, def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("AfterCreateImport", &types.Dashboard{
+			ID:           1,
+			UniqueID:     valast.Addr("team-dashboard").(*string),
+			Title:        "team dashboard",
+			InsightIDs:   []string{"unique1234", "other1234"},
+			UserIdGrants: []int64{},
+			OrgIdGrants:  []int64{1},
+		}).Equal(t, got)
+	})
+
+	t.Run("import again", func(t *testing.T) {
+		if _, err := store.ImportDashboard(ctx, def); err != nil {
+			t.Fatal(err)
+		}
+		got, err := store.GetDashboards(ctx, DashboardQueryArgs{WithoutAuthorization: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("AfterRepeatedImport", []*types.Dashboard{{
+			ID:           1,
+			UniqueID:     valast.Addr("team-dashboard").(*string),
+			Title:        "team dashboard",
+			InsightIDs:   []string{"unique1234", "other1234"},
+			UserIdGrants: []int64{},
+			OrgIdGrants:  []int64{1},
+		}}).Equal(t, got)
+	})
+
+	t.Run("update deleted dashboard", func(t *testing.T) {
+		if err := store.DeleteDashboard(ctx, 1); err != nil {
+			t.Fatal(err)
+		}
+		updated := types.DashboardDefinition{
+			ID:       "team-dashboard",
+			Title:    "renamed dashboard",
+			Insights: []string{"other1234"},
+			Grants:   types.GrantsDefinition{Users: []int64{2}, Global: true},
+		}
+		got, err := store.ImportDashboard(ctx, updated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("AfterUpdateImport", &types.Dashboard{
+			ID:           1,
+			UniqueID:     valast.Addr("team-dashboard").(*string),
+			Title:        "renamed dashboard",
+			InsightIDs:   []string{"other1234"},
+			UserIdGrants: []int64{2},
+			OrgIdGrants:  []int64{},
+			GlobalGrant:  true,
+		}).Equal(t, got)
+	})
+
+	t.Run("no unique id", func(t *testing.T) {
+		if _, err := store.ImportDashboard(ctx, types.DashboardDefinition{Title: "no id"}); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestExportDashboards(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	ctx := context.Background()
+	store := NewDashboardStore(insightsDB)
+
+	_, err := insightsDB.ExecContext(context.Background(), `
+	INSERT INTO dashboard (id, title, unique_id)
+	VALUES (1, 'imported dashboard', 'imported'), (2, 'created dashboard', NULL);
+	INSERT INTO dashboard_grants (dashboard_id, global)
+	VALUES (1, true), (2, true);`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := store.ExportDashboards(ctx, DashboardQueryArgs{WithoutAuthorization: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exported) != 2 {
+		t.Fatalf("unexpected number of dashboards, want=2 have=%d", len(exported))
+	}
+	autogold.Want("ImportedDashboardDefinition", types.DashboardDefinition{
+		ID:     "imported",
+		Title:  "imported dashboard",
+		Grants: types.GrantsDefinition{Users: []int64{}, Orgs: []int64{}, Global: true},
+	}).Equal(t, exported[0])
+	if exported[1].ID == "" {
+		t.Fatal("expected dashboard to be assigned a unique ID")
+	}
+
+	// Exporting again must return the same unique ID, so that importing the definition updates the dashboard.
+	again, err := store.ExportDashboards(ctx, DashboardQueryArgs{ID: []int{2}, WithoutAuthorization: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 1 || again[0].ID != exported[1].ID {
+		t.Fatalf("unstable dashboard unique ID, want=%q have=%v", exported[1].ID, again)
+	}
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"reflect"
 	"sort"
 	"time"
 
@@ -50,6 +51,7 @@ type InsightQueryArgs struct {
 	UserID      []int
 	OrgID       []int
 	DashboardID int
+	SeriesID    string
 
 	After    string
 	Limit    int
@@ -79,6 +81,9 @@ func (s *InsightStore) Get(ctx context.Context, args InsightQueryArgs) ([]types.
 		viewConditions = append(viewConditions, sqlf.Sprintf("id in (select insight_view_id from dashboard_insight_view where dashboard_id = %s)", args.DashboardID))
 	}
 	preds = append(preds, sqlf.Sprintf("i.deleted_at IS NULL"))
+	if len(args.SeriesID) > 0 {
+		preds = append(preds, sqlf.Sprintf("i.series_id = %s", args.SeriesID))
+	}
 	if !args.WithoutAuthorization {
 		viewConditions = append(viewConditions, sqlf.Sprintf("id in (%s)", visibleViewsQuery(args.UserID, args.OrgID)))
 	}
@@ -737,6 +742,343 @@ const deleteViewSql = `
 delete from insight_view where %s;
 `
 
+// ImportInsight creates or updates the insight view with the unique ID of the given definition and attaches its
+// series. Importing the same definition many times has only one effect. Series are matched by their series ID and
+// the series that didn't exist yet are returned, so that the caller can start filling them.
+//
+// The query and scope of an existing series can't be changed by an import, since that would invalidate its
+// recorded data. Changing them requires a new series ID.
+func (s *InsightStore) ImportInsight(ctx context.Context, def types.InsightDefinition) (_ types.InsightView, created []types.InsightSeries, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return types.InsightView{}, nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	view := def.View()
+	grants := insightViewGrantsFromDefinition(def.Grants)
+	_, found, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(getViewIDByUniqueIDSql, view.UniqueID)))
+	if err != nil {
+		return types.InsightView{}, nil, errors.Wrap(err, "getViewIDByUniqueID")
+	}
+	if !found {
+		if _, err = tx.CreateView(ctx, view, grants); err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "CreateView")
+		}
+	}
+	// CreateView doesn't store the series display options, so the view is updated in both cases.
+	view, err = tx.UpdateView(ctx, view)
+	if err != nil {
+		return types.InsightView{}, nil, errors.Wrap(err, "UpdateView")
+	}
+	if found {
+		if err := tx.Exec(ctx, sqlf.Sprintf(removeViewGrantsSql, view.ID)); err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "removing existing view grants")
+		}
+		if err := tx.AddViewGrants(ctx, view, grants); err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "AddViewGrants")
+		}
+	}
+
+	attachedIDs, err := basestore.ScanStrings(tx.Query(ctx, sqlf.Sprintf(getViewSeriesIDsSql, view.ID)))
+	if err != nil {
+		return types.InsightView{}, nil, errors.Wrap(err, "getViewSeriesIDs")
+	}
+	attached := make(map[string]struct{}, len(attachedIDs))
+	for _, seriesID := range attachedIDs {
+		attached[seriesID] = struct{}{}
+	}
+	wanted := make(map[string]struct{}, len(def.Series))
+	for _, seriesDef := range def.Series {
+		wanted[seriesDef.ID] = struct{}{}
+	}
+	for _, seriesID := range attachedIDs {
+		if _, ok := wanted[seriesID]; !ok {
+			if err := tx.RemoveSeriesFromView(ctx, seriesID, view.ID); err != nil {
+				return types.InsightView{}, nil, errors.Wrap(err, "RemoveSeriesFromView")
+			}
+		}
+	}
+
+	for _, seriesDef := range def.Series {
+		series := seriesDef.Series()
+		existing, err := tx.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: series.SeriesID, IncludeDeleted: true})
+		if err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "GetDataSeries")
+		}
+		if len(existing) == 0 {
+			if series.GroupBy != nil {
+				// Compute series aren't recorded at intervals. December 31, 9999 is the maximum possible date in postgres.
+				series.NextRecordingAfter = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+				series.OldestHistoricalAt = s.Now()
+			}
+			series, err = tx.CreateSeries(ctx, series)
+			if err != nil {
+				return types.InsightView{}, nil, errors.Wrap(err, "CreateSeries")
+			}
+			created = append(created, series)
+		} else if !sameSeriesDefinition(existing[0], series) {
+			return types.InsightView{}, nil, errors.Newf("series %q already exists with a different definition", series.SeriesID)
+		} else {
+			series = existing[0]
+		}
+
+		if _, ok := attached[series.SeriesID]; ok {
+			err = tx.UpdateViewSeries(ctx, series.SeriesID, view.ID, seriesDef.Metadata())
+		} else {
+			err = tx.AttachSeriesToView(ctx, series, view, seriesDef.Metadata())
+		}
+		if err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "attaching series to view")
+		}
+
+		if err := tx.syncSeriesAlerts(ctx, series.ID, seriesDef.Alerts); err != nil {
+			return types.InsightView{}, nil, errors.Wrap(err, "syncSeriesAlerts")
+		}
+	}
+	return view, created, nil
+}
+
+// syncSeriesAlerts replaces the alert rules of the series with the given definitions. Unchanged alert rules are
+// kept, so that their recordings aren't evaluated again.
+func (s *InsightStore) syncSeriesAlerts(ctx context.Context, insightSeriesID int, defs []types.AlertDefinition) error {
+	existing, err := s.GetSeriesAlerts(ctx, GetSeriesAlertsArgs{InsightSeriesID: insightSeriesID})
+	if err != nil {
+		return err
+	}
+
+	wanted := make([]types.AlertDefinition, len(defs))
+	copy(wanted, defs)
+	for _, alert := range existing {
+		kept := false
+		for i, def := range wanted {
+			if reflect.DeepEqual(def, types.NewAlertDefinition(alert)) {
+				wanted = append(wanted[:i], wanted[i+1:]...)
+				kept = true
+				break
+			}
+		}
+		if !kept {
+			if err := s.DeleteSeriesAlert(ctx, alert.ID); err != nil {
+				return err
+			}
+		}
+	}
+	for _, def := range wanted {
+		if _, err := s.CreateSeriesAlert(ctx, def.Alert(insightSeriesID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameSeriesDefinition returns true if both series record the same data.
+func sameSeriesDefinition(a, b types.InsightSeries) bool {
+	if a.Query != b.Query ||
+		a.SampleIntervalUnit != b.SampleIntervalUnit ||
+		a.SampleIntervalValue != b.SampleIntervalValue ||
+		a.GeneratedFromCaptureGroups != b.GeneratedFromCaptureGroups ||
+		a.GenerationMethod != b.GenerationMethod ||
+		len(a.Repositories) != len(b.Repositories) {
+		return false
+	}
+	for i := range a.Repositories {
+		if a.Repositories[i] != b.Repositories[i] {
+			return false
+		}
+	}
+	if a.GroupBy == nil || b.GroupBy == nil {
+		return a.GroupBy == nil && b.GroupBy == nil
+	}
+	return *a.GroupBy == *b.GroupBy
+}
+
+// ExportInsights returns the definitions of the insight views matching the given arguments.
+func (s *InsightStore) ExportInsights(ctx context.Context, args InsightQueryArgs) ([]types.InsightDefinition, error) {
+	insights, err := s.GetMapped(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	defs := make([]types.InsightDefinition, 0, len(insights))
+	for _, insight := range insights {
+		grants, err := scanViewGrantsDefinition(s.Query(ctx, sqlf.Sprintf(getViewGrantsSql, insight.ViewID)))
+		if err != nil {
+			return nil, errors.Wrap(err, "getViewGrants")
+		}
+		def := types.NewInsightDefinition(insight, grants)
+		for i, series := range insight.Series {
+			alerts, err := s.GetSeriesAlerts(ctx, GetSeriesAlertsArgs{InsightSeriesID: series.InsightSeriesID})
+			if err != nil {
+				return nil, errors.Wrap(err, "GetSeriesAlerts")
+			}
+			for _, alert := range alerts {
+				def.Series[i].Alerts = append(def.Series[i].Alerts, types.NewAlertDefinition(alert))
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+func scanViewGrantsDefinition(rows *sql.Rows, queryErr error) (_ types.GrantsDefinition, err error) {
+	if queryErr != nil {
+		return types.GrantsDefinition{}, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var grants types.GrantsDefinition
+	for rows.Next() {
+		var userID, orgID sql.NullInt64
+		var global sql.NullBool
+		if err := rows.Scan(&userID, &orgID, &global); err != nil {
+			return types.GrantsDefinition{}, err
+		}
+		if userID.Valid {
+			grants.Users = append(grants.Users, userID.Int64)
+		}
+		if orgID.Valid {
+			grants.Orgs = append(grants.Orgs, orgID.Int64)
+		}
+		if global.Valid && global.Bool {
+			grants.Global = true
+		}
+	}
+	return grants, nil
+}
+
+const getViewIDByUniqueIDSql = `
+SELECT id FROM insight_view WHERE unique_id = %s;
+`
+
+const removeViewGrantsSql = `
+DELETE FROM insight_view_grants WHERE insight_view_id = %s;
+`
+
+const getViewSeriesIDsSql = `
+SELECT s.series_id
+FROM insight_view_series vs
+	JOIN insight_series s ON vs.insight_series_id = s.id
+WHERE vs.insight_view_id = %s
+ORDER BY s.series_id;
+`
+
+const getViewGrantsSql = `
+SELECT user_id, org_id, global FROM insight_view_grants WHERE insight_view_id = %s ORDER BY id;
+`
+
+// CreateSeriesAlert creates an alert rule on the insight series referenced by the alert.
+func (s *InsightStore) CreateSeriesAlert(ctx context.Context, alert types.SeriesAlert) (types.SeriesAlert, error) {
+	if alert.InsightSeriesID == 0 {
+		return types.SeriesAlert{}, errors.New("unable to create alert invalid series ID")
+	}
+	if alert.EmailUserID == nil && alert.SlackWebhookURL == nil && alert.WebhookURL == nil {
+		return types.SeriesAlert{}, errors.New("unable to create alert without recipient")
+	}
+	switch alert.Kind {
+	case types.AlertAbove, types.AlertBelow, types.AlertDelta:
+	default:
+		return types.SeriesAlert{}, errors.Newf("unable to create alert unknown kind %q", alert.Kind)
+	}
+
+	id, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(createSeriesAlertSql,
+		alert.InsightSeriesID,
+		alert.Kind,
+		alert.Threshold,
+		alert.EmailUserID,
+		alert.SlackWebhookURL,
+		alert.WebhookURL,
+	)))
+	if err != nil {
+		return types.SeriesAlert{}, errors.Wrap(err, "failed to insert series alert")
+	}
+	alert.ID = id
+	return alert, nil
+}
+
+// GetSeriesAlertsArgs contains query predicates for fetching alert rules.
+type GetSeriesAlertsArgs struct {
+	InsightSeriesID int
+}
+
+// GetSeriesAlerts returns the alert rules of enabled insight series.
+func (s *InsightStore) GetSeriesAlerts(ctx context.Context, args GetSeriesAlertsArgs) ([]types.SeriesAlert, error) {
+	preds := []*sqlf.Query{sqlf.Sprintf("s.deleted_at IS NULL")}
+	if args.InsightSeriesID != 0 {
+		preds = append(preds, sqlf.Sprintf("a.insight_series_id = %s", args.InsightSeriesID))
+	}
+	return scanSeriesAlerts(s.Query(ctx, sqlf.Sprintf(getSeriesAlertsSql, sqlf.Join(preds, "\n AND"))))
+}
+
+// DeleteSeriesAlert deletes an alert rule.
+func (s *InsightStore) DeleteSeriesAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteSeriesAlertSql, id))
+}
+
+// StampSeriesAlert records that the alert rule was evaluated against the recording of the series at the given time,
+// so that every recording is evaluated once, along with the channels the alert still has to be delivered to.
+func (s *InsightStore) StampSeriesAlert(ctx context.Context, id int, recordingTime time.Time, triggered bool, undeliveredChannels []string) error {
+	if undeliveredChannels == nil {
+		undeliveredChannels = []string{}
+	}
+	return s.Exec(ctx, sqlf.Sprintf(stampSeriesAlertSql, recordingTime, triggered, s.Now(), pq.Array(undeliveredChannels), id))
+}
+
+func scanSeriesAlerts(rows *sql.Rows, queryErr error) (_ []types.SeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.SeriesAlert, 0)
+	for rows.Next() {
+		var temp types.SeriesAlert
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.InsightSeriesID,
+			&temp.Kind,
+			&temp.Threshold,
+			&temp.EmailUserID,
+			&temp.SlackWebhookURL,
+			&temp.WebhookURL,
+			&temp.LastEvaluatedAt,
+			&temp.LastTriggeredAt,
+			pq.Array(&temp.UndeliveredChannels),
+		); err != nil {
+			return nil, err
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+const createSeriesAlertSql = `
+INSERT INTO insight_series_alerts (insight_series_id, kind, threshold, email_user_id, slack_webhook_url, webhook_url)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING id;
+`
+
+const getSeriesAlertsSql = `
+SELECT a.id, a.insight_series_id, a.kind, a.threshold, a.email_user_id, a.slack_webhook_url, a.webhook_url,
+a.last_evaluated_at, a.last_triggered_at, a.undelivered_channels
+FROM insight_series_alerts a
+JOIN insight_series s ON a.insight_series_id = s.id
+WHERE %s
+ORDER BY a.id
+`
+
+const deleteSeriesAlertSql = `
+DELETE FROM insight_series_alerts WHERE id = %s;
+`
+
+const stampSeriesAlertSql = `
+UPDATE insight_series_alerts
+SET last_evaluated_at = %s,
+    last_triggered_at = CASE WHEN %s THEN %s ELSE last_triggered_at END,
+    undelivered_channels = %s
+WHERE id = %s;
+`
+
 // IncrementBackfillAttempts increments backfill_attempts to track how many attempts at backfilling a series has taken.
 func (s *InsightStore) IncrementBackfillAttempts(ctx context.Context, series types.InsightSeries) error {
 	return s.Exec(ctx, sqlf.Sprintf(incrementSeriesBackfillAttemptsSql, series.SeriesID))
@@ -882,6 +1224,9 @@ type MatchSeriesArgs struct {
 	StepIntervalValue         int
 	GenerateFromCaptureGroups bool
 	GroupBy                   *string
+	// GenerationMethod restricts the matches to series generated with the method. Series that aren't generated
+	// from a search query, like precise references or breakdown series, only match when it is set.
+	GenerationMethod types.GenerationMethod
 }
 
 func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesArgs) (_ types.InsightSeries, found bool, _ error) {
@@ -889,9 +1234,13 @@ func (s *InsightStore) FindMatchingSeries(ctx context.Context, args MatchSeriesA
 	if args.GroupBy != nil {
 		groupByClause = sqlf.Sprintf("group_by = %s", *args.GroupBy)
 	}
+	generationMethodClause := sqlf.Sprintf("generation_method NOT IN (%s, %s, %s)", types.PreciseReferences, types.SearchAuthorBreakdown, types.SearchCodeOwnerBreakdown)
+	if args.GenerationMethod != "" {
+		generationMethodClause = sqlf.Sprintf("generation_method = %s", args.GenerationMethod)
+	}
 	where := sqlf.Sprintf(
-		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s",
-		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause,
+		"(repositories = '{}' OR repositories is NULL) AND query = %s AND sample_interval_unit = %s AND sample_interval_value = %s AND generated_from_capture_groups = %s AND %s AND %s",
+		args.Query, args.StepIntervalUnit, args.StepIntervalValue, args.GenerateFromCaptureGroups, groupByClause, generationMethodClause,
 	)
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, where)
@@ -970,6 +1319,58 @@ func (s *InsightStore) SetSeriesBackfillComplete(ctx context.Context, seriesId s
 	return s.Exec(ctx, sqlf.Sprintf(setSeriesBackfillComplete, timestamp, seriesId))
 }
 
+// AddSeriesBreakdownValues adds the given values, in order, to the values the results of the breakdown series are
+// recorded under until the series has limit values, and returns the values of the series. Values are never removed,
+// so every recording of the series, historical or not, records its results under the same values.
+func (s *InsightStore) AddSeriesBreakdownValues(ctx context.Context, id int, values []string, limit int) (_ []string, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	var current []string
+	if err := tx.QueryRow(ctx, sqlf.Sprintf(getSeriesBreakdownValuesSql, id)).Scan(pq.Array(&current)); err != nil {
+		return nil, errors.Wrap(err, "getSeriesBreakdownValues")
+	}
+	kept := make(map[string]struct{}, len(current))
+	for _, value := range current {
+		kept[value] = struct{}{}
+	}
+	added := false
+	for _, value := range values {
+		if len(current) >= limit {
+			break
+		}
+		if _, ok := kept[value]; ok {
+			continue
+		}
+		kept[value] = struct{}{}
+		current = append(current, value)
+		added = true
+	}
+	if !added {
+		return current, nil
+	}
+	if err := tx.Exec(ctx, sqlf.Sprintf(setSeriesBreakdownValuesSql, pq.Array(current), id)); err != nil {
+		return nil, errors.Wrap(err, "setSeriesBreakdownValues")
+	}
+	return current, nil
+}
+
+const getSeriesBreakdownValuesSql = `
+SELECT breakdown_values
+FROM insight_series
+WHERE id = %s
+FOR UPDATE;
+`
+
+const setSeriesBreakdownValuesSql = `
+UPDATE insight_series
+SET breakdown_values = %s
+WHERE id = %s;
+`
+
 const setSeriesStatusSql = `
 UPDATE insight_series
 SET deleted_at = %s
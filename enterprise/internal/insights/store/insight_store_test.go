@@ -2104,6 +2104,70 @@ func TestFindMatchingSeries(t *testing.T) {
 		autogold.Equal(t, gotSeries, autogold.ExportedOnly())
 		autogold.Want("FoundTrueCaptureGroups", true).Equal(t, gotFound)
 	})
+	t.Run("match breakdown series by generation method", func(t *testing.T) {
+		_, err := store.CreateSeries(ctx, types.InsightSeries{
+			SeriesID:                   "series id author breakdown",
+			Query:                      "type:diff query 3",
+			CreatedAt:                  now,
+			OldestHistoricalAt:         now,
+			LastRecordedAt:             now,
+			NextRecordingAfter:         now,
+			LastSnapshotAt:             now,
+			NextSnapshotAfter:          now,
+			BackfillQueuedAt:           now,
+			SampleIntervalUnit:         string(types.Week),
+			SampleIntervalValue:        1,
+			GeneratedFromCaptureGroups: true,
+			GenerationMethod:           types.SearchAuthorBreakdown,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, gotFound, err := store.FindMatchingSeries(ctx, MatchSeriesArgs{Query: "type:diff query 3", StepIntervalUnit: string(types.Week), StepIntervalValue: 1, GenerateFromCaptureGroups: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("FoundFalseBreakdown", false).Equal(t, gotFound)
+
+		gotSeries, gotFound, err := store.FindMatchingSeries(ctx, MatchSeriesArgs{Query: "type:diff query 3", StepIntervalUnit: string(types.Week), StepIntervalValue: 1, GenerateFromCaptureGroups: true, GenerationMethod: types.SearchAuthorBreakdown})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("FoundTrueBreakdown", true).Equal(t, gotFound)
+		autogold.Want("FoundBreakdownSeriesID", "series id author breakdown").Equal(t, gotSeries.SeriesID)
+	})
+	t.Run("match precise references series by generation method", func(t *testing.T) {
+		query := "scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap"
+		_, err := store.CreateSeries(ctx, types.InsightSeries{
+			SeriesID:            "series id precise references",
+			Query:               query,
+			CreatedAt:           now,
+			OldestHistoricalAt:  now,
+			LastRecordedAt:      now,
+			NextRecordingAfter:  now,
+			LastSnapshotAt:      now,
+			NextSnapshotAfter:   now,
+			BackfillQueuedAt:    now,
+			SampleIntervalUnit:  string(types.Week),
+			SampleIntervalValue: 1,
+			GenerationMethod:    types.PreciseReferences,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, gotFound, err := store.FindMatchingSeries(ctx, MatchSeriesArgs{Query: query, StepIntervalUnit: string(types.Week), StepIntervalValue: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("FoundFalsePreciseReferencesWithoutMethod", false).Equal(t, gotFound)
+
+		gotSeries, gotFound, err := store.FindMatchingSeries(ctx, MatchSeriesArgs{Query: query, StepIntervalUnit: string(types.Week), StepIntervalValue: 1, GenerationMethod: types.PreciseReferences})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("FoundTruePreciseReferences", true).Equal(t, gotFound)
+		autogold.Want("FoundPreciseReferencesSeriesID", "series id precise references").Equal(t, gotSeries.SeriesID)
+	})
 }
 
 func TestUpdateFrontendSeries(t *testing.T) {
@@ -2619,3 +2683,281 @@ func TestHardDeleteSeries(t *testing.T) {
 		t.Errorf("expected 1 recording times to remain for series2")
 	}
 }
+
+func TestImportInsight(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	now := time.Date(2022, 11, 22, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	def := types.InsightDefinition{
+		ID:    "gitops-insight",
+		Title: "my insight",
+		Series: []types.SeriesDefinition{
+			{ID: "series-1", Label: "todos", Stroke: "blue", Query: "TODO", IntervalUnit: types.Week, IntervalValue: 2},
+			{ID: "series-2", Label: "fixmes", Stroke: "red", Query: "FIXME", Repositories: []string{"github.com/sourcegraph/sourcegraph"}},
+		},
+		Grants: types.GrantsDefinition{Global: true},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		view, created, err := store.ImportInsight(ctx, def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("ImportedView", types.InsightView{
+			ID: 1, Title: "my insight", UniqueID: "gitops-insight",
+			PresentationType: types.PresentationType("LINE"),
+		}).Equal(t, view)
+		createdIDs := make([]string, 0, len(created))
+		for _, series := range created {
+			createdIDs = append(createdIDs, series.SeriesID)
+		}
+		autogold.Want("CreatedSeries", []string{"series-1", "series-2"}).Equal(t, createdIDs)
+	})
+
+	t.Run("import again", func(t *testing.T) {
+		_, created, err := store.ImportInsight(ctx, def)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(created) != 0 {
+			t.Fatalf("expected no series to be created, got %d", len(created))
+		}
+		exported, err := store.ExportInsights(ctx, InsightQueryArgs{WithoutAuthorization: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("ExportedDefinitions", []types.InsightDefinition{{
+			ID:               "gitops-insight",
+			Title:            "my insight",
+			PresentationType: types.PresentationType("LINE"),
+			Series: []types.SeriesDefinition{
+				{
+					ID:               "series-1",
+					Label:            "todos",
+					Stroke:           "blue",
+					Query:            "TODO",
+					IntervalUnit:     types.IntervalUnit("WEEK"),
+					IntervalValue:    2,
+					GenerationMethod: types.GenerationMethod("search"),
+				},
+				{
+					ID:               "series-2",
+					Label:            "fixmes",
+					Stroke:           "red",
+					Query:            "FIXME",
+					Repositories:     []string{"github.com/sourcegraph/sourcegraph"},
+					IntervalUnit:     types.IntervalUnit("MONTH"),
+					IntervalValue:    1,
+					GenerationMethod: types.GenerationMethod("search"),
+				},
+			},
+			Grants: types.GrantsDefinition{Global: true},
+		}}).Equal(t, exported)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updated := def
+		updated.Title = "renamed insight"
+		updated.Series = []types.SeriesDefinition{
+			{ID: "series-1", Label: "renamed todos", Stroke: "green", Query: "TODO", IntervalUnit: types.Week, IntervalValue: 2},
+		}
+		updated.Grants = types.GrantsDefinition{Users: []int64{1}}
+		view, created, err := store.ImportInsight(ctx, updated)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(created) != 0 {
+			t.Fatalf("expected no series to be created, got %d", len(created))
+		}
+		autogold.Want("UpdatedView", types.InsightView{
+			ID: 1, Title: "renamed insight", UniqueID: "gitops-insight",
+			PresentationType: types.PresentationType("LINE"),
+		}).Equal(t, view)
+
+		got, err := store.Get(ctx, InsightQueryArgs{UserID: []int{1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("unexpected number of series, want=1 have=%d", len(got))
+		}
+		autogold.Want("UpdatedSeriesMetadata", []string{"series-1", "renamed todos", "green"}).Equal(t, []string{got[0].SeriesID, got[0].Label, got[0].LineColor})
+
+		// The removed series is no longer referenced by any view and is soft-deleted.
+		series, err := store.GetDataSeries(ctx, GetDataSeriesArgs{SeriesID: "series-2", IncludeDeleted: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Want("RemovedSeriesEnabled", false).Equal(t, series[0].Enabled)
+	})
+
+	t.Run("changed series definition", func(t *testing.T) {
+		changed := def
+		changed.Series = []types.SeriesDefinition{{ID: "series-1", Query: "TODO OR FIXME", IntervalUnit: types.Week, IntervalValue: 2}}
+		if _, _, err := store.ImportInsight(ctx, changed); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestSeriesAlerts(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	now := time.Date(2022, 11, 23, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	webhookURL := "https://example.com/webhook"
+	def := types.InsightDefinition{
+		ID:    "alerting-insight",
+		Title: "alerts",
+		Series: []types.SeriesDefinition{{
+			ID:    "series-1",
+			Query: "TODO",
+			Alerts: []types.AlertDefinition{
+				{Kind: types.AlertAbove, Threshold: 100, WebhookURL: &webhookURL},
+				{Kind: types.AlertDelta, Threshold: 10, WebhookURL: &webhookURL},
+			},
+		}},
+		Grants: types.GrantsDefinition{Global: true},
+	}
+	if _, _, err := store.ImportInsight(ctx, def); err != nil {
+		t.Fatal(err)
+	}
+
+	alerts, err := store.GetSeriesAlerts(ctx, GetSeriesAlertsArgs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("ImportedAlerts", []types.SeriesAlert{
+		{ID: 1, InsightSeriesID: 1, Kind: types.SeriesAlertKind("ABOVE"), Threshold: 100, WebhookURL: &webhookURL, UndeliveredChannels: []string{}},
+		{ID: 2, InsightSeriesID: 1, Kind: types.SeriesAlertKind("DELTA"), Threshold: 10, WebhookURL: &webhookURL, UndeliveredChannels: []string{}},
+	}).Equal(t, alerts)
+
+	t.Run("stamp", func(t *testing.T) {
+		recordingTime := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+		if err := store.StampSeriesAlert(ctx, 1, recordingTime, true, []string{"webhook"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.StampSeriesAlert(ctx, 2, recordingTime, false, nil); err != nil {
+			t.Fatal(err)
+		}
+		alerts, err := store.GetSeriesAlerts(ctx, GetSeriesAlertsArgs{InsightSeriesID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(alerts) != 2 {
+			t.Fatalf("unexpected number of alerts, want=2 have=%d", len(alerts))
+		}
+		autogold.Want("StampedTriggered", []string{"2022-11-01T00:00:00Z", "2022-11-23T00:00:00Z"}).Equal(t, []string{alerts[0].LastEvaluatedAt.UTC().Format(time.RFC3339), alerts[0].LastTriggeredAt.UTC().Format(time.RFC3339)})
+		if alerts[1].LastTriggeredAt != nil {
+			t.Fatalf("expected alert that didn't trigger to have no trigger time, got %v", alerts[1].LastTriggeredAt)
+		}
+		if diff := cmp.Diff([]string{"webhook"}, alerts[0].UndeliveredChannels); diff != "" {
+			t.Fatalf("unexpected undelivered channels (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("import keeps unchanged alerts", func(t *testing.T) {
+		updated := def
+		updated.Series = []types.SeriesDefinition{{
+			ID:    "series-1",
+			Query: "TODO",
+			Alerts: []types.AlertDefinition{
+				{Kind: types.AlertAbove, Threshold: 100, WebhookURL: &webhookURL},
+				{Kind: types.AlertBelow, Threshold: 5, WebhookURL: &webhookURL},
+			},
+		}}
+		if _, _, err := store.ImportInsight(ctx, updated); err != nil {
+			t.Fatal(err)
+		}
+		alerts, err := store.GetSeriesAlerts(ctx, GetSeriesAlertsArgs{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]int, 0, len(alerts))
+		for _, alert := range alerts {
+			ids = append(ids, alert.ID)
+		}
+		autogold.Want("AlertIDsAfterUpdate", []int{1, 3}).Equal(t, ids)
+
+		exported, err := store.ExportInsights(ctx, InsightQueryArgs{WithoutAuthorization: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(updated.Series[0].Alerts, exported[0].Series[0].Alerts); diff != "" {
+			t.Fatalf("unexpected exported alerts (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("alert without recipient", func(t *testing.T) {
+		if _, err := store.CreateSeriesAlert(ctx, types.SeriesAlert{InsightSeriesID: 1, Kind: types.AlertAbove}); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestAddSeriesBreakdownValues(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	now := time.Date(2021, 10, 14, 0, 0, 0, 0, time.UTC).Round(0).Truncate(time.Microsecond)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	series, err := store.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		SampleIntervalUnit: string(types.Month),
+		GenerationMethod:   types.SearchAuthorBreakdown,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := store.AddSeriesBreakdownValues(ctx, series.ID, []string{"alice", "bob", "carol"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"alice", "bob"}, got); diff != "" {
+		t.Errorf("unexpected values after first recording (-want +got):\n%s", diff)
+	}
+
+	// Later recordings keep the values of the first one even if other values have more results.
+	got, err = store.AddSeriesBreakdownValues(ctx, series.ID, []string{"carol", "bob"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"alice", "bob"}, got); diff != "" {
+		t.Errorf("unexpected values after second recording (-want +got):\n%s", diff)
+	}
+
+	// Raising the limit adds new values after the existing ones.
+	got, err = store.AddSeriesBreakdownValues(ctx, series.ID, []string{"carol", "alice", "dave"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"alice", "bob", "carol"}, got); diff != "" {
+		t.Errorf("unexpected values after raising the limit (-want +got):\n%s", diff)
+	}
+}
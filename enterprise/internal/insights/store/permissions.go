@@ -8,6 +8,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -148,3 +149,31 @@ func GlobalDashboardGrant() DashboardGrant {
 	b := true
 	return DashboardGrant{Global: &b}
 }
+
+func dashboardGrantsFromDefinition(def types.GrantsDefinition) []DashboardGrant {
+	grants := make([]DashboardGrant, 0, len(def.Users)+len(def.Orgs)+1)
+	for _, userID := range def.Users {
+		grants = append(grants, UserDashboardGrant(int(userID)))
+	}
+	for _, orgID := range def.Orgs {
+		grants = append(grants, OrgDashboardGrant(int(orgID)))
+	}
+	if def.Global {
+		grants = append(grants, GlobalDashboardGrant())
+	}
+	return grants
+}
+
+func insightViewGrantsFromDefinition(def types.GrantsDefinition) []InsightViewGrant {
+	grants := make([]InsightViewGrant, 0, len(def.Users)+len(def.Orgs)+1)
+	for _, userID := range def.Users {
+		grants = append(grants, UserGrant(int(userID)))
+	}
+	for _, orgID := range def.Orgs {
+		grants = append(grants, OrgGrant(int(orgID)))
+	}
+	if def.Global {
+		grants = append(grants, GlobalGrant())
+	}
+	return grants
+}
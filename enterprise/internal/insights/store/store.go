@@ -10,6 +10,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -501,6 +502,82 @@ func countDataQuery(opts CountDataOpts) *sqlf.Query {
 	)
 }
 
+// SeriesRepoIDs returns the IDs of the repositories in the breakdown of the
+// given series, which are the repositories with a non-zero value at the most
+// recent point in time.
+func (s *Store) SeriesRepoIDs(ctx context.Context, seriesID string) ([]api.RepoID, error) {
+	// 🚨 SECURITY: Repositories the current user cannot see are excluded, the
+	// same way they are excluded from the points of the series. See SeriesPoints. 🚨
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	excluded := make([]int32, 0, len(denylist))
+	for _, id := range denylist {
+		excluded = append(excluded, int32(id))
+	}
+
+	ids, err := basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(seriesRepoIDsFmtstr, seriesID, seriesID, pq.Array(excluded))))
+	if err != nil {
+		return nil, errors.Wrap(err, "ScanInts")
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(ids))
+	for _, id := range ids {
+		repoIDs = append(repoIDs, api.RepoID(id))
+	}
+	return repoIDs, nil
+}
+
+const seriesRepoIDsFmtstr = `
+WITH points AS (
+	SELECT time, repo_id, value FROM series_points WHERE series_id = %s
+	UNION ALL
+	SELECT time, repo_id, value FROM series_points_snapshots WHERE series_id = %s
+)
+SELECT DISTINCT repo_id FROM points
+WHERE repo_id IS NOT NULL AND value > 0 AND time = (SELECT MAX(time) FROM points)
+AND NOT repo_id = ANY(%s)
+ORDER BY repo_id
+`
+
+// RecordingTotal is the total value of a series over all repositories at one of its recordings.
+type RecordingTotal struct {
+	Time  time.Time
+	Value float64
+}
+
+// LatestRecordingTotals returns the totals of the given series at its most recent recordings, newest first.
+// Snapshots are not recordings and are ignored.
+func (s *Store) LatestRecordingTotals(ctx context.Context, seriesID string, limit int) (_ []RecordingTotal, err error) {
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(latestRecordingTotalsFmtstr, seriesID, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var totals []RecordingTotal
+	for rows.Next() {
+		var total RecordingTotal
+		if err := rows.Scan(&total.Time, &total.Value); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+	return totals, nil
+}
+
+const latestRecordingTotalsFmtstr = `
+SELECT rt.recording_time, COALESCE(SUM(sp.value), 0)
+FROM insight_series_recording_times rt
+	JOIN insight_series s ON rt.insight_series_id = s.id
+	LEFT JOIN series_points sp ON sp.series_id = s.series_id AND date_trunc('seconds', sp.time) = date_trunc('seconds', rt.recording_time)
+WHERE s.series_id = %s AND rt.snapshot IS FALSE
+GROUP BY rt.recording_time
+ORDER BY rt.recording_time DESC
+LIMIT %s
+`
+
 func (s *Store) DeleteSnapshots(ctx context.Context, series *types.InsightSeries) error {
 	if series == nil {
 		return errors.New("invalid input for Delete Snapshots")
@@ -219,6 +219,94 @@ func TestCountData(t *testing.T) {
 	autogold.Want("third", int(5)).Equal(t, numDataPoints)
 }
 
+func TestSeriesRepoIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t))
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	older := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	records := []RecordSeriesPointArgs{
+		// Only matched in an older point, so not part of the breakdown.
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: older, Value: 1},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(1),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: newer, Value: 2},
+			RepoName:    optionalString("repo3"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: newer, Value: 0},
+			RepoName:    optionalString("repo4"),
+			RepoID:      optionalRepoID(4),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: newer, Value: 5},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(2),
+			PersistMode: SnapshotMode,
+		},
+		{
+			SeriesID:    "two",
+			Point:       SeriesPoint{Time: newer, Value: 1},
+			RepoName:    optionalString("repo5"),
+			RepoID:      optionalRepoID(5),
+			PersistMode: RecordMode,
+		},
+	}
+	if err := store.RecordSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	repoIDs, err := store.SeriesRepoIDs(ctx, "one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("series one", []api.RepoID{api.RepoID(2), api.RepoID(3)}).Equal(t, repoIDs)
+
+	repoIDs, err = store.SeriesRepoIDs(ctx, "unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("unknown series", []api.RepoID{}).Equal(t, repoIDs)
+
+	// Repositories the user cannot see are excluded.
+	store = NewWithClock(insightsDB, unauthorizedRepoIDs{3}, clock)
+	repoIDs, err = store.SeriesRepoIDs(ctx, "one")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Want("series one without unauthorized repos", []api.RepoID{api.RepoID(2)}).Equal(t, repoIDs)
+}
+
+// unauthorizedRepoIDs is an InsightPermissionStore denying access to the given repositories.
+type unauthorizedRepoIDs []api.RepoID
+
+func (u unauthorizedRepoIDs) GetUnauthorizedRepoIDs(context.Context) ([]api.RepoID, error) {
+	return u, nil
+}
+
 func TestRecordSeriesPoints(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
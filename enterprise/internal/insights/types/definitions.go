@@ -0,0 +1,312 @@
+package types
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefinitionsVersion is the version of the insights definitions format written by MarshalDefinitions. Documents
+// with a different version are rejected by ParseDefinitions.
+const DefinitionsVersion = 1
+
+// Definitions is the serializable representation of insight views, their data series and dashboards. Insights
+// and dashboards are identified by stable IDs so that importing the same definitions many times is idempotent.
+type Definitions struct {
+	Version    int                   `yaml:"version"`
+	Insights   []InsightDefinition   `yaml:"insights,omitempty"`
+	Dashboards []DashboardDefinition `yaml:"dashboards,omitempty"`
+}
+
+// InsightDefinition is the serializable representation of an InsightView and its series. ID is the unique ID of
+// the insight view.
+type InsightDefinition struct {
+	ID                  string               `yaml:"id"`
+	Title               string               `yaml:"title"`
+	Description         string               `yaml:"description,omitempty"`
+	PresentationType    PresentationType     `yaml:"presentationType,omitempty"`
+	Filters             InsightViewFilters   `yaml:"filters,omitempty"`
+	OtherThreshold      *float64             `yaml:"otherThreshold,omitempty"`
+	SeriesSortMode      *SeriesSortMode      `yaml:"seriesSortMode,omitempty"`
+	SeriesSortDirection *SeriesSortDirection `yaml:"seriesSortDirection,omitempty"`
+	SeriesLimit         *int32               `yaml:"seriesLimit,omitempty"`
+	Series              []SeriesDefinition   `yaml:"series"`
+	Grants              GrantsDefinition     `yaml:"grants,omitempty"`
+}
+
+// SeriesDefinition is the serializable representation of an InsightSeries attached to an insight view. ID is the
+// series ID of the data series, which may be shared by many insight views.
+type SeriesDefinition struct {
+	ID                         string            `yaml:"id"`
+	Label                      string            `yaml:"label,omitempty"`
+	Stroke                     string            `yaml:"stroke,omitempty"`
+	Query                      string            `yaml:"query"`
+	Repositories               []string          `yaml:"repositories,omitempty"`
+	IntervalUnit               IntervalUnit      `yaml:"intervalUnit,omitempty"`
+	IntervalValue              int               `yaml:"intervalValue,omitempty"`
+	GeneratedFromCaptureGroups bool              `yaml:"generatedFromCaptureGroups,omitempty"`
+	GenerationMethod           GenerationMethod  `yaml:"generationMethod,omitempty"`
+	GroupBy                    *string           `yaml:"groupBy,omitempty"`
+	Alerts                     []AlertDefinition `yaml:"alerts,omitempty"`
+}
+
+// AlertDefinition is the serializable representation of a SeriesAlert. At least one recipient must be set.
+type AlertDefinition struct {
+	Kind            SeriesAlertKind `yaml:"kind"`
+	Threshold       float64         `yaml:"threshold"`
+	EmailUserID     *int32          `yaml:"emailUserID,omitempty"`
+	SlackWebhookURL *string         `yaml:"slackWebhookURL,omitempty"`
+	WebhookURL      *string         `yaml:"webhookURL,omitempty"`
+}
+
+// DashboardDefinition is the serializable representation of a Dashboard. ID is the unique ID of the dashboard and
+// Insights contains the unique IDs of the insight views on the dashboard.
+type DashboardDefinition struct {
+	ID       string           `yaml:"id"`
+	Title    string           `yaml:"title"`
+	Insights []string         `yaml:"insights,omitempty"`
+	Grants   GrantsDefinition `yaml:"grants,omitempty"`
+}
+
+// GrantsDefinition lists the principals that are granted access to an insight view or a dashboard.
+type GrantsDefinition struct {
+	Users  []int64 `yaml:"users,omitempty"`
+	Orgs   []int64 `yaml:"orgs,omitempty"`
+	Global bool    `yaml:"global,omitempty"`
+}
+
+// ParseDefinitions parses and validates insights definitions from YAML.
+func ParseDefinitions(data []byte) (*Definitions, error) {
+	var defs Definitions
+	if err := yaml.Unmarshal(data, &defs); err != nil {
+		return nil, errors.Wrap(err, "parsing insights definitions")
+	}
+	if err := defs.Validate(); err != nil {
+		return nil, err
+	}
+	return &defs, nil
+}
+
+// MarshalDefinitions serializes the given insights definitions to YAML, using the current DefinitionsVersion.
+func MarshalDefinitions(defs Definitions) ([]byte, error) {
+	defs.Version = DefinitionsVersion
+	return yaml.Marshal(defs)
+}
+
+// Validate returns an error if the definitions can't be imported.
+func (d *Definitions) Validate() error {
+	if d.Version != DefinitionsVersion {
+		return errors.Newf("unsupported insights definitions version %d", d.Version)
+	}
+
+	var errs error
+	insightIDs := make(map[string]struct{}, len(d.Insights))
+	for _, insight := range d.Insights {
+		if insight.ID == "" {
+			errs = errors.Append(errs, errors.Newf("insight %q has no id", insight.Title))
+			continue
+		}
+		if _, ok := insightIDs[insight.ID]; ok {
+			errs = errors.Append(errs, errors.Newf("insight id %q is used more than once", insight.ID))
+		}
+		insightIDs[insight.ID] = struct{}{}
+		errs = errors.Append(errs, insight.validate())
+	}
+
+	dashboardIDs := make(map[string]struct{}, len(d.Dashboards))
+	for _, dashboard := range d.Dashboards {
+		if dashboard.ID == "" {
+			errs = errors.Append(errs, errors.Newf("dashboard %q has no id", dashboard.Title))
+			continue
+		}
+		if _, ok := dashboardIDs[dashboard.ID]; ok {
+			errs = errors.Append(errs, errors.Newf("dashboard id %q is used more than once", dashboard.ID))
+		}
+		dashboardIDs[dashboard.ID] = struct{}{}
+	}
+	return errs
+}
+
+func (d InsightDefinition) validate() error {
+	switch d.PresentationType {
+	case "", Line, Pie:
+	default:
+		return errors.Newf("insight %q has unknown presentation type %q", d.ID, d.PresentationType)
+	}
+	if len(d.Series) == 0 {
+		return errors.Newf("insight %q has no series", d.ID)
+	}
+
+	var errs error
+	seriesIDs := make(map[string]struct{}, len(d.Series))
+	for _, series := range d.Series {
+		if series.ID == "" {
+			errs = errors.Append(errs, errors.Newf("insight %q has a series without id", d.ID))
+			continue
+		}
+		if _, ok := seriesIDs[series.ID]; ok {
+			errs = errors.Append(errs, errors.Newf("insight %q uses series id %q more than once", d.ID, series.ID))
+		}
+		seriesIDs[series.ID] = struct{}{}
+		if series.Query == "" {
+			errs = errors.Append(errs, errors.Newf("series %q of insight %q has no query", series.ID, d.ID))
+		}
+		if series.GenerationMethod == PreciseReferences {
+			if _, err := ParsePreciseReferencesQuery(series.Query); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "series %q of insight %q", series.ID, d.ID))
+			}
+			if series.GroupBy != nil {
+				errs = errors.Append(errs, errors.Newf("series %q of insight %q can't group precise references", series.ID, d.ID))
+			}
+		}
+		if series.GenerationMethod.IsBreakdown() && series.GroupBy != nil {
+			errs = errors.Append(errs, errors.Newf("series %q of insight %q can't group a breakdown series", series.ID, d.ID))
+		}
+		switch series.IntervalUnit {
+		case "", Month, Day, Week, Year, Hour:
+		default:
+			errs = errors.Append(errs, errors.Newf("series %q of insight %q has unknown interval unit %q", series.ID, d.ID, series.IntervalUnit))
+		}
+		for _, alert := range series.Alerts {
+			switch alert.Kind {
+			case AlertAbove, AlertBelow, AlertDelta:
+			default:
+				errs = errors.Append(errs, errors.Newf("series %q of insight %q has an alert with unknown kind %q", series.ID, d.ID, alert.Kind))
+			}
+			if alert.EmailUserID == nil && alert.SlackWebhookURL == nil && alert.WebhookURL == nil {
+				errs = errors.Append(errs, errors.Newf("series %q of insight %q has an alert without recipient", series.ID, d.ID))
+			}
+		}
+	}
+	return errs
+}
+
+// View returns the insight view described by the definition.
+func (d InsightDefinition) View() InsightView {
+	presentationType := d.PresentationType
+	if presentationType == "" {
+		presentationType = Line
+	}
+	return InsightView{
+		Title:               d.Title,
+		Description:         d.Description,
+		UniqueID:            d.ID,
+		Filters:             d.Filters,
+		OtherThreshold:      d.OtherThreshold,
+		PresentationType:    presentationType,
+		SeriesSortMode:      d.SeriesSortMode,
+		SeriesSortDirection: d.SeriesSortDirection,
+		SeriesLimit:         d.SeriesLimit,
+	}
+}
+
+// Series returns the data series described by the definition.
+func (d SeriesDefinition) Series() InsightSeries {
+	intervalUnit, intervalValue := d.IntervalUnit, d.IntervalValue
+	if intervalUnit == "" {
+		intervalUnit = Month
+	}
+	if intervalValue == 0 {
+		intervalValue = 1
+	}
+	generationMethod := d.GenerationMethod
+	if generationMethod == "" {
+		generationMethod = Search
+	}
+	var groupBy *string
+	if d.GroupBy != nil {
+		lowercased := strings.ToLower(*d.GroupBy)
+		groupBy = &lowercased
+	}
+	return InsightSeries{
+		SeriesID:            d.ID,
+		Query:               d.Query,
+		Repositories:        d.Repositories,
+		SampleIntervalUnit:  string(intervalUnit),
+		SampleIntervalValue: intervalValue,
+		// Breakdown series are always expanded into one series per recorded value.
+		GeneratedFromCaptureGroups: d.GeneratedFromCaptureGroups || generationMethod.IsBreakdown(),
+		GenerationMethod:           generationMethod,
+		GroupBy:                    groupBy,
+	}
+}
+
+// Metadata returns the render properties of the series in its insight view.
+func (d SeriesDefinition) Metadata() InsightViewSeriesMetadata {
+	return InsightViewSeriesMetadata{Label: d.Label, Stroke: d.Stroke}
+}
+
+// Alert returns the alert rule described by the definition on the given series.
+func (d AlertDefinition) Alert(insightSeriesID int) SeriesAlert {
+	return SeriesAlert{
+		InsightSeriesID: insightSeriesID,
+		Kind:            d.Kind,
+		Threshold:       d.Threshold,
+		EmailUserID:     d.EmailUserID,
+		SlackWebhookURL: d.SlackWebhookURL,
+		WebhookURL:      d.WebhookURL,
+	}
+}
+
+// NewAlertDefinition returns the definition of the given alert rule.
+func NewAlertDefinition(alert SeriesAlert) AlertDefinition {
+	return AlertDefinition{
+		Kind:            alert.Kind,
+		Threshold:       alert.Threshold,
+		EmailUserID:     alert.EmailUserID,
+		SlackWebhookURL: alert.SlackWebhookURL,
+		WebhookURL:      alert.WebhookURL,
+	}
+}
+
+// NewInsightDefinition returns the definition of the given insight.
+func NewInsightDefinition(insight Insight, grants GrantsDefinition) InsightDefinition {
+	def := InsightDefinition{
+		ID:               insight.UniqueID,
+		Title:            insight.Title,
+		Description:      insight.Description,
+		PresentationType: insight.PresentationType,
+		Filters:          insight.Filters,
+		OtherThreshold:   insight.OtherThreshold,
+		SeriesLimit:      insight.SeriesOptions.Limit,
+		Grants:           grants,
+	}
+	if sort := insight.SeriesOptions.SortOptions; sort != nil {
+		def.SeriesSortMode = &sort.Mode
+		def.SeriesSortDirection = &sort.Direction
+	}
+	for _, series := range insight.Series {
+		def.Series = append(def.Series, SeriesDefinition{
+			ID:                         series.SeriesID,
+			Label:                      series.Label,
+			Stroke:                     series.LineColor,
+			Query:                      series.Query,
+			Repositories:               series.Repositories,
+			IntervalUnit:               IntervalUnit(series.SampleIntervalUnit),
+			IntervalValue:              series.SampleIntervalValue,
+			GeneratedFromCaptureGroups: series.GeneratedFromCaptureGroups,
+			GenerationMethod:           series.GenerationMethod,
+			GroupBy:                    series.GroupBy,
+		})
+	}
+	return def
+}
+
+// NewDashboardDefinition returns the definition of the given dashboard. The dashboard must have a unique ID.
+func NewDashboardDefinition(dashboard Dashboard) DashboardDefinition {
+	def := DashboardDefinition{
+		Title:    dashboard.Title,
+		Insights: dashboard.InsightIDs,
+		Grants: GrantsDefinition{
+			Users:  dashboard.UserIdGrants,
+			Orgs:   dashboard.OrgIdGrants,
+			Global: dashboard.GlobalGrant,
+		},
+	}
+	if dashboard.UniqueID != nil {
+		def.ID = *dashboard.UniqueID
+	}
+	return def
+}
@@ -0,0 +1,129 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDefinitions(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		defs, err := ParseDefinitions([]byte(`
+version: 1
+insights:
+  - id: todos
+    title: TODOs
+    series:
+      - id: todos-series
+        label: TODOs
+        stroke: blue
+        query: TODO
+        intervalUnit: WEEK
+        intervalValue: 2
+    grants:
+      global: true
+dashboards:
+  - id: team
+    title: Team dashboard
+    insights: [todos]
+    grants:
+      orgs: [1]
+`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := &Definitions{
+			Version: 1,
+			Insights: []InsightDefinition{{
+				ID:    "todos",
+				Title: "TODOs",
+				Series: []SeriesDefinition{{
+					ID:            "todos-series",
+					Label:         "TODOs",
+					Stroke:        "blue",
+					Query:         "TODO",
+					IntervalUnit:  Week,
+					IntervalValue: 2,
+				}},
+				Grants: GrantsDefinition{Global: true},
+			}},
+			Dashboards: []DashboardDefinition{{
+				ID:       "team",
+				Title:    "Team dashboard",
+				Insights: []string{"todos"},
+				Grants:   GrantsDefinition{Orgs: []int64{1}},
+			}},
+		}
+		if diff := cmp.Diff(want, defs); diff != "" {
+			t.Fatalf("unexpected definitions (-want +got):\n%s", diff)
+		}
+
+		// Marshalling and parsing the definitions again must not change them.
+		data, err := MarshalDefinitions(*defs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := ParseDefinitions(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(defs, again); diff != "" {
+			t.Fatalf("definitions changed after round trip (-want +got):\n%s", diff)
+		}
+	})
+
+	for name, data := range map[string]string{
+		"unsupported version":     "version: 2",
+		"missing insight id":      "version: 1\ninsights:\n  - title: no id\n    series: [{id: a, query: TODO}]",
+		"duplicate insight id":    "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO}]}\n  - {id: a, series: [{id: b, query: TODO}]}",
+		"no series":               "version: 1\ninsights:\n  - id: a",
+		"series without query":    "version: 1\ninsights:\n  - {id: a, series: [{id: a}]}",
+		"unknown interval unit":   "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO, intervalUnit: DECADE}]}",
+		"unknown alert kind":      "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO, alerts: [{kind: SIDEWAYS, webhookURL: x}]}]}",
+		"alert without recipient": "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO, alerts: [{kind: ABOVE, threshold: 1}]}]}",
+		"invalid precise query":   "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO, generationMethod: precise-references}]}",
+		"grouped breakdown":       "version: 1\ninsights:\n  - {id: a, series: [{id: a, query: TODO, generationMethod: search-author-breakdown, groupBy: REPO}]}",
+		"duplicate dashboard":     "version: 1\ndashboards:\n  - {id: a}\n  - {id: a}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseDefinitions([]byte(data)); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestSeriesDefinitionSeries(t *testing.T) {
+	groupBy := "REPO"
+	have := SeriesDefinition{ID: "a", Query: "TODO", GroupBy: &groupBy}.Series()
+
+	lowercased := "repo"
+	want := InsightSeries{
+		SeriesID:            "a",
+		Query:               "TODO",
+		SampleIntervalUnit:  string(Month),
+		SampleIntervalValue: 1,
+		GenerationMethod:    Search,
+		GroupBy:             &lowercased,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected series (-want +got):\n%s", diff)
+	}
+}
+
+func TestSeriesDefinitionSeriesBreakdown(t *testing.T) {
+	have := SeriesDefinition{ID: "a", Query: "type:diff TODO", GenerationMethod: SearchAuthorBreakdown}.Series()
+
+	want := InsightSeries{
+		SeriesID:                   "a",
+		Query:                      "type:diff TODO",
+		SampleIntervalUnit:         string(Month),
+		SampleIntervalValue:        1,
+		GeneratedFromCaptureGroups: true,
+		GenerationMethod:           SearchAuthorBreakdown,
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected series (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PreciseReferencesQuery identifies a symbol by the moniker that precise code intelligence indexers attach to it.
+// An empty PackageVersion matches references to every version of the package.
+type PreciseReferencesQuery struct {
+	Scheme         string
+	PackageName    string
+	PackageVersion string
+	Identifier     string
+}
+
+// ParsePreciseReferencesQuery parses the query of a PreciseReferences series. The query is a list of fields
+// separated by whitespace, for example:
+//
+//	scheme:gomod package:github.com/sourcegraph/sourcegraph identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap
+//
+// The scheme, package and identifier fields are required. The optional version field restricts the count to the
+// references to a single version of the package.
+func ParsePreciseReferencesQuery(query string) (PreciseReferencesQuery, error) {
+	var q PreciseReferencesQuery
+	for _, field := range strings.Fields(query) {
+		name, value, ok := strings.Cut(field, ":")
+		if !ok || value == "" {
+			return PreciseReferencesQuery{}, errors.Newf("invalid field %q in precise references query", field)
+		}
+
+		var target *string
+		switch name {
+		case "scheme":
+			target = &q.Scheme
+		case "package":
+			target = &q.PackageName
+		case "version":
+			target = &q.PackageVersion
+		case "identifier":
+			target = &q.Identifier
+		default:
+			return PreciseReferencesQuery{}, errors.Newf("unknown field %q in precise references query", name)
+		}
+		if *target != "" {
+			return PreciseReferencesQuery{}, errors.Newf("field %q is used more than once in precise references query", name)
+		}
+		*target = value
+	}
+
+	if q.Scheme == "" || q.PackageName == "" || q.Identifier == "" {
+		return PreciseReferencesQuery{}, errors.New("precise references query requires the scheme, package and identifier fields")
+	}
+	return q, nil
+}
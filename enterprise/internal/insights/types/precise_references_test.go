@@ -0,0 +1,38 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParsePreciseReferencesQuery(t *testing.T) {
+	q, err := ParsePreciseReferencesQuery("scheme:gomod package:github.com/sourcegraph/sourcegraph version:v0.0.0-abc identifier:github.com/sourcegraph/sourcegraph/lib/errors:Wrap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PreciseReferencesQuery{
+		Scheme:         "gomod",
+		PackageName:    "github.com/sourcegraph/sourcegraph",
+		PackageVersion: "v0.0.0-abc",
+		Identifier:     "github.com/sourcegraph/sourcegraph/lib/errors:Wrap",
+	}
+	if diff := cmp.Diff(want, q); diff != "" {
+		t.Fatalf("unexpected query (-want +got):\n%s", diff)
+	}
+
+	for name, query := range map[string]string{
+		"empty":              "",
+		"missing identifier": "scheme:gomod package:github.com/sourcegraph/sourcegraph",
+		"unknown field":      "scheme:gomod package:a identifier:b repo:c",
+		"duplicate field":    "scheme:gomod scheme:npm package:a identifier:b",
+		"empty value":        "scheme: package:a identifier:b",
+		"search query":       "TODO",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParsePreciseReferencesQuery(query); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+}
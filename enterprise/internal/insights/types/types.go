@@ -58,9 +58,9 @@ type Insight struct {
 }
 
 type InsightViewFilters struct {
-	IncludeRepoRegex *string
-	ExcludeRepoRegex *string
-	SearchContexts   []string
+	IncludeRepoRegex *string  `yaml:"includeRepoRegex,omitempty"`
+	ExcludeRepoRegex *string  `yaml:"excludeRepoRegex,omitempty"`
+	SearchContexts   []string `yaml:"searchContexts,omitempty"`
 }
 
 // InsightViewSeriesMetadata contains metadata about a viewable insight series such as render properties.
@@ -109,6 +109,31 @@ type InsightSeries struct {
 	SupportsAugmentation       bool
 }
 
+// SeriesAlertKind is the condition of an alert rule on an insight series.
+type SeriesAlertKind string
+
+const (
+	AlertAbove SeriesAlertKind = "ABOVE" // Triggers when the series rises above the threshold.
+	AlertBelow SeriesAlertKind = "BELOW" // Triggers when the series falls below the threshold.
+	AlertDelta SeriesAlertKind = "DELTA" // Triggers when the series changes by at least the threshold between two recordings.
+)
+
+// SeriesAlert is an alert rule on an insight series. Alerts are evaluated against the total value of the series
+// after each recording and are delivered to any of the configured recipients.
+type SeriesAlert struct {
+	ID              int
+	InsightSeriesID int
+	Kind            SeriesAlertKind
+	Threshold       float64
+	EmailUserID     *int32
+	SlackWebhookURL *string
+	WebhookURL      *string
+	LastEvaluatedAt *time.Time
+	LastTriggeredAt *time.Time
+	// UndeliveredChannels are the channels the alert failed to be delivered to when it was last triggered.
+	UndeliveredChannels []string
+}
+
 type IntervalUnit string
 
 const (
@@ -127,8 +152,23 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+
+	// PreciseReferences series count the references to a symbol found by precise code intelligence. The query of
+	// these series is parsed with ParsePreciseReferencesQuery.
+	PreciseReferences GenerationMethod = "precise-references"
+
+	// SearchAuthorBreakdown and SearchCodeOwnerBreakdown series split the results of a search query into one series
+	// per commit author (for commit and diff queries) or per CODEOWNERS owner of the matched files (for content
+	// queries). Like capture group series, the series are generated dynamically from the recorded values.
+	SearchAuthorBreakdown    GenerationMethod = "search-author-breakdown"
+	SearchCodeOwnerBreakdown GenerationMethod = "search-code-owner-breakdown"
 )
 
+// IsBreakdown returns true if series generated with the method are broken down by commit author or code owner.
+func (m GenerationMethod) IsBreakdown() bool {
+	return m == SearchAuthorBreakdown || m == SearchCodeOwnerBreakdown
+}
+
 type DirtyQuery struct {
 	ID      int
 	Query   string
@@ -145,6 +185,7 @@ type DirtyQueryAggregate struct {
 
 type Dashboard struct {
 	ID           int
+	UniqueID     *string // stable ID used to import dashboard definitions
 	Title        string
 	InsightIDs   []string // shallow references
 	UserIdGrants []int64
@@ -0,0 +1,35 @@
+package background
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// NewBackgroundJobs returns the routines that process search jobs: one worker
+// resolves the repository revisions of queued jobs, the other searches each
+// repository revision and writes the results to uploadStore.
+func NewBackgroundJobs(logger log.Logger, db database.DB, uploadStore uploadstore.Store) []goroutine.BackgroundRoutine {
+	logger = logger.Scoped("BackgroundJobs", "search jobs background jobs")
+
+	store := searchjobs.NewStore(db)
+	searcher := searchjobs.NewSearcher(logger.Scoped("Searcher", ""), db)
+
+	jobMetrics := newJobMetrics(logger)
+	repoRevisionMetrics := newRepoRevisionJobMetrics(logger)
+
+	// Create a new context. Each background routine will wrap this with
+	// a cancellable context that is canceled when Stop() is called.
+	ctx := context.Background()
+	return []goroutine.BackgroundRoutine{
+		newJobWorker(ctx, logger.Scoped("JobWorker", ""), db, store, searcher, jobMetrics),
+		newJobResetter(ctx, logger.Scoped("JobResetter", ""), store, jobMetrics),
+		newRepoRevisionJobWorker(ctx, logger.Scoped("RepoRevisionJobWorker", ""), db, store, searcher, uploadStore, repoRevisionMetrics),
+		newRepoRevisionJobResetter(ctx, logger.Scoped("RepoRevisionJobResetter", ""), store, repoRevisionMetrics),
+	}
+}
@@ -0,0 +1,60 @@
+package background
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type searchJobsMetrics struct {
+	workerMetrics workerutil.WorkerObservability
+	resets        prometheus.Counter
+	resetFailures prometheus.Counter
+	errors        prometheus.Counter
+}
+
+func newJobMetrics(logger log.Logger) searchJobsMetrics {
+	return newMetrics(logger.Scoped("jobs", "search jobs"), "job", "search_jobs")
+}
+
+func newRepoRevisionJobMetrics(logger log.Logger) searchJobsMetrics {
+	return newMetrics(logger.Scoped("repoRevisionJobs", "search job repository revisions"), "repo_revision", "search_job_repo_revisions")
+}
+
+func newMetrics(logger log.Logger, prefix, workerName string) searchJobsMetrics {
+	observationContext := &observation.Context{
+		Logger:     logger,
+		Tracer:     &trace.Tracer{TracerProvider: otel.GetTracerProvider()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	resetFailures := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_search_jobs_" + prefix + "_reset_failures_total",
+		Help: "The number of reset failures.",
+	})
+	observationContext.Registerer.MustRegister(resetFailures)
+
+	resets := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_search_jobs_" + prefix + "_resets_total",
+		Help: "The number of records reset.",
+	})
+	observationContext.Registerer.MustRegister(resets)
+
+	errors := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_search_jobs_" + prefix + "_errors_total",
+		Help: "The number of errors that occur during job.",
+	})
+	observationContext.Registerer.MustRegister(errors)
+
+	return searchJobsMetrics{
+		workerMetrics: workerutil.NewMetrics(observationContext, workerName),
+		resets:        resets,
+		resetFailures: resetFailures,
+		errors:        errors,
+	}
+}
@@ -0,0 +1,223 @@
+package background
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func newJobWorker(ctx context.Context, logger log.Logger, db database.DB, s searchjobs.Store, searcher *searchjobs.Searcher, metrics searchJobsMetrics) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:                 "search_jobs_worker",
+		NumHandlers:          1,
+		Interval:             5 * time.Second,
+		HeartbeatInterval:    15 * time.Second,
+		CancelInterval:       10 * time.Second,
+		Metrics:              metrics.workerMetrics,
+		MaximumRuntimePerJob: time.Hour,
+	}
+	return dbworker.NewWorker(ctx, createDBWorkerStoreForJobs(logger, s), &jobHandler{db: db, store: s, searcher: searcher}, options)
+}
+
+func newJobResetter(_ context.Context, logger log.Logger, s searchjobs.Store, metrics searchJobsMetrics) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "search_jobs_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(logger, createDBWorkerStoreForJobs(logger, s), options)
+}
+
+func newRepoRevisionJobWorker(ctx context.Context, logger log.Logger, db database.DB, s searchjobs.Store, searcher *searchjobs.Searcher, uploadStore uploadstore.Store, metrics searchJobsMetrics) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:                 "search_job_repo_revisions_worker",
+		NumHandlers:          4,
+		Interval:             5 * time.Second,
+		HeartbeatInterval:    15 * time.Second,
+		CancelInterval:       10 * time.Second,
+		Metrics:              metrics.workerMetrics,
+		MaximumRuntimePerJob: 30 * time.Minute,
+	}
+	handler := &repoRevisionJobHandler{
+		db:          db,
+		store:       s,
+		searcher:    searcher,
+		uploadStore: uploadStore,
+	}
+	return dbworker.NewWorker(ctx, createDBWorkerStoreForRepoRevisionJobs(logger, s), handler, options)
+}
+
+func newRepoRevisionJobResetter(_ context.Context, logger log.Logger, s searchjobs.Store, metrics searchJobsMetrics) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "search_job_repo_revisions_worker_resetter",
+		Interval: 1 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			Errors:              metrics.errors,
+			RecordResetFailures: metrics.resetFailures,
+			RecordResets:        metrics.resets,
+		},
+	}
+	return dbworker.NewResetter(logger, createDBWorkerStoreForRepoRevisionJobs(logger, s), options)
+}
+
+func createDBWorkerStoreForJobs(logger log.Logger, s basestore.ShareableStore) dbworkerstore.Store {
+	return dbworkerstore.New(logger.Scoped("jobs.dbworker.Store", ""), s.Handle(), dbworkerstore.Options{
+		Name:              "search_jobs_worker_store",
+		TableName:         "search_jobs",
+		ColumnExpressions: searchjobs.JobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(searchjobs.ScanJob),
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        time.Minute,
+		MaxNumRetries:     3,
+		OrderByExpression: sqlf.Sprintf("id"),
+	})
+}
+
+func createDBWorkerStoreForRepoRevisionJobs(logger log.Logger, s basestore.ShareableStore) dbworkerstore.Store {
+	return dbworkerstore.New(logger.Scoped("repoRevisionJobs.dbworker.Store", ""), s.Handle(), dbworkerstore.Options{
+		Name:              "search_job_repo_revisions_worker_store",
+		TableName:         "search_job_repo_revisions",
+		ColumnExpressions: searchjobs.RepoRevisionJobColumns,
+		Scan:              dbworkerstore.BuildWorkerScan(searchjobs.ScanRepoRevisionJob),
+		StalledMaxAge:     60 * time.Second,
+		RetryAfter:        time.Minute,
+		MaxNumRetries:     3,
+		OrderByExpression: sqlf.Sprintf("id"),
+	})
+}
+
+// jobHandler fans a search job out into one repository revision job per
+// repository revision its query matches. Creating a repository revision job
+// is idempotent, so a job that is retried or reset after a crash picks up
+// where it stopped.
+type jobHandler struct {
+	db       database.DB
+	store    searchjobs.Store
+	searcher *searchjobs.Searcher
+}
+
+func (h *jobHandler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("jobHandler.Handle", log.Error(err))
+		}
+	}()
+
+	job, ok := record.(*searchjobs.Job)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	// SECURITY: resolve repositories as the user who created the job, so
+	// that only repositories visible to them are searched.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+	ctx = featureflag.WithFlags(ctx, h.db.FeatureFlags())
+
+	return h.searcher.ResolveRepoRevisions(ctx, job, func(repo types.MinimalRepo, revision string, commit api.CommitID) error {
+		return h.store.CreateRepoRevisionJob(ctx, job.ID, repo.ID, revision, commit)
+	})
+}
+
+// repoRevisionJobHandler searches a single repository revision of a search job
+// and uploads the results in every format.
+type repoRevisionJobHandler struct {
+	db          database.DB
+	store       searchjobs.Store
+	searcher    *searchjobs.Searcher
+	uploadStore uploadstore.Store
+}
+
+func (h *repoRevisionJobHandler) Handle(ctx context.Context, logger log.Logger, record workerutil.Record) (err error) {
+	defer func() {
+		if err != nil {
+			logger.Error("repoRevisionJobHandler.Handle", log.Error(err))
+		}
+	}()
+
+	repoRevisionJob, ok := record.(*searchjobs.RepoRevisionJob)
+	if !ok {
+		return errors.Errorf("unexpected record type %T", record)
+	}
+
+	job, err := h.store.GetJob(ctx, repoRevisionJob.JobID)
+	if err != nil {
+		return err
+	}
+
+	// SECURITY: search as the user who created the job.
+	ctx = actor.WithActor(ctx, actor.FromUser(job.UserID))
+	ctx = featureflag.WithFlags(ctx, h.db.FeatureFlags())
+
+	repo, err := h.db.Repos().Get(ctx, repoRevisionJob.RepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			// The repository was deleted or the user lost access to it
+			// since the job was created.
+			return errcode.MakeNonRetryable(err)
+		}
+		return err
+	}
+
+	// Results are uploaded as they are streamed rather than buffered, since a
+	// repository revision can have an unbounded number of results. Keys are
+	// deterministic, so a retried job overwrites the results of the previous
+	// attempt.
+	jsonlReader, jsonlWriter := io.Pipe()
+	csvReader, csvWriter := io.Pipe()
+	g, gctx := errgroup.WithContext(ctx)
+	for format, r := range map[searchjobs.Format]*io.PipeReader{
+		searchjobs.FormatJSONLines: jsonlReader,
+		searchjobs.FormatCSV:       csvReader,
+	} {
+		format, r := format, r
+		g.Go(func() error {
+			_, err := h.uploadStore.Upload(gctx, searchjobs.ResultsKey(repoRevisionJob, format), r)
+			// Unblock the writer if the upload stopped reading early.
+			r.CloseWithError(err)
+			return errors.Wrapf(err, "upload %s results", format)
+		})
+	}
+
+	w := searchjobs.NewResultsWriter(jsonlWriter, csvWriter)
+	searchErr := h.searcher.Search(gctx, job, repo.Name, repoRevisionJob.Commit, w)
+	if searchErr != nil {
+		searchErr = errors.Wrap(searchErr, "search")
+	} else if err := w.Flush(); err != nil {
+		searchErr = errors.Wrap(err, "flush results")
+	}
+	// Failing the writers fails the uploads, and the job is retried with
+	// the same keys.
+	jsonlWriter.CloseWithError(searchErr)
+	csvWriter.CloseWithError(searchErr)
+	if err := g.Wait(); err != nil && searchErr == nil {
+		return err
+	}
+	if searchErr != nil {
+		return searchErr
+	}
+
+	return h.store.SetRepoRevisionJobResultCount(ctx, repoRevisionJob.ID, int32(w.Count()))
+}
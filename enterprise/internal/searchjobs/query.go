@@ -0,0 +1,48 @@
+package searchjobs
+
+import (
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// repoScopeFields are the fields that select which repository revisions are
+// searched and how many results are returned. They are replaced when a query
+// is scoped to a single repository revision, since the revisions have already
+// been resolved and every result should be returned.
+var repoScopeFields = map[string]struct{}{
+	query.FieldRepo:               {},
+	query.FieldRev:                {},
+	query.FieldContext:            {},
+	query.FieldFork:               {},
+	query.FieldArchived:           {},
+	query.FieldVisibility:         {},
+	query.FieldRepoHasFile:        {},
+	query.FieldRepoHasCommitAfter: {},
+	query.FieldCount:              {},
+	query.FieldTimeout:            {},
+}
+
+// exhaustiveCount is the count used to search a repository revision. It is
+// the same value count:all is substituted with.
+const exhaustiveCount = "99999999"
+
+// ScopeToRepoRevision returns plan restricted to the given commit of repo and
+// without a limit on the number of results.
+func ScopeToRepoRevision(plan query.Plan, repo api.RepoName, commit api.CommitID) query.Plan {
+	return query.MapPlan(plan, func(b query.Basic) query.Basic {
+		parameters := make([]query.Parameter, 0, len(b.Parameters)+2)
+		for _, p := range b.Parameters {
+			if _, ok := repoScopeFields[p.Field]; ok {
+				continue
+			}
+			parameters = append(parameters, p)
+		}
+		parameters = append(parameters,
+			query.Parameter{Field: query.FieldRepo, Value: "^" + regexp.QuoteMeta(string(repo)) + "$@" + string(commit)},
+			query.Parameter{Field: query.FieldCount, Value: exhaustiveCount},
+		)
+		return b.MapParameters(parameters)
+	})
+}
@@ -0,0 +1,44 @@
+package searchjobs
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+func TestScopeToRepoRevision(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: `foo`,
+			want:  `(and "repo:^github\\.com/sourcegraph/sourcegraph$@deadbeef" "count:99999999" "foo")`,
+		},
+		{
+			input: `repo:sourcegraph rev:main fork:yes count:10 timeout:10s foo`,
+			want:  `(and "repo:^github\\.com/sourcegraph/sourcegraph$@deadbeef" "count:99999999" "foo")`,
+		},
+		{
+			input: `context:global lang:go file:internal/ type:file foo`,
+			want:  `(and "lang:go" "file:internal/" "type:file" "repo:^github\\.com/sourcegraph/sourcegraph$@deadbeef" "count:99999999" "foo")`,
+		},
+		{
+			input: `(foo or bar) repo:a patterntype:literal`,
+			want:  `(and "patterntype:literal" "repo:^github\\.com/sourcegraph/sourcegraph$@deadbeef" "count:99999999" (or "foo" "bar"))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			plan, err := query.Pipeline(query.InitRegexp(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := ScopeToRepoRevision(plan, "github.com/sourcegraph/sourcegraph", "deadbeef")
+			if have := got.ToQ().String(); have != tt.want {
+				t.Fatalf("unexpected query.\nwant: %s\nhave: %s", tt.want, have)
+			}
+		})
+	}
+}
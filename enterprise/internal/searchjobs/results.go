@@ -0,0 +1,201 @@
+package searchjobs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Format is a format search job results can be downloaded in.
+type Format string
+
+const (
+	// FormatJSONLines writes one JSON object per result and line.
+	FormatJSONLines Format = "jsonl"
+
+	// FormatCSV writes one CSV row per result. The header row is written once
+	// per download, not per repository revision.
+	FormatCSV Format = "csv"
+)
+
+// Formats are all the formats results are stored in.
+var Formats = []Format{FormatJSONLines, FormatCSV}
+
+// ParseFormat returns the Format with the given name.
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", errors.Errorf("unknown search job results format %q", s)
+}
+
+// ContentType returns the MIME type of results in the format.
+func (f Format) ContentType() string {
+	if f == FormatCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+// Record is a single result of a search job. Content matches are split into
+// one record per matched chunk, and symbol matches into one record per symbol,
+// so that every record can be represented by a flat CSV row.
+type Record struct {
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+	Type       string `json:"type"`
+	Path       string `json:"path,omitempty"`
+	// Line is the 1-based line the result starts at, or 0 if the result is
+	// not associated with a line.
+	Line    int    `json:"line,omitempty"`
+	Content string `json:"content,omitempty"`
+	URL     string `json:"url"`
+}
+
+// CSVHeader is the header row of results downloaded as CSV.
+var CSVHeader = []string{"repository", "commit", "type", "path", "line", "content", "url"}
+
+func (r Record) csvRow() []string {
+	line := ""
+	if r.Line > 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	return []string{r.Repository, r.Commit, r.Type, r.Path, line, r.Content, r.URL}
+}
+
+// RecordsFromMatch converts a search result to records.
+func RecordsFromMatch(match result.Match) []Record {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		base := Record{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.CommitID),
+			Path:       m.Path,
+			URL:        m.File.URL().String(),
+		}
+		if m.IsPathMatch() {
+			base.Type = "path"
+			return []Record{base}
+		}
+
+		records := make([]Record, 0, len(m.ChunkMatches)+len(m.Symbols))
+		for _, cm := range m.ChunkMatches {
+			r := base
+			r.Type = "content"
+			r.Line = cm.ContentStart.Line + 1
+			r.Content = cm.Content
+			records = append(records, r)
+		}
+		for _, sm := range m.Symbols {
+			r := base
+			r.Type = "symbol"
+			r.Line = sm.Symbol.Line
+			r.Content = sm.Symbol.Name
+			r.URL = sm.URL().String()
+			records = append(records, r)
+		}
+		return records
+
+	case *result.CommitMatch:
+		r := Record{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.Commit.ID),
+			Type:       "commit",
+			URL:        m.URL().String(),
+		}
+		if m.DiffPreview != nil {
+			r.Type = "diff"
+			r.Content = m.DiffPreview.Content
+		} else {
+			r.Content = string(m.Commit.Message)
+		}
+		return []Record{r}
+
+	case *result.CommitDiffMatch:
+		u := (&result.RepoMatch{Name: m.Repo.Name, ID: m.Repo.ID}).URL()
+		u.Path += "/-/commit/" + string(m.Commit.ID)
+		r := Record{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.Commit.ID),
+			Type:       "diff",
+			Path:       m.Path(),
+			URL:        u.String(),
+		}
+		if m.Preview != nil {
+			r.Content = m.Preview.Content
+		}
+		return []Record{r}
+
+	case *result.RepoMatch:
+		return []Record{{
+			Repository: string(m.Name),
+			Commit:     m.Rev,
+			Type:       "repo",
+			URL:        m.URL().String(),
+		}}
+
+	case *result.OwnerMatch:
+		owner := m.Handle
+		if owner == "" {
+			owner = m.Email
+		}
+		return []Record{{
+			Repository: string(m.Repo.Name),
+			Commit:     string(m.CommitID),
+			Type:       "owner",
+			Content:    owner,
+		}}
+	}
+	return nil
+}
+
+// ResultsWriter writes search results as records in every format at once.
+type ResultsWriter struct {
+	jsonl *json.Encoder
+	csv   *csv.Writer
+	count int
+}
+
+// NewResultsWriter returns a ResultsWriter that writes JSON lines to jsonl
+// and CSV rows without a header row to csvw.
+func NewResultsWriter(jsonl, csvw io.Writer) *ResultsWriter {
+	enc := json.NewEncoder(jsonl)
+	enc.SetEscapeHTML(false)
+	return &ResultsWriter{
+		jsonl: enc,
+		csv:   csv.NewWriter(csvw),
+	}
+}
+
+// Write writes the records of the given matches.
+func (w *ResultsWriter) Write(matches result.Matches) error {
+	for _, m := range matches {
+		for _, r := range RecordsFromMatch(m) {
+			if err := w.jsonl.Encode(r); err != nil {
+				return err
+			}
+			if err := w.csv.Write(r.csvRow()); err != nil {
+				return err
+			}
+			w.count++
+		}
+	}
+	return nil
+}
+
+// Flush flushes any buffered CSV rows.
+func (w *ResultsWriter) Flush() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// Count returns the number of records written so far.
+func (w *ResultsWriter) Count() int {
+	return w.count
+}
@@ -0,0 +1,158 @@
+package searchjobs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRecordsFromMatch(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	file := result.File{Repo: repo, CommitID: "deadbeef", Path: "README.md"}
+
+	tests := []struct {
+		name  string
+		match result.Match
+		want  []Record
+	}{
+		{
+			name:  "path match",
+			match: &result.FileMatch{File: file},
+			want: []Record{{
+				Repository: "github.com/sourcegraph/sourcegraph",
+				Commit:     "deadbeef",
+				Type:       "path",
+				Path:       "README.md",
+				URL:        "/github.com/sourcegraph/sourcegraph/-/blob/README.md",
+			}},
+		},
+		{
+			name: "content match",
+			match: &result.FileMatch{
+				File: file,
+				ChunkMatches: result.ChunkMatches{
+					{Content: "# Sourcegraph", ContentStart: result.Location{Line: 0}, Ranges: result.Ranges{{}}},
+					{Content: "Code search", ContentStart: result.Location{Offset: 20, Line: 4}, Ranges: result.Ranges{{}}},
+				},
+			},
+			want: []Record{
+				{
+					Repository: "github.com/sourcegraph/sourcegraph",
+					Commit:     "deadbeef",
+					Type:       "content",
+					Path:       "README.md",
+					Line:       1,
+					Content:    "# Sourcegraph",
+					URL:        "/github.com/sourcegraph/sourcegraph/-/blob/README.md",
+				},
+				{
+					Repository: "github.com/sourcegraph/sourcegraph",
+					Commit:     "deadbeef",
+					Type:       "content",
+					Path:       "README.md",
+					Line:       5,
+					Content:    "Code search",
+					URL:        "/github.com/sourcegraph/sourcegraph/-/blob/README.md",
+				},
+			},
+		},
+		{
+			name: "repo match",
+			match: &result.RepoMatch{
+				Name: "github.com/sourcegraph/sourcegraph",
+				ID:   1,
+				Rev:  "main",
+			},
+			want: []Record{{
+				Repository: "github.com/sourcegraph/sourcegraph",
+				Commit:     "main",
+				Type:       "repo",
+				URL:        "/github.com/sourcegraph/sourcegraph@main",
+			}},
+		},
+		{
+			name: "owner match",
+			match: &result.OwnerMatch{
+				Repo:     repo,
+				CommitID: api.CommitID("deadbeef"),
+				Handle:   "sourcegraph/search",
+			},
+			want: []Record{{
+				Repository: "github.com/sourcegraph/sourcegraph",
+				Commit:     "deadbeef",
+				Type:       "owner",
+				Content:    "sourcegraph/search",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecordsFromMatch(tt.match)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected records (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResultsWriter(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+	matches := result.Matches{
+		&result.FileMatch{File: result.File{Repo: repo, CommitID: "deadbeef", Path: "a.go"}},
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "deadbeef", Path: "b.go"},
+			ChunkMatches: result.ChunkMatches{
+				{Content: `fmt.Println("a, b")`, ContentStart: result.Location{Line: 2}, Ranges: result.Ranges{{}}},
+			},
+		},
+	}
+
+	var jsonl, csvw bytes.Buffer
+	w := NewResultsWriter(&jsonl, &csvw)
+	if err := w.Write(matches); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := w.Count(), 2; have != want {
+		t.Fatalf("unexpected count. want=%d have=%d", want, have)
+	}
+
+	wantJSONL := `{"repository":"github.com/sourcegraph/sourcegraph","commit":"deadbeef","type":"path","path":"a.go","url":"/github.com/sourcegraph/sourcegraph/-/blob/a.go"}
+{"repository":"github.com/sourcegraph/sourcegraph","commit":"deadbeef","type":"content","path":"b.go","line":3,"content":"fmt.Println(\"a, b\")","url":"/github.com/sourcegraph/sourcegraph/-/blob/b.go"}
+`
+	if diff := cmp.Diff(wantJSONL, jsonl.String()); diff != "" {
+		t.Errorf("unexpected JSON lines (-want +got):\n%s", diff)
+	}
+
+	wantCSV := `github.com/sourcegraph/sourcegraph,deadbeef,path,a.go,,,/github.com/sourcegraph/sourcegraph/-/blob/a.go
+github.com/sourcegraph/sourcegraph,deadbeef,content,b.go,3,"fmt.Println(""a, b"")",/github.com/sourcegraph/sourcegraph/-/blob/b.go
+`
+	if diff := cmp.Diff(wantCSV, csvw.String()); diff != "" {
+		t.Errorf("unexpected CSV (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(string(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != f {
+			t.Fatalf("unexpected format. want=%q have=%q", f, got)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
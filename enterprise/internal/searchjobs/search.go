@@ -0,0 +1,189 @@
+package searchjobs
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	searchrepos "github.com/sourcegraph/sourcegraph/internal/search/repos"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Searcher resolves the repository revisions of search jobs and searches
+// them.
+type Searcher struct {
+	logger    log.Logger
+	db        database.DB
+	client    client.SearchClient
+	resolver  *searchrepos.Resolver
+	gitserver gitserver.Client
+}
+
+func NewSearcher(logger log.Logger, db database.DB) *Searcher {
+	gitserverClient := gitserver.NewClient(db)
+	return &Searcher{
+		logger:    logger,
+		db:        db,
+		client:    client.NewSearchClient(logger, db, search.Indexed(), search.SearcherURLs()),
+		resolver:  searchrepos.NewResolver(logger, db, gitserverClient, search.SearcherURLs(), search.Indexed()),
+		gitserver: gitserverClient,
+	}
+}
+
+// plan parses the query of job with the settings of the user who created it.
+// Invalid queries are not retried.
+func (s *Searcher) plan(ctx context.Context, job *Job) (*search.Inputs, error) {
+	settings, err := s.db.Settings().GetLatestSchemaSettings(ctx, api.SettingsSubject{User: &job.UserID})
+	if err != nil {
+		return nil, errors.Wrap(err, "get settings")
+	}
+
+	inputs, err := s.client.Plan(
+		ctx,
+		"V3",
+		nil,
+		job.Query,
+		search.Precise,
+		search.Streaming,
+		settings,
+		envvar.SourcegraphDotComMode(),
+	)
+	if err != nil {
+		return nil, errcode.MakeNonRetryable(err)
+	}
+	return inputs, nil
+}
+
+// ResolveRepoRevisions calls handle for every repository revision the query
+// of job should be run over, with the commit the revision currently points at.
+func (s *Searcher) ResolveRepoRevisions(ctx context.Context, job *Job, handle func(repo types.MinimalRepo, revision string, commit api.CommitID) error) error {
+	inputs, err := s.plan(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range inputs.Plan {
+		opts := jobutil.ToRepoOptions(b, inputs.UserSettings)
+
+		var handleErr error
+		err := s.resolver.Paginate(ctx, opts, func(page *searchrepos.Resolved) error {
+			for _, missing := range page.MissingRepoRevs {
+				s.logger.Debug("skipping missing revisions",
+					log.Int64("jobID", job.ID),
+					log.String("repo", string(missing.Repo.Name)),
+					log.Strings("revisions", missing.RevSpecs()))
+			}
+
+			for _, repoRevs := range page.RepoRevs {
+				for _, rev := range repoRevs.Revs {
+					commit, err := s.gitserver.ResolveRevision(ctx, repoRevs.Repo.Name, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+					if err != nil {
+						if errcode.IsNotFound(err) {
+							continue
+						}
+						handleErr = errors.Wrapf(err, "resolve revision %q of %s", rev, repoRevs.Repo.Name)
+						return handleErr
+					}
+					if err := handle(repoRevs.Repo, rev, commit); err != nil {
+						handleErr = err
+						return handleErr
+					}
+				}
+			}
+			return nil
+		})
+		if handleErr != nil {
+			return handleErr
+		}
+		// Missing revisions are skipped above and do not fail the job.
+		if err != nil && !errors.Is(err, &searchrepos.MissingRepoRevsError{}) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Search runs the query of job over commit of repo and writes every result to
+// w as it is streamed. It returns an error if the search did not return every
+// result, so that the repository revision is searched again rather than
+// completed with partial results.
+func (s *Searcher) Search(ctx context.Context, job *Job, repo api.RepoName, commit api.CommitID, w *ResultsWriter) error {
+	inputs, err := s.plan(ctx, job)
+	if err != nil {
+		return err
+	}
+	inputs.Plan = ScopeToRepoRevision(inputs.Plan, repo, commit)
+	inputs.Query = inputs.Plan.ToQ()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		stats    streaming.Stats
+		writeErr error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		stats.Update(&event.Stats)
+		if writeErr != nil || len(event.Results) == 0 {
+			return
+		}
+		if writeErr = w.Write(event.Results); writeErr != nil {
+			// Results can no longer be written, stop searching.
+			cancel()
+		}
+	})
+
+	alert, err := s.client.Execute(ctx, stream, inputs)
+	if writeErr != nil {
+		return errors.Wrap(writeErr, "write results")
+	}
+	if err != nil {
+		return err
+	}
+	if alert != nil {
+		s.logger.Debug("search job returned alert",
+			log.Int64("jobID", job.ID),
+			log.String("repo", string(repo)),
+			log.String("title", alert.Title))
+	}
+	return incompleteSearchError(&stats)
+}
+
+// incompleteSearchError returns an error describing why the search with the
+// given stats did not return every result, or nil if it did.
+func incompleteSearchError(stats *streaming.Stats) error {
+	var reasons []string
+	if stats.Status.Any(search.RepoStatusTimedout) {
+		reasons = append(reasons, "timed out")
+	}
+	if stats.Status.Any(search.RepoStatusCloning) {
+		reasons = append(reasons, "repository is being cloned")
+	}
+	if stats.Status.Any(search.RepoStatusMissing) {
+		reasons = append(reasons, "repository is missing")
+	}
+	if stats.IsLimitHit || stats.Status.Any(search.RepoStatusLimitHit) {
+		// Limits include the result count as well as shards skipped by
+		// Zoekt.
+		reasons = append(reasons, "limit hit")
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+	return errors.Newf("incomplete search results: %s", strings.Join(reasons, ", "))
+}
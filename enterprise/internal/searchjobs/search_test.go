@@ -0,0 +1,63 @@
+package searchjobs
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+func TestIncompleteSearchError(t *testing.T) {
+	statusStats := func(status search.RepoStatus) streaming.Stats {
+		var stats streaming.Stats
+		stats.Status.Update(api.RepoID(1), status)
+		return stats
+	}
+
+	tests := []struct {
+		name  string
+		stats streaming.Stats
+		want  string
+	}{
+		{
+			name:  "complete",
+			stats: streaming.Stats{Repos: map[api.RepoID]struct{}{1: {}}},
+		},
+		{
+			name:  "timed out",
+			stats: statusStats(search.RepoStatusTimedout),
+			want:  "incomplete search results: timed out",
+		},
+		{
+			name:  "cloning",
+			stats: statusStats(search.RepoStatusCloning),
+			want:  "incomplete search results: repository is being cloned",
+		},
+		{
+			name:  "repository limit hit",
+			stats: statusStats(search.RepoStatusLimitHit | search.RepoStatusTimedout),
+			want:  "incomplete search results: timed out, limit hit",
+		},
+		{
+			name:  "limit hit",
+			stats: streaming.Stats{IsLimitHit: true},
+			want:  "incomplete search results: limit hit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := incompleteSearchError(&tt.stats)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.want {
+				t.Fatalf("unexpected error.\nwant: %s\nhave: %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package searchjobs
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var ErrJobNotFound = errors.New("search job not found")
+
+// Store persists search jobs and their repository revision jobs.
+type Store interface {
+	basestore.ShareableStore
+	Transact(context.Context) (Store, error)
+	Done(error) error
+
+	CreateJob(ctx context.Context, userID int32, query string) (*Job, error)
+	GetJob(ctx context.Context, id int64) (*Job, error)
+	ListJobs(ctx context.Context, opts ListJobsOpts) ([]*Job, error)
+	CountJobs(ctx context.Context, opts ListJobsOpts) (int32, error)
+	CancelJob(ctx context.Context, id int64) error
+	GetJobStats(ctx context.Context, id int64) (JobStats, error)
+
+	CreateRepoRevisionJob(ctx context.Context, jobID int64, repoID api.RepoID, revision string, commit api.CommitID) error
+	ListRepoRevisionJobs(ctx context.Context, opts ListRepoRevisionJobsOpts) ([]*RepoRevisionJob, error)
+	SetRepoRevisionJobResultCount(ctx context.Context, id int64, resultCount int32) error
+}
+
+// NewStore returns a Store backed by db.
+func NewStore(db database.DB) Store {
+	return &store{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+type store struct {
+	*basestore.Store
+}
+
+func (s *store) Transact(ctx context.Context) (Store, error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &store{Store: tx}, nil
+}
+
+// JobColumns are the columns of the search_jobs table, in the order expected
+// by ScanJob.
+var JobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_jobs.id"),
+	sqlf.Sprintf("search_jobs.user_id"),
+	sqlf.Sprintf("search_jobs.query"),
+	sqlf.Sprintf("search_jobs.state"),
+	sqlf.Sprintf("search_jobs.failure_message"),
+	sqlf.Sprintf("search_jobs.started_at"),
+	sqlf.Sprintf("search_jobs.finished_at"),
+	sqlf.Sprintf("search_jobs.process_after"),
+	sqlf.Sprintf("search_jobs.num_resets"),
+	sqlf.Sprintf("search_jobs.num_failures"),
+	sqlf.Sprintf("search_jobs.created_at"),
+	sqlf.Sprintf("search_jobs.updated_at"),
+}
+
+// ScanJob scans a Job selected with JobColumns.
+func ScanJob(scanner dbutil.Scanner) (*Job, error) {
+	var j Job
+	err := scanner.Scan(
+		&j.ID,
+		&j.UserID,
+		&j.Query,
+		&j.State,
+		&j.FailureMessage,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+func scanJobs(rows *sql.Rows, queryErr error) (_ []*Job, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var jobs []*Job
+	for rows.Next() {
+		j, err := ScanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+const createJobFmtStr = `
+INSERT INTO search_jobs (user_id, query)
+VALUES (%s, %s)
+RETURNING %s
+`
+
+func (s *store) CreateJob(ctx context.Context, userID int32, query string) (*Job, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(createJobFmtStr, userID, query, sqlf.Join(JobColumns, ", ")))
+	return ScanJob(row)
+}
+
+const getJobFmtStr = `
+SELECT %s
+FROM search_jobs
+WHERE id = %s
+`
+
+func (s *store) GetJob(ctx context.Context, id int64) (*Job, error) {
+	row := s.QueryRow(ctx, sqlf.Sprintf(getJobFmtStr, sqlf.Join(JobColumns, ", "), id))
+	j, err := ScanJob(row)
+	if err == sql.ErrNoRows {
+		return nil, ErrJobNotFound
+	}
+	return j, err
+}
+
+// ListJobsOpts filters and paginates the search jobs returned by ListJobs and
+// counted by CountJobs. Jobs are returned most recent first.
+type ListJobsOpts struct {
+	// UserID restricts the jobs to those created by the given user. All jobs
+	// are returned if zero.
+	UserID int32
+
+	// First is the maximum number of jobs to return. All jobs are returned if
+	// zero.
+	First int
+
+	// After restricts the jobs to those with an ID lower than After.
+	After int64
+}
+
+func (o ListJobsOpts) conds() *sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", o.UserID))
+	}
+	if o.After != 0 {
+		conds = append(conds, sqlf.Sprintf("id < %s", o.After))
+	}
+	return sqlf.Join(conds, "AND")
+}
+
+func (o ListJobsOpts) limit() *sqlf.Query {
+	if o.First == 0 {
+		return sqlf.Sprintf("ALL")
+	}
+	return sqlf.Sprintf("%s", o.First)
+}
+
+const listJobsFmtStr = `
+SELECT %s
+FROM search_jobs
+WHERE %s
+ORDER BY id DESC
+LIMIT %s
+`
+
+func (s *store) ListJobs(ctx context.Context, opts ListJobsOpts) ([]*Job, error) {
+	return scanJobs(s.Query(ctx, sqlf.Sprintf(listJobsFmtStr, sqlf.Join(JobColumns, ", "), opts.conds(), opts.limit())))
+}
+
+const countJobsFmtStr = `
+SELECT COUNT(*)
+FROM search_jobs
+WHERE %s
+`
+
+func (s *store) CountJobs(ctx context.Context, opts ListJobsOpts) (int32, error) {
+	// Pagination does not apply to the total count.
+	opts.After = 0
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countJobsFmtStr, opts.conds())))
+	return int32(count), err
+}
+
+const cancelJobFmtStr = `
+WITH job AS (
+	UPDATE search_jobs
+	SET
+		cancel = TRUE,
+		state = CASE WHEN state = 'processing' THEN state ELSE 'canceled' END,
+		finished_at = CASE WHEN state = 'processing' THEN finished_at ELSE NOW() END,
+		updated_at = NOW()
+	WHERE id = %s AND state IN ('queued', 'errored', 'processing')
+)
+UPDATE search_job_repo_revisions
+SET
+	cancel = TRUE,
+	state = CASE WHEN state = 'processing' THEN state ELSE 'canceled' END,
+	finished_at = CASE WHEN state = 'processing' THEN finished_at ELSE NOW() END
+WHERE search_job_id = %s AND state IN ('queued', 'errored', 'processing')
+`
+
+// CancelJob cancels the search job and all of its repository revision jobs
+// that have not finished yet. Records that are being processed are canceled
+// by their worker.
+func (s *store) CancelJob(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(cancelJobFmtStr, id, id))
+}
+
+const getJobStatsFmtStr = `
+SELECT
+	COUNT(*),
+	COUNT(*) FILTER (WHERE state IN ('queued', 'errored')),
+	COUNT(*) FILTER (WHERE state = 'processing'),
+	COUNT(*) FILTER (WHERE state = 'completed'),
+	COUNT(*) FILTER (WHERE state = 'failed'),
+	COUNT(*) FILTER (WHERE state = 'canceled'),
+	COALESCE(SUM(result_count), 0)
+FROM search_job_repo_revisions
+WHERE search_job_id = %s
+`
+
+func (s *store) GetJobStats(ctx context.Context, id int64) (stats JobStats, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(getJobStatsFmtStr, id)).Scan(
+		&stats.Total,
+		&stats.Queued,
+		&stats.Processing,
+		&stats.Completed,
+		&stats.Failed,
+		&stats.Canceled,
+		&stats.ResultCount,
+	)
+	return stats, err
+}
+
+// RepoRevisionJobColumns are the columns of the search_job_repo_revisions
+// table, in the order expected by ScanRepoRevisionJob.
+var RepoRevisionJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_job_repo_revisions.id"),
+	sqlf.Sprintf("search_job_repo_revisions.search_job_id"),
+	sqlf.Sprintf("search_job_repo_revisions.repo_id"),
+	sqlf.Sprintf("search_job_repo_revisions.revision"),
+	sqlf.Sprintf("search_job_repo_revisions.commit"),
+	sqlf.Sprintf("search_job_repo_revisions.result_count"),
+	sqlf.Sprintf("search_job_repo_revisions.state"),
+	sqlf.Sprintf("search_job_repo_revisions.failure_message"),
+	sqlf.Sprintf("search_job_repo_revisions.started_at"),
+	sqlf.Sprintf("search_job_repo_revisions.finished_at"),
+	sqlf.Sprintf("search_job_repo_revisions.process_after"),
+	sqlf.Sprintf("search_job_repo_revisions.num_resets"),
+	sqlf.Sprintf("search_job_repo_revisions.num_failures"),
+}
+
+// ScanRepoRevisionJob scans a RepoRevisionJob selected with
+// RepoRevisionJobColumns.
+func ScanRepoRevisionJob(scanner dbutil.Scanner) (*RepoRevisionJob, error) {
+	var j RepoRevisionJob
+	err := scanner.Scan(
+		&j.ID,
+		&j.JobID,
+		&j.RepoID,
+		&j.Revision,
+		&j.Commit,
+		&j.ResultCount,
+		&j.State,
+		&j.FailureMessage,
+		&j.StartedAt,
+		&j.FinishedAt,
+		&j.ProcessAfter,
+		&j.NumResets,
+		&j.NumFailures,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+const createRepoRevisionJobFmtStr = `
+INSERT INTO search_job_repo_revisions (search_job_id, repo_id, revision, commit)
+VALUES (%s, %s, %s, %s)
+ON CONFLICT (search_job_id, repo_id, revision) DO NOTHING
+`
+
+// CreateRepoRevisionJob enqueues the search of a repository revision for the
+// given search job. It is a no-op if the revision has already been enqueued,
+// so that resolving the revisions of a job can safely be retried.
+func (s *store) CreateRepoRevisionJob(ctx context.Context, jobID int64, repoID api.RepoID, revision string, commit api.CommitID) error {
+	return s.Exec(ctx, sqlf.Sprintf(createRepoRevisionJobFmtStr, jobID, repoID, revision, commit))
+}
+
+// ListRepoRevisionJobsOpts filters the repository revision jobs returned by
+// ListRepoRevisionJobs. Jobs are returned in the order they were created.
+type ListRepoRevisionJobsOpts struct {
+	JobID int64
+
+	// States restricts the jobs to those in one of the given states. Jobs in
+	// all states are returned if empty.
+	States []State
+}
+
+const listRepoRevisionJobsFmtStr = `
+SELECT %s
+FROM search_job_repo_revisions
+WHERE %s
+ORDER BY id
+`
+
+func (s *store) ListRepoRevisionJobs(ctx context.Context, opts ListRepoRevisionJobsOpts) (_ []*RepoRevisionJob, err error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("search_job_id = %s", opts.JobID)}
+	if len(opts.States) > 0 {
+		states := make([]*sqlf.Query, 0, len(opts.States))
+		for _, state := range opts.States {
+			states = append(states, sqlf.Sprintf("%s", state))
+		}
+		conds = append(conds, sqlf.Sprintf("state IN (%s)", sqlf.Join(states, ", ")))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listRepoRevisionJobsFmtStr, sqlf.Join(RepoRevisionJobColumns, ", "), sqlf.Join(conds, "AND")))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var jobs []*RepoRevisionJob
+	for rows.Next() {
+		j, err := ScanRepoRevisionJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, nil
+}
+
+const setRepoRevisionJobResultCountFmtStr = `
+UPDATE search_job_repo_revisions
+SET result_count = %s
+WHERE id = %s
+`
+
+func (s *store) SetRepoRevisionJobResultCount(ctx context.Context, id int64, resultCount int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(setRepoRevisionJobResultCountFmtStr, resultCount, id))
+}
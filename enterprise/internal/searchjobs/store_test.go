@@ -0,0 +1,128 @@
+package searchjobs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+	s := NewStore(db)
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := db.Users().Create(ctx, database.NewUser{Username: "o", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &types.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	if err := db.Repos().Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := s.CreateJob(ctx, user.ID, "type:file foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.State != StateQueued || job.Query != "type:file foo" || job.UserID != user.ID {
+		t.Fatalf("unexpected job %+v", job)
+	}
+	if _, err := s.CreateJob(ctx, other.ID, "bar"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("GetJob", func(t *testing.T) {
+		have, err := s.GetJob(ctx, job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(job, have); diff != "" {
+			t.Fatalf("unexpected job (-want +got):\n%s", diff)
+		}
+
+		if _, err := s.GetJob(ctx, job.ID+100); err != ErrJobNotFound {
+			t.Fatalf("unexpected error. want=%v have=%v", ErrJobNotFound, err)
+		}
+	})
+
+	t.Run("ListJobs", func(t *testing.T) {
+		jobs, err := s.ListJobs(ctx, ListJobsOpts{UserID: user.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].ID != job.ID {
+			t.Fatalf("unexpected jobs %+v", jobs)
+		}
+
+		count, err := s.CountJobs(ctx, ListJobsOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 2 {
+			t.Fatalf("unexpected count. want=%d have=%d", 2, count)
+		}
+	})
+
+	t.Run("RepoRevisionJobs", func(t *testing.T) {
+		for _, revision := range []string{"main", "main", "v1.0.0"} {
+			if err := s.CreateRepoRevisionJob(ctx, job.ID, repo.ID, revision, "deadbeef"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		jobs, err := s.ListRepoRevisionJobs(ctx, ListRepoRevisionJobsOpts{JobID: job.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 2 {
+			t.Fatalf("expected duplicate revisions to be ignored, got %d jobs", len(jobs))
+		}
+
+		if err := s.SetRepoRevisionJobResultCount(ctx, jobs[0].ID, 42); err != nil {
+			t.Fatal(err)
+		}
+
+		stats, err := s.GetJobStats(ctx, job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(JobStats{Total: 2, Queued: 2, ResultCount: 42}, stats); diff != "" {
+			t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CancelJob", func(t *testing.T) {
+		if err := s.CancelJob(ctx, job.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetJob(ctx, job.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have.State != StateCanceled {
+			t.Fatalf("unexpected state. want=%q have=%q", StateCanceled, have.State)
+		}
+
+		jobs, err := s.ListRepoRevisionJobs(ctx, ListRepoRevisionJobsOpts{JobID: job.ID, States: []State{StateCanceled}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 2 {
+			t.Fatalf("expected all repository revision jobs to be canceled, got %d", len(jobs))
+		}
+	})
+}
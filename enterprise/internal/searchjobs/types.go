@@ -0,0 +1,120 @@
+// Package searchjobs implements exhaustive search jobs. A search job runs a
+// query over every repository revision it matches without the result limits
+// and timeouts of interactive search, and stores the results in the upload
+// store so they can be downloaded once the job has finished.
+//
+// A search job is processed in two stages. First, the query is resolved to the
+// set of repository revisions it should run over, and one repository revision
+// job is enqueued for each of them. Second, each repository revision job runs
+// the query scoped to its revision and uploads the results. Both stages are
+// backed by dbworker, so progress is persisted and survives restarts.
+package searchjobs
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// State is the state of a search job or of one of its repository revision
+// jobs. The values match the states used by dbworker.
+type State string
+
+const (
+	StateQueued     State = "queued"
+	StateProcessing State = "processing"
+	StateErrored    State = "errored"
+	StateFailed     State = "failed"
+	StateCompleted  State = "completed"
+	StateCanceled   State = "canceled"
+)
+
+// Job is a search job created by a user.
+type Job struct {
+	ID     int64
+	UserID int32
+	Query  string
+
+	// Fields demanded for any dbworker.
+	State          State
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (j *Job) RecordID() int {
+	return int(j.ID)
+}
+
+// RepoRevisionJob is the part of a search job that runs over a single
+// repository revision.
+type RepoRevisionJob struct {
+	ID     int64
+	JobID  int64
+	RepoID api.RepoID
+
+	// Revision is the revision specifier as it was resolved from the query.
+	// It is empty for the default branch.
+	Revision string
+
+	// Commit is the commit Revision pointed at when the job was created.
+	// Searching a fixed commit makes retries return the same results.
+	Commit api.CommitID
+
+	// ResultCount is the number of results uploaded for this revision.
+	ResultCount int32
+
+	// Fields demanded for any dbworker.
+	State          State
+	FailureMessage *string
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	ProcessAfter   *time.Time
+	NumResets      int32
+	NumFailures    int32
+}
+
+func (j *RepoRevisionJob) RecordID() int {
+	return int(j.ID)
+}
+
+// JobStats summarizes the progress of the repository revision jobs of a
+// search job.
+type JobStats struct {
+	Total       int32
+	Queued      int32
+	Processing  int32
+	Completed   int32
+	Failed      int32
+	Canceled    int32
+	ResultCount int32
+}
+
+// AggregateState returns the state of job taking the progress of its
+// repository revision jobs into account: a job is only completed once all of
+// its repository revisions have been searched.
+func AggregateState(job *Job, stats JobStats) State {
+	if job.State != StateCompleted {
+		if job.State == StateErrored {
+			// The job is going to be retried.
+			return StateProcessing
+		}
+		return job.State
+	}
+	if stats.Queued+stats.Processing > 0 {
+		return StateProcessing
+	}
+	if stats.Canceled > 0 {
+		return StateCanceled
+	}
+	if stats.Total > 0 && stats.Failed == stats.Total {
+		return StateFailed
+	}
+	return StateCompleted
+}
@@ -0,0 +1,30 @@
+package searchjobs
+
+import "testing"
+
+func TestAggregateState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state State
+		stats JobStats
+		want  State
+	}{
+		{name: "queued", state: StateQueued, want: StateQueued},
+		{name: "resolving", state: StateProcessing, stats: JobStats{Total: 3, Queued: 3}, want: StateProcessing},
+		{name: "retrying", state: StateErrored, want: StateProcessing},
+		{name: "resolving failed", state: StateFailed, want: StateFailed},
+		{name: "searching", state: StateCompleted, stats: JobStats{Total: 3, Completed: 1, Processing: 1, Queued: 1}, want: StateProcessing},
+		{name: "canceled", state: StateCompleted, stats: JobStats{Total: 2, Completed: 1, Canceled: 1}, want: StateCanceled},
+		{name: "all failed", state: StateCompleted, stats: JobStats{Total: 2, Failed: 2}, want: StateFailed},
+		{name: "some failed", state: StateCompleted, stats: JobStats{Total: 2, Completed: 1, Failed: 1}, want: StateCompleted},
+		{name: "no repositories", state: StateCompleted, want: StateCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if have := AggregateState(&Job{State: tt.state}, tt.stats); have != tt.want {
+				t.Fatalf("unexpected state. want=%q have=%q", tt.want, have)
+			}
+		})
+	}
+}
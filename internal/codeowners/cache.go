@@ -0,0 +1,91 @@
+package codeowners
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RulesetCache memoizes the CODEOWNERS ruleset of repositories at a commit.
+// It is meant to live for the duration of a single search, where many results
+// share the same repository and commit. It is safe for concurrent use.
+type RulesetCache struct {
+	client gitserver.Client
+
+	mu      sync.Mutex
+	entries map[cacheKey]*cacheEntry
+}
+
+type cacheKey struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+type cacheEntry struct {
+	// done is closed once the ruleset is fetched.
+	done    chan struct{}
+	ruleset *Ruleset
+	err     error
+	// canceled is set if the fetch failed because the context of the caller
+	// fetching the ruleset was canceled. The entry is then dropped from the
+	// cache, and the ruleset is fetched again by the next caller.
+	canceled bool
+}
+
+// NewRulesetCache returns an empty cache that reads CODEOWNERS files with
+// client.
+func NewRulesetCache(client gitserver.Client) *RulesetCache {
+	return &RulesetCache{
+		client:  client,
+		entries: make(map[cacheKey]*cacheEntry),
+	}
+}
+
+// Get returns the ruleset of repo at commit, fetching it on first use. A nil
+// ruleset means the repository has no CODEOWNERS file.
+//
+// Concurrent callers share a single fetch, made with the context of the first
+// caller. Errors are cached, except when that context is canceled, so that the
+// cancellation of one caller doesn't fail the others.
+func (c *RulesetCache) Get(ctx context.Context, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	key := cacheKey{repo: repo, commit: commit}
+
+	for {
+		c.mu.Lock()
+		entry, ok := c.entries[key]
+		if !ok {
+			entry = &cacheEntry{done: make(chan struct{})}
+			c.entries[key] = entry
+		}
+		c.mu.Unlock()
+
+		if !ok {
+			return c.fetch(ctx, key, entry)
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if !entry.canceled {
+			return entry.ruleset, entry.err
+		}
+	}
+}
+
+func (c *RulesetCache) fetch(ctx context.Context, key cacheKey, entry *cacheEntry) (*Ruleset, error) {
+	defer close(entry.done)
+
+	entry.ruleset, entry.err = Fetch(ctx, c.client, key.repo, key.commit)
+	if entry.err != nil && (ctx.Err() != nil || errors.IsContextError(entry.err)) {
+		entry.canceled = true
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	return entry.ruleset, entry.err
+}
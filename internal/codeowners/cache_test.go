@@ -0,0 +1,54 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestRulesetCache(t *testing.T) {
+	var reads int
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultHook(func(ctx context.Context, _ api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		reads++
+		if name != "CODEOWNERS" {
+			return nil, os.ErrNotExist
+		}
+		return []byte("* @sourcegraph/everyone\n"), nil
+	})
+	cache := NewRulesetCache(client)
+
+	t.Run("context errors are not cached", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := cache.Get(ctx, "repo", "deadbeef"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		rs, err := cache.Get(context.Background(), "repo", "deadbeef")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs == nil || rs.Path != "CODEOWNERS" {
+			t.Fatalf("unexpected ruleset: %+v", rs)
+		}
+	})
+
+	t.Run("rulesets are fetched once", func(t *testing.T) {
+		before := reads
+		if _, err := cache.Get(context.Background(), "repo", "deadbeef"); err != nil {
+			t.Fatal(err)
+		}
+		if reads != before {
+			t.Fatalf("ruleset fetched again, %d reads", reads-before)
+		}
+	})
+}
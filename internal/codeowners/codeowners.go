@@ -0,0 +1,211 @@
+// Package codeowners parses CODEOWNERS files and resolves the owners of paths
+// in a repository.
+//
+// The supported syntax follows the GitHub flavour of CODEOWNERS, which is a
+// subset of gitignore patterns. Each non-empty line that does not start with
+// '#' consists of a pattern followed by zero or more owners. The last rule in
+// the file that matches a path determines its owners.
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Rule is a single line of a CODEOWNERS file.
+type Rule struct {
+	// Pattern is the raw pattern as it appears in the file.
+	Pattern string
+	// Owners are the handles, teams or email addresses listed for Pattern.
+	// A rule without owners explicitly marks matching paths as unowned.
+	Owners []string
+	// LineNumber is the 1-based line of the rule in the file.
+	LineNumber int
+
+	matcher *regexp.Regexp
+}
+
+// Match reports whether the rule applies to path. Paths are relative to the
+// repository root and must not have a leading slash.
+func (r *Rule) Match(path string) bool {
+	return r.matcher.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	// Path is the location of the CODEOWNERS file in the repository it was
+	// read from. It is empty if the ruleset was not read from a repository.
+	Path  string
+	Rules []*Rule
+}
+
+// Parse reads a CODEOWNERS file from r.
+func Parse(r io.Reader) (*Ruleset, error) {
+	var rules []*Rule
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		fields := splitFields(line)
+		matcher, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		var owners []string
+		if len(fields) > 1 {
+			owners = fields[1:]
+		}
+		rules = append(rules, &Rule{
+			Pattern:    fields[0],
+			Owners:     owners,
+			LineNumber: lineNumber,
+			matcher:    matcher,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return &Ruleset{Rules: rules}, nil
+}
+
+// FindRule returns the rule that determines ownership of path, or nil if no
+// rule matches it.
+func (rs *Ruleset) FindRule(path string) *Rule {
+	if rs == nil {
+		return nil
+	}
+	// Later rules take precedence over earlier ones.
+	for i := len(rs.Rules) - 1; i >= 0; i-- {
+		if rs.Rules[i].Match(path) {
+			return rs.Rules[i]
+		}
+	}
+	return nil
+}
+
+// FindOwners returns the owners of path. It returns nil if path is unowned.
+func (rs *Ruleset) FindOwners(path string) []string {
+	if rule := rs.FindRule(path); rule != nil {
+		return rule.Owners
+	}
+	return nil
+}
+
+// OwnerMatches reports whether owner, as it appears in a CODEOWNERS file,
+// refers to the search term ref. The comparison is case-insensitive and the
+// leading '@' of handles and teams is optional in ref, so "alice" and
+// "@Alice" both match the owner "@alice".
+func OwnerMatches(owner, ref string) bool {
+	owner = strings.TrimPrefix(owner, "@")
+	ref = strings.TrimPrefix(ref, "@")
+	return ref != "" && strings.EqualFold(owner, ref)
+}
+
+// stripComment removes a trailing comment from line. A '#' preceded by a
+// backslash is an escaped literal and does not start a comment.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitFields splits line on unescaped whitespace and unescapes "\ " and
+// "\#" sequences in the resulting fields.
+func splitFields(line string) []string {
+	var (
+		fields []string
+		cur    strings.Builder
+	)
+	flush := func() {
+		if cur.Len() > 0 {
+			fields = append(fields, cur.String())
+			cur.Reset()
+		}
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && (line[i+1] == ' ' || line[i+1] == '#'):
+			i++
+			cur.WriteByte(line[i])
+		case c == ' ' || c == '\t':
+			flush()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	flush()
+	return fields
+}
+
+// compilePattern converts a CODEOWNERS pattern into a regular expression
+// matching repository-relative paths.
+//
+// As with gitignore, a pattern containing a slash anywhere but at its end is
+// anchored to the repository root, while other patterns match at any depth.
+// A pattern that matches a directory also matches everything inside it,
+// except for patterns ending in "/*", which only match direct children.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") {
+		return nil, errors.Errorf("negated pattern %q is not supported", pattern)
+	}
+	if strings.Contains(pattern, "[") || strings.Contains(pattern, "]") {
+		return nil, errors.Errorf("character ranges in pattern %q are not supported", pattern)
+	}
+
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored && !strings.HasPrefix(trimmed, "**") {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		c := trimmed[i]
+		switch c {
+		case '*':
+			if i+1 < len(trimmed) && trimmed[i+1] == '*' {
+				i++
+				if i+1 < len(trimmed) && trimmed[i+1] == '/' {
+					// "**/" matches zero or more directories.
+					i++
+					b.WriteString("(?:.*/)?")
+				} else {
+					b.WriteString(".*")
+				}
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	if strings.HasSuffix(trimmed, "/*") {
+		// "dir/*" matches the direct children of dir, but not anything
+		// nested deeper.
+		b.WriteString("$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,98 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	input := `
+# Global owners
+*                     @global-owner
+
+*.js                  @js-owner @octo-org/js-team # trailing comment
+/build/logs/          @doctocat
+docs/*                docs@example.com
+apps/                 @octocat
+/scripts/**/deploy.sh @ops
+**/vendor
+path\ with\ spaces/   @spaces
+`
+
+	rs, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, r := range rs.Rules {
+		got = append(got, r.Pattern)
+	}
+	want := []string{"*", "*.js", "/build/logs/", "docs/*", "apps/", "/scripts/**/deploy.sh", "**/vendor", "path with spaces/"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected patterns (-want +got):\n%s", diff)
+	}
+
+	for _, tc := range []struct {
+		path string
+		want []string
+	}{
+		{"README.md", []string{"@global-owner"}},
+		{"web/app.js", []string{"@js-owner", "@octo-org/js-team"}},
+		{"build/logs/output.txt", []string{"@doctocat"}},
+		{"nested/build/logs/output.txt", []string{"@global-owner"}},
+		{"docs/getting-started.md", []string{"docs@example.com"}},
+		{"docs/build-app/troubleshooting.md", []string{"@global-owner"}},
+		{"apps/main.go", []string{"@octocat"}},
+		{"nested/apps/main.go", []string{"@octocat"}},
+		{"scripts/deploy.sh", []string{"@ops"}},
+		{"scripts/prod/eu/deploy.sh", []string{"@ops"}},
+		{"lib/vendor/dep.go", nil},
+		{"path with spaces/file.txt", []string{"@spaces"}},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, rs.FindOwners(tc.path)); diff != "" {
+				t.Errorf("unexpected owners (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseUnsupported(t *testing.T) {
+	for _, input := range []string{
+		"!negated @owner",
+		"file[0-9].txt @owner",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error parsing %q", input)
+		}
+	}
+}
+
+func TestFindOwnersEmptyRuleset(t *testing.T) {
+	var rs *Ruleset
+	if owners := rs.FindOwners("main.go"); owners != nil {
+		t.Fatalf("expected no owners, got %v", owners)
+	}
+}
+
+func TestOwnerMatches(t *testing.T) {
+	for _, tc := range []struct {
+		owner, ref string
+		want       bool
+	}{
+		{"@alice", "alice", true},
+		{"@alice", "@Alice", true},
+		{"@org/team", "org/team", true},
+		{"@org/team", "team", false},
+		{"alice@example.com", "alice@example.com", true},
+		{"@alice", "", false},
+		{"@alice", "@", false},
+	} {
+		if got := OwnerMatches(tc.owner, tc.ref); got != tc.want {
+			t.Errorf("OwnerMatches(%q, %q) = %v, want %v", tc.owner, tc.ref, got, tc.want)
+		}
+	}
+}
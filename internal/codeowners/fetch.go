@@ -0,0 +1,42 @@
+package codeowners
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// CandidatePaths are the locations of a CODEOWNERS file in a repository, in
+// the order in which they are looked up. Only the first file found is used.
+var CandidatePaths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Fetch reads and parses the CODEOWNERS file of repo at commit. It returns a
+// nil ruleset and no error if the repository does not have a CODEOWNERS file.
+func Fetch(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID) (*Ruleset, error) {
+	for _, path := range CandidatePaths {
+		content, err := client.ReadFile(ctx, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		rs, err := Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", path)
+		}
+		rs.Path = path
+		return rs, nil
+	}
+	return nil, nil
+}
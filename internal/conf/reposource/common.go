@@ -26,7 +26,7 @@ type RepoSource interface {
 	CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error)
 }
 
-var nonSCPURLRegex = lazyregexp.New(`^(git\+)?(https?|ssh|rsync|file|git)://`)
+var nonSCPURLRegex = lazyregexp.New(`^(git\+)?(https?|ssh|rsync|file|git|svn(\+ssh)?)://`)
 
 // parseCloneURL parses a git clone URL into a URL struct. It supports the SCP-style git@host:path
 // syntax that is common among code hosts.
@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGitea_cloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.GiteaConnection
+		urls []urlToRepoName
+	}{{
+		conn: schema.GiteaConnection{
+			Url: "https://gitea.mycompany.com",
+		},
+		urls: []urlToRepoName{
+			{"git@gitea.mycompany.com:myorg/myrepo.git", "gitea.mycompany.com/myorg/myrepo"},
+			{"https://gitea.mycompany.com/myorg/myrepo.git", "gitea.mycompany.com/myorg/myrepo"},
+			{"https://token@gitea.mycompany.com/myorg/myrepo.git", "gitea.mycompany.com/myorg/myrepo"},
+
+			{"git@asdf.com:myorg/myrepo.git", ""},
+			{"https://asdf.com/myorg/myrepo.git", ""},
+		},
+	}, {
+		conn: schema.GiteaConnection{
+			Url:                   "https://mycompany.com/gitea/",
+			RepositoryPathPattern: "gitea/{nameWithOwner}",
+		},
+		urls: []urlToRepoName{
+			{"https://mycompany.com/gitea/myorg/myrepo.git", "gitea/myorg/myrepo"},
+
+			{"https://asdf.com/gitea/myorg/myrepo.git", ""},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Gitea{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package reposource
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type Mercurial struct {
+	*schema.MercurialConnection
+}
+
+var _ RepoSource = Mercurial{}
+
+func (c Mercurial) CloneURLToRepoURI(cloneURL string) (string, error) {
+	return cloneURLToRepoName(cloneURL, c.Url, DefaultRepositoryPathPattern)
+}
+
+func (c Mercurial) CloneURLToRepoName(cloneURL string) (api.RepoName, error) {
+	repoName, err := cloneURLToRepoName(cloneURL, c.Url, c.RepositoryPathPattern)
+	return api.RepoName(repoName), err
+}
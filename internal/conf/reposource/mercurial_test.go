@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMercurialCloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.MercurialConnection
+		urls []urlToRepoName
+	}{
+		{
+			conn: schema.MercurialConnection{
+				Url:   "https://hg.example.org/repos",
+				Repos: []string{"tools/build"},
+			},
+			urls: []urlToRepoName{
+				{"https://hg.example.org/repos/tools/build", "hg.example.org/repos/tools/build"},
+				{"https://hg.example.org/other/tools/build", ""},
+				{"https://hg.mozilla.org/repos/tools/build", ""},
+			},
+		},
+		{
+			conn: schema.MercurialConnection{
+				Url:                   "ssh://hg@hg.example.org/",
+				RepositoryPathPattern: "hg/{repo}",
+				Repos:                 []string{"tools/build"},
+			},
+			urls: []urlToRepoName{
+				{"ssh://hg@hg.example.org/tools/build", "hg/tools/build"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Mercurial{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
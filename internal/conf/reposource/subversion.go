@@ -0,0 +1,21 @@
+package reposource
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type Subversion struct {
+	*schema.SubversionConnection
+}
+
+var _ RepoSource = Subversion{}
+
+func (c Subversion) CloneURLToRepoURI(cloneURL string) (string, error) {
+	return cloneURLToRepoName(cloneURL, c.Url, DefaultRepositoryPathPattern)
+}
+
+func (c Subversion) CloneURLToRepoName(cloneURL string) (api.RepoName, error) {
+	repoName, err := cloneURLToRepoName(cloneURL, c.Url, c.RepositoryPathPattern)
+	return api.RepoName(repoName), err
+}
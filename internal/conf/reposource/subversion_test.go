@@ -0,0 +1,46 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSubversionCloneURLToRepoName(t *testing.T) {
+	tests := []struct {
+		conn schema.SubversionConnection
+		urls []urlToRepoName
+	}{
+		{
+			conn: schema.SubversionConnection{
+				Url: "https://svn.example.org/repos",
+			},
+			urls: []urlToRepoName{
+				{"https://svn.example.org/repos/tools", "svn.example.org/repos/tools"},
+				{"https://svn.example.org/other/tools", ""},
+				{"https://svn.apache.org/repos/tools", ""},
+			},
+		},
+		{
+			conn: schema.SubversionConnection{
+				Url:                   "svn+ssh://svn.example.org/",
+				RepositoryPathPattern: "svn/{repo}",
+			},
+			urls: []urlToRepoName{
+				{"svn+ssh://svn.example.org/tools", "svn/tools"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		for _, u := range test.urls {
+			repoName, err := Subversion{&test.conn}.CloneURLToRepoName(u.cloneURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.repoName != string(repoName) {
+				t.Errorf("expected %q but got %q for clone URL %q (connection: %+v)", u.repoName, repoName, u.cloneURL, test.conn)
+			}
+		}
+	}
+}
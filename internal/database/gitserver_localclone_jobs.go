@@ -5,6 +5,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 )
 
@@ -13,6 +14,12 @@ type GitserverLocalCloneStore interface {
 	basestore.ShareableStore
 	With(other basestore.ShareableStore) GitserverLocalCloneStore
 	Enqueue(ctx context.Context, repoID int, sourceHostname, destHostname string, deleteSource bool) (int, error)
+	// EnqueueIfNotExists is like Enqueue, but does nothing and returns 0 if an unfinished job
+	// already exists for the repository.
+	EnqueueIfNotExists(ctx context.Context, repoID int, sourceHostname, destHostname string, deleteSource bool) (int, error)
+	// ListUnfinishedSources returns the source hostname of the latest unfinished job of every
+	// repository that has one. Jobs are unfinished until they have completed or failed for good.
+	ListUnfinishedSources(ctx context.Context) (map[api.RepoName]string, error)
 }
 
 type gitserverLocalCloneStore struct {
@@ -48,3 +55,47 @@ VALUES (%s, %s, %s, %s) RETURNING id
 
 	return jobId, nil
 }
+
+// EnqueueIfNotExists enqueues a local clone request unless an unfinished one exists for the repo.
+func (s *gitserverLocalCloneStore) EnqueueIfNotExists(ctx context.Context, repoID int, sourceHostname string, destHostname string, deleteSource bool) (int, error) {
+	jobID, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`
+INSERT INTO
+	gitserver_relocator_jobs (repo_id, source_hostname, dest_hostname, delete_source)
+SELECT %s, %s, %s, %s
+WHERE NOT EXISTS (
+	SELECT 1 FROM gitserver_relocator_jobs
+	WHERE repo_id = %s AND state IN ('queued', 'processing', 'errored')
+)
+RETURNING id
+	`, repoID, sourceHostname, destHostname, deleteSource, repoID)))
+	return jobID, err
+}
+
+// ListUnfinishedSources returns the source hostname of the latest unfinished local clone request
+// of every repository that has one.
+func (s *gitserverLocalCloneStore) ListUnfinishedSources(ctx context.Context) (_ map[api.RepoName]string, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+SELECT DISTINCT ON (r.name) r.name, glj.source_hostname
+FROM gitserver_relocator_jobs glj
+JOIN repo r ON r.id = glj.repo_id
+WHERE glj.state IN ('queued', 'processing', 'errored')
+ORDER BY r.name, glj.id DESC
+	`))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	sources := make(map[api.RepoName]string)
+	for rows.Next() {
+		var (
+			repo   api.RepoName
+			source string
+		)
+		if err := rows.Scan(&repo, &source); err != nil {
+			return nil, err
+		}
+		sources[repo] = source
+	}
+	return sources, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 func TestGitserverLocalCloneEnqueue(t *testing.T) {
@@ -29,3 +30,72 @@ func TestGitserverLocalCloneEnqueue(t *testing.T) {
 	// TODO: right now we don't have a way to get the job ID from the job queue
 	// We'll test that once we implement getting the job from the queue.
 }
+
+func TestGitserverLocalCloneEnqueueIfNotExists(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo := &types.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	if err := db.Repos().Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+	store := db.GitserverLocalClone()
+
+	sources, err := store.ListUnfinishedSources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 0 {
+		t.Fatalf("expected no sources, got %v", sources)
+	}
+
+	jobID, err := store.EnqueueIfNotExists(ctx, int(repo.ID), "gitserver1", "gitserver2", false)
+	if err != nil {
+		t.Fatal("failed to enqueue job", err)
+	}
+	if jobID == 0 {
+		t.Fatal("expected job to be enqueued")
+	}
+
+	// The unfinished job prevents another one from being enqueued.
+	jobID2, err := store.EnqueueIfNotExists(ctx, int(repo.ID), "gitserver3", "gitserver2", false)
+	if err != nil {
+		t.Fatal("failed to enqueue job", err)
+	}
+	if jobID2 != 0 {
+		t.Fatalf("expected no job to be enqueued, got %d", jobID2)
+	}
+
+	sources, err = store.ListUnfinishedSources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if source := sources[repo.Name]; len(sources) != 1 || source != "gitserver1" {
+		t.Fatalf("expected source gitserver1, got %v", sources)
+	}
+
+	if _, err := db.ExecContext(ctx, "UPDATE gitserver_relocator_jobs SET state = 'completed' WHERE id = $1", jobID); err != nil {
+		t.Fatal(err)
+	}
+
+	sources, err = store.ListUnfinishedSources(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sources) != 0 {
+		t.Fatalf("expected no sources once the job completed, got %v", sources)
+	}
+	jobID, err = store.EnqueueIfNotExists(ctx, int(repo.ID), "gitserver3", "gitserver2", false)
+	if err != nil {
+		t.Fatal("failed to enqueue job", err)
+	}
+	if jobID == 0 {
+		t.Fatal("expected job to be enqueued once the previous one completed")
+	}
+}
@@ -22365,9 +22365,15 @@ type MockGitserverLocalCloneStore struct {
 	// EnqueueFunc is an instance of a mock function object controlling the
 	// behavior of the method Enqueue.
 	EnqueueFunc *GitserverLocalCloneStoreEnqueueFunc
+	// EnqueueIfNotExistsFunc is an instance of a mock function object
+	// controlling the behavior of the method EnqueueIfNotExists.
+	EnqueueIfNotExistsFunc *GitserverLocalCloneStoreEnqueueIfNotExistsFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *GitserverLocalCloneStoreHandleFunc
+	// ListUnfinishedSourcesFunc is an instance of a mock function object
+	// controlling the behavior of the method ListUnfinishedSources.
+	ListUnfinishedSourcesFunc *GitserverLocalCloneStoreListUnfinishedSourcesFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *GitserverLocalCloneStoreWithFunc
@@ -22383,11 +22389,21 @@ func NewMockGitserverLocalCloneStore() *MockGitserverLocalCloneStore {
 				return
 			},
 		},
+		EnqueueIfNotExistsFunc: &GitserverLocalCloneStoreEnqueueIfNotExistsFunc{
+			defaultHook: func(context.Context, int, string, string, bool) (r0 int, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &GitserverLocalCloneStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
 			},
 		},
+		ListUnfinishedSourcesFunc: &GitserverLocalCloneStoreListUnfinishedSourcesFunc{
+			defaultHook: func(context.Context) (r0 map[api.RepoName]string, r1 error) {
+				return
+			},
+		},
 		WithFunc: &GitserverLocalCloneStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) (r0 GitserverLocalCloneStore) {
 				return
@@ -22406,11 +22422,21 @@ func NewStrictMockGitserverLocalCloneStore() *MockGitserverLocalCloneStore {
 				panic("unexpected invocation of MockGitserverLocalCloneStore.Enqueue")
 			},
 		},
+		EnqueueIfNotExistsFunc: &GitserverLocalCloneStoreEnqueueIfNotExistsFunc{
+			defaultHook: func(context.Context, int, string, string, bool) (int, error) {
+				panic("unexpected invocation of MockGitserverLocalCloneStore.EnqueueIfNotExists")
+			},
+		},
 		HandleFunc: &GitserverLocalCloneStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockGitserverLocalCloneStore.Handle")
 			},
 		},
+		ListUnfinishedSourcesFunc: &GitserverLocalCloneStoreListUnfinishedSourcesFunc{
+			defaultHook: func(context.Context) (map[api.RepoName]string, error) {
+				panic("unexpected invocation of MockGitserverLocalCloneStore.ListUnfinishedSources")
+			},
+		},
 		WithFunc: &GitserverLocalCloneStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) GitserverLocalCloneStore {
 				panic("unexpected invocation of MockGitserverLocalCloneStore.With")
@@ -22427,9 +22453,15 @@ func NewMockGitserverLocalCloneStoreFrom(i GitserverLocalCloneStore) *MockGitser
 		EnqueueFunc: &GitserverLocalCloneStoreEnqueueFunc{
 			defaultHook: i.Enqueue,
 		},
+		EnqueueIfNotExistsFunc: &GitserverLocalCloneStoreEnqueueIfNotExistsFunc{
+			defaultHook: i.EnqueueIfNotExists,
+		},
 		HandleFunc: &GitserverLocalCloneStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
+		ListUnfinishedSourcesFunc: &GitserverLocalCloneStoreListUnfinishedSourcesFunc{
+			defaultHook: i.ListUnfinishedSources,
+		},
 		WithFunc: &GitserverLocalCloneStoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -22556,6 +22588,122 @@ func (c GitserverLocalCloneStoreEnqueueFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverLocalCloneStoreEnqueueIfNotExistsFunc describes the behavior
+// when the EnqueueIfNotExists method of the parent
+// MockGitserverLocalCloneStore instance is invoked.
+type GitserverLocalCloneStoreEnqueueIfNotExistsFunc struct {
+	defaultHook func(context.Context, int, string, string, bool) (int, error)
+	hooks       []func(context.Context, int, string, string, bool) (int, error)
+	history     []GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall
+	mutex       sync.Mutex
+}
+
+// EnqueueIfNotExists delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverLocalCloneStore) EnqueueIfNotExists(v0 context.Context, v1 int, v2 string, v3 string, v4 bool) (int, error) {
+	r0, r1 := m.EnqueueIfNotExistsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.EnqueueIfNotExistsFunc.appendCall(GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the EnqueueIfNotExists
+// method of the parent MockGitserverLocalCloneStore instance is invoked and
+// the hook queue is empty.
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) SetDefaultHook(hook func(context.Context, int, string, string, bool) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EnqueueIfNotExists method of the parent MockGitserverLocalCloneStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) PushHook(hook func(context.Context, int, string, string, bool) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, string, bool) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int, string, string, bool) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) nextHook() func(context.Context, int, string, string, bool) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) appendCall(r0 GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverLocalCloneStoreEnqueueIfNotExistsFunc) History() []GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall is an object that
+// describes an invocation of method EnqueueIfNotExists on an instance of
+// MockGitserverLocalCloneStore.
+type GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method invocation.
+	Arg4 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverLocalCloneStoreEnqueueIfNotExistsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverLocalCloneStoreHandleFunc describes the behavior when the Handle
 // method of the parent MockGitserverLocalCloneStore instance is invoked.
 type GitserverLocalCloneStoreHandleFunc struct {
@@ -22656,6 +22804,114 @@ func (c GitserverLocalCloneStoreHandleFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverLocalCloneStoreListUnfinishedSourcesFunc describes the behavior
+// when the ListUnfinishedSources method of the parent
+// MockGitserverLocalCloneStore instance is invoked.
+type GitserverLocalCloneStoreListUnfinishedSourcesFunc struct {
+	defaultHook func(context.Context) (map[api.RepoName]string, error)
+	hooks       []func(context.Context) (map[api.RepoName]string, error)
+	history     []GitserverLocalCloneStoreListUnfinishedSourcesFuncCall
+	mutex       sync.Mutex
+}
+
+// ListUnfinishedSources delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverLocalCloneStore) ListUnfinishedSources(v0 context.Context) (map[api.RepoName]string, error) {
+	r0, r1 := m.ListUnfinishedSourcesFunc.nextHook()(v0)
+	m.ListUnfinishedSourcesFunc.appendCall(GitserverLocalCloneStoreListUnfinishedSourcesFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListUnfinishedSources
+// method of the parent MockGitserverLocalCloneStore instance is invoked and
+// the hook queue is empty.
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) SetDefaultHook(hook func(context.Context) (map[api.RepoName]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListUnfinishedSources method of the parent MockGitserverLocalCloneStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) PushHook(hook func(context.Context) (map[api.RepoName]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) SetDefaultReturn(r0 map[api.RepoName]string, r1 error) {
+	f.SetDefaultHook(func(context.Context) (map[api.RepoName]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) PushReturn(r0 map[api.RepoName]string, r1 error) {
+	f.PushHook(func(context.Context) (map[api.RepoName]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) nextHook() func(context.Context) (map[api.RepoName]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) appendCall(r0 GitserverLocalCloneStoreListUnfinishedSourcesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverLocalCloneStoreListUnfinishedSourcesFuncCall objects describing
+// the invocations of this function.
+func (f *GitserverLocalCloneStoreListUnfinishedSourcesFunc) History() []GitserverLocalCloneStoreListUnfinishedSourcesFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverLocalCloneStoreListUnfinishedSourcesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverLocalCloneStoreListUnfinishedSourcesFuncCall is an object that
+// describes an invocation of method ListUnfinishedSources on an instance of
+// MockGitserverLocalCloneStore.
+type GitserverLocalCloneStoreListUnfinishedSourcesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoName]string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverLocalCloneStoreListUnfinishedSourcesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverLocalCloneStoreListUnfinishedSourcesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverLocalCloneStoreWithFunc describes the behavior when the With
 // method of the parent MockGitserverLocalCloneStore instance is invoked.
 type GitserverLocalCloneStoreWithFunc struct {
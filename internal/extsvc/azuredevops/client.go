@@ -0,0 +1,258 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// The metric generated here will be named as "src_azuredevops_requests_total".
+var requestCounter = metrics.NewRequestMeter("azuredevops", "Total number of requests sent to the Azure DevOps API.")
+
+// apiVersion is the version of the Azure DevOps REST API used for all requests.
+const apiVersion = "7.0"
+
+// profileURL is the URL of the profile API, which is the only API that is
+// hosted outside of the organization-scoped dev.azure.com API.
+var profileURL = "https://app.vssps.visualstudio.com/_apis/profile/profiles/me"
+
+type Client interface {
+	Authenticator() auth.Authenticator
+	WithAuthenticator(a auth.Authenticator) Client
+
+	GetAuthorizedProfile(ctx context.Context) (*Profile, error)
+
+	ListRepositories(ctx context.Context, org, project string) ([]*Repository, error)
+	GetRepository(ctx context.Context, org, project, name string) (*Repository, error)
+
+	CreatePullRequest(ctx context.Context, repo *Repository, input CreatePullRequestInput) (*PullRequest, error)
+	GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error)
+	FindActivePullRequest(ctx context.Context, repo *Repository, sourceRefName, targetRefName string) (*PullRequest, error)
+	UpdatePullRequest(ctx context.Context, repo *Repository, id int, input UpdatePullRequestInput) (*PullRequest, error)
+	CompletePullRequest(ctx context.Context, repo *Repository, id int, input CompletePullRequestInput) (*PullRequest, error)
+	CreatePullRequestCommentThread(ctx context.Context, repo *Repository, id int, content string) (*CommentThread, error)
+	GetPullRequestStatuses(ctx context.Context, repo *Repository, id int) ([]*PullRequestBuildStatus, error)
+}
+
+// client accesses Azure DevOps Services via the REST API.
+type client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Azure DevOps Services.
+	URL *url.URL
+
+	// Auth is the authentication method used when accessing the server. Only
+	// auth.BasicAuth with a personal access token as the password is currently
+	// supported.
+	Auth auth.Authenticator
+
+	// RateLimit is the self-imposed rate limiter (since Azure DevOps only
+	// reports throttling after the fact in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient creates a new Azure DevOps API client from the given external
+// service configuration. If a nil httpClient is provided, http.DefaultClient
+// will be used.
+func NewClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (Client, error) {
+	return newClient(urn, config, httpClient)
+}
+
+func newClient(urn string, config *schema.AzureDevOpsConnection, httpClient httpcli.Doer) (*client, error) {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// The path of all API requests is of the form
+		// /{org}[/{project}]/_apis/{area}/..., so we use the area as category.
+		_, rest, ok := strings.Cut(u.Path, "/_apis/")
+		if !ok {
+			return ""
+		}
+		category, _, _ := strings.Cut(rest, "/")
+		return category
+	})
+
+	u := extsvc.AzureDevOpsURL
+	if config.Url != "" {
+		var err error
+		if u, err = url.Parse(config.Url); err != nil {
+			return nil, err
+		}
+	}
+
+	return &client{
+		httpClient: httpClient,
+		URL:        extsvc.NormalizeBaseURL(u),
+		Auth: &auth.BasicAuth{
+			Username: config.Username,
+			Password: config.Token,
+		},
+		// Default limits are defined in extsvc.GetLimitFromConfig
+		rateLimit: ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+func (c *client) Authenticator() auth.Authenticator {
+	return c.Auth
+}
+
+// WithAuthenticator returns a new Client that uses the same configuration,
+// HTTPClient, and RateLimiter as the current Client, except authenticated with
+// the given authenticator instance.
+//
+// Note that using an unsupported Authenticator implementation may result in
+// unexpected behaviour, or (more likely) errors. At present, only BasicAuth is
+// supported.
+func (c *client) WithAuthenticator(a auth.Authenticator) Client {
+	return &client{
+		httpClient: c.httpClient,
+		URL:        c.URL,
+		Auth:       a,
+		rateLimit:  c.rateLimit,
+	}
+}
+
+// GetAuthorizedProfile returns the profile of the user the client is
+// authenticated as, thereby validating that the current authenticator is valid.
+func (c *client) GetAuthorizedProfile(ctx context.Context) (*Profile, error) {
+	req, err := http.NewRequest("GET", profileURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+
+	var p Profile
+	if err := c.do(ctx, req, &p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// newRequest creates a request for the API path relative to the base URL. The
+// API version is always added to the query string.
+func (c *client) newRequest(method, path string, qs url.Values, body any) (*http.Request, error) {
+	if qs == nil {
+		qs = make(url.Values)
+	}
+	qs.Set("api-version", apiVersion)
+
+	var r io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshalling request")
+		}
+		r = bytes.NewReader(data)
+	}
+
+	u := url.URL{Path: path, RawQuery: qs.Encode()}
+	req, err := http.NewRequest(method, u.String(), r)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request")
+	}
+	return req, nil
+}
+
+func (c *client) do(ctx context.Context, req *http.Request, result any) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Azure DevOps"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	if err := c.Auth.Authenticate(req); err != nil {
+		return err
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Azure DevOps answers requests with invalid credentials with a redirect to
+	// the sign in page rather than an error, which ends up as a 203 with an
+	// HTML body.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		resp.StatusCode = http.StatusUnauthorized
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       string(bs),
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bs, result)
+	}
+
+	return nil
+}
+
+// listResponse is the envelope of all Azure DevOps API responses that return
+// a collection.
+type listResponse[T any] struct {
+	Count int `json:"count"`
+	Value []T `json:"value"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       string
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err is an Azure DevOps API error caused by a
+// conflict, such as an active pull request already existing for a branch.
+func IsConflict(err error) bool {
+	var e *httpError
+	return errors.As(err, &e) && e.StatusCode == http.StatusConflict
+}
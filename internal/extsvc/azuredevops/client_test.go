@@ -0,0 +1,220 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_ListRepositories(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.URL.Path, "/myorg/my project/_apis/git/repositories"; have != want {
+			t.Errorf("unexpected path. want=%q have=%q", want, have)
+		}
+		if have, want := r.URL.Query().Get("api-version"), apiVersion; have != want {
+			t.Errorf("unexpected API version. want=%q have=%q", want, have)
+		}
+		if user, pass, _ := r.BasicAuth(); user != "alice" || pass != "secret" {
+			t.Errorf("unexpected credentials %q:%q", user, pass)
+		}
+
+		_, _ = io.WriteString(w, `{"count": 1, "value": [{"id": "r1", "name": "myrepo", "project": {"id": "p1", "name": "my project"}}]}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, srv.URL)
+
+	repos, err := cli.ListRepositories(context.Background(), "myorg", "my project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*Repository{{ID: "r1", Name: "myrepo", Project: Project{ID: "p1", Name: "my project"}}}
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repository{
+		ID:      "r1",
+		URL:     "https://dev.azure.com/myorg/p1/_apis/git/repositories/r1",
+		Project: Project{ID: "p1"},
+	}
+
+	t.Run("created", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/myorg/p1/_apis/git/repositories/r1/pullrequests" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+
+			var input CreatePullRequestInput
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+				t.Fatal(err)
+			}
+			_ = json.NewEncoder(w).Encode(PullRequest{
+				ID:            42,
+				Status:        PullRequestStatusActive,
+				Title:         input.Title,
+				SourceRefName: input.SourceRefName,
+				TargetRefName: input.TargetRefName,
+				IsDraft:       input.IsDraft,
+			})
+		}))
+		defer srv.Close()
+
+		pr, err := newTestClient(t, srv.URL).CreatePullRequest(context.Background(), repo, CreatePullRequestInput{
+			SourceRefName: "refs/heads/feature",
+			TargetRefName: "refs/heads/main",
+			Title:         "My change",
+			IsDraft:       true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.ID != 42 || pr.Title != "My change" || !pr.IsDraft || pr.SourceRefName != "refs/heads/feature" {
+			t.Errorf("unexpected pull request: %+v", pr)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = io.WriteString(w, `{"message": "TF401179: An active pull request for the source and target branch already exists."}`)
+		}))
+		defer srv.Close()
+
+		_, err := newTestClient(t, srv.URL).CreatePullRequest(context.Background(), repo, CreatePullRequestInput{})
+		if !IsConflict(err) {
+			t.Fatalf("expected conflict error, got %v", err)
+		}
+	})
+}
+
+func TestClient_FindActivePullRequest(t *testing.T) {
+	repo := &Repository{ID: "r1", URL: "https://dev.azure.com/myorg/p1/_apis/git/repositories/r1", Project: Project{ID: "p1"}}
+
+	var value string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("searchCriteria.status") != "active" ||
+			q.Get("searchCriteria.sourceRefName") != "refs/heads/feature" ||
+			q.Get("searchCriteria.targetRefName") != "refs/heads/main" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		_, _ = io.WriteString(w, `{"count": 1, "value": [`+value+`]}`)
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, srv.URL)
+
+	value = `{"pullRequestId": 7}`
+	pr, err := cli.FindActivePullRequest(context.Background(), repo, "refs/heads/feature", "refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.ID != 7 {
+		t.Errorf("unexpected pull request ID %d", pr.ID)
+	}
+
+	value = ``
+	if _, err := cli.FindActivePullRequest(context.Background(), repo, "refs/heads/feature", "refs/heads/main"); !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestClient_CompletePullRequest(t *testing.T) {
+	repo := &Repository{ID: "r1", URL: "https://dev.azure.com/myorg/p1/_apis/git/repositories/r1", Project: Project{ID: "p1"}}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/myorg/p1/_apis/git/repositories/r1/pullrequests/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		want := `{"status":"completed","lastMergeSourceCommit":{"commitId":"abc"},"completionOptions":{"mergeStrategy":"squash","deleteSourceBranch":false}}`
+		if string(body) != want {
+			t.Errorf("unexpected body. want=%s have=%s", want, body)
+		}
+		_, _ = io.WriteString(w, `{"pullRequestId": 7, "status": "completed"}`)
+	}))
+	defer srv.Close()
+
+	pr, err := newTestClient(t, srv.URL).CompletePullRequest(context.Background(), repo, 7, CompletePullRequestInput{
+		LastMergeSourceCommit: GitCommitRef{CommitID: "abc"},
+		MergeStrategy:         MergeStrategySquash,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pr.Status != PullRequestStatusCompleted {
+		t.Errorf("unexpected status %q", pr.Status)
+	}
+}
+
+func TestClient_GetAuthorizedProfile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "bob" || pass != "other" {
+			// Azure DevOps redirects unauthenticated requests to the sign in
+			// page.
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			_, _ = io.WriteString(w, `<html></html>`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"id": "u1", "displayName": "Bob"}`)
+	}))
+	defer srv.Close()
+
+	old := profileURL
+	profileURL = srv.URL + "/_apis/profile/profiles/me"
+	t.Cleanup(func() { profileURL = old })
+
+	cli := newTestClient(t, srv.URL)
+	if _, err := cli.GetAuthorizedProfile(context.Background()); !errcode.IsUnauthorized(err) {
+		t.Fatalf("expected unauthorized error, got %v", err)
+	}
+
+	profile, err := cli.WithAuthenticator(&auth.BasicAuth{Username: "bob", Password: "other"}).GetAuthorizedProfile(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if profile.DisplayName != "Bob" {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestRepository_Organization(t *testing.T) {
+	for url, want := range map[string]string{
+		"https://dev.azure.com/myorg/p1/_apis/git/repositories/r1":    "myorg",
+		"https://myorg.visualstudio.com/p1/_apis/git/repositories/r1": "myorg",
+	} {
+		org, err := (&Repository{URL: url}).Organization()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if org != want {
+			t.Errorf("unexpected organization for %q. want=%q have=%q", url, want, org)
+		}
+	}
+
+	if _, err := (&Repository{}).Organization(); err == nil {
+		t.Error("expected error for repository without URL")
+	}
+}
+
+func newTestClient(t *testing.T, url string) Client {
+	t.Helper()
+
+	cli, err := NewClient("urn", &schema.AzureDevOpsConnection{Url: url, Username: "alice", Token: "secret"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
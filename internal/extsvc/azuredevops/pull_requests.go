@@ -0,0 +1,238 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type CreatePullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft"`
+}
+
+// CreatePullRequest opens a new pull request.
+//
+// If an active pull request for the same source and target branches already
+// exists, an error for which IsConflict returns true is returned.
+func (c *client) CreatePullRequest(ctx context.Context, repo *Repository, input CreatePullRequestInput) (*PullRequest, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("POST", path+"/pullrequests", nil, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// GetPullRequest retrieves a single pull request.
+func (c *client) GetPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("GET", fmt.Sprintf("%s/pullrequests/%d", path, id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// FindActivePullRequest returns the active pull request from the given source
+// branch into the given target branch. Azure DevOps allows at most one of those
+// at a time. If there is none, a not found error is returned.
+func (c *client) FindActivePullRequest(ctx context.Context, repo *Repository, sourceRefName, targetRefName string) (*PullRequest, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make(url.Values)
+	qs.Set("searchCriteria.status", string(PullRequestStatusActive))
+	qs.Set("searchCriteria.sourceRefName", sourceRefName)
+	qs.Set("searchCriteria.targetRefName", targetRefName)
+
+	req, err := c.newRequest("GET", path+"/pullrequests", qs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PullRequest]
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	if len(resp.Value) == 0 {
+		return nil, &notFoundError{fmt.Sprintf("no active pull request from %q into %q", sourceRefName, targetRefName)}
+	}
+	return resp.Value[0], nil
+}
+
+// UpdatePullRequestInput contains the fields of a pull request that can be
+// updated. Only non-nil fields are changed.
+type UpdatePullRequestInput struct {
+	Title         *string            `json:"title,omitempty"`
+	Description   *string            `json:"description,omitempty"`
+	Status        *PullRequestStatus `json:"status,omitempty"`
+	TargetRefName *string            `json:"targetRefName,omitempty"`
+	IsDraft       *bool              `json:"isDraft,omitempty"`
+}
+
+// UpdatePullRequest updates a pull request. This is also used to abandon and
+// reactivate pull requests by changing their status.
+func (c *client) UpdatePullRequest(ctx context.Context, repo *Repository, id int, input UpdatePullRequestInput) (*PullRequest, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("PATCH", fmt.Sprintf("%s/pullrequests/%d", path, id), nil, &input)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+type MergeStrategy string
+
+const (
+	MergeStrategyNoFastForward MergeStrategy = "noFastForward"
+	MergeStrategySquash        MergeStrategy = "squash"
+)
+
+type CompletePullRequestInput struct {
+	// LastMergeSourceCommit must be the current head commit of the source
+	// branch, which guards against completing a pull request that has been
+	// updated in the meantime.
+	LastMergeSourceCommit GitCommitRef
+	MergeStrategy         MergeStrategy
+	DeleteSourceBranch    bool
+}
+
+// CompletePullRequest merges a pull request.
+func (c *client) CompletePullRequest(ctx context.Context, repo *Repository, id int, input CompletePullRequestInput) (*PullRequest, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeStrategy := input.MergeStrategy
+	if mergeStrategy == "" {
+		mergeStrategy = MergeStrategyNoFastForward
+	}
+
+	body := struct {
+		Status                PullRequestStatus `json:"status"`
+		LastMergeSourceCommit GitCommitRef      `json:"lastMergeSourceCommit"`
+		CompletionOptions     struct {
+			MergeStrategy      MergeStrategy `json:"mergeStrategy"`
+			DeleteSourceBranch bool          `json:"deleteSourceBranch"`
+		} `json:"completionOptions"`
+	}{
+		Status:                PullRequestStatusCompleted,
+		LastMergeSourceCommit: input.LastMergeSourceCommit,
+	}
+	body.CompletionOptions.MergeStrategy = mergeStrategy
+	body.CompletionOptions.DeleteSourceBranch = input.DeleteSourceBranch
+
+	req, err := c.newRequest("PATCH", fmt.Sprintf("%s/pullrequests/%d", path, id), nil, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr PullRequest
+	if err := c.do(ctx, req, &pr); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &pr, nil
+}
+
+// CreatePullRequestCommentThread posts a new top level comment on a pull
+// request.
+func (c *client) CreatePullRequestCommentThread(ctx context.Context, repo *Repository, id int, content string) (*CommentThread, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	type commentInput struct {
+		ParentCommentID int    `json:"parentCommentId"`
+		Content         string `json:"content"`
+		CommentType     string `json:"commentType"`
+	}
+	body := struct {
+		Comments []commentInput `json:"comments"`
+		Status   string         `json:"status"`
+	}{
+		Comments: []commentInput{{Content: content, CommentType: "text"}},
+		Status:   "active",
+	}
+
+	req, err := c.newRequest("POST", fmt.Sprintf("%s/pullRequests/%d/threads", path, id), nil, &body)
+	if err != nil {
+		return nil, err
+	}
+
+	var thread CommentThread
+	if err := c.do(ctx, req, &thread); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return &thread, nil
+}
+
+// GetPullRequestStatuses returns all statuses that have been posted to a pull
+// request, including outdated ones.
+func (c *client) GetPullRequestStatuses(ctx context.Context, repo *Repository, id int) ([]*PullRequestBuildStatus, error) {
+	path, err := repositoryPath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("GET", fmt.Sprintf("%s/pullRequests/%d/statuses", path, id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*PullRequestBuildStatus]
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, errors.Wrap(err, "sending request")
+	}
+
+	return resp.Value, nil
+}
+
+type notFoundError struct {
+	msg string
+}
+
+func (e *notFoundError) Error() string  { return e.msg }
+func (e *notFoundError) NotFound() bool { return true }
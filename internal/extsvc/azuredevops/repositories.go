@@ -0,0 +1,52 @@
+package azuredevops
+
+import (
+	"context"
+)
+
+// ListRepositories returns all repositories of the given organization. If
+// project is not empty, only the repositories of that project are returned.
+//
+// The API returns all repositories at once, so there is no pagination.
+func (c *client) ListRepositories(ctx context.Context, org, project string) ([]*Repository, error) {
+	path := org
+	if project != "" {
+		path += "/" + project
+	}
+
+	req, err := c.newRequest("GET", path+"/_apis/git/repositories", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp listResponse[*Repository]
+	if err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// GetRepository returns the repository with the given name (or ID) in the given
+// organization and project.
+func (c *client) GetRepository(ctx context.Context, org, project, name string) (*Repository, error) {
+	req, err := c.newRequest("GET", org+"/"+project+"/_apis/git/repositories/"+name, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var repo Repository
+	if err := c.do(ctx, req, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// repositoryPath returns the API path of the given repository, which is used
+// as the prefix of all pull request paths.
+func repositoryPath(repo *Repository) (string, error) {
+	org, err := repo.Organization()
+	if err != nil {
+		return "", err
+	}
+	return org + "/" + repo.Project.ID + "/_apis/git/repositories/" + repo.ID, nil
+}
@@ -0,0 +1,200 @@
+package azuredevops
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Types that are returned by Azure DevOps calls.
+
+type Profile struct {
+	ID           string `json:"id"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+	PublicAlias  string `json:"publicAlias"`
+}
+
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state"`
+	Visibility string `json:"visibility"`
+}
+
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch"`
+	Size          int64   `json:"size"`
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled"`
+	IsFork        bool    `json:"isFork"`
+}
+
+// Organization returns the name of the organization the repository belongs
+// to, which is not part of the repository object returned by the API. It is
+// derived from the API URL of the repository, which is either of the form
+// https://dev.azure.com/{org}/... or, for older organizations,
+// https://{org}.visualstudio.com/....
+func (r *Repository) Organization() (string, error) {
+	u, err := url.Parse(r.URL)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing repository URL")
+	}
+
+	if host := u.Hostname(); strings.HasSuffix(host, ".visualstudio.com") {
+		return strings.TrimSuffix(host, ".visualstudio.com"), nil
+	}
+
+	org, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if org == "" {
+		return "", errors.Errorf("unable to determine organization of repository %q", r.URL)
+	}
+	return org, nil
+}
+
+// FullName returns the "org/project/repo" name of the repository.
+func (r *Repository) FullName() (string, error) {
+	org, err := r.Organization()
+	if err != nil {
+		return "", err
+	}
+	return org + "/" + r.Project.Name + "/" + r.Name, nil
+}
+
+type IdentityRef struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	ImageURL    string `json:"imageUrl,omitempty"`
+}
+
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+)
+
+// ReviewerVote is the vote of a reviewer on a pull request.
+type ReviewerVote int
+
+const (
+	ReviewerVoteApproved                ReviewerVote = 10
+	ReviewerVoteApprovedWithSuggestions ReviewerVote = 5
+	ReviewerVoteNoVote                  ReviewerVote = 0
+	ReviewerVoteWaitingForAuthor        ReviewerVote = -5
+	ReviewerVoteRejected                ReviewerVote = -10
+)
+
+type Reviewer struct {
+	IdentityRef
+	Vote        ReviewerVote `json:"vote"`
+	IsRequired  bool         `json:"isRequired"`
+	IsContainer bool         `json:"isContainer"`
+	HasDeclined bool         `json:"hasDeclined"`
+}
+
+type GitCommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// PullRequest represents a single pull request, as returned by the API.
+type PullRequest struct {
+	ID                    int               `json:"pullRequestId"`
+	Repository            Repository        `json:"repository"`
+	Status                PullRequestStatus `json:"status"`
+	CreatedBy             IdentityRef       `json:"createdBy"`
+	CreationDate          time.Time         `json:"creationDate"`
+	ClosedDate            time.Time         `json:"closedDate"`
+	Title                 string            `json:"title"`
+	Description           string            `json:"description"`
+	SourceRefName         string            `json:"sourceRefName"`
+	TargetRefName         string            `json:"targetRefName"`
+	MergeStatus           string            `json:"mergeStatus"`
+	IsDraft               bool              `json:"isDraft"`
+	LastMergeSourceCommit *GitCommitRef     `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *GitCommitRef     `json:"lastMergeTargetCommit,omitempty"`
+	LastMergeCommit       *GitCommitRef     `json:"lastMergeCommit,omitempty"`
+	Reviewers             []Reviewer        `json:"reviewers"`
+	URL                   string            `json:"url"`
+}
+
+// WebURL returns the URL of the pull request in the Azure DevOps web UI. It
+// relies on the web URL of the repository, which the API only includes in
+// pull requests if the repository was expanded by the caller.
+func (pr *PullRequest) WebURL() string {
+	if pr.Repository.WebURL == "" {
+		return ""
+	}
+	return pr.Repository.WebURL + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+type PullRequestStatusState string
+
+const (
+	PullRequestStatusStateError         PullRequestStatusState = "error"
+	PullRequestStatusStateFailed        PullRequestStatusState = "failed"
+	PullRequestStatusStateNotApplicable PullRequestStatusState = "notApplicable"
+	PullRequestStatusStateNotSet        PullRequestStatusState = "notSet"
+	PullRequestStatusStatePending       PullRequestStatusState = "pending"
+	PullRequestStatusStateSucceeded     PullRequestStatusState = "succeeded"
+)
+
+type StatusContext struct {
+	Name  string `json:"name"`
+	Genre string `json:"genre,omitempty"`
+}
+
+// PullRequestBuildStatus is a status posted to a pull request, usually by a
+// build pipeline or another external service.
+type PullRequestBuildStatus struct {
+	ID           int                    `json:"id"`
+	State        PullRequestStatusState `json:"state"`
+	Description  string                 `json:"description"`
+	Context      StatusContext          `json:"context"`
+	TargetURL    string                 `json:"targetUrl,omitempty"`
+	IterationID  int                    `json:"iterationId,omitempty"`
+	CreatedBy    IdentityRef            `json:"createdBy"`
+	CreationDate time.Time              `json:"creationDate"`
+	UpdatedDate  time.Time              `json:"updatedDate"`
+}
+
+func (s *PullRequestBuildStatus) Key() string {
+	return strconv.Itoa(s.ID)
+}
+
+// ContextKey identifies the check a status belongs to. Azure DevOps keeps all
+// statuses ever posted to a pull request, so only the most recent status for
+// each context reflects the current state of the check.
+func (s *PullRequestBuildStatus) ContextKey() string {
+	if s.Context.Genre == "" {
+		return s.Context.Name
+	}
+	return s.Context.Genre + "/" + s.Context.Name
+}
+
+type Comment struct {
+	ID              int         `json:"id"`
+	ParentCommentID int         `json:"parentCommentId"`
+	Content         string      `json:"content"`
+	CommentType     string      `json:"commentType"`
+	Author          IdentityRef `json:"author"`
+	PublishedDate   time.Time   `json:"publishedDate"`
+}
+
+type CommentThread struct {
+	ID            int       `json:"id"`
+	Status        string    `json:"status"`
+	Comments      []Comment `json:"comments"`
+	PublishedDate time.Time `json:"publishedDate"`
+}
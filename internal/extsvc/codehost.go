@@ -31,6 +31,8 @@ var (
 
 	BitbucketOrgURL = mustParseURL("https://bitbucket.org")
 
+	AzureDevOpsURL = mustParseURL("https://dev.azure.com")
+
 	MavenURL    = &url.URL{Host: "maven"}
 	JVMPackages = NewCodeHost(MavenURL, TypeJVMPackages)
 
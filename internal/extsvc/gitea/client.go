@@ -0,0 +1,319 @@
+//nolint:bodyclose // Body is closed in Client.Do, but the response is still returned to provide access to the headers
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client access a Gitea (or Forgejo) instance via the REST API.
+type Client struct {
+	// Config is the code host connection config for this client
+	Config *schema.GiteaConnection
+
+	// URL is the base URL of Gitea.
+	URL *url.URL
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter (since Gitea does not have a concept
+	// of rate limiting in HTTP response headers).
+	rateLimit *ratelimit.InstrumentedLimiter
+
+	// sudo is the login of the user to impersonate in API requests. This requires
+	// the configured token to belong to a site administrator.
+	sudo string
+}
+
+// NewClient returns an authenticated Gitea API client with
+// the provided configuration. If a nil httpClient is provided, http.DefaultClient
+// will be used.
+func NewClient(urn string, config *schema.GiteaConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		Config:     config,
+		URL:        u,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// WithSudo returns a copy of the client that performs all requests on behalf of
+// the user with the given login.
+func (c *Client) WithSudo(login string) *Client {
+	cc := *c
+	cc.sudo = login
+	return &cc
+}
+
+// Pagination selects a page of a list response. Gitea caps the page size to a
+// site-configurable maximum, so callers must rely on the hasNextPage results of
+// list methods rather than on the number of items returned.
+type Pagination struct {
+	Page  int
+	Limit int
+}
+
+func (p *Pagination) encodeTo(qs url.Values) {
+	page, limit := 1, 50
+	if p != nil {
+		if p.Page > 0 {
+			page = p.Page
+		}
+		if p.Limit > 0 {
+			limit = p.Limit
+		}
+	}
+
+	qs.Set("page", strconv.Itoa(page))
+	qs.Set("limit", strconv.Itoa(limit))
+}
+
+// SearchRepositoriesArgs defines options to be set on SearchRepositories method calls.
+type SearchRepositoriesArgs struct {
+	Cursor *Pagination
+
+	// Query is a keyword to search for. An empty query matches all repositories.
+	Query string
+
+	// Topic restricts Query to match repository topics exactly.
+	Topic bool
+}
+
+type searchRepositoriesResponse struct {
+	OK    bool          `json:"ok"`
+	Data  []*Repository `json:"data"`
+	Error string        `json:"error,omitempty"`
+}
+
+// SearchRepositories returns a page of repositories visible to the authenticated
+// user that match the given search.
+func (c *Client) SearchRepositories(ctx context.Context, opts SearchRepositoriesArgs) (repos []*Repository, hasNextPage bool, err error) {
+	qs := make(url.Values)
+	opts.Cursor.encodeTo(qs)
+	if opts.Query != "" {
+		qs.Set("q", opts.Query)
+	}
+	if opts.Topic {
+		qs.Set("topic", "true")
+	}
+
+	var resp searchRepositoriesResponse
+	res, err := c.get(ctx, "api/v1/repos/search", qs, &resp)
+	if err != nil {
+		return nil, false, err
+	}
+	if !resp.OK {
+		return nil, false, errors.Newf("Gitea repository search failed: %s", resp.Error)
+	}
+
+	return resp.Data, hasNextPageLink(res.Header), nil
+}
+
+// ListOrgRepositories returns a page of the repositories of the given organization.
+func (c *Client) ListOrgRepositories(ctx context.Context, org string, cursor *Pagination) (repos []*Repository, hasNextPage bool, err error) {
+	qs := make(url.Values)
+	cursor.encodeTo(qs)
+
+	res, err := c.get(ctx, "api/v1/orgs/"+url.PathEscape(org)+"/repos", qs, &repos)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repos, hasNextPageLink(res.Header), nil
+}
+
+// ListUserRepositories returns a page of the repositories the authenticated (or
+// impersonated) user has access to.
+func (c *Client) ListUserRepositories(ctx context.Context, cursor *Pagination) (repos []*Repository, hasNextPage bool, err error) {
+	qs := make(url.Values)
+	cursor.encodeTo(qs)
+
+	res, err := c.get(ctx, "api/v1/user/repos", qs, &repos)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return repos, hasNextPageLink(res.Header), nil
+}
+
+// GetRepository returns the repository with the given owner and name.
+func (c *Client) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo Repository
+	if _, err := c.get(ctx, "api/v1/repos/"+url.PathEscape(owner)+"/"+url.PathEscape(name), nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GetUser returns the user with the given login.
+func (c *Client) GetUser(ctx context.Context, login string) (*User, error) {
+	var user User
+	if _, err := c.get(ctx, "api/v1/users/"+url.PathEscape(login), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetAuthenticatedUser returns the user the configured token belongs to (or the
+// impersonated user).
+func (c *Client) GetAuthenticatedUser(ctx context.Context) (*User, error) {
+	var user User
+	if _, err := c.get(ctx, "api/v1/user", nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetVersion returns the version of the Gitea instance.
+func (c *Client) GetVersion(ctx context.Context) (string, error) {
+	var v struct {
+		Version string `json:"version"`
+	}
+	if _, err := c.get(ctx, "api/v1/version", nil, &v); err != nil {
+		return "", err
+	}
+	return v.Version, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, qs url.Values, result any) (*http.Response, error) {
+	u := url.URL{Path: path, RawQuery: qs.Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result any) (*http.Response, error) {
+	// The base URL may include a path prefix when Gitea is served from a sub-path.
+	base := *c.URL
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	req.URL = base.ResolveReference(req.URL)
+	req.Header.Set("Accept", "application/json")
+
+	if c.Config.Token != "" {
+		req.Header.Set("Authorization", "token "+c.Config.Token)
+	}
+	if c.sudo != "" {
+		req.Header.Set("Sudo", c.sudo)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	return resp, json.Unmarshal(bs, result)
+}
+
+// hasNextPageLink returns true if the Link header of a list response references
+// a next page.
+func hasNextPageLink(header http.Header) bool {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		if strings.Contains(link, `rel="next"`) {
+			return true
+		}
+	}
+	return false
+}
+
+// Repository is a Gitea repository as returned by the REST API.
+type Repository struct {
+	ID            int64       `json:"id"`
+	Owner         *User       `json:"owner"`
+	Name          string      `json:"name"`
+	FullName      string      `json:"full_name"`
+	Description   string      `json:"description"`
+	Private       bool        `json:"private"`
+	Fork          bool        `json:"fork"`
+	Parent        *Repository `json:"parent,omitempty"`
+	Mirror        bool        `json:"mirror"`
+	Archived      bool        `json:"archived"`
+	Empty         bool        `json:"empty"`
+	HTMLURL       string      `json:"html_url"`
+	CloneURL      string      `json:"clone_url"`
+	SSHURL        string      `json:"ssh_url"`
+	DefaultBranch string      `json:"default_branch"`
+	Stars         int         `json:"stars_count"`
+	Topics        []string    `json:"topics"`
+	UpdatedAt     string      `json:"updated_at"`
+}
+
+// User is a Gitea user or organization as returned by the REST API.
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email"`
+	IsAdmin  bool   `json:"is_admin"`
+}
+
+// AccountData is stored in the user_external_accounts table for Gitea accounts
+// matched by the authorization provider.
+type AccountData struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Gitea API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
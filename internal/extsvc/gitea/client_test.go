@@ -0,0 +1,102 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestClient_SearchRepositories(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gitea/api/v1/repos/search" {
+			http.NotFound(w, r)
+			return
+		}
+		if have, want := r.Header.Get("Authorization"), "token secret"; have != want {
+			t.Errorf("unexpected Authorization header. want=%q have=%q", want, have)
+		}
+		if have, want := r.URL.RawQuery, "limit=50&page=2&q=go&topic=true"; have != want {
+			t.Errorf("unexpected query. want=%q have=%q", want, have)
+		}
+
+		w.Header().Set("Link", `<https://gitea.example.com/api/v1/repos/search?page=3>; rel="next",<https://gitea.example.com/api/v1/repos/search?page=1>; rel="first"`)
+		_ = json.NewEncoder(w).Encode(searchRepositoriesResponse{
+			OK:   true,
+			Data: []*Repository{{ID: 1, FullName: "org/repo", Topics: []string{"go"}}},
+		})
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, srv.URL+"/gitea")
+
+	repos, hasNextPage, err := cli.SearchRepositories(context.Background(), SearchRepositoriesArgs{
+		Cursor: &Pagination{Page: 2},
+		Query:  "go",
+		Topic:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasNextPage {
+		t.Error("expected next page")
+	}
+	if diff := cmp.Diff([]*Repository{{ID: 1, FullName: "org/repo", Topics: []string{"go"}}}, repos); diff != "" {
+		t.Errorf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestClient_ListUserRepositories_Sudo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if have, want := r.Header.Get("Sudo"), "alice"; have != want {
+			t.Errorf("unexpected Sudo header. want=%q have=%q", want, have)
+		}
+		w.Header().Set("Link", `<https://gitea.example.com/api/v1/user/repos?page=1>; rel="prev"`)
+		_ = json.NewEncoder(w).Encode([]*Repository{{ID: 2, FullName: "alice/private", Private: true}})
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, srv.URL).WithSudo("alice")
+
+	repos, hasNextPage, err := cli.ListUserRepositories(context.Background(), &Pagination{Page: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasNextPage {
+		t.Error("unexpected next page")
+	}
+	if len(repos) != 1 || repos[0].ID != 2 {
+		t.Errorf("unexpected repos: %+v", repos)
+	}
+}
+
+func TestClient_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	cli := newTestClient(t, srv.URL)
+
+	_, err := cli.GetRepository(context.Background(), "org", "missing")
+	var e *httpError
+	if !errors.As(err, &e) || !e.NotFound() {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+
+	cli, err := NewClient("urn", &schema.GiteaConnection{Url: url, Token: "secret"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
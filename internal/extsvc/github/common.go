@@ -1011,6 +1011,63 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+const enablePullRequestAutoMergeMutation = `
+mutation EnablePullRequestAutoMerge($input: EnablePullRequestAutoMergeInput!) {
+  enablePullRequestAutoMerge(input: $input) {
+	  pullRequest {
+		  ...pr
+	  }
+  }
+}
+`
+
+// EnablePullRequestAutoMerge enables auto-merge for the PullRequest on GitHub,
+// so that it is merged once all of its requirements are met.
+func (c *V4Client) EnablePullRequestAutoMerge(ctx context.Context, pr *PullRequest, squash bool) error {
+	version := c.determineGitHubVersion(ctx)
+	prFragment, err := pullRequestFragments(version)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		EnablePullRequestAutoMerge struct {
+			PullRequest struct {
+				PullRequest
+				Participants  struct{ Nodes []Actor }
+				TimelineItems TimelineItemConnection
+			} `json:"pullRequest"`
+		} `json:"enablePullRequestAutoMerge"`
+	}
+
+	mergeMethod := "MERGE"
+	if squash {
+		mergeMethod = "SQUASH"
+	}
+	input := map[string]any{"input": struct {
+		PullRequestID string `json:"pullRequestId"`
+		MergeMethod   string `json:"mergeMethod,omitempty"`
+	}{
+		PullRequestID: pr.ID,
+		MergeMethod:   mergeMethod,
+	}}
+	if err := c.requestGraphQL(ctx, prFragment+"\n"+enablePullRequestAutoMergeMutation, input, &result); err != nil {
+		return err
+	}
+
+	ti := result.EnablePullRequestAutoMerge.PullRequest.TimelineItems
+	*pr = result.EnablePullRequestAutoMerge.PullRequest.PullRequest
+	pr.TimelineItems = ti.Nodes
+	pr.Participants = result.EnablePullRequestAutoMerge.PullRequest.Participants.Nodes
+
+	items, err := c.loadRemainingTimelineItems(ctx, pr.ID, ti.PageInfo)
+	if err != nil {
+		return err
+	}
+	pr.TimelineItems = append(pr.TimelineItems, items...)
+	return nil
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1672,6 +1729,21 @@ func IsNotMergeable(err error) bool {
 	return false
 }
 
+// IsPullRequestInCleanStatus reports whether the error was returned because
+// auto-merge can't be enabled for a pull request that can already be merged.
+func IsPullRequestInCleanStatus(err error) bool {
+	var errs graphqlErrors
+	if errors.As(err, &errs) {
+		for _, err := range errs {
+			if strings.Contains(strings.ToLower(err.Message), "pull request is in clean status") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
 var errInternalRateLimitExceeded = errors.New("internal rate limit exceeded")
 
 // ErrIncompleteResults is returned when the GitHub Search API returns an `incomplete_results: true` field in their response
@@ -731,6 +731,46 @@ func (c *V3Client) Fork(ctx context.Context, owner, repo string, org *string) (*
 	return convertRestRepo(restRepo), nil
 }
 
+// Issue is a GitHub issue as returned by the REST API.
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
+// CreateIssue opens a new issue with the given title and body in the given
+// repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo, title, body string) (*Issue, error) {
+	payload := struct {
+		Title string `json:"title"`
+		Body  string `json:"body,omitempty"`
+	}{Title: title, Body: body}
+
+	var issue Issue
+	if _, err := c.post(ctx, "repos/"+owner+"/"+repo+"/issues", payload, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment adds a comment with the given body to an issue.
+//
+// API docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (c *V3Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	var comment struct {
+		ID int64 `json:"id"`
+	}
+	_, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, number), payload, &comment)
+	return err
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
@@ -750,6 +750,44 @@ func TestV3Client_Fork(t *testing.T) {
 	})
 }
 
+func TestV3Client_Issues(t *testing.T) {
+	ctx := context.Background()
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/repos/sourcegraph/sourcegraph/issues":
+			assert.Equal(t, "Monitor fired", payload["title"])
+			assert.Equal(t, "New results", payload["body"])
+			_, _ = w.Write([]byte(`{"number": 42, "title": "Monitor fired", "state": "open", "html_url": "https://github.com/sourcegraph/sourcegraph/issues/42"}`))
+		case r.Method == "POST" && r.URL.Path == "/repos/sourcegraph/sourcegraph/issues/42/comments":
+			assert.Equal(t, "More results", payload["body"])
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer testServer.Close()
+
+	uri, _ := url.Parse(testServer.URL)
+	cli := NewV3Client(logtest.Scoped(t), "Test", uri, gheToken, testServer.Client())
+
+	issue, err := cli.CreateIssue(ctx, "sourcegraph", "sourcegraph", "Monitor fired", "New results")
+	assert.Nil(t, err)
+	assert.Equal(t, &Issue{
+		Number:  42,
+		Title:   "Monitor fired",
+		State:   "open",
+		HTMLURL: "https://github.com/sourcegraph/sourcegraph/issues/42",
+	}, issue)
+
+	assert.Nil(t, cli.CreateIssueComment(ctx, "sourcegraph", "sourcegraph", issue.Number, "More results"))
+}
+
 func newV3TestClient(t testing.TB, name string) (*V3Client, func()) {
 	t.Helper()
 
@@ -0,0 +1,85 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Issue struct {
+	ID     ID     `json:"id"`
+	IID    ID     `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+}
+
+type CreateIssueOpts struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateIssue opens a new issue in the given project.
+func (c *Client) CreateIssue(ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error) {
+	if MockCreateIssue != nil {
+		return MockCreateIssue(c, ctx, project, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues", project.ID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create an issue")
+	}
+
+	resp := &Issue{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to create an issue")
+	}
+
+	return resp, nil
+}
+
+// CreateIssueNote adds a comment with the given body to the issue with the
+// given project-scoped ID.
+func (c *Client) CreateIssueNote(ctx context.Context, project *Project, iid ID, body string) error {
+	if MockCreateIssueNote != nil {
+		return MockCreateIssueNote(c, ctx, project, iid, body)
+	}
+
+	var payload = struct {
+		Body string `json:"body"`
+	}{
+		Body: body,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues/%d/notes", project.ID, iid), bytes.NewBuffer(data))
+	if err != nil {
+		return errors.Wrap(err, "creating request to comment on an issue")
+	}
+
+	var resp struct {
+		ID int32 `json:"id"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return errors.Wrap(err, "sending request to comment on an issue")
+	}
+
+	return nil
+}
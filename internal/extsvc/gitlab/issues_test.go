@@ -0,0 +1,65 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusForbidden}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 1, "iid": 2, "title": "title", "state": "opened", "web_url": "https://gitlab.com/a/b/-/issues/2"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "title"})
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+		if issue.IID != 2 || issue.WebURL != "https://gitlab.com/a/b/-/issues/2" {
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+	})
+}
+
+func TestCreateIssueNote(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		if err := client.CreateIssueNote(ctx, project, 2, "test-comment"); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 3, "body": "test-comment"}`,
+		}
+
+		if err := client.CreateIssueNote(ctx, project, 2, "test-comment"); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+}
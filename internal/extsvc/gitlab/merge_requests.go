@@ -275,11 +275,28 @@ func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *Me
 		return MockMergeMergeRequest(c, ctx, project, mr, squash)
 	}
 
+	return c.mergeMergeRequest(ctx, project, mr, squash, false)
+}
+
+// MergeMergeRequestWhenPipelineSucceeds sets the merge request to be merged
+// once its pipeline succeeds. Merge requests without a running pipeline are
+// merged right away.
+func (c *Client) MergeMergeRequestWhenPipelineSucceeds(ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error) {
+	if MockMergeMergeRequestWhenPipelineSucceeds != nil {
+		return MockMergeMergeRequestWhenPipelineSucceeds(c, ctx, project, mr, squash)
+	}
+
+	return c.mergeMergeRequest(ctx, project, mr, squash, true)
+}
+
+func (c *Client) mergeMergeRequest(ctx context.Context, project *Project, mr *MergeRequest, squash, whenPipelineSucceeds bool) (*MergeRequest, error) {
 	payload := struct {
-		Squash              bool   `json:"squash,omitempty"`
-		SquashCommitMessage string `json:"squash_commit_message,omitempty"`
+		Squash                    bool   `json:"squash,omitempty"`
+		SquashCommitMessage       string `json:"squash_commit_message,omitempty"`
+		MergeWhenPipelineSucceeds bool   `json:"merge_when_pipeline_succeeds,omitempty"`
 	}{
-		Squash: squash,
+		Squash:                    squash,
+		MergeWhenPipelineSucceeds: whenPipelineSucceeds,
 	}
 	if squash {
 		payload.SquashCommitMessage = mr.Title + "\n\n" + mr.Description
@@ -2,7 +2,9 @@ package gitlab
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/Masterminds/semver"
@@ -10,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -570,3 +573,35 @@ func TestMergeMergeRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestMergeMergeRequestWhenPipelineSucceeds(t *testing.T) {
+	ctx := context.Background()
+	mr := &MergeRequest{IID: 2}
+	project := &Project{ProjectCommon: ProjectCommon{ID: 1}}
+
+	var body string
+	client := newTestClient(t)
+	client.httpClient = httpcli.DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if want := "/projects/1/merge_requests/2/merge"; !strings.HasSuffix(req.URL.Path, want) {
+			t.Errorf("unexpected request path: want suffix %q have %q", want, req.URL.Path)
+		}
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+
+		return &http.Response{
+			Request:    req,
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(`{"iid":2,"merge_when_pipeline_succeeds":true}`)),
+		}, nil
+	})
+
+	if _, err := client.MergeMergeRequestWhenPipelineSucceeds(ctx, project, mr, false); err != nil {
+		t.Fatalf("unexpected non-nil error: %+v", err)
+	}
+	if want := `{"merge_when_pipeline_succeeds":true}`; body != want {
+		t.Errorf("unexpected request body: want %s have %s", want, body)
+	}
+}
@@ -49,10 +49,21 @@ var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project
 // Client.MergeMergeRequest
 var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
 
+// MockMergeMergeRequestWhenPipelineSucceeds, if non-nil, will be called
+// instead of Client.MergeMergeRequestWhenPipelineSucceeds
+var MockMergeMergeRequestWhenPipelineSucceeds func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
+
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
 
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error)
+
+// MockCreateIssueNote, if non-nil, will be called instead of
+// Client.CreateIssueNote
+var MockCreateIssueNote func(c *Client, ctx context.Context, project *Project, iid ID, body string) error
+
 // MockForkProject, if non-nil, will be called instead of Client.ForkProject
 var MockForkProject func(c *Client, ctx context.Context, project *Project, namespace *string) (*Project, error)
 
@@ -113,12 +113,15 @@ const (
 	// in preference to the Type values below.
 
 	KindAWSCodeCommit   = "AWSCODECOMMIT"
+	KindAzureDevOps     = "AZUREDEVOPS"
 	KindBitbucketServer = "BITBUCKETSERVER"
 	KindBitbucketCloud  = "BITBUCKETCLOUD"
 	KindGerrit          = "GERRIT"
+	KindGitea           = "GITEA"
 	KindGitHub          = "GITHUB"
 	KindGitLab          = "GITLAB"
 	KindGitolite        = "GITOLITE"
+	KindMercurial       = "MERCURIAL"
 	KindPerforce        = "PERFORCE"
 	KindPhabricator     = "PHABRICATOR"
 	KindGoPackages      = "GOMODULES"
@@ -128,6 +131,7 @@ const (
 	KindRubyPackages    = "RUBYPACKAGES"
 	KindNpmPackages     = "NPMPACKAGES"
 	KindPagure          = "PAGURE"
+	KindSubversion      = "SUBVERSION"
 	KindOther           = "OTHER"
 )
 
@@ -139,6 +143,10 @@ const (
 	// suffix (e.g., "arn:aws:codecommit:us-west-1:123456789:").
 	TypeAWSCodeCommit = "awscodecommit"
 
+	// TypeAzureDevOps is the (api.ExternalRepoSpec).ServiceType value for Azure DevOps repositories. The
+	// ServiceID value is the base URL to Azure DevOps Services.
+	TypeAzureDevOps = "azuredevops"
+
 	// TypeBitbucketServer is the (api.ExternalRepoSpec).ServiceType value for Bitbucket Server projects. The
 	// ServiceID value is the base URL to the Bitbucket Server instance.
 	TypeBitbucketServer = "bitbucketServer"
@@ -150,6 +158,10 @@ const (
 	// TypeGerrit is the (api.ExternalRepoSpec).ServiceType value for Gerrit projects.
 	TypeGerrit = "gerrit"
 
+	// TypeGitea is the (api.ExternalRepoSpec).ServiceType value for Gitea (and Forgejo) repositories.
+	// The ServiceID value is the base URL to the Gitea instance.
+	TypeGitea = "gitea"
+
 	// TypeGitHub is the (api.ExternalRepoSpec).ServiceType value for GitHub repositories. The ServiceID value
 	// is the base URL to the GitHub instance (https://github.com or the GitHub Enterprise URL).
 	TypeGitHub = "github"
@@ -164,6 +176,10 @@ const (
 	// TypeGitolite is the (api.ExternalRepoSpec).ServiceType value for Gitolite projects.
 	TypeGitolite = "gitolite"
 
+	// TypeMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories. The
+	// ServiceID value is the base URL to the Mercurial server.
+	TypeMercurial = "mercurial"
+
 	// TypePerforce is the (api.ExternalRepoSpec).ServiceType value for Perforce projects.
 	TypePerforce = "perforce"
 
@@ -191,6 +207,10 @@ const (
 	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby packages.
 	TypeRubyPackages = "rubyPackages"
 
+	// TypeSubversion is the (api.ExternalRepoSpec).ServiceType value for Subversion repositories.
+	// The ServiceID value is the URL of the Subversion server.
+	TypeSubversion = "subversion"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 )
@@ -201,18 +221,24 @@ func KindToType(kind string) string {
 	switch kind {
 	case KindAWSCodeCommit:
 		return TypeAWSCodeCommit
+	case KindAzureDevOps:
+		return TypeAzureDevOps
 	case KindBitbucketServer:
 		return TypeBitbucketServer
 	case KindBitbucketCloud:
 		return TypeBitbucketCloud
 	case KindGerrit:
 		return TypeGerrit
+	case KindGitea:
+		return TypeGitea
 	case KindGitHub:
 		return TypeGitHub
 	case KindGitLab:
 		return TypeGitLab
 	case KindGitolite:
 		return TypeGitolite
+	case KindMercurial:
+		return TypeMercurial
 	case KindPhabricator:
 		return TypePhabricator
 	case KindPerforce:
@@ -231,6 +257,8 @@ func KindToType(kind string) string {
 		return TypeGoModules
 	case KindPagure:
 		return TypePagure
+	case KindSubversion:
+		return TypeSubversion
 	case KindOther:
 		return TypeOther
 	default:
@@ -244,18 +272,24 @@ func TypeToKind(t string) string {
 	switch t {
 	case TypeAWSCodeCommit:
 		return KindAWSCodeCommit
+	case TypeAzureDevOps:
+		return KindAzureDevOps
 	case TypeBitbucketServer:
 		return KindBitbucketServer
 	case TypeBitbucketCloud:
 		return KindBitbucketCloud
 	case TypeGerrit:
 		return KindGerrit
+	case TypeGitea:
+		return KindGitea
 	case TypeGitHub:
 		return KindGitHub
 	case TypeGitLab:
 		return KindGitLab
 	case TypeGitolite:
 		return KindGitolite
+	case TypeMercurial:
+		return KindMercurial
 	case TypePerforce:
 		return KindPerforce
 	case TypePhabricator:
@@ -274,6 +308,8 @@ func TypeToKind(t string) string {
 		return KindGoPackages
 	case TypePagure:
 		return KindPagure
+	case TypeSubversion:
+		return KindSubversion
 	case TypeOther:
 		return KindOther
 	default:
@@ -299,18 +335,24 @@ func ParseServiceType(s string) (string, bool) {
 	switch strings.ToLower(s) {
 	case TypeAWSCodeCommit:
 		return TypeAWSCodeCommit, true
+	case TypeAzureDevOps:
+		return TypeAzureDevOps, true
 	case bbsLower:
 		return TypeBitbucketServer, true
 	case bbcLower:
 		return TypeBitbucketCloud, true
 	case TypeGerrit:
 		return TypeGerrit, true
+	case TypeGitea:
+		return TypeGitea, true
 	case TypeGitHub:
 		return TypeGitHub, true
 	case TypeGitLab:
 		return TypeGitLab, true
 	case TypeGitolite:
 		return TypeGitolite, true
+	case TypeMercurial:
+		return TypeMercurial, true
 	case TypePerforce:
 		return TypePerforce, true
 	case TypePhabricator:
@@ -329,6 +371,8 @@ func ParseServiceType(s string) (string, bool) {
 		return TypeRubyPackages, true
 	case TypePagure:
 		return TypePagure, true
+	case TypeSubversion:
+		return TypeSubversion, true
 	case TypeOther:
 		return TypeOther, true
 	default:
@@ -342,18 +386,24 @@ func ParseServiceKind(s string) (string, bool) {
 	switch strings.ToUpper(s) {
 	case KindAWSCodeCommit:
 		return KindAWSCodeCommit, true
+	case KindAzureDevOps:
+		return KindAzureDevOps, true
 	case KindBitbucketServer:
 		return KindBitbucketServer, true
 	case KindBitbucketCloud:
 		return KindBitbucketCloud, true
 	case KindGerrit:
 		return KindGerrit, true
+	case KindGitea:
+		return KindGitea, true
 	case KindGitHub:
 		return KindGitHub, true
 	case KindGitLab:
 		return KindGitLab, true
 	case KindGitolite:
 		return KindGitolite, true
+	case KindMercurial:
+		return KindMercurial, true
 	case KindPerforce:
 		return KindPerforce, true
 	case KindPhabricator:
@@ -370,6 +420,8 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindRubyPackages, true
 	case KindPagure:
 		return KindPagure, true
+	case KindSubversion:
+		return KindSubversion, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -421,18 +473,24 @@ func getConfigPrototype(kind string) (any, error) {
 	switch strings.ToUpper(kind) {
 	case KindAWSCodeCommit:
 		return &schema.AWSCodeCommitConnection{}, nil
+	case KindAzureDevOps:
+		return &schema.AzureDevOpsConnection{}, nil
 	case KindBitbucketServer:
 		return &schema.BitbucketServerConnection{}, nil
 	case KindBitbucketCloud:
 		return &schema.BitbucketCloudConnection{}, nil
 	case KindGerrit:
 		return &schema.GerritConnection{}, nil
+	case KindGitea:
+		return &schema.GiteaConnection{}, nil
 	case KindGitHub:
 		return &schema.GitHubConnection{}, nil
 	case KindGitLab:
 		return &schema.GitLabConnection{}, nil
 	case KindGitolite:
 		return &schema.GitoliteConnection{}, nil
+	case KindMercurial:
+		return &schema.MercurialConnection{}, nil
 	case KindPerforce:
 		return &schema.PerforceConnection{}, nil
 	case KindPhabricator:
@@ -451,6 +509,8 @@ func getConfigPrototype(kind string) (any, error) {
 		return &schema.RustPackagesConnection{}, nil
 	case KindRubyPackages:
 		return &schema.RubyPackagesConnection{}, nil
+	case KindSubversion:
+		return &schema.SubversionConnection{}, nil
 	case KindOther:
 		return &schema.OtherExternalServiceConnection{}, nil
 	default:
@@ -522,6 +582,10 @@ func extractToken(parsed any, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.GiteaConnection:
+		return c.Token, nil
+	case *schema.AzureDevOpsConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -589,6 +653,11 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.AzureDevOpsConnection:
+		limit = rate.Limit(5) // Same as the default in azuredevops.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.PerforceConnection:
 		limit = rate.Limit(5000.0 / 3600.0)
 		if c != nil && c.RateLimit != nil {
@@ -605,6 +674,12 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.GiteaConnection:
+		// 8/s is the default limit we enforce
+		limit = rate.Limit(8)
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(6000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -685,6 +760,8 @@ func DecodeURN(urn string) (kind string, id int64) {
 	return fields[1], id
 }
 
+// OtherRepoMetadata is the metadata of repositories synced by the Other, Mercurial and
+// Subversion external services.
 type OtherRepoMetadata struct {
 	// RelativePath is relative to ServiceID which is usually the host URL.
 	// Joining them gives you the clone url.
@@ -734,10 +811,18 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.GerritConnection:
 		rawURL = c.Url
+	case *schema.GiteaConnection:
+		rawURL = c.Url
+	case *schema.AzureDevOpsConnection:
+		rawURL = c.Url
 	case *schema.PhabricatorConnection:
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
+	case *schema.SubversionConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
@@ -37,6 +37,16 @@ func TestExtractToken(t *testing.T) {
 			kind:   KindPhabricator,
 			want:   "deadbeef",
 		},
+		{
+			config: `{"token": "deadbeef"}`,
+			kind:   KindGitea,
+			want:   "deadbeef",
+		},
+		{
+			config: `{"username": "alice", "token": "deadbeef"}`,
+			kind:   KindAzureDevOps,
+			want:   "deadbeef",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := ExtractToken(tc.config, tc.kind)
@@ -112,6 +122,30 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			kind:   KindBitbucketCloud,
 			want:   1.0,
 		},
+		{
+			name:   "Gitea default",
+			config: `{"url": "https://example.com/"}`,
+			kind:   KindGitea,
+			want:   8.0,
+		},
+		{
+			name:   "Gitea non-default",
+			config: `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:   KindGitea,
+			want:   1.0,
+		},
+		{
+			name:   "Azure DevOps default",
+			config: `{"url": "https://dev.azure.com"}`,
+			kind:   KindAzureDevOps,
+			want:   5.0,
+		},
+		{
+			name:   "Azure DevOps non-default",
+			config: `{"url": "https://dev.azure.com", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:   KindAzureDevOps,
+			want:   1.0,
+		},
 		{
 			name:   "NPM default",
 			config: `{"registry": "https://registry.npmjs.org"}`,
@@ -289,6 +323,16 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "https://example.com"}`,
 			want:   "https://example.com/",
 		},
+		{
+			kind:   KindGitea,
+			config: `{"url": "https://gitea.example.com"}`,
+			want:   "https://gitea.example.com/",
+		},
+		{
+			kind:   KindAzureDevOps,
+			config: `{"url": "https://dev.azure.com"}`,
+			want:   "https://dev.azure.com/",
+		},
 		{
 			kind:   KindBitbucketServer,
 			config: `{"url": "https://bitbucket.sgdev.org/"}`,
@@ -326,6 +370,16 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			config: `{"url": "ssh://user@host.xz:2333/"}`,
 			want:   "ssh://user@host.xz:2333/",
 		},
+		{
+			kind:   KindMercurial,
+			config: `{"url": "https://hg.example.org/repos/", "repos": []}`,
+			want:   "https://hg.example.org/repos/",
+		},
+		{
+			kind:   KindSubversion,
+			config: `{"url": "svn://svn.example.org/"}`,
+			want:   "svn://svn.example.org/",
+		},
 	} {
 		t.Run(tc.kind, func(t *testing.T) {
 			have, err := UniqueCodeHostIdentifier(tc.kind, tc.config)
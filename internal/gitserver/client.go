@@ -78,10 +78,11 @@ func NewClient(db database.DB) Client {
 		addrs: func() []string {
 			return conf.Get().ServiceConnections().GitServers
 		},
-		pinned:      pinnedReposFromConfig,
-		db:          db,
-		httpClient:  defaultDoer,
-		HTTPLimiter: defaultLimiter,
+		pinned:            pinnedReposFromConfig,
+		replicationFactor: replicationFactorFromConfig,
+		db:                db,
+		httpClient:        defaultDoer,
+		HTTPLimiter:       defaultLimiter,
 		// Use the binary name for userAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -98,9 +99,10 @@ func NewTestClient(cli httpcli.Doer, db database.DB, addrs []string) Client {
 		addrs: func() []string {
 			return addrs
 		},
-		pinned:      pinnedReposFromConfig,
-		httpClient:  cli,
-		HTTPLimiter: parallel.NewRun(500),
+		pinned:            pinnedReposFromConfig,
+		replicationFactor: replicationFactorFromConfig,
+		httpClient:        cli,
+		HTTPLimiter:       parallel.NewRun(500),
 		// Use the binary name for userAgent. This should effectively identify
 		// which service is making the request (excluding requests proxied via the
 		// frontend internal API)
@@ -206,6 +208,10 @@ type clientImplementor struct {
 	// and sync the pinned map.
 	pinned func() map[string]string
 
+	// replicationFactor returns the number of gitserver instances each repository is stored
+	// on. Like pinned, it should read a fresh value from the conf on every call.
+	replicationFactor func() int
+
 	// db is a connection to the database
 	db database.DB
 
@@ -461,9 +467,21 @@ func (c *clientImplementor) AddrForRepo(ctx context.Context, repo api.RepoName)
 	return AddrForRepo(ctx, c.userAgent, c.db, repo, GitServerAddresses{
 		Addresses:     addrs,
 		PinnedServers: c.pinned(),
+		Rebalancing:   rebalancingFromConfig(),
 	})
 }
 
+// addrsForRepo returns the address of the gitserver instance owning the given repo, followed by
+// the addresses of the instances holding a replica of it. Reads are sent to the first address
+// and fail over to the next one when an instance is unreachable.
+func (c *clientImplementor) addrsForRepo(ctx context.Context, repo api.RepoName) ([]string, error) {
+	addr, err := c.AddrForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{addr}, ReplicaAddrs(addr, c.Addrs(), c.replicationFactor())...), nil
+}
+
 func (c *clientImplementor) RendezvousAddrForRepo(repo api.RepoName) string {
 	addrs := c.Addrs()
 	if len(addrs) == 0 {
@@ -487,6 +505,19 @@ func AddrForRepo(ctx context.Context, userAgent string, db database.DB, repo api
 
 	repo = protocol.NormalizeRepo(repo) // in case the caller didn't already normalize it
 	rs := string(repo)
+	if addresses.Rebalancing {
+		// While a repository is copied to its new instance, the previous instance keeps serving it.
+		source, err := relocations.source(ctx, db, repo)
+		if err != nil {
+			return "", err
+		}
+		for _, addr := range addresses.Addresses {
+			if source != "" && addr == source {
+				return source, nil
+			}
+		}
+	}
+
 	if repoPinned, addr := getPinnedRepoAddr(string(repo), addresses.PinnedServers); repoPinned {
 		return addr, nil
 	}
@@ -505,6 +536,14 @@ func AddrForRepo(ctx context.Context, userAgent string, db database.DB, repo api
 type GitServerAddresses struct {
 	Addresses     []string
 	PinnedServers map[string]string
+
+	// ReplicationFactor is the number of gitserver instances each repository is stored on, see
+	// ReplicaAddrs. It is ignored by AddrForRepo.
+	ReplicationFactor int
+
+	// Rebalancing makes AddrForRepo return the instance a repository is being copied from until
+	// the copy to the instance owning it has finished.
+	Rebalancing bool
 }
 
 // RendezvousAddrForRepo returns the gitserver address to use for the given repo name using the
@@ -524,6 +563,35 @@ func addrForKey(key string, addrs []string) string {
 	return addrs[serverIndex]
 }
 
+// ReplicaAddrs returns the addresses of the gitserver instances that hold a replica of the
+// repositories owned by primary, given the total number of copies of each repository. Replicas
+// are the instances following primary in addrs, wrapping around at the end. This means that all
+// repositories of a shard share the same replicas, and that the replicas of a repository only
+// change when the list of addresses changes.
+//
+// It returns nil if primary is not part of addrs, e.g. for repositories pinned to an instance
+// that has been removed.
+func ReplicaAddrs(primary string, addrs []string, factor int) []string {
+	if factor > len(addrs) {
+		factor = len(addrs)
+	}
+	if factor <= 1 {
+		return nil
+	}
+
+	for i, addr := range addrs {
+		if addr != primary {
+			continue
+		}
+		replicas := make([]string, 0, factor-1)
+		for j := 1; j < factor; j++ {
+			replicas = append(replicas, addrs[(i+j)%len(addrs)])
+		}
+		return replicas
+	}
+	return nil
+}
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
@@ -565,9 +633,9 @@ func (a *archiveReader) Close() error {
 	return a.base.Close()
 }
 
-// archiveURL returns a URL from which an archive of the given Git repository can
-// be downloaded from.
-func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, opt ArchiveOptions) (*url.URL, error) {
+// archiveURL returns the URL, relative to a gitserver instance, from which an
+// archive of the given Git repository can be downloaded from.
+func archiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -578,16 +646,10 @@ func (c *clientImplementor) archiveURL(ctx context.Context, repo api.RepoName, o
 		q.Add("path", string(pathspec))
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repo)
-	if err != nil {
-		return nil, err
-	}
 	return &url.URL{
-		Scheme:   "http",
-		Host:     addrForRepo,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
-	}, nil
+	}
 }
 
 type badRequestError struct{ error }
@@ -669,13 +731,12 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	addrForRepo, err := c.AddrForRepo(ctx, repoName)
+	addrs, err := c.addrsForRepo(ctx, repoName)
 	if err != nil {
 		return false, err
 	}
 
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	resp, err := c.doWithFailover(ctx, repoName, addrs, "/search", buf.Bytes())
 	if err != nil {
 		return false, err
 	}
@@ -761,7 +822,8 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 	// Make a request to a single gitserver shard and feed the results to the user-supplied
 	// callback. This function is invoked multiple times (and concurrently) in the loops below
 	// this function definition.
-	performLogRequestToShard := func(ctx context.Context, addr string, repoCommits []api.RepoCommit) (err error) {
+	performLogRequestToShard := func(ctx context.Context, addrs []string, repoCommits []api.RepoCommit) (err error) {
+		addr := addrs[0]
 		var numProcessed int
 		repoNames := repoNamesFromRepoCommits(repoCommits)
 
@@ -780,7 +842,6 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 			})
 		}()
 
-		uri := "http://" + addr + "/batch-log"
 		repoName := api.RepoName(strings.Join(repoNames, ",")) // only used to label spans
 
 		request := protocol.BatchLogRequest{
@@ -793,7 +854,7 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 			return err
 		}
 
-		resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+		resp, err := c.doWithFailover(ctx, repoName, addrs, "/batch-log", buf.Bytes())
 		if err != nil {
 			return err
 		}
@@ -867,6 +928,12 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 	// repository or a bad commit reference, but does not attempt to return partial
 	// results when an entire shard is down. Any of these operations failing will
 	// cause an error to be returned from the entire BatchLog function.
+	//
+	// All repositories of a shard share the same replicas, so a batch whose shard
+	// is unreachable is sent to the replicas of that shard as a whole.
+
+	addrs := c.Addrs()
+	replicationFactor := c.replicationFactor()
 
 	sem := semaphore.NewWeighted(int64(32))
 	g, ctx := errgroup.WithContext(ctx)
@@ -882,7 +949,7 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 		g.Go(func() (err error) {
 			defer sem.Release(1)
 
-			return performLogRequestToShard(ctx, addr, repoCommits)
+			return performLogRequestToShard(ctx, append([]string{addr}, ReplicaAddrs(addr, addrs, replicationFactor)...), repoCommits)
 		})
 	}
 
@@ -915,7 +982,7 @@ func (c *clientImplementor) gitCommand(repo api.RepoName, arg ...string) GitComm
 	}
 	return &RemoteGitCommand{
 		repo:   repo,
-		execFn: c.httpPost,
+		execFn: c.httpPostWithFailover,
 		args:   append([]string{git}, arg...),
 	}
 }
@@ -1210,6 +1277,48 @@ func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
+// httpPostWithFailover is like httpPost, but sends the request to a replica of the repo if the
+// gitserver instance owning it is unreachable. It must only be used for read requests.
+func (c *clientImplementor) httpPostWithFailover(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := c.addrsForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.doWithFailover(ctx, repo, addrs, "/"+op, b)
+}
+
+var replicaFailoverCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover_total",
+	Help: "Number of read requests sent to a gitserver replica because the previous instance was unreachable.",
+})
+
+// doWithFailover sends a POST request to the given path of the first gitserver instance in addrs.
+// If the instance can't be reached, the request is retried against the next instance in addrs.
+// Responses are never retried, so an instance which is up but returns an error is not failed
+// over from.
+func (c *clientImplementor) doWithFailover(ctx context.Context, repo api.RepoName, addrs []string, path string, payload []byte) (resp *http.Response, err error) {
+	for i, addr := range addrs {
+		resp, err = c.do(ctx, repo, "POST", "http://"+addr+path, payload)
+		if err == nil || ctx.Err() != nil || i == len(addrs)-1 {
+			break
+		}
+
+		replicaFailoverCounter.Inc()
+		c.logger.Warn("gitserver instance unreachable, failing over to replica",
+			sglog.String("repo", string(repo)),
+			sglog.String("addr", addr),
+			sglog.String("replica", addrs[i+1]),
+			sglog.Error(err),
+		)
+	}
+	return resp, err
+}
+
 // httpPostWithURI does not apply any transformations to the given URI. This allows the consumer to
 // use the predetermined hashing scheme (md5 or rendezvous) of their choice to derive the gitserver
 // instance to which the HTTP POST request is sent.
@@ -1433,6 +1542,19 @@ func readResponseBody(body io.Reader) string {
 	return strings.TrimSpace(string(content))
 }
 
+func replicationFactorFromConfig() int {
+	cfg := conf.Get()
+	if cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerReplicationFactor > 1 {
+		return cfg.ExperimentalFeatures.GitServerReplicationFactor
+	}
+	return 1
+}
+
+func rebalancingFromConfig() bool {
+	cfg := conf.Get()
+	return cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerRebalancing
+}
+
 func pinnedReposFromConfig() map[string]string {
 	cfg := conf.Get()
 	if cfg.ExperimentalFeatures != nil && cfg.ExperimentalFeatures.GitServerPinnedRepos != nil {
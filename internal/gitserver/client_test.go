@@ -366,6 +366,93 @@ func TestAddrForRepo(t *testing.T) {
 	}
 }
 
+func TestAddrForRepo_Rebalancing(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(database.NewMockGitserverRepoStore())
+	relocator := database.NewMockGitserverLocalCloneStore()
+	relocator.ListUnfinishedSourcesFunc.SetDefaultReturn(map[api.RepoName]string{
+		"repo1": "gitserver-1",
+		// The previous instance has been removed, it can't serve the repository anymore.
+		"github.com/sourcegraph/sourcegraph": "gitserver-4",
+	}, nil)
+	db.GitserverLocalCloneFunc.SetDefaultReturn(relocator)
+
+	testCases := []struct {
+		name        string
+		repo        api.RepoName
+		rebalancing bool
+		want        string
+	}{
+		{
+			name:        "relocated repo",
+			repo:        "repo1",
+			rebalancing: true,
+			want:        "gitserver-1",
+		},
+		{
+			name:        "rebalancing disabled",
+			repo:        "repo1",
+			rebalancing: false,
+			want:        "gitserver-3",
+		},
+		{
+			name:        "previous instance removed",
+			repo:        "github.com/sourcegraph/sourcegraph.git",
+			rebalancing: true,
+			want:        "gitserver-2",
+		},
+		{
+			name:        "not relocated",
+			repo:        "repo2",
+			rebalancing: true,
+			want:        "gitserver-2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := gitserver.AddrForRepo(context.Background(), "gitserver", db, tc.repo, gitserver.GitServerAddresses{
+				Addresses:   addrs,
+				Rebalancing: tc.rebalancing,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("Want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestReplicaAddrs(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	testCases := []struct {
+		name    string
+		primary string
+		factor  int
+		want    []string
+	}{
+		{name: "replication disabled", primary: "gitserver-1", factor: 1, want: nil},
+		{name: "invalid factor", primary: "gitserver-1", factor: 0, want: nil},
+		{name: "single replica", primary: "gitserver-1", factor: 2, want: []string{"gitserver-2"}},
+		{name: "wraps around", primary: "gitserver-3", factor: 2, want: []string{"gitserver-1"}},
+		{name: "factor larger than the number of instances", primary: "gitserver-2", factor: 5, want: []string{"gitserver-3", "gitserver-1"}},
+		{name: "unknown primary", primary: "gitserver-4", factor: 2, want: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, gitserver.ReplicaAddrs(tc.primary, addrs, tc.factor)); diff != "" {
+				t.Fatalf("unexpected replicas (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestRendezvousAddrForRepo(t *testing.T) {
 	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
 
@@ -711,6 +798,80 @@ func TestClient_BatchLog(t *testing.T) {
 	}
 }
 
+func TestClient_ReplicaFailover(t *testing.T) {
+	// github.com/test/foo is owned by the last instance, its replica is the first one.
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080", "172.16.8.3:8080"}
+	repoCommit := api.RepoCommit{Repo: "github.com/test/foo", CommitID: "deadbeef01"}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			GitServerReplicationFactor: 2,
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	var requested []string
+	var mu sync.Mutex
+	cli := gitserver.NewTestClient(
+		httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			mu.Lock()
+			requested = append(requested, r.URL.Host+r.URL.Path)
+			mu.Unlock()
+
+			if r.URL.Host == "172.16.8.3:8080" {
+				return nil, errors.New("connection refused")
+			}
+
+			switch r.URL.Path {
+			case "/batch-log":
+				encoded, _ := json.Marshal(protocol.BatchLogResponse{Results: []protocol.BatchLogResult{
+					{RepoCommit: repoCommit, CommandOutput: "out<" + r.URL.Host + ">"},
+				}})
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewReader(encoded))}, nil
+			case "/archive":
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewBufferString("archive")),
+					Trailer:    http.Header{"X-Exec-Exit-Status": {"0"}},
+				}, nil
+			default:
+				return nil, errors.Newf("unexpected URL: %q", r.URL.String())
+			}
+		}),
+		newMockDB(),
+		addrs,
+	)
+
+	ctx := context.Background()
+
+	var stdout string
+	if err := cli.BatchLog(ctx, gitserver.BatchLogOptions{RepoCommits: []api.RepoCommit{repoCommit}}, func(_ api.RepoCommit, result gitserver.RawBatchLogResult) error {
+		stdout = result.Stdout
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error performing batch log: %s", err)
+	}
+	require.Equal(t, "out<172.16.8.1:8080>", stdout)
+
+	rc, err := cli.ArchiveReader(ctx, nil, repoCommit.Repo, gitserver.ArchiveOptions{Treeish: "HEAD", Format: gitserver.ArchiveFormatZip})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc.Close()
+	require.Equal(t, "archive", string(data))
+
+	require.Equal(t, []string{
+		"172.16.8.3:8080/batch-log",
+		"172.16.8.1:8080/batch-log",
+		"172.16.8.3:8080/archive",
+		"172.16.8.1:8080/archive",
+	}, requested)
+}
+
 func TestLocalGitCommand(t *testing.T) {
 	// creating a repo with 1 committed file
 	root := gitserver.CreateRepoDir(t)
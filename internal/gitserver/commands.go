@@ -2395,12 +2395,12 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
-	u, err := c.archiveURL(ctx, repo, options)
+	addrs, err := c.addrsForRepo(ctx, repo)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+	resp, err := c.doWithFailover(ctx, repo, addrs, archiveURL(repo, options).String(), nil)
 	if err != nil {
 		return nil, err
 	}
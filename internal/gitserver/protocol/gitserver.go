@@ -170,6 +170,11 @@ type RepoUpdateRequest struct {
 	// repository. If this is set, then the RepoUpdateRequest is to migrate the repo from
 	// that gitserver instance to the new home of the repo.
 	CloneFromShard string `json:"cloneFromShard"`
+
+	// Replicate is set when the request is sent by the gitserver instance owning the repository
+	// to update the replica of it stored on the receiving instance. The replica is then cloned or
+	// fetched from CloneFromShard, even if it is already cloned.
+	Replicate bool `json:"replicate,omitempty"`
 }
 
 // RepoUpdateResponse returns meta information of the repo enqueued for update.
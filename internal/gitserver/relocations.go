@@ -0,0 +1,49 @@
+package gitserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// relocationsRefreshInterval is how long the repositories being relocated are cached for.
+// A repository whose relocation just finished is served by its previous instance until the
+// next refresh, which is fine since that instance only removes it once it has been cloned
+// elsewhere.
+const relocationsRefreshInterval = 10 * time.Second
+
+// relocations caches the repositories being copied to their new instance while rebalancing,
+// so that AddrForRepo doesn't query the database on every call.
+var relocations = &relocationCache{ttl: relocationsRefreshInterval, now: time.Now}
+
+type relocationCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	sources map[api.RepoName]string
+	expires time.Time
+}
+
+// source returns the instance repo is being copied from, or an empty string if it isn't
+// being relocated.
+func (c *relocationCache) source(ctx context.Context, db database.DB, repo api.RepoName) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.sources == nil || !c.now().Before(c.expires) {
+		sources, err := db.GitserverLocalClone().ListUnfinishedSources(ctx)
+		if err != nil {
+			return "", err
+		}
+		if sources == nil {
+			sources = map[api.RepoName]string{}
+		}
+		c.sources = sources
+		c.expires = c.now().Add(c.ttl)
+	}
+	return c.sources[repo], nil
+}
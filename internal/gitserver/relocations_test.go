@@ -0,0 +1,56 @@
+package gitserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestRelocationCache(t *testing.T) {
+	ctx := context.Background()
+
+	db := database.NewMockDB()
+	relocator := database.NewMockGitserverLocalCloneStore()
+	relocator.ListUnfinishedSourcesFunc.PushReturn(map[api.RepoName]string{"repo1": "gitserver-1"}, nil)
+	relocator.ListUnfinishedSourcesFunc.SetDefaultReturn(map[api.RepoName]string{"repo2": "gitserver-2"}, nil)
+	db.GitserverLocalCloneFunc.SetDefaultReturn(relocator)
+
+	now := time.Now()
+	c := &relocationCache{ttl: time.Minute, now: func() time.Time { return now }}
+
+	source := func(repo api.RepoName) string {
+		t.Helper()
+		source, err := c.source(ctx, db, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return source
+	}
+
+	for _, repo := range []api.RepoName{"repo1", "repo2", "repo1"} {
+		want := ""
+		if repo == "repo1" {
+			want = "gitserver-1"
+		}
+		if got := source(repo); got != want {
+			t.Fatalf("source(%q): want %q, got %q", repo, want, got)
+		}
+	}
+	if calls := len(relocator.ListUnfinishedSourcesFunc.History()); calls != 1 {
+		t.Fatalf("expected the relocations to be listed once, got %d", calls)
+	}
+
+	now = now.Add(time.Minute)
+	if got := source("repo1"); got != "" {
+		t.Fatalf("expected repo1 to be done relocating after a refresh, got %q", got)
+	}
+	if got := source("repo2"); got != "gitserver-2" {
+		t.Fatalf("expected repo2 to be relocating after a refresh, got %q", got)
+	}
+	if calls := len(relocator.ListUnfinishedSourcesFunc.History()); calls != 2 {
+		t.Fatalf("expected the relocations to be listed twice, got %d", calls)
+	}
+}
@@ -21,6 +21,9 @@ type LazyCommit struct {
 	diff        []*diff.FileDiff
 	diffFetcher *DiffFetcher
 
+	// prefetchDiff, if set, is called before fetching the diff
+	prefetchDiff func(hash []byte) error
+
 	// LowerBuf is a re-usable buffer for doing case-transformations on the fields of LazyCommit
 	LowerBuf []byte
 }
@@ -45,6 +48,11 @@ func (l *LazyCommit) CommitterDate() (time.Time, error) {
 
 // RawDiff returns the diff exactly as returned by git diff-tree
 func (l *LazyCommit) RawDiff() ([]byte, error) {
+	if l.prefetchDiff != nil {
+		if err := l.prefetchDiff(l.Hash); err != nil {
+			return nil, err
+		}
+	}
 	return l.diffFetcher.Fetch(l.Hash)
 }
 
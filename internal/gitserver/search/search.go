@@ -89,6 +89,11 @@ type CommitSearcher struct {
 	IncludeDiff          bool
 	IncludeModifiedFiles bool
 	RepoName             api.RepoName
+
+	// PrefetchDiffs, if set, is called before the diff of a commit is computed, with that commit
+	// and the ones following it in its batch. It is called at most once per batch. Partial
+	// clones use it to fetch the blobs of the diffs of many commits at once.
+	PrefetchDiffs func(ctx context.Context, commits [][]byte) error
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -226,7 +231,11 @@ func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 	runJob := func(j job) error {
 		defer close(j.resultChan)
 
-		for _, cv := range j.batch {
+		var (
+			prefetched  bool
+			prefetchErr error
+		)
+		for i, cv := range j.batch {
 			if ctx.Err() != nil {
 				// ignore context error, and don't spend time running the job
 				return nil
@@ -237,6 +246,20 @@ func (cs *CommitSearcher) runJobs(ctx context.Context, jobs chan job) error {
 				diffFetcher: diffFetcher,
 				LowerBuf:    startBuf,
 			}
+			if cs.PrefetchDiffs != nil {
+				remaining := j.batch[i:]
+				lc.prefetchDiff = func([]byte) error {
+					if !prefetched {
+						prefetched = true
+						commits := make([][]byte, 0, len(remaining))
+						for _, rc := range remaining {
+							commits = append(commits, rc.Hash)
+						}
+						prefetchErr = cs.PrefetchDiffs(ctx, commits)
+					}
+					return prefetchErr
+				}
+			}
 			mergedResult, highlights, err := cs.Query.Match(lc)
 			if err != nil {
 				return err
@@ -0,0 +1,169 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps connection
+// configured in Sourcegraph via the external services configuration.
+type AzureDevOpsSource struct {
+	svc     *types.ExternalService
+	config  *schema.AzureDevOpsConnection
+	cli     azuredevops.Client
+	baseURL *url.URL
+	exclude excludeFunc
+}
+
+var _ Source = &AzureDevOpsSource{}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := azuredevops.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := extsvc.AzureDevOpsURL
+	if c.Url != "" {
+		if baseURL, err = url.Parse(c.Url); err != nil {
+			return nil, err
+		}
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AzureDevOpsSource{
+		svc:     svc,
+		config:  &c,
+		cli:     cli,
+		baseURL: extsvc.NormalizeBaseURL(baseURL),
+		exclude: exclude,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories configured with this
+// AzureDevOpsSource's config: the union of the repositories of the configured
+// orgs and projects.
+func (s *AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]bool)
+	emit := func(repos []*azuredevops.Repository) {
+		for _, r := range repos {
+			// Disabled repositories can neither be cloned nor fetched.
+			if seen[r.ID] || r.IsDisabled {
+				continue
+			}
+			seen[r.ID] = true
+
+			fullName, err := r.FullName()
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				continue
+			}
+			if s.exclude(fullName) {
+				continue
+			}
+			results <- SourceResult{Source: s, Repo: s.makeRepo(fullName, r)}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		repos, err := s.cli.ListRepositories(ctx, org, "")
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing repositories of Azure DevOps organization %q", org)}
+			continue
+		}
+		emit(repos)
+	}
+
+	for _, orgProject := range s.config.Projects {
+		org, project, ok := strings.Cut(orgProject, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Azure DevOps project %q", orgProject)}
+			continue
+		}
+
+		repos, err := s.cli.ListRepositories(ctx, org, project)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing repositories of Azure DevOps project %q", orgProject)}
+			continue
+		}
+		emit(repos)
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *AzureDevOpsSource) makeRepo(fullName string, r *azuredevops.Repository) *types.Repo {
+	urn := s.svc.URN()
+	name := s.baseURL.Hostname() + "/" + fullName
+
+	return &types.Repo{
+		Name:    api.RepoName(name),
+		URI:     name,
+		Fork:    r.IsFork,
+		Private: r.Project.Visibility != "public",
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.baseURL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// remoteURL returns the Azure DevOps repository's Git remote URL
+//
+// note: this does not contain credentials; use repos.CloneURL to get an
+// authenticated clone URL.
+func (s *AzureDevOpsSource) remoteURL(r *azuredevops.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL // SSH authentication must be provided out-of-band
+	}
+	return r.RemoteURL
+}
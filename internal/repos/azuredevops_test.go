@@ -0,0 +1,130 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	newRepo := func(id, org, project, name string) *azuredevops.Repository {
+		return &azuredevops.Repository{
+			ID:      id,
+			Name:    name,
+			URL:     "https://dev.azure.com/" + org + "/" + project + "/_apis/git/repositories/" + id,
+			Project: azuredevops.Project{ID: project, Name: project},
+		}
+	}
+
+	var (
+		backend  = newRepo("r1", "myorg", "core", "backend")
+		frontend = newRepo("r2", "myorg", "core", "frontend")
+		disabled = newRepo("r3", "myorg", "web", "disabled")
+		tool     = newRepo("r4", "other", "tools", "tool")
+	)
+	disabled.IsDisabled = true
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/myorg/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": 3, "value": []*azuredevops.Repository{backend, frontend, disabled}})
+	})
+	mux.HandleFunc("/myorg/core/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": 2, "value": []*azuredevops.Repository{backend, frontend}})
+	})
+	mux.HandleFunc("/other/tools/_apis/git/repositories", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"count": 1, "value": []*azuredevops.Repository{tool}})
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	conf := &schema.AzureDevOpsConnection{
+		Url:      srv.URL,
+		Username: "alice",
+		Token:    "secret",
+		Orgs:     []string{"myorg"},
+		Projects: []string{"myorg/core", "other/tools"},
+		Exclude: []*schema.ExcludedAzureDevOpsRepo{
+			{Name: "myorg/core/frontend"},
+		},
+	}
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	src, err := NewAzureDevOpsSource(context.Background(), svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, string(r.Name))
+	}
+	sort.Strings(names)
+
+	host := src.baseURL.Hostname()
+	want := []string{
+		host + "/myorg/core/backend",
+		host + "/other/tools/tool",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureDevOpsSource_makeRepo(t *testing.T) {
+	conf := &schema.AzureDevOpsConnection{
+		Username: "alice",
+		Token:    "secret",
+	}
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindAzureDevOps,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	src, err := NewAzureDevOpsSource(context.Background(), svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := src.makeRepo("myorg/myproject/myrepo", &azuredevops.Repository{
+		ID:        "r1",
+		Name:      "myrepo",
+		Project:   azuredevops.Project{ID: "p1", Name: "myproject", Visibility: "private"},
+		RemoteURL: "https://myorg@dev.azure.com/myorg/myproject/_git/myrepo",
+		IsFork:    true,
+	})
+
+	if have, want := string(repo.Name), "dev.azure.com/myorg/myproject/myrepo"; have != want {
+		t.Errorf("unexpected name. want=%q have=%q", want, have)
+	}
+	if have, want := repo.ExternalRepo.ServiceID, "https://dev.azure.com/"; have != want {
+		t.Errorf("unexpected service ID. want=%q have=%q", want, have)
+	}
+	if have, want := repo.ExternalRepo.ID, "r1"; have != want {
+		t.Errorf("unexpected external ID. want=%q have=%q", want, have)
+	}
+	if !repo.Private || !repo.Fork {
+		t.Errorf("unexpected repo attributes: %+v", repo)
+	}
+	if have, want := repo.Sources[svc.URN()].CloneURL, "https://myorg@dev.azure.com/myorg/myproject/_git/myrepo"; have != want {
+		t.Errorf("unexpected clone URL. want=%q have=%q", want, have)
+	}
+}
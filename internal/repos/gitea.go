@@ -0,0 +1,265 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A GiteaSource yields repositories from a single Gitea (or Forgejo) connection
+// configured in Sourcegraph via the external services configuration.
+type GiteaSource struct {
+	svc             *types.ExternalService
+	config          *schema.GiteaConnection
+	cli             *gitea.Client
+	baseURL         *url.URL
+	exclude         excludeFunc
+	excludeArchived bool
+	excludeForks    bool
+	perPage         int
+}
+
+var _ Source = &GiteaSource{}
+var _ RepoGetter = &GiteaSource{}
+var _ VersionSource = &GiteaSource{}
+
+// NewGiteaSource returns a new GiteaSource from the given external service.
+func NewGiteaSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*GiteaSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.GiteaConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := gitea.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		eb              excludeBuilder
+		excludeArchived bool
+		excludeForks    bool
+	)
+
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		if r.Id != 0 {
+			eb.Exact(strconv.Itoa(r.Id))
+		}
+		eb.Pattern(r.Pattern)
+
+		if r.Archived {
+			excludeArchived = true
+		}
+
+		if r.Forks {
+			excludeForks = true
+		}
+	}
+
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GiteaSource{
+		svc:             svc,
+		config:          &c,
+		cli:             cli,
+		baseURL:         extsvc.NormalizeBaseURL(cli.URL),
+		exclude:         exclude,
+		excludeArchived: excludeArchived,
+		excludeForks:    excludeForks,
+		perPage:         50,
+	}, nil
+}
+
+// Version returns the version of the Gitea instance.
+func (s *GiteaSource) Version(ctx context.Context) (string, error) {
+	return s.cli.GetVersion(ctx)
+}
+
+// ListRepos returns all Gitea repositories configured with this GiteaSource's
+// config: the union of the repos, orgs, topics and repositoryQuery settings.
+func (s *GiteaSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[int64]bool)
+	emit := func(repos []*gitea.Repository) {
+		for _, r := range repos {
+			if seen[r.ID] || s.excludes(r) {
+				continue
+			}
+			seen[r.ID] = true
+			results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+		}
+	}
+
+	for _, nameWithOwner := range s.config.Repos {
+		owner, name, ok := strings.Cut(nameWithOwner, "/")
+		if !ok {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid Gitea repository name %q", nameWithOwner)}
+			continue
+		}
+
+		repo, err := s.cli.GetRepository(ctx, owner, name)
+		if err != nil {
+			// A missing repository should not prevent the remaining ones from being synced.
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "getting Gitea repository %q", nameWithOwner)}
+			continue
+		}
+		emit([]*gitea.Repository{repo})
+	}
+
+	for _, org := range s.config.Orgs {
+		err := s.paginate(ctx, emit, func(cursor *gitea.Pagination) ([]*gitea.Repository, bool, error) {
+			return s.cli.ListOrgRepositories(ctx, org, cursor)
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "listing repositories of Gitea organization %q", org)}
+		}
+	}
+
+	for _, topic := range s.config.Topics {
+		err := s.paginate(ctx, emit, func(cursor *gitea.Pagination) ([]*gitea.Repository, bool, error) {
+			return s.cli.SearchRepositories(ctx, gitea.SearchRepositoriesArgs{Cursor: cursor, Query: topic, Topic: true})
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "searching Gitea repositories with topic %q", topic)}
+		}
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		switch query {
+		case "none":
+			continue
+		case "all":
+			query = ""
+		}
+
+		err := s.paginate(ctx, emit, func(cursor *gitea.Pagination) ([]*gitea.Repository, bool, error) {
+			return s.cli.SearchRepositories(ctx, gitea.SearchRepositoriesArgs{Cursor: cursor, Query: query})
+		})
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "searching Gitea repositories with query %q", query)}
+		}
+	}
+}
+
+// paginate calls list with increasing page numbers until the Gitea instance no
+// longer reports a next page, passing each page of repositories to emit.
+func (s *GiteaSource) paginate(ctx context.Context, emit func([]*gitea.Repository), list func(*gitea.Pagination) ([]*gitea.Repository, bool, error)) error {
+	cursor := &gitea.Pagination{Page: 1, Limit: s.perPage}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		repos, hasNextPage, err := list(cursor)
+		if err != nil {
+			return err
+		}
+		emit(repos)
+
+		if !hasNextPage || len(repos) == 0 {
+			return nil
+		}
+		cursor.Page++
+	}
+}
+
+// GetRepo returns the Gitea repository with the given "owner/name".
+func (s *GiteaSource) GetRepo(ctx context.Context, nameWithOwner string) (*types.Repo, error) {
+	owner, name, ok := strings.Cut(nameWithOwner, "/")
+	if !ok {
+		return nil, errors.Errorf("invalid Gitea repository name %q", nameWithOwner)
+	}
+
+	repo, err := s.cli.GetRepository(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.makeRepo(repo), nil
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *GiteaSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *GiteaSource) excludes(r *gitea.Repository) bool {
+	if s.exclude(r.FullName) || s.exclude(strconv.FormatInt(r.ID, 10)) {
+		return true
+	}
+
+	if s.excludeArchived && r.Archived {
+		return true
+	}
+
+	if s.excludeForks && r.Fork {
+		return true
+	}
+
+	return false
+}
+
+func (s *GiteaSource) makeRepo(r *gitea.Repository) *types.Repo {
+	urn := s.svc.URN()
+	host := s.baseURL.Hostname()
+
+	return &types.Repo{
+		Name:        reposource.GiteaRepoName(s.config.RepositoryPathPattern, host, r.FullName),
+		URI:         string(reposource.GiteaRepoName("", host, r.FullName)),
+		Description: r.Description,
+		Fork:        r.Fork,
+		Archived:    r.Archived,
+		Private:     r.Private,
+		Stars:       r.Stars,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          strconv.FormatInt(r.ID, 10),
+			ServiceType: extsvc.TypeGitea,
+			ServiceID:   s.baseURL.String(),
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: s.remoteURL(r),
+			},
+		},
+		Metadata: r,
+	}
+}
+
+// remoteURL returns the Gitea repository's Git remote URL
+//
+// note: this does not contain credentials; use repos.CloneURL to get an
+// authenticated clone URL.
+func (s *GiteaSource) remoteURL(r *gitea.Repository) string {
+	if s.config.GitURLType == "ssh" {
+		return r.SSHURL // SSH authentication must be provided out-of-band
+	}
+	return r.CloneURL
+}
@@ -0,0 +1,162 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitea"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGiteaSource_ListRepos(t *testing.T) {
+	var (
+		backend  = &gitea.Repository{ID: 1, FullName: "myorg/backend", Topics: []string{"go"}}
+		frontend = &gitea.Repository{ID: 2, FullName: "myorg/frontend", Private: true}
+		fork     = &gitea.Repository{ID: 3, FullName: "myorg/fork", Fork: true}
+		archived = &gitea.Repository{ID: 4, FullName: "myorg/archived", Archived: true}
+		tool     = &gitea.Repository{ID: 5, FullName: "alice/tool", Topics: []string{"go"}}
+		other    = &gitea.Repository{ID: 6, FullName: "alice/other"}
+	)
+
+	// writePage serves repos one per page, linking to the next page as Gitea does.
+	writePage := func(w http.ResponseWriter, r *http.Request, repos []*gitea.Repository, wrap bool) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+
+		var data []*gitea.Repository
+		if page <= len(repos) {
+			data = repos[page-1 : page]
+		}
+		if page < len(repos) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=%d>; rel="next"`, r.URL.Path, page+1))
+		}
+
+		if wrap {
+			_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "data": data})
+		} else {
+			_ = json.NewEncoder(w).Encode(data)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/orgs/myorg/repos", func(w http.ResponseWriter, r *http.Request) {
+		writePage(w, r, []*gitea.Repository{backend, frontend, fork, archived}, false)
+	})
+	mux.HandleFunc("/api/v1/repos/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") == "go" && r.URL.Query().Get("topic") == "true" {
+			writePage(w, r, []*gitea.Repository{backend, tool}, true)
+			return
+		}
+		writePage(w, r, nil, true)
+	})
+	mux.HandleFunc("/api/v1/repos/alice/other", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(other)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	conf := &schema.GiteaConnection{
+		Url:             srv.URL,
+		Token:           "secret",
+		Orgs:            []string{"myorg"},
+		Topics:          []string{"go"},
+		Repos:           []string{"alice/other"},
+		RepositoryQuery: []string{"none"},
+		Exclude: []*schema.ExcludedGiteaRepo{
+			{Forks: true},
+			{Archived: true},
+			{Name: "myorg/frontend"},
+		},
+	}
+	svc := &types.ExternalService{
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	src, err := NewGiteaSource(context.Background(), svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, r := range repos {
+		names = append(names, string(r.Name))
+	}
+	sort.Strings(names)
+
+	host := extsvc.NormalizeBaseURL(src.cli.URL).Hostname()
+	want := []string{
+		host + "/alice/other",
+		host + "/alice/tool",
+		host + "/myorg/backend",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
+
+func TestGiteaSource_makeRepo(t *testing.T) {
+	conf := &schema.GiteaConnection{
+		Url:                   "https://gitea.example.com",
+		Token:                 "secret",
+		GitURLType:            "ssh",
+		RepositoryPathPattern: "gitea/{nameWithOwner}",
+	}
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindGitea,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conf)),
+	}
+
+	src, err := NewGiteaSource(context.Background(), svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := src.makeRepo(&gitea.Repository{
+		ID:          42,
+		FullName:    "myorg/myrepo",
+		Description: "my repo",
+		Private:     true,
+		Archived:    true,
+		Stars:       7,
+		CloneURL:    "https://gitea.example.com/myorg/myrepo.git",
+		SSHURL:      "git@gitea.example.com:myorg/myrepo.git",
+	})
+
+	if have, want := string(repo.Name), "gitea/myorg/myrepo"; have != want {
+		t.Errorf("unexpected name. want=%q have=%q", want, have)
+	}
+	if have, want := repo.URI, "gitea.example.com/myorg/myrepo"; have != want {
+		t.Errorf("unexpected URI. want=%q have=%q", want, have)
+	}
+	if have, want := repo.ExternalRepo.ServiceID, "https://gitea.example.com/"; have != want {
+		t.Errorf("unexpected service ID. want=%q have=%q", want, have)
+	}
+	if have, want := repo.ExternalRepo.ID, "42"; have != want {
+		t.Errorf("unexpected external ID. want=%q have=%q", want, have)
+	}
+	if !repo.Private || !repo.Archived || repo.Stars != 7 {
+		t.Errorf("unexpected repo attributes: %+v", repo)
+	}
+	if have, want := repo.Sources[svc.URN()].CloneURL, "git@gitea.example.com:myorg/myrepo.git"; have != want {
+		t.Errorf("unexpected clone URL. want=%q have=%q", want, have)
+	}
+}
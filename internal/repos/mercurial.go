@@ -0,0 +1,111 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection configured
+// in Sourcegraph via the external services configuration. gitserver converts their
+// history to Git when cloning them.
+type MercurialSource struct {
+	svc  *types.ExternalService
+	conn *schema.MercurialConnection
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+	return &MercurialSource{svc: svc, conn: &c}, nil
+}
+
+// ListRepos returns all Mercurial repositories listed in the connection.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	base, err := url.Parse(s.conn.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		// Resolve repository paths below the base path rather than next to it.
+		base.Path += "/"
+	}
+
+	urn := s.svc.URN()
+	for _, path := range s.conn.Repos {
+		if err := ctx.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		u, err := base.Parse(strings.TrimPrefix(path, "/"))
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		r, err := s.makeRepo(urn, u)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s MercurialSource) makeRepo(urn string, u *url.URL) (*types.Repo, error) {
+	cloneURL := u.String()
+	repoSource := reposource.Mercurial{MercurialConnection: s.conn}
+	repoName, err := repoSource.CloneURLToRepoName(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	if repoName == "" {
+		return nil, errors.Errorf("repository %q is not below %q", cloneURL, s.conn.Url)
+	}
+	repoURI, err := repoSource.CloneURLToRepoURI(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path, u.RawQuery = "", ""
+	serviceID := u.String()
+
+	return &types.Repo{
+		Name: repoName,
+		URI:  repoURI,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceType: extsvc.TypeMercurial,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{
+			RelativePath: strings.TrimPrefix(cloneURL, serviceID),
+		},
+	}, nil
+}
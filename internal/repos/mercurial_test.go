@@ -0,0 +1,67 @@
+package repos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMercurialSource_ListRepos(t *testing.T) {
+	ctx := context.Background()
+	svc := types.ExternalService{
+		ID:   1,
+		Kind: extsvc.KindMercurial,
+		Config: extsvc.NewUnencryptedConfig(marshalJSON(t, &schema.MercurialConnection{
+			Url:   "https://hg.example.org/repos",
+			Repos: []string{"tools/build", "/docs"},
+		})),
+	}
+
+	s, err := NewMercurialSource(ctx, &svc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := make(chan SourceResult, 10)
+	s.ListRepos(ctx, results)
+	close(results)
+
+	var got []*types.Repo
+	for res := range results {
+		if res.Err != nil {
+			t.Fatal(res.Err)
+		}
+		got = append(got, res.Repo)
+	}
+
+	repo := func(name, path string) *types.Repo {
+		return &types.Repo{
+			Name: api.RepoName(name),
+			URI:  name,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          name,
+				ServiceType: extsvc.TypeMercurial,
+				ServiceID:   "https://hg.example.org",
+			},
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:mercurial:1": {
+					ID:       "extsvc:mercurial:1",
+					CloneURL: "https://hg.example.org" + path,
+				},
+			},
+			Metadata: &extsvc.OtherRepoMetadata{RelativePath: path},
+		}
+	}
+	want := []*types.Repo{
+		repo("hg.example.org/repos/tools/build", "/repos/tools/build"),
+		repo("hg.example.org/repos/docs", "/repos/docs"),
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+	}
+}
@@ -51,12 +51,20 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewGitLabSource(ctx, logger.Scoped("GitLabSource", "GitLab repo source"), db, svc, cf)
 	case extsvc.KindGerrit:
 		return NewGerritSource(ctx, svc, cf)
+	case extsvc.KindGitea:
+		return NewGiteaSource(ctx, svc, cf)
+	case extsvc.KindAzureDevOps:
+		return NewAzureDevOpsSource(ctx, svc, cf)
 	case extsvc.KindBitbucketServer:
 		return NewBitbucketServerSource(ctx, logger.Scoped("BitbucketServerSource", "bitbucket server repo source"), svc, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(ctx, logger.Scoped("BitbucketCloudSource", "bitbucket cloud repo source"), svc, cf)
 	case extsvc.KindGitolite:
 		return NewGitoliteSource(ctx, svc, cf)
+	case extsvc.KindMercurial:
+		return NewMercurialSource(ctx, svc)
+	case extsvc.KindSubversion:
+		return NewSubversionSource(ctx, svc, cf)
 	case extsvc.KindPhabricator:
 		return NewPhabricatorSource(ctx, logger.Scoped("PhabricatorSource", "phabricator repo source"), svc, cf)
 	case extsvc.KindAWSCodeCommit:
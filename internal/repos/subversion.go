@@ -0,0 +1,183 @@
+package repos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SubversionSource yields repositories from a single Subversion connection configured
+// in Sourcegraph via the external services configuration. gitserver converts their
+// history to Git when cloning them.
+type SubversionSource struct {
+	svc    *types.ExternalService
+	conn   *schema.SubversionConnection
+	client httpcli.Doer
+}
+
+// NewSubversionSource returns a new SubversionSource from the given external service.
+func NewSubversionSource(ctx context.Context, svc *types.ExternalService, cf *httpcli.Factory) (*SubversionSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.SubversionConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubversionSource{svc: svc, conn: &c, client: cli}, nil
+}
+
+// ListRepos returns all Subversion repositories listed in the connection or, if there
+// are none, all repositories listed by the server at the connection URL.
+func (s SubversionSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	base, err := url.Parse(s.conn.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		// Resolve repository paths below the base path rather than next to it.
+		base.Path += "/"
+	}
+
+	paths := s.conn.Repos
+	if len(paths) == 0 {
+		if paths, err = s.listServerRepos(ctx, base); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+	}
+
+	excluded := make(map[string]bool, len(s.conn.Exclude))
+	for _, path := range s.conn.Exclude {
+		excluded[strings.Trim(path, "/")] = true
+	}
+
+	urn := s.svc.URN()
+	for _, path := range paths {
+		if err := ctx.Err(); err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+
+		path = strings.Trim(path, "/")
+		if excluded[path] {
+			continue
+		}
+		u := base.ResolveReference(&url.URL{Path: path})
+		r, err := s.makeRepo(urn, u)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s SubversionSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+// svnParentPathEntry matches the repositories in the listing served by mod_dav_svn for
+// SVNParentPath locations, both in its HTML form and in its XML form (SVNIndexXSLT).
+var svnParentPathEntry = lazyregexp.New(`href="([^"./?#:][^"?#:]*)/"`)
+
+// listServerRepos returns the paths of the repositories listed by the Subversion server
+// at base.
+func (s SubversionSource) listServerRepos(ctx context.Context, base *url.URL) ([]string, error) {
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, errors.Errorf("repositories of %q must be listed in \"repos\": only HTTP(S) servers can list their repositories", s.conn.Url)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", base.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if s.conn.Username != "" {
+		req.SetBasicAuth(s.conn.Username, s.conn.Password)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read Subversion repository listing")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d listing Subversion repositories at %q: is SVNListParentPath enabled?", resp.StatusCode, s.conn.Url)
+	}
+
+	var paths []string
+	for _, m := range svnParentPathEntry.FindAllStringSubmatch(string(b), -1) {
+		path, err := url.PathUnescape(m[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid Subversion repository path %q", m[1])
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func (s SubversionSource) makeRepo(urn string, u *url.URL) (*types.Repo, error) {
+	cloneURL := u.String()
+	repoSource := reposource.Subversion{SubversionConnection: s.conn}
+	repoName, err := repoSource.CloneURLToRepoName(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	if repoName == "" {
+		return nil, errors.Errorf("repository %q is not below %q", cloneURL, s.conn.Url)
+	}
+	repoURI, err := repoSource.CloneURLToRepoURI(cloneURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path, u.RawPath, u.RawQuery = "", "", ""
+	serviceID := u.String()
+
+	return &types.Repo{
+		Name: repoName,
+		URI:  repoURI,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceType: extsvc.TypeSubversion,
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: &extsvc.OtherRepoMetadata{
+			RelativePath: strings.TrimPrefix(cloneURL, serviceID),
+		},
+	}, nil
+}
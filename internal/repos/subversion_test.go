@@ -0,0 +1,122 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSubversionSource_ListRepos(t *testing.T) {
+	// The listing served by mod_dav_svn for an SVNParentPath location.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/repos/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `<html><head><title>Collection of Repositories</title></head>
+<body>
+ <h2>Collection of Repositories</h2>
+ <ul>
+  <li><a href="../">..</a></li>
+  <li><a href="archive/">archive</a></li>
+  <li><a href="my%20tools/">my tools</a></li>
+  <li><a href="website/">website</a></li>
+ </ul>
+ <hr noshade><em>Powered by <a href="http://subversion.apache.org/">Apache Subversion</a> version 1.14.2.</em>
+</body></html>`)
+	}))
+	t.Cleanup(srv.Close)
+
+	listRepos := func(t *testing.T, conn *schema.SubversionConnection) []*types.Repo {
+		t.Helper()
+
+		ctx := context.Background()
+		svc := types.ExternalService{
+			ID:     1,
+			Kind:   extsvc.KindSubversion,
+			Config: extsvc.NewUnencryptedConfig(marshalJSON(t, conn)),
+		}
+		s, err := NewSubversionSource(ctx, &svc, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := make(chan SourceResult, 10)
+		s.ListRepos(ctx, results)
+		close(results)
+
+		var got []*types.Repo
+		for res := range results {
+			if res.Err != nil {
+				t.Fatal(res.Err)
+			}
+			got = append(got, res.Repo)
+		}
+		return got
+	}
+
+	repo := func(serviceID, name, uri, path string) *types.Repo {
+		return &types.Repo{
+			Name: api.RepoName(name),
+			URI:  uri,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          name,
+				ServiceType: extsvc.TypeSubversion,
+				ServiceID:   serviceID,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"extsvc:subversion:1": {
+					ID:       "extsvc:subversion:1",
+					CloneURL: serviceID + path,
+				},
+			},
+			Metadata: &extsvc.OtherRepoMetadata{RelativePath: path},
+		}
+	}
+
+	t.Run("repos", func(t *testing.T) {
+		got := listRepos(t, &schema.SubversionConnection{
+			Url:     "svn://svn.example.org/repos",
+			Repos:   []string{"tools/build", "/docs/", "archive"},
+			Exclude: []string{"archive/"},
+		})
+		want := []*types.Repo{
+			repo("svn://svn.example.org", "svn.example.org/repos/tools/build", "svn.example.org/repos/tools/build", "/repos/tools/build"),
+			repo("svn://svn.example.org", "svn.example.org/repos/docs", "svn.example.org/repos/docs", "/repos/docs"),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("server listing", func(t *testing.T) {
+		got := listRepos(t, &schema.SubversionConnection{
+			Url:                   srv.URL + "/repos",
+			Username:              "alice",
+			Password:              "secret",
+			Exclude:               []string{"archive"},
+			RepositoryPathPattern: "svn/{repo}",
+		})
+		host := strings.Replace(strings.TrimPrefix(srv.URL, "http://"), ":", "-", 1)
+		want := []*types.Repo{
+			repo(srv.URL, "svn/my tools", host+"/repos/my tools", "/repos/my%20tools"),
+			repo(srv.URL, "svn/website", host+"/repos/website", "/repos/website"),
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+		}
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/redisworker"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -61,11 +62,25 @@ func (w *webhookBuildJob) Routines(_ context.Context, logger log.Logger) ([]goro
 		return nil, errors.Wrap(err, "create client")
 	}
 
-	return []goroutine.BackgroundRoutine{
+	routines := []goroutine.BackgroundRoutine{
 		webhookworker.NewWorker(context.Background(), newWebhookBuildHandler(store, doer), workerStore, webhookBuildWorkerMetrics),
 		webhookworker.NewResetter(context.Background(), logger.Scoped("webhookworker.Resetter", ""), workerStore, webhookBuildResetterMetrics),
 		webhookworker.NewCleaner(context.Background(), baseStore, observationContext),
-	}, nil
+	}
+
+	// The Postgres-backed worker keeps running so jobs enqueued before switching
+	// the queue to Redis are still processed.
+	if redisworker.QueueEnabled(webhookworker.RedisQueueName) {
+		redisWorkerMetrics, redisResetterMetrics := newWebhookBuildWorkerMetrics(observationContext, "webhook_build_redis_worker")
+		redisWorkerStore := webhookworker.CreateRedisWorkerStore(observationContext)
+
+		routines = append(routines,
+			webhookworker.NewRedisWorker(context.Background(), newWebhookBuildHandler(store, doer), redisWorkerStore, redisWorkerMetrics),
+			webhookworker.NewRedisResetter(context.Background(), logger.Scoped("webhookworker.RedisResetter", ""), redisWorkerStore, redisResetterMetrics),
+		)
+	}
+
+	return routines, nil
 }
 
 func newWebhookBuildWorkerMetrics(observationContext *observation.Context, workerName string) (workerutil.WorkerObservability, dbworker.ResetterMetrics) {
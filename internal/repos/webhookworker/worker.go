@@ -3,6 +3,8 @@ package webhookworker
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"sync"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
@@ -11,11 +13,18 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	workerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/redisworker"
+	redisstore "github.com/sourcegraph/sourcegraph/internal/workerutil/redisworker/store"
 )
 
+// RedisQueueName is the name of the webhook build queue in WORKERUTIL_REDIS_QUEUES.
+const RedisQueueName = "webhook_build_jobs"
+
 func NewWorker(ctx context.Context, handler workerutil.Handler, workerStore workerstore.Store, metrics workerutil.WorkerObservability) *workerutil.Worker {
 	options := workerutil.WorkerOptions{
 		Name:              "webhook_build_worker",
@@ -38,6 +47,29 @@ func NewResetter(ctx context.Context, logger log.Logger, workerStore workerstore
 	return dbworker.NewResetter(logger, workerStore, options)
 }
 
+// NewRedisWorker returns a worker processing webhook build jobs enqueued in Redis.
+func NewRedisWorker(ctx context.Context, handler workerutil.Handler, workerStore redisstore.Store, metrics workerutil.WorkerObservability) *workerutil.Worker {
+	options := workerutil.WorkerOptions{
+		Name:              "webhook_build_redis_worker",
+		NumHandlers:       3,
+		Interval:          1 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           metrics,
+	}
+
+	return redisworker.NewWorker(ctx, workerStore, handler, options)
+}
+
+func NewRedisResetter(ctx context.Context, logger log.Logger, workerStore redisstore.Store, metrics dbworker.ResetterMetrics) *dbworker.Resetter {
+	options := dbworker.ResetterOptions{
+		Name:     "webhook_build_redis_resetter",
+		Interval: 1 * time.Minute,
+		Metrics:  metrics,
+	}
+
+	return redisworker.NewResetter(logger, workerStore, options)
+}
+
 func CreateWorkerStore(logger log.Logger, dbHandle basestore.TransactableHandle) workerstore.Store {
 	return workerstore.New(logger, dbHandle, workerstore.Options{
 		Name:              "webhook_build_worker_store",
@@ -51,7 +83,37 @@ func CreateWorkerStore(logger log.Logger, dbHandle basestore.TransactableHandle)
 	})
 }
 
+var redisWorkerStoreOptions = redisstore.Options{
+	Name:          "webhook_build_worker_store",
+	Scan:          scanRedisWebhookBuildJob,
+	StalledMaxAge: 30 * time.Second,
+	MaxNumResets:  5,
+	RetryAfter:    10 * time.Second,
+	MaxNumRetries: 5,
+}
+
+// CreateRedisWorkerStore returns a store for webhook build jobs backed by Redis, which
+// is used instead of the webhook_build_jobs table when RedisQueueName is listed in
+// WORKERUTIL_REDIS_QUEUES.
+func CreateRedisWorkerStore(observationContext *observation.Context) redisstore.Store {
+	return redisstore.NewWithMetrics(redispool.Store, redisWorkerStoreOptions, observationContext)
+}
+
+var (
+	enqueueRedisStoreOnce sync.Once
+	enqueueRedisStore     redisstore.Store
+)
+
+// EnqueueJob enqueues a webhook build job into the webhook_build_jobs table, or into
+// Redis if the Redis-backed queue is enabled.
 func EnqueueJob(ctx context.Context, workerBaseStore *basestore.Store, job *Job) (id int, err error) {
+	if redisworker.QueueEnabled(RedisQueueName) {
+		enqueueRedisStoreOnce.Do(func() {
+			enqueueRedisStore = redisstore.New(redispool.Store, redisWorkerStoreOptions)
+		})
+		return enqueueRedisJob(ctx, enqueueRedisStore, job)
+	}
+
 	tx, err := workerBaseStore.Transact(ctx)
 	if err != nil {
 		return 0, err
@@ -76,6 +138,22 @@ func EnqueueJob(ctx context.Context, workerBaseStore *basestore.Store, job *Job)
 	return id, nil
 }
 
+func enqueueRedisJob(ctx context.Context, workerStore redisstore.Store, job *Job) (int, error) {
+	queuedAt := time.Now()
+	job.QueuedAt = &queuedAt
+
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return 0, err
+	}
+	id, err := workerStore.Enqueue(ctx, payload)
+	if err != nil {
+		return 0, err
+	}
+	job.ID = id
+	return id, nil
+}
+
 const enqueueJobFmtStr = `
 INSERT INTO webhook_build_jobs (
 	repo_id,
@@ -128,3 +206,13 @@ func scanWebhookBuildJob(sc dbutil.Scanner) (*Job, error) {
 		pq.Array(&job.ExecutionLogs),
 	)
 }
+
+func scanRedisWebhookBuildJob(id int, payload []byte) (workerutil.Record, error) {
+	var job Job
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return nil, err
+	}
+	job.ID = id
+	job.State = "processing"
+	return &job, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -163,6 +164,18 @@ func NewRepoInfo(r *types.Repo) *RepoInfo {
 			Blob:   pathAppend(href, "/src/{rev}/{path}"),
 			Commit: pathAppend(href, "/commits/{commit}"),
 		}
+	case extsvc.TypeAzureDevOps:
+		repo := r.Metadata.(*azuredevops.Repository)
+		if repo.WebURL == "" {
+			break
+		}
+
+		info.Links = &RepoLinks{
+			Root:   repo.WebURL,
+			Tree:   repo.WebURL + "?path=/{path}&version=GB{rev}",
+			Blob:   repo.WebURL + "?path=/{path}&version=GB{rev}",
+			Commit: pathAppend(repo.WebURL, "/commit/{commit}"),
+		}
 	case extsvc.TypeAWSCodeCommit:
 		repo := r.Metadata.(*awscodecommit.Repository)
 		if repo.ARN == "" {
@@ -12,6 +12,10 @@ const (
 	File       = "file"
 	Repository = "repo"
 	Symbol     = "symbol"
+
+	// Owners is the select:file.owners leaf, which selects the owners of
+	// files as declared in CODEOWNERS.
+	Owners = "owners"
 )
 
 // SelectPath represents a parsed and validated select value
@@ -43,6 +47,7 @@ var validSelectors = object{
 	File: {
 		"directory": nil,
 		"path":      nil,
+		Owners:      nil,
 	},
 	Repository: nil,
 	Symbol: object{
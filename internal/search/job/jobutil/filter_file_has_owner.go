@@ -0,0 +1,155 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewFileHasOwnerFilterJob creates a filter job to post-filter results for
+// the file:has.owner() predicate.
+//
+// Owners are resolved from the CODEOWNERS file of the repository at the
+// commit of each file result. A file is kept if every owner in
+// includeOwners owns it and no owner in excludeOwners does. Results that are
+// not file results are dropped, since ownership is only defined for files.
+func NewFileHasOwnerFilterJob(includeOwners, excludeOwners []string, child job.Job) job.Job {
+	return &fileHasOwnerFilterJob{
+		includeOwners: includeOwners,
+		excludeOwners: excludeOwners,
+		child:         child,
+	}
+}
+
+type fileHasOwnerFilterJob struct {
+	includeOwners []string
+	excludeOwners []string
+
+	child job.Job
+}
+
+func (j *fileHasOwnerFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu   sync.Mutex
+		errs error
+		// seen holds the rulesets whose lookup errors were already reported.
+		// Every result at a repository commit whose ruleset can't be resolved
+		// fails the same way, and it is reported once.
+		seen = make(map[rulesetLookup]struct{})
+	)
+
+	rules := codeowners.NewRulesetCache(clients.Gitserver)
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var filterErrs map[rulesetLookup]error
+		event.Results, filterErrs = j.filterMatches(ctx, rules, event.Results)
+		mu.Lock()
+		for lookup, err := range filterErrs {
+			if _, ok := seen[lookup]; ok {
+				continue
+			}
+			seen[lookup] = struct{}{}
+			errs = errors.Append(errs, err)
+		}
+		mu.Unlock()
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// rulesetLookup identifies the CODEOWNERS ruleset of a repository at a commit.
+type rulesetLookup struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+// filterMatches returns the file matches owned according to the owners of the
+// job, along with the errors of the ruleset lookups that failed.
+func (j *fileHasOwnerFilterJob) filterMatches(ctx context.Context, rules *codeowners.RulesetCache, matches []result.Match) ([]result.Match, map[rulesetLookup]error) {
+	errs := make(map[rulesetLookup]error)
+	filtered := matches[:0]
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		rs, err := rules.Get(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			errs[rulesetLookup{repo: fm.Repo.Name, commit: fm.CommitID}] = errors.Wrapf(err, "resolving code owners of %s", fm.Repo.Name)
+			continue
+		}
+
+		if j.matchesOwners(rs.FindOwners(fm.Path)) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, errs
+}
+
+func (j *fileHasOwnerFilterJob) matchesOwners(owners []string) bool {
+	for _, want := range j.includeOwners {
+		if !containsOwner(owners, want) {
+			return false
+		}
+	}
+	for _, unwanted := range j.excludeOwners {
+		if containsOwner(owners, unwanted) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsOwner(owners []string, ref string) bool {
+	for _, owner := range owners {
+		if codeowners.OwnerMatches(owner, ref) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *fileHasOwnerFilterJob) Name() string {
+	return "FileHasOwnerFilterJob"
+}
+
+func (j *fileHasOwnerFilterJob) Fields(v job.Verbosity) (res []otlog.Field) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Strings("includeOwners", j.includeOwners),
+			trace.Strings("excludeOwners", j.excludeOwners),
+		)
+	}
+	return res
+}
+
+func (j *fileHasOwnerFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasOwnerFilterJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
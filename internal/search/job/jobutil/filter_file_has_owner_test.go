@@ -0,0 +1,137 @@
+package jobutil
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFileHasOwnerFilterJob(t *testing.T) {
+	codeownersFile := `
+*        @everyone
+/search/ @sourcegraph/search
+*.md     @docs @sourcegraph/search
+`
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, repo api.RepoName, _ api.CommitID, name string, _ authz.SubRepoPermissionChecker) ([]byte, error) {
+		if repo == "owned" && name == ".github/CODEOWNERS" {
+			return []byte(codeownersFile), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	})
+
+	fm := func(repo, path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: api.RepoName(repo)},
+				CommitID: "deadbeef",
+				Path:     path,
+			},
+		}
+	}
+
+	cases := []struct {
+		name          string
+		includeOwners []string
+		excludeOwners []string
+		input         result.Matches
+		output        result.Matches
+	}{{
+		name:          "single owner",
+		includeOwners: []string{"@sourcegraph/search"},
+		input:         result.Matches{fm("owned", "search/job.go"), fm("owned", "README.md"), fm("owned", "main.go")},
+		output:        result.Matches{fm("owned", "search/job.go"), fm("owned", "README.md")},
+	}, {
+		name:          "handle without @",
+		includeOwners: []string{"docs"},
+		input:         result.Matches{fm("owned", "search/job.go"), fm("owned", "README.md")},
+		output:        result.Matches{fm("owned", "README.md")},
+	}, {
+		name:          "multiple owners must all match",
+		includeOwners: []string{"@docs", "@sourcegraph/search"},
+		input:         result.Matches{fm("owned", "search/job.go"), fm("owned", "README.md")},
+		output:        result.Matches{fm("owned", "README.md")},
+	}, {
+		name:          "negated owner",
+		excludeOwners: []string{"@sourcegraph/search"},
+		input:         result.Matches{fm("owned", "search/job.go"), fm("owned", "README.md"), fm("owned", "main.go")},
+		output:        result.Matches{fm("owned", "main.go")},
+	}, {
+		name:          "repo without CODEOWNERS",
+		includeOwners: []string{"@everyone"},
+		input:         result.Matches{fm("unowned", "main.go"), fm("owned", "main.go")},
+		output:        result.Matches{fm("owned", "main.go")},
+	}, {
+		name:          "repo without CODEOWNERS negated",
+		excludeOwners: []string{"@everyone"},
+		input:         result.Matches{fm("unowned", "main.go"), fm("owned", "main.go")},
+		output:        result.Matches{fm("unowned", "main.go")},
+	}, {
+		name:          "non-file matches are dropped",
+		includeOwners: []string{"@everyone"},
+		input:         result.Matches{&result.RepoMatch{Name: "owned"}, fm("owned", "main.go")},
+		output:        result.Matches{fm("owned", "main.go")},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: tc.input})
+				return nil, nil
+			})
+			var got streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				got = ev
+			})
+			j := NewFileHasOwnerFilterJob(tc.includeOwners, tc.excludeOwners, childJob)
+			alert, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitserverClient}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, got.Results)
+		})
+	}
+}
+
+func TestFileHasOwnerFilterJobReportsErrorsOnce(t *testing.T) {
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultReturn(nil, errors.New("gitserver unavailable"))
+
+	fm := func(repo string, commit api.CommitID, path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: api.RepoName(repo)},
+				CommitID: commit,
+				Path:     path,
+			},
+		}
+	}
+
+	// Every file of a repository commit fails the same way. The rulesets of
+	// the two commits fail with the same message, but are reported apart.
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{fm("broken", "deadbeef", "a.go"), fm("broken", "deadbeef", "b.go")}})
+		s.Send(streaming.SearchEvent{Results: result.Matches{fm("broken", "deadbeef", "c.go"), fm("broken", "cafebabe", "a.go")}})
+		return nil, nil
+	})
+	j := NewFileHasOwnerFilterJob([]string{"@everyone"}, nil, childJob)
+	_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitserverClient}, streaming.NewAggregatingStream())
+
+	var multi errors.MultiError
+	require.True(t, errors.As(err, &multi))
+	require.Len(t, multi.Errors(), 2)
+}
@@ -85,7 +85,7 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		resultTypes := computeResultTypes(b, inputs.PatternType)
 		fileMatchLimit := int32(computeFileMatchLimit(b, inputs.Protocol))
 		selector, _ := filter.SelectPathFromString(b.FindValue(query.FieldSelect)) // Invariant: select is validated
-		repoOptions := toRepoOptions(b, inputs.UserSettings)
+		repoOptions := ToRepoOptions(b, inputs.UserSettings)
 		repoUniverseSearch, skipRepoSubsetSearch, runZoektOverRepos := jobMode(b, repoOptions, resultTypes, inputs.PatternType, inputs.OnSourcegraphDotCom)
 
 		builder := &jobBuilder{
@@ -181,6 +181,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.owner() post-filter
+		if includeOwners, excludeOwners := b.FileHasOwner(); len(includeOwners) > 0 || len(excludeOwners) > 0 {
+			basicJob = NewFileHasOwnerFilterJob(includeOwners, excludeOwners, basicJob)
+		}
+	}
+
 	{ // Apply selectors
 		if v, _ := b.ToParseTree().StringValue(query.FieldSelect); v != "" {
 			sp, _ := filter.SelectPathFromString(v) // Invariant: select already validated
@@ -299,7 +305,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 	// searcher to use full deadline if timeout: set or we are streaming.
 	useFullDeadline := f.GetTimeout() != nil || f.Count() != nil || searchInputs.Protocol == search.Streaming
 
-	repoOptions := toRepoOptions(f.ToBasic(), searchInputs.UserSettings)
+	repoOptions := ToRepoOptions(f.ToBasic(), searchInputs.UserSettings)
 
 	_, skipRepoSubsetSearch, _ := jobMode(f.ToBasic(), repoOptions, resultTypes, searchInputs.PatternType, searchInputs.OnSourcegraphDotCom)
 
@@ -644,7 +650,9 @@ func computeResultTypes(b query.Basic, searchType query.SearchType) result.Types
 	return rts
 }
 
-func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
+// ToRepoOptions returns the options to resolve the repositories searched by
+// the basic query b.
+func ToRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOptions {
 	repoFilters, minusRepoFilters := b.Repositories()
 
 	var settingForks, settingArchived bool
@@ -69,6 +69,8 @@ func (j *sanitizeJob) sanitizeEvent(event streaming.SearchEvent) streaming.Searc
 			}
 		case *result.RepoMatch:
 			sanitized = append(sanitized, v)
+		case *result.OwnerMatch:
+			sanitized = append(sanitized, v)
 		default:
 			// default to dropping this result
 		}
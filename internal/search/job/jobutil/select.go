@@ -6,12 +6,14 @@ import (
 
 	"github.com/opentracing/opentracing-go/log"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // NewSelectJob creates a job that transforms streamed results with
@@ -29,10 +31,47 @@ func (j *selectJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
 	defer func() { finish(alert, err) }()
 
+	if isOwnersSelect(j.path) {
+		return j.runOwnersSelect(ctx, clients, stream)
+	}
+
 	selectingStream := newSelectingStream(stream, j.path)
 	return j.child.Run(ctx, clients, selectingStream)
 }
 
+// runOwnersSelect runs the child job, mapping file results to the owners of
+// the file. Owners are resolved from the CODEOWNERS file of the repository at
+// the commit of each result.
+func (j *selectJob) runOwnersSelect(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (*search.Alert, error) {
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	rules := codeowners.NewRulesetCache(clients.Gitserver)
+	selectingStream := newOwnerSelectingStream(stream, func(fm *result.FileMatch) ([]string, error) {
+		rs, err := rules.Get(ctx, fm.Repo.Name, fm.CommitID)
+		if err != nil {
+			err = errors.Wrapf(err, "resolving code owners of %s", fm.Repo.Name)
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+			return nil, err
+		}
+		return rs.FindOwners(fm.Path), nil
+	})
+
+	alert, err := j.child.Run(ctx, clients, selectingStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func isOwnersSelect(path filter.SelectPath) bool {
+	return path.Root() == filter.File && len(path) > 1 && path[1] == filter.Owners
+}
+
 func (j *selectJob) Name() string {
 	return "SelectJob"
 }
@@ -96,3 +135,45 @@ func newSelectingStream(parent streaming.Sender, s filter.SelectPath) streaming.
 		parent.Send(e)
 	})
 }
+
+// newOwnerSelectingStream returns a child Stream of parent that replaces each
+// file result with a result for every owner of the file, as returned by
+// findOwners. Every owner is only sent once. Files for which findOwners fails
+// are dropped, as are results that are not file results.
+func newOwnerSelectingStream(parent streaming.Sender, findOwners func(*result.FileMatch) ([]string, error)) streaming.Sender {
+	var mux sync.Mutex
+	dedup := result.NewDeduper()
+
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		// Resolve owners before taking the lock, since it may require a
+		// round trip to gitserver.
+		var candidates []result.Match
+		for _, match := range e.Results {
+			fm, ok := match.(*result.FileMatch)
+			if !ok {
+				continue
+			}
+			owners, err := findOwners(fm)
+			if err != nil {
+				continue
+			}
+			for _, owner := range owners {
+				candidates = append(candidates, result.NewOwnerMatch(owner, fm.Repo, fm.CommitID))
+			}
+		}
+
+		mux.Lock()
+		selected := candidates[:0]
+		for _, m := range candidates {
+			if dedup.Seen(m) {
+				continue
+			}
+			dedup.Add(m)
+			selected = append(selected, m)
+		}
+		mux.Unlock()
+
+		e.Results = selected
+		parent.Send(e)
+	})
+}
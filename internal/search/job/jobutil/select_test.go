@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
 
 	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestWithSelect(t *testing.T) {
@@ -221,3 +223,42 @@ func TestWithSelect(t *testing.T) {
   }
 ]`).Equal(t, test("content"))
 }
+
+func TestWithOwnersSelect(t *testing.T) {
+	owners := map[string][]string{
+		"pokeman/charmandar": {"@ash", "@pokeman/team"},
+		"pokeman/bulbosaur":  {"@Ash", "misty@example.com"},
+		"digiman/ummm":       nil,
+	}
+	findOwners := func(fm *result.FileMatch) ([]string, error) {
+		if fm.Path == "broken" {
+			return nil, errors.New("broken")
+		}
+		return owners[fm.Path], nil
+	}
+
+	agg := streaming.NewAggregatingStream()
+	selectAgg := newOwnerSelectingStream(agg, findOwners)
+	selectAgg.Send(streaming.SearchEvent{
+		Results: []result.Match{
+			&result.FileMatch{File: result.File{Path: "pokeman/charmandar"}},
+			&result.FileMatch{File: result.File{Path: "broken"}},
+			&result.RepoMatch{Name: "pokeman"},
+		},
+	})
+	selectAgg.Send(streaming.SearchEvent{
+		Results: []result.Match{
+			&result.FileMatch{File: result.File{Path: "pokeman/bulbosaur"}},
+			&result.FileMatch{File: result.File{Path: "digiman/ummm"}},
+		},
+	})
+
+	want := result.Matches{
+		&result.OwnerMatch{Handle: "ash"},
+		&result.OwnerMatch{Handle: "pokeman/team"},
+		&result.OwnerMatch{Email: "misty@example.com"},
+	}
+	if diff := cmp.Diff(want, agg.Results); diff != "" {
+		t.Fatalf("unexpected owners (-want +got):\n%s", diff)
+	}
+}
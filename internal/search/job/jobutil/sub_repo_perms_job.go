@@ -117,6 +117,10 @@ func applySubRepoFiltering(ctx context.Context, logger log.Logger, checker authz
 		case *result.RepoMatch:
 			// Repo filtering is taking care of by our usual repo filtering logic
 			filtered = append(filtered, m)
+		case *result.OwnerMatch:
+			// Owners are read from CODEOWNERS files, which are already
+			// subject to sub-repo permissions when fetched.
+			filtered = append(filtered, m)
 		}
 
 	}
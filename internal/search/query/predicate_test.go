@@ -124,3 +124,51 @@ func TestRepoHasDescriptionPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasOwnerPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			negated  bool
+			expected *FileHasOwnerPredicate
+		}
+
+		valid := []test{
+			{`handle`, `@alice`, false, &FileHasOwnerPredicate{Owner: "@alice"}},
+			{`team`, `@sourcegraph/search`, false, &FileHasOwnerPredicate{Owner: "@sourcegraph/search"}},
+			{`email`, `alice@example.com`, false, &FileHasOwnerPredicate{Owner: "alice@example.com"}},
+			{`negated`, `@alice`, true, &FileHasOwnerPredicate{Owner: "@alice", Negated: true}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, false, nil},
+			{`only @`, `@`, false, nil},
+			{`multiple owners`, `@alice @bob`, false, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasOwnerPredicate{}
+				err := p.Unmarshal(tc.params, tc.negated)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -393,6 +393,20 @@ func (p Parameters) FileContainsContent() (include []string) {
 	return include
 }
 
+// FileHasOwner returns the owners referenced by file:has.owner() predicates,
+// split into those files must be owned by and those they must not be owned
+// by.
+func (p Parameters) FileHasOwner() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Owner)
+		} else {
+			include = append(include, pred.Owner)
+		}
+	})
+	return include, exclude
+}
+
 type RepoHasCommitAfterArgs struct {
 	TimeRef string
 	Negated bool
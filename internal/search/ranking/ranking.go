@@ -0,0 +1,205 @@
+// Package ranking scores search results by how important the code they point
+// at is likely to be. It is used by the ranked mode of the streaming search
+// API to decide the order in which buffered results are sent to clients.
+package ranking
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// DocumentRanker returns the number of precise references to each document of
+// a repository. It is implemented by the code intelligence ranking service.
+type DocumentRanker interface {
+	GetDocumentReferenceCounts(ctx context.Context, repoName api.RepoName) (map[string]float64, error)
+}
+
+const (
+	// The weights of the individual signals. They sum up to 1, so scores are
+	// in the range [0, 1).
+	documentWeight = 0.6
+	starsWeight    = 0.25
+	recencyWeight  = 0.15
+
+	// starsScale is the star count at which the stars signal reaches half of
+	// its maximum.
+	starsScale = 1000
+
+	// recencyHalfLife is the age of the last fetched commit at which the
+	// recency signal reaches half of its maximum.
+	recencyHalfLife = 30 * 24 * time.Hour
+)
+
+// Scorer assigns relevance scores to search results. Repository metadata and
+// document reference counts are fetched the first time a repository is seen
+// and cached for the lifetime of the scorer, so a Scorer should be created per
+// search request.
+type Scorer struct {
+	logger log.Logger
+	db     database.DB
+	ranker DocumentRanker
+	now    func() time.Time
+
+	mu    sync.Mutex
+	repos map[api.RepoID]*repoSignals
+}
+
+type repoSignals struct {
+	stars       int
+	lastFetched *time.Time
+
+	// refCounts is nil until a file match in the repository has been scored.
+	refCounts map[string]float64
+}
+
+// NewScorer returns a Scorer that reads repository metadata from db and
+// document reference counts from ranker. ranker may be nil, in which case
+// only repository signals contribute to scores.
+func NewScorer(logger log.Logger, db database.DB, ranker DocumentRanker) *Scorer {
+	return &Scorer{
+		logger: logger.Scoped("scorer", "scores search results for ranked streaming search"),
+		db:     db,
+		ranker: ranker,
+		now:    time.Now,
+		repos:  map[api.RepoID]*repoSignals{},
+	}
+}
+
+// Score returns a score for each of the given matches. Higher scores indicate
+// more relevant results. Failures to load ranking signals are logged and
+// treated as absent signals, as ranking is best-effort.
+//
+// Score is safe for concurrent use. Signals are loaded without holding the
+// lock, so concurrent calls seeing the same new repository may both load it.
+func (s *Scorer) Score(ctx context.Context, matches result.Matches) []float64 {
+	s.loadRepos(ctx, matches)
+	s.loadRefCounts(ctx, matches)
+
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scores := make([]float64, len(matches))
+	for i, match := range matches {
+		scores[i] = s.score(match, now)
+	}
+	return scores
+}
+
+func (s *Scorer) score(match result.Match, now time.Time) float64 {
+	signals, ok := s.repos[match.RepoName().ID]
+	if !ok {
+		return 0
+	}
+
+	var score float64
+	if fm, ok := match.(*result.FileMatch); ok {
+		score += documentWeight * squashRange(signals.refCounts[fm.Path])
+	}
+	score += starsWeight * squashRange(float64(signals.stars)/starsScale)
+	if signals.lastFetched != nil {
+		age := now.Sub(*signals.lastFetched)
+		if age < 0 {
+			age = 0
+		}
+		score += recencyWeight * math.Exp2(-float64(age)/float64(recencyHalfLife))
+	}
+	return score
+}
+
+// loadRepos fetches the metadata of all repositories of matches that have not
+// been seen before.
+func (s *Scorer) loadRepos(ctx context.Context, matches result.Matches) {
+	loaded := map[api.RepoID]*repoSignals{}
+	var ids []api.RepoID
+	s.mu.Lock()
+	for _, match := range matches {
+		id := match.RepoName().ID
+		if _, ok := s.repos[id]; ok {
+			continue
+		}
+		if _, ok := loaded[id]; ok {
+			continue
+		}
+		loaded[id] = &repoSignals{}
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	if len(ids) == 0 {
+		return
+	}
+
+	repos, err := s.db.Repos().Metadata(ctx, ids...)
+	if err != nil {
+		s.logger.Warn("failed to load repository metadata", log.Error(err))
+	}
+	for _, repo := range repos {
+		if signals, ok := loaded[repo.ID]; ok {
+			signals.stars = repo.Stars
+			signals.lastFetched = repo.LastFetched
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, signals := range loaded {
+		// A concurrent call may have loaded the repository in the meantime.
+		if _, ok := s.repos[id]; !ok {
+			s.repos[id] = signals
+		}
+	}
+}
+
+// loadRefCounts fetches the document reference counts of all repositories of
+// file matches that have not been fetched before.
+func (s *Scorer) loadRefCounts(ctx context.Context, matches result.Matches) {
+	if s.ranker == nil {
+		return
+	}
+
+	pending := map[api.RepoID]api.RepoName{}
+	s.mu.Lock()
+	for _, match := range matches {
+		fm, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		if signals := s.repos[fm.Repo.ID]; signals != nil && signals.refCounts != nil {
+			continue
+		}
+		pending[fm.Repo.ID] = fm.Repo.Name
+	}
+	s.mu.Unlock()
+
+	for id, name := range pending {
+		refCounts, err := s.ranker.GetDocumentReferenceCounts(ctx, name)
+		if err != nil {
+			s.logger.Warn("failed to load document reference counts", log.String("repo", string(name)), log.Error(err))
+		}
+		if refCounts == nil {
+			// Mark as loaded so we don't retry for every match.
+			refCounts = map[string]float64{}
+		}
+
+		s.mu.Lock()
+		if signals := s.repos[id]; signals != nil && signals.refCounts == nil {
+			signals.refCounts = refCounts
+		}
+		s.mu.Unlock()
+	}
+}
+
+// squashRange maps a value in the range [0, inf) to a value in the range
+// [0, 1) monotonically (i.e., (a < b) <-> (squashRange(a) < squashRange(b))).
+func squashRange(j float64) float64 {
+	return j / (1 + j)
+}
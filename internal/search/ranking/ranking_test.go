@@ -0,0 +1,137 @@
+package ranking
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeRanker map[api.RepoName]map[string]float64
+
+func (f fakeRanker) GetDocumentReferenceCounts(_ context.Context, repoName api.RepoName) (map[string]float64, error) {
+	return f[repoName], nil
+}
+
+func TestScorer(t *testing.T) {
+	now := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	recent := now.Add(-time.Hour)
+	stale := now.Add(-365 * 24 * time.Hour)
+
+	popular := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/popular"}
+	obscure := types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/obscure"}
+
+	var metadataCalls int
+	repos := database.NewMockRepoStore()
+	repos.MetadataFunc.SetDefaultHook(func(_ context.Context, ids ...api.RepoID) ([]*types.SearchedRepo, error) {
+		metadataCalls++
+		all := map[api.RepoID]*types.SearchedRepo{
+			popular.ID: {ID: popular.ID, Name: popular.Name, Stars: 10000, LastFetched: &recent},
+			obscure.ID: {ID: obscure.ID, Name: obscure.Name, Stars: 1, LastFetched: &stale},
+		}
+		var res []*types.SearchedRepo
+		for _, id := range ids {
+			res = append(res, all[id])
+		}
+		return res, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	ranker := fakeRanker{
+		popular.Name: {"core.go": 500, "util.go": 1},
+	}
+
+	s := NewScorer(logtest.Scoped(t), db, ranker)
+	s.now = func() time.Time { return now }
+
+	matches := result.Matches{
+		&result.FileMatch{File: result.File{Repo: popular, Path: "util.go"}},
+		&result.FileMatch{File: result.File{Repo: obscure, Path: "core.go"}},
+		&result.FileMatch{File: result.File{Repo: popular, Path: "core.go"}},
+		&result.RepoMatch{ID: obscure.ID, Name: obscure.Name},
+	}
+	scores := s.Score(context.Background(), matches)
+	if len(scores) != len(matches) {
+		t.Fatalf("unexpected number of scores. want=%d have=%d", len(matches), len(scores))
+	}
+
+	// A heavily referenced document in a popular, active repository should
+	// rank above a rarely referenced one in the same repository, which in
+	// turn should rank above anything in an obscure, stale repository.
+	if !(scores[2] > scores[0] && scores[0] > scores[1] && scores[1] >= scores[3]) {
+		t.Fatalf("unexpected score order: %v", scores)
+	}
+
+	// Signals are cached across calls.
+	s.Score(context.Background(), matches)
+	if metadataCalls != 1 {
+		t.Fatalf("expected repository metadata to be fetched once, got %d calls", metadataCalls)
+	}
+}
+
+func TestScorerWithoutRanker(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	repos.MetadataFunc.SetDefaultReturn([]*types.SearchedRepo{{ID: 1, Stars: 1000}}, nil)
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	s := NewScorer(logtest.Scoped(t), db, nil)
+	scores := s.Score(context.Background(), result.Matches{
+		&result.FileMatch{File: result.File{Repo: types.MinimalRepo{ID: 1}, Path: "a.go"}},
+	})
+	if want := starsWeight * 0.5; scores[0] != want {
+		t.Fatalf("unexpected score. want=%f have=%f", want, scores[0])
+	}
+}
+
+type blockingRanker struct {
+	blocked api.RepoName
+	release chan struct{}
+}
+
+func (b blockingRanker) GetDocumentReferenceCounts(_ context.Context, repoName api.RepoName) (map[string]float64, error) {
+	if repoName == b.blocked {
+		<-b.release
+	}
+	return nil, nil
+}
+
+func TestScorerConcurrentCalls(t *testing.T) {
+	repos := database.NewMockRepoStore()
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	slow := types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/slow"}
+	fast := types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/fast"}
+	ranker := blockingRanker{blocked: slow.Name, release: make(chan struct{})}
+	s := NewScorer(logtest.Scoped(t), db, ranker)
+
+	slowDone := make(chan struct{})
+	go func() {
+		defer close(slowDone)
+		s.Score(context.Background(), result.Matches{&result.FileMatch{File: result.File{Repo: slow, Path: "a.go"}}})
+	}()
+
+	// Scoring matches of another repository doesn't wait for the reference
+	// counts of the slow one.
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		s.Score(context.Background(), result.Matches{&result.FileMatch{File: result.File{Repo: fast, Path: "a.go"}}})
+	}()
+	select {
+	case <-fastDone:
+	case <-time.After(10 * time.Second):
+		t.Fatal("scoring blocked on a concurrent call")
+	}
+
+	close(ranker.release)
+	<-slowDone
+}
@@ -8,8 +8,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-// Match is *FileMatch | *RepoMatch | *CommitMatch. We have a private method
-// to ensure only those types implement Match.
+// Match is *FileMatch | *RepoMatch | *CommitMatch | *OwnerMatch. We have a
+// private method to ensure only those types implement Match.
 type Match interface {
 	ResultCount() int
 
@@ -34,6 +34,7 @@ var (
 	_ Match = (*RepoMatch)(nil)
 	_ Match = (*CommitMatch)(nil)
 	_ Match = (*CommitDiffMatch)(nil)
+	_ Match = (*OwnerMatch)(nil)
 )
 
 // Match ranks are used for sorting the different match types.
@@ -44,6 +45,7 @@ const (
 	rankCommitMatch = 1
 	rankDiffMatch   = 2
 	rankRepoMatch   = 3
+	rankOwnerMatch  = 4
 )
 
 // Key is a sorting or deduplicating key for a Match. It contains all the
@@ -74,6 +76,10 @@ type Key struct {
 	// Empty if there is no file associated with the match (e.g. RepoMatch or CommitMatch)
 	Path string
 
+	// Owner is the normalized handle or email of the owner the match belongs
+	// to. Empty if the match is not an owner match.
+	Owner string
+
 	// TypeRank is the sorting rank of the type this key belongs to.
 	TypeRank int
 }
@@ -100,6 +106,10 @@ func (k Key) Less(other Key) bool {
 		return k.Path < other.Path
 	}
 
+	if k.Owner != other.Owner {
+		return k.Owner < other.Owner
+	}
+
 	return k.TypeRank < other.TypeRank
 }
 
@@ -0,0 +1,78 @@
+package result
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OwnerMatch represents an owner of files in the search results, as declared
+// by the CODEOWNERS file of their repository. Exactly one of Handle and Email
+// is set.
+type OwnerMatch struct {
+	// Handle is the user or team handle of the owner without the leading
+	// '@', for example "alice" or "sourcegraph/search".
+	Handle string
+
+	// Email is the email address of the owner.
+	Email string
+
+	// Repo and CommitID identify where the owner was first found.
+	Repo     types.MinimalRepo
+	CommitID api.CommitID
+}
+
+// NewOwnerMatch returns the OwnerMatch for owner as it appears in a
+// CODEOWNERS file in repo at commit.
+func NewOwnerMatch(owner string, repo types.MinimalRepo, commit api.CommitID) *OwnerMatch {
+	m := &OwnerMatch{Repo: repo, CommitID: commit}
+	if strings.HasPrefix(owner, "@") || !strings.Contains(owner, "@") {
+		m.Handle = strings.TrimPrefix(owner, "@")
+	} else {
+		m.Email = owner
+	}
+	return m
+}
+
+// IsTeam reports whether the owner is a team rather than a single person.
+func (m *OwnerMatch) IsTeam() bool {
+	return strings.Contains(m.Handle, "/")
+}
+
+func (m *OwnerMatch) RepoName() types.MinimalRepo {
+	return m.Repo
+}
+
+func (m *OwnerMatch) ResultCount() int {
+	return 1
+}
+
+func (m *OwnerMatch) Limit(limit int) int {
+	// Always represents one result and limit > 0 so we just return limit - 1.
+	return limit - 1
+}
+
+func (m *OwnerMatch) Select(path filter.SelectPath) Match {
+	if path.Root() == filter.File && len(path) > 1 && path[1] == filter.Owners {
+		return m
+	}
+	return nil
+}
+
+// Key identifies an owner independently of the repository it was found in,
+// so that an owner of files in several repositories is only reported once.
+// Handles and emails are compared case-insensitively.
+func (m *OwnerMatch) Key() Key {
+	owner := m.Handle
+	if owner == "" {
+		owner = m.Email
+	}
+	return Key{
+		TypeRank: rankOwnerMatch,
+		Owner:    strings.ToLower(owner),
+	}
+}
+
+func (m *OwnerMatch) searchResultMarker() {}
@@ -107,6 +107,15 @@ func (s *SearchFilters) Update(event SearchEvent) {
 		}
 	}
 
+	addOwnerFilter := func(owner *result.OwnerMatch) {
+		label := owner.Email
+		if owner.Handle != "" {
+			label = "@" + owner.Handle
+		}
+		filter := fmt.Sprintf(`file:has.owner(%s)`, label)
+		s.filters.Add(filter, label, 1, event.Stats.IsLimitHit, "owner")
+	}
+
 	if event.Stats.ExcludedForks > 0 {
 		s.filters.Add("fork:yes", "Include forked repos", int32(event.Stats.ExcludedForks), event.Stats.IsLimitHit, "utility")
 		s.filters.MarkImportant("fork:yes")
@@ -136,6 +145,8 @@ func (s *SearchFilters) Update(event SearchEvent) {
 			// We leave "rev" empty, instead of using "CommitMatch.Commit.ID". This way we
 			// get 1 filter per repo instead of 1 filter per sha in the side-bar.
 			addRepoFilter(v.Repo.Name, v.Repo.ID, "", int32(v.ResultCount()))
+		case *result.OwnerMatch:
+			addOwnerFilter(v)
 		}
 	}
 }
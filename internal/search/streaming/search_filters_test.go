@@ -69,6 +69,22 @@ func TestSearchFiltersUpdate(t *testing.T) {
 			wantFilterKind:  "repo",
 			wantFilterCount: 2,
 		},
+		{
+			name: "OwnerMatch",
+			events: []SearchEvent{
+				{
+					Results: []result.Match{
+						&result.OwnerMatch{
+							Handle: "sourcegraph/search",
+							Repo:   repo,
+						},
+					},
+				},
+			},
+			wantFilterName:  "file:has.owner(@sourcegraph/search)",
+			wantFilterKind:  "owner",
+			wantFilterCount: 1,
+		},
 	}
 
 	for _, c := range cases {
@@ -1,6 +1,7 @@
 package streaming
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -201,3 +202,102 @@ func (s *batchingStream) flush() {
 		s.dirty = false
 	}
 }
+
+// NewRankingStream returns a stream that buffers results sent to it and
+// forwards them to the parent stream ordered by descending score, as computed
+// by score. Buffered events are held back for at most window after the first
+// event of a batch arrives, which bounds the latency added by ranking. Ties
+// keep their arrival order. score must return one score per match.
+// When there will be no more events sent on the ranking stream, Done() must be
+// called to flush the remaining buffered events.
+func NewRankingStream(window time.Duration, score func(result.Matches) []float64, parent Sender) *rankingStream {
+	return &rankingStream{
+		parent: parent,
+		window: window,
+		score:  score,
+	}
+}
+
+type rankingStream struct {
+	parent Sender
+	window time.Duration
+	score  func(result.Matches) []float64
+
+	mu             sync.Mutex
+	dirty          bool
+	batch          []scoredMatch
+	stats          Stats
+	timer          *time.Timer
+	flushScheduled bool
+}
+
+type scoredMatch struct {
+	match result.Match
+	score float64
+}
+
+func (s *rankingStream) Send(event SearchEvent) {
+	// Scoring may be expensive, so do it before taking the lock.
+	var scores []float64
+	if len(event.Results) > 0 {
+		scores = s.score(event.Results)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, match := range event.Results {
+		s.batch = append(s.batch, scoredMatch{match: match, score: scores[i]})
+	}
+	s.stats.Update(&event.Stats)
+	s.dirty = true
+
+	if s.flushScheduled {
+		return
+	}
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.window, func() {
+			s.mu.Lock()
+			s.flush()
+			s.flushScheduled = false
+			s.mu.Unlock()
+		})
+	} else {
+		s.timer.Reset(s.window)
+	}
+	s.flushScheduled = true
+}
+
+// Done should be called when no more events will be sent down
+// the stream. It flushes any events that are currently buffered
+// and cancels any scheduled flush.
+func (s *rankingStream) Done() {
+	s.mu.Lock()
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+
+	s.flush()
+	s.mu.Unlock()
+}
+
+// flush sorts the buffered results by score and sends them to the parent
+// stream. The caller must hold a lock on the ranking stream.
+func (s *rankingStream) flush() {
+	if !s.dirty {
+		return
+	}
+
+	sort.SliceStable(s.batch, func(i, j int) bool {
+		return s.batch[i].score > s.batch[j].score
+	})
+	results := make(result.Matches, 0, len(s.batch))
+	for _, sm := range s.batch {
+		results = append(results, sm.match)
+	}
+
+	s.parent.Send(SearchEvent{Results: results, Stats: s.stats})
+	s.batch = nil
+	s.stats = Stats{}
+	s.dirty = false
+}
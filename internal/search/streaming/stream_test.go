@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/group"
 )
@@ -132,6 +133,69 @@ func TestBatchingStream(t *testing.T) {
 	})
 }
 
+func TestRankingStream(t *testing.T) {
+	// Score repository matches by their ID.
+	score := func(matches result.Matches) []float64 {
+		scores := make([]float64, len(matches))
+		for i, m := range matches {
+			scores[i] = float64(m.(*result.RepoMatch).ID)
+		}
+		return scores
+	}
+	ids := func(matches result.Matches) []int {
+		res := make([]int, 0, len(matches))
+		for _, m := range matches {
+			res = append(res, int(m.(*result.RepoMatch).ID))
+		}
+		return res
+	}
+
+	t.Run("sorts within window", func(t *testing.T) {
+		var mu sync.Mutex
+		var events []SearchEvent
+		s := NewRankingStream(100*time.Millisecond, score, StreamFunc(func(event SearchEvent) {
+			mu.Lock()
+			events = append(events, event)
+			mu.Unlock()
+		}))
+
+		for _, id := range []api.RepoID{2, 5, 1, 5, 3} {
+			s.Send(SearchEvent{
+				Results: result.Matches{&result.RepoMatch{ID: id}},
+				Stats:   Stats{IsLimitHit: id == 3},
+			})
+		}
+
+		// Nothing is sent until the window has passed
+		mu.Lock()
+		require.Len(t, events, 0)
+		mu.Unlock()
+
+		time.Sleep(150 * time.Millisecond)
+		mu.Lock()
+		require.Len(t, events, 1)
+		require.Equal(t, []int{5, 5, 3, 2, 1}, ids(events[0].Results))
+		require.True(t, events[0].Stats.IsLimitHit)
+		mu.Unlock()
+
+		// A later batch is ranked independently and flushed by Done
+		s.Send(SearchEvent{Results: result.Matches{&result.RepoMatch{ID: 4}, &result.RepoMatch{ID: 6}}})
+		s.Done()
+		require.Len(t, events, 2)
+		require.Equal(t, []int{6, 4}, ids(events[1].Results))
+		require.False(t, events[1].Stats.IsLimitHit)
+	})
+
+	t.Run("done without events", func(t *testing.T) {
+		var count atomic.Int64
+		s := NewRankingStream(100*time.Millisecond, score, StreamFunc(func(event SearchEvent) {
+			count.Inc()
+		}))
+		s.Done()
+		require.Equal(t, int64(0), count.Load())
+	})
+}
+
 func TestDedupingStream(t *testing.T) {
 	var sent []result.Match
 	s := NewDedupingStream(StreamFunc(func(e SearchEvent) {
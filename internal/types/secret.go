@@ -47,6 +47,10 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		es.redactString(c.TokenOauthRefresh, "token.oauth.refresh")
 	case *schema.GerritConnection:
 		es.redactString(c.Password, "password")
+	case *schema.GiteaConnection:
+		es.redactString(c.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		es.redactString(c.Token, "token")
 	case *schema.BitbucketServerConnection:
 		es.redactString(c.Password, "password")
 		es.redactString(c.Token, "token")
@@ -92,6 +96,13 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		if err != nil {
 			return "", err
 		}
+	case *schema.MercurialConnection:
+		err = es.redactURL(c.Url, "url")
+		if err != nil {
+			return "", err
+		}
+	case *schema.SubversionConnection:
+		es.redactString(c.Password, "password")
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
 		return "", errors.Errorf("Unrecognized ExternalServiceConfig for redaction: kind %+v not implemented", reflect.TypeOf(cfg))
@@ -145,6 +156,12 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		o := oldCfg.(*schema.GitLabConnection)
 		es.unredactString(c.Token, o.Token, "token")
 		es.unredactString(c.TokenOauthRefresh, o.TokenOauthRefresh, "token.oauth.refresh")
+	case *schema.GiteaConnection:
+		o := oldCfg.(*schema.GiteaConnection)
+		es.unredactString(c.Token, o.Token, "token")
+	case *schema.AzureDevOpsConnection:
+		o := oldCfg.(*schema.AzureDevOpsConnection)
+		es.unredactString(c.Token, o.Token, "token")
 	case *schema.BitbucketServerConnection:
 		o := oldCfg.(*schema.BitbucketServerConnection)
 		es.unredactString(c.Password, o.Password, "password")
@@ -196,6 +213,15 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		if err != nil {
 			return err
 		}
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		err = es.unredactURL(c.Url, o.Url, "url")
+		if err != nil {
+			return err
+		}
+	case *schema.SubversionConnection:
+		o := oldCfg.(*schema.SubversionConnection)
+		es.unredactString(c.Password, o.Password, "password")
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
 		return errors.Errorf("Unrecognized ExternalServiceConfig for redaction: kind %+v not implemented", reflect.TypeOf(newCfg))
@@ -20,7 +20,7 @@ import (
 // state for more than a few seconds are very likely to be stuck after the worker processing
 // them has crashed.
 type Resetter struct {
-	store    store.Store
+	store    ResetterStore
 	options  ResetterOptions
 	clock    glock.Clock
 	ctx      context.Context // root context passed to the database
@@ -29,6 +29,16 @@ type Resetter struct {
 	logger   log.Logger
 }
 
+// ResetterStore is the subset of a store used by a Resetter. It is implemented by
+// both the Postgres-backed store.Store and the Redis-backed redisworker store.
+type ResetterStore interface {
+	// ResetStalled moves records that have not received a heartbeat recently back to
+	// the queued state, or to the failed state once they've been reset too often.
+	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
+}
+
+var _ ResetterStore = store.Store(nil)
+
 type ResetterOptions struct {
 	Name     string
 	Interval time.Duration
@@ -70,11 +80,11 @@ func NewMetrics(observationContext *observation.Context, metricNameRoot string)
 	}
 }
 
-func NewResetter(logger log.Logger, store store.Store, options ResetterOptions) *Resetter {
+func NewResetter(logger log.Logger, store ResetterStore, options ResetterOptions) *Resetter {
 	return newResetter(logger, store, options, glock.NewRealClock())
 }
 
-func newResetter(logger log.Logger, store store.Store, options ResetterOptions, clock glock.Clock) *Resetter {
+func newResetter(logger log.Logger, store ResetterStore, options ResetterOptions, clock glock.Clock) *Resetter {
 	if options.Name == "" {
 		panic("no name supplied to github.com/sourcegraph/sourcegraph/internal/dbworker/newResetter")
 	}
@@ -0,0 +1,24 @@
+package redisworker
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+var redisQueues = env.Get("WORKERUTIL_REDIS_QUEUES", "", "Comma-separated list of worker queues to back by Redis streams instead of Postgres.")
+
+// QueueEnabled returns true if the queue with the given name should use a Redis-backed
+// store rather than the Postgres-backed dbworker store.
+func QueueEnabled(name string) bool {
+	return queueEnabled(redisQueues, name)
+}
+
+func queueEnabled(queues, name string) bool {
+	for _, queue := range strings.Split(queues, ",") {
+		if strings.TrimSpace(queue) == name {
+			return true
+		}
+	}
+	return false
+}
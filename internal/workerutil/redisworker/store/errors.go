@@ -0,0 +1,11 @@
+package store
+
+import "github.com/sourcegraph/sourcegraph/lib/errors"
+
+// ErrExtraArguments occurs when Dequeue is called with extra arguments, which are
+// only meaningful to the Postgres-backed dbworker store.
+var ErrExtraArguments = errors.New("redis worker stores do not support dequeue arguments")
+
+// ErrExecutionLogEntryNotUpdated is returned by AddExecutionLogEntry and UpdateExecutionLogEntry
+// when the record or log entry does not exist.
+var ErrExecutionLogEntryNotUpdated = errors.New("execution log entry not updated")
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type operations struct {
+	addExecutionLogEntry    *observation.Operation
+	cancel                  *observation.Operation
+	canceledJobs            *observation.Operation
+	dequeue                 *observation.Operation
+	enqueue                 *observation.Operation
+	heartbeat               *observation.Operation
+	markComplete            *observation.Operation
+	markErrored             *observation.Operation
+	markFailed              *observation.Operation
+	queuedCount             *observation.Operation
+	requeue                 *observation.Operation
+	resetStalled            *observation.Operation
+	updateExecutionLogEntry *observation.Operation
+}
+
+// As with dbworker stores, a store for a given name can be created more than
+// once, so we avoid registering the same metrics twice.
+var (
+	metricsMap = map[string]*metrics.REDMetrics{}
+	metricsMu  sync.Mutex
+)
+
+func newOperations(storeName string, observationContext *observation.Context) *operations {
+	metricsMu.Lock()
+
+	var red *metrics.REDMetrics
+	if m, ok := metricsMap[storeName]; ok {
+		red = m
+	} else {
+		red = metrics.NewREDMetrics(
+			observationContext.Registerer,
+			fmt.Sprintf("workerutil_redisworker_store_%s", storeName),
+			metrics.WithLabels("op"),
+			metrics.WithCountHelp("Total number of method invocations."),
+		)
+		metricsMap[storeName] = red
+	}
+
+	metricsMu.Unlock()
+
+	op := func(opName string) *observation.Operation {
+		return observationContext.Operation(observation.Op{
+			Name:              fmt.Sprintf("workerutil.redisworker.store.%s.%s", storeName, opName),
+			MetricLabelValues: []string{opName},
+			Metrics:           red,
+		})
+	}
+
+	return &operations{
+		addExecutionLogEntry:    op("AddExecutionLogEntry"),
+		cancel:                  op("Cancel"),
+		canceledJobs:            op("CanceledJobs"),
+		dequeue:                 op("Dequeue"),
+		enqueue:                 op("Enqueue"),
+		heartbeat:               op("Heartbeat"),
+		markComplete:            op("MarkComplete"),
+		markErrored:             op("MarkErrored"),
+		markFailed:              op("MarkFailed"),
+		queuedCount:             op("QueuedCount"),
+		requeue:                 op("Requeue"),
+		resetStalled:            op("ResetStalled"),
+		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
+	}
+}
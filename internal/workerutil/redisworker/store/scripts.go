@@ -0,0 +1,231 @@
+package store
+
+import "github.com/gomodule/redigo/redis"
+
+// The scripts below perform each state transition of a record atomically. All
+// keys of a store share a hash tag, so the scripts also work against a Redis
+// cluster.
+//
+// A record is a hash with the following fields:
+//
+//   - payload: the opaque payload passed to Enqueue
+//   - state: one of queued, processing, completed, errored, failed, or canceled
+//   - stream_id: the ID of the stream entry of the record while queued or processing
+//   - queued_at, started_at, finished_at, last_heartbeat_at: Unix milliseconds
+//   - worker_hostname: the consumer that dequeued the record
+//   - failure_message: the message passed to MarkErrored or MarkFailed
+//   - num_resets, num_failures: counters used to bound resets and retries
+//   - cancel: set to 1 when cancellation of a processing record is requested
+//
+// Execution logs are kept in a separate list, and the stream only carries the
+// record ID. The hash is the source of truth: stream entries whose ID doesn't
+// match the stream_id of their record are stale and are dropped when read.
+
+// enqueueScript creates a queued record and adds it to the stream.
+//
+// KEYS: record, stream
+// ARGV: id, payload, now
+var enqueueScript = redis.NewScript(2, `
+redis.call('HSET', KEYS[1], 'payload', ARGV[2], 'state', 'queued', 'queued_at', ARGV[3], 'num_resets', 0, 'num_failures', 0)
+local streamID = redis.call('XADD', KEYS[2], '*', 'id', ARGV[1])
+redis.call('HSET', KEYS[1], 'stream_id', streamID)
+return streamID
+`)
+
+// startScript moves a record delivered to a consumer into the processing state
+// and returns its payload. Stale stream entries are acknowledged and deleted,
+// in which case nil is returned.
+//
+// KEYS: record, stream, logs
+// ARGV: group, stream entry ID, now, worker hostname
+var startScript = redis.NewScript(3, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id', 'payload')
+if fields[1] ~= 'queued' or fields[2] ~= ARGV[2] then
+	redis.call('XACK', KEYS[2], ARGV[1], ARGV[2])
+	redis.call('XDEL', KEYS[2], ARGV[2])
+	return false
+end
+redis.call('HSET', KEYS[1], 'state', 'processing', 'started_at', ARGV[3], 'last_heartbeat_at', ARGV[3], 'worker_hostname', ARGV[4])
+redis.call('HDEL', KEYS[1], 'finished_at', 'failure_message', 'cancel')
+redis.call('DEL', KEYS[3])
+return fields[3]
+`)
+
+// heartbeatScript resets the idle time of the stream entry of a processing
+// record. It returns 1 if the record is processing and 0 otherwise.
+//
+// KEYS: record, stream
+// ARGV: group, now
+var heartbeatScript = redis.NewScript(2, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id', 'worker_hostname')
+if fields[1] ~= 'processing' then
+	return 0
+end
+redis.call('XCLAIM', KEYS[2], ARGV[1], fields[3], 0, fields[2], 'JUSTID')
+redis.call('HSET', KEYS[1], 'last_heartbeat_at', ARGV[2])
+return 1
+`)
+
+// finishScript moves a processing record into a final state. Errored records
+// that have retries left are scheduled to be queued again. It returns 1 if the
+// record was processing and 0 otherwise.
+//
+// KEYS: record, stream, delayed, logs
+// ARGV: group, state, failure message, now, retry at, max number of retries, retention in seconds, id
+var finishScript = redis.NewScript(4, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id', 'cancel')
+if fields[1] ~= 'processing' then
+	return 0
+end
+redis.call('XACK', KEYS[2], ARGV[1], fields[2])
+redis.call('XDEL', KEYS[2], fields[2])
+redis.call('HDEL', KEYS[1], 'stream_id')
+
+local state = ARGV[2]
+if state == 'failed' and fields[3] == '1' then
+	state = 'canceled'
+end
+redis.call('HSET', KEYS[1], 'state', state, 'finished_at', ARGV[4])
+if ARGV[3] ~= '' then
+	redis.call('HSET', KEYS[1], 'failure_message', ARGV[3])
+end
+
+if state ~= 'completed' then
+	local numFailures = redis.call('HINCRBY', KEYS[1], 'num_failures', 1)
+	if state == 'errored' and tonumber(ARGV[5]) > 0 and numFailures < tonumber(ARGV[6]) then
+		redis.call('ZADD', KEYS[3], ARGV[5], ARGV[8])
+		return 1
+	end
+end
+
+redis.call('EXPIRE', KEYS[1], ARGV[7])
+redis.call('EXPIRE', KEYS[4], ARGV[7])
+return 1
+`)
+
+// requeueScript moves a record back into the queued state and schedules it to
+// be added to the stream at the given time. It returns 1 if the record exists
+// and 0 otherwise.
+//
+// KEYS: record, stream, delayed, logs
+// ARGV: group, id, process after
+var requeueScript = redis.NewScript(4, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id')
+if not fields[1] then
+	return 0
+end
+if fields[2] then
+	redis.call('XACK', KEYS[2], ARGV[1], fields[2])
+	redis.call('XDEL', KEYS[2], fields[2])
+	redis.call('HDEL', KEYS[1], 'stream_id')
+end
+redis.call('HSET', KEYS[1], 'state', 'queued')
+redis.call('PERSIST', KEYS[1])
+redis.call('PERSIST', KEYS[4])
+redis.call('ZADD', KEYS[3], ARGV[3], ARGV[2])
+return 1
+`)
+
+// promoteScript adds a record scheduled by finishScript or requeueScript to the
+// stream once it's due. It returns 1 if the record was added and 0 otherwise.
+//
+// KEYS: record, stream, delayed
+// ARGV: id
+var promoteScript = redis.NewScript(3, `
+if redis.call('ZREM', KEYS[3], ARGV[1]) == 0 then
+	return 0
+end
+local state = redis.call('HGET', KEYS[1], 'state')
+if state ~= 'queued' and state ~= 'errored' then
+	return 0
+end
+local streamID = redis.call('XADD', KEYS[2], '*', 'id', ARGV[1])
+redis.call('HSET', KEYS[1], 'state', 'queued', 'stream_id', streamID)
+return 1
+`)
+
+// resetScript handles a stream entry that hasn't been acknowledged or
+// heartbeated within the stalled max age. The record is queued again, or
+// marked as failed once it has been reset too many times. It returns 1 if the
+// record was queued, 2 if it failed, and 0 if the entry was stale.
+//
+// KEYS: record, stream, logs
+// ARGV: group, stream entry ID, now, max number of resets, failure message, retention in seconds, id
+var resetScript = redis.NewScript(3, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id')
+redis.call('XACK', KEYS[2], ARGV[1], ARGV[2])
+redis.call('XDEL', KEYS[2], ARGV[2])
+if (fields[1] ~= 'queued' and fields[1] ~= 'processing') or fields[2] ~= ARGV[2] then
+	return 0
+end
+
+local numResets = redis.call('HINCRBY', KEYS[1], 'num_resets', 1)
+if numResets > tonumber(ARGV[4]) then
+	redis.call('HSET', KEYS[1], 'state', 'failed', 'failure_message', ARGV[5], 'finished_at', ARGV[3])
+	redis.call('HDEL', KEYS[1], 'stream_id')
+	redis.call('EXPIRE', KEYS[1], ARGV[6])
+	redis.call('EXPIRE', KEYS[3], ARGV[6])
+	return 2
+end
+
+local streamID = redis.call('XADD', KEYS[2], '*', 'id', ARGV[7])
+redis.call('HSET', KEYS[1], 'state', 'queued', 'stream_id', streamID)
+return 1
+`)
+
+// cancelScript cancels a record. Queued records and errored records waiting
+// for a retry are canceled immediately, while processing records are flagged so
+// that CanceledJobs reports them to their worker. It returns 1 if the record
+// was canceled or flagged and 0 otherwise.
+//
+// KEYS: record, stream, delayed, logs
+// ARGV: group, id, now, retention in seconds
+var cancelScript = redis.NewScript(4, `
+local fields = redis.call('HMGET', KEYS[1], 'state', 'stream_id')
+if fields[1] == 'processing' then
+	redis.call('HSET', KEYS[1], 'cancel', 1)
+	return 1
+end
+if fields[1] ~= 'queued' and fields[1] ~= 'errored' then
+	return 0
+end
+if fields[2] then
+	redis.call('XACK', KEYS[2], ARGV[1], fields[2])
+	redis.call('XDEL', KEYS[2], fields[2])
+	redis.call('HDEL', KEYS[1], 'stream_id')
+end
+redis.call('ZREM', KEYS[3], ARGV[2])
+redis.call('HSET', KEYS[1], 'state', 'canceled', 'finished_at', ARGV[3])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('EXPIRE', KEYS[4], ARGV[4])
+return 1
+`)
+
+// addExecutionLogEntryScript appends an entry to the execution logs of a record
+// and returns the 1-based ID of the entry, or 0 if the record doesn't exist.
+//
+// KEYS: record, logs
+// ARGV: entry
+var addExecutionLogEntryScript = redis.NewScript(2, `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+return redis.call('RPUSH', KEYS[2], ARGV[1])
+`)
+
+// updateExecutionLogEntryScript replaces an entry of the execution logs of a
+// record. It returns 1 if the entry was updated and 0 otherwise.
+//
+// KEYS: record, logs
+// ARGV: entry ID, entry
+var updateExecutionLogEntryScript = redis.NewScript(2, `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+local entryID = tonumber(ARGV[1])
+if entryID < 1 or entryID > redis.call('LLEN', KEYS[2]) then
+	return 0
+end
+redis.call('LSET', KEYS[2], entryID - 1, ARGV[2])
+return 1
+`)
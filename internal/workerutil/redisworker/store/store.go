@@ -0,0 +1,745 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/gomodule/redigo/redis"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Store is the persistence layer for the redisworker package that handles worker-side operations
+// backed by Redis streams. It is an alternative to the Postgres-backed dbworker store for queues
+// with a high volume of short-lived records, where dequeues and heartbeats would otherwise put a
+// lot of load on the database.
+//
+// Records are opaque payloads supplied by the producer via Enqueue and decoded by the configured
+// Scan function on dequeue. Queued records are entries of a stream read by a single consumer group,
+// so that the stream's pending entries list tracks records being processed and their last heartbeat.
+type Store interface {
+	workerutil.Store
+
+	// Enqueue adds a new record with the given payload to the queue and returns its identifier.
+	Enqueue(ctx context.Context, payload []byte) (int, error)
+
+	// Requeue updates the state of the record with the given identifier to queued and adds a
+	// processing delay before the next dequeue of this record can be performed.
+	Requeue(ctx context.Context, id int, after time.Time) error
+
+	// Cancel cancels the record with the given identifier. Queued records are canceled immediately,
+	// while records being processed are reported by CanceledJobs so that their worker can stop them.
+	// This method returns a boolean flag indicating if the record was found in a cancelable state.
+	Cancel(ctx context.Context, id int) (bool, error)
+
+	// ResetStalled moves all processing records that have not received a heartbeat within
+	// `StalledMaxAge` back to the queued state. Records that have been reset more than `MaxNumResets`
+	// times are marked as failed. This method returns a pair of maps from record identifiers to the
+	// age of the record's last heartbeat for each record reset to queued and failed states, respectively.
+	ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error)
+
+	// State returns the state of the record with the given identifier, and false if no such record
+	// exists. Records in a final state are only retained for `RetentionPeriod`.
+	State(ctx context.Context, id int) (string, bool, error)
+
+	// ExecutionLogs returns the execution log entries of the record with the given identifier.
+	ExecutionLogs(ctx context.Context, id int) ([]workerutil.ExecutionLogEntry, error)
+}
+
+// Options configure the behavior of Store.
+type Options struct {
+	// Name denotes the name of the store used to namespace its keys in Redis and to distinguish
+	// log messages and emitted metrics. The store constructor will fail if this field is not supplied.
+	Name string
+
+	// Scan is the function used to decode the payload of a dequeued record.
+	Scan RecordScanFn
+
+	// StalledMaxAge is the maximum allowed duration between heartbeats of a record being processed.
+	// A record that hasn't received a heartbeat for longer likely indicates that the worker that
+	// dequeued the record has died.
+	StalledMaxAge time.Duration
+
+	// MaxNumResets is the maximum number of times a record can be implicitly reset back to the queued
+	// state (via `ResetStalled`). Records reset more often are marked as failed to prevent an infinite
+	// retry cycle of the same input.
+	MaxNumResets int
+
+	// ResetFailureMessage overrides the default failure message written to records that have been
+	// reset the maximum number of times.
+	ResetFailureMessage string
+
+	// RetryAfter is the delay after which errored records are queued again. Setting this value to zero
+	// will disable retries entirely.
+	RetryAfter time.Duration
+
+	// MaxNumRetries is the maximum number of times a record can be retried after an explicit failure.
+	// Setting this value to zero will disable retries entirely.
+	MaxNumRetries int
+
+	// RetentionPeriod is how long records and their execution logs are kept once they reach a final
+	// state. Defaults to 24 hours.
+	RetentionPeriod time.Duration
+
+	// clock is used to mock out the wall clock used for timestamps.
+	clock glock.Clock
+}
+
+// RecordScanFn is a function that decodes the payload of the record with the given identifier, as
+// passed to Enqueue, into a record.
+type RecordScanFn func(id int, payload []byte) (workerutil.Record, error)
+
+const (
+	consumerGroup = "workers"
+
+	defaultResetFailureMessage = "job processor died while handling this message too many times"
+	defaultRetentionPeriod     = 24 * time.Hour
+
+	// batchSize bounds the number of delayed records promoted per dequeue and the number of
+	// pending entries inspected per request while resetting stalled records.
+	batchSize = 100
+)
+
+type store struct {
+	pool       *redis.Pool
+	options    Options
+	prefix     string
+	operations *operations
+}
+
+var _ Store = &store{}
+
+// New creates a new store with the given Redis pool and options.
+func New(pool *redis.Pool, options Options) Store {
+	return NewWithMetrics(pool, options, &observation.TestContext)
+}
+
+func NewWithMetrics(pool *redis.Pool, options Options, observationContext *observation.Context) Store {
+	return newStore(pool, options, observationContext)
+}
+
+func newStore(pool *redis.Pool, options Options, observationContext *observation.Context) *store {
+	if options.Name == "" {
+		panic("no name supplied to github.com/sourcegraph/sourcegraph/internal/workerutil/redisworker/store:newStore")
+	}
+
+	if options.ResetFailureMessage == "" {
+		options.ResetFailureMessage = defaultResetFailureMessage
+	}
+	if options.RetentionPeriod == 0 {
+		options.RetentionPeriod = defaultRetentionPeriod
+	}
+	if options.clock == nil {
+		options.clock = glock.NewRealClock()
+	}
+
+	return &store{
+		pool:    pool,
+		options: options,
+		// The braces form a hash tag, so all keys of a store are assigned to the
+		// same slot when running against a Redis cluster.
+		prefix:     fmt.Sprintf("workerutil:{%s}", options.Name),
+		operations: newOperations(options.Name, observationContext),
+	}
+}
+
+func (s *store) streamKey() string                            { return s.prefix + ":queue" }
+func (s *store) delayedKey() string                           { return s.prefix + ":delayed" }
+func (s *store) sequenceKey() string                          { return s.prefix + ":sequence" }
+func (s *store) recordKey(id int) string                      { return s.prefix + ":record:" + strconv.Itoa(id) }
+func (s *store) logsKey(id int) string                        { return s.prefix + ":logs:" + strconv.Itoa(id) }
+func (s *store) now() int64                                   { return s.options.clock.Now().UnixMilli() }
+func (s *store) retentionSeconds() int64                      { return int64(s.options.RetentionPeriod / time.Second) }
+func (s *store) conn(ctx context.Context) (redis.Conn, error) { return s.pool.GetContext(ctx) }
+
+// Enqueue adds a new record with the given payload to the queue.
+func (s *store) Enqueue(ctx context.Context, payload []byte) (id int, err error) {
+	ctx, _, endObservation := s.operations.enqueue.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	id, err = redis.Int(conn.Do("INCR", s.sequenceKey()))
+	if err != nil {
+		return 0, err
+	}
+	if _, err := enqueueScript.Do(conn, s.recordKey(id), s.streamKey(), id, payload, s.now()); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// QueuedCount returns the number of queued records, including errored records waiting for a retry.
+func (s *store) QueuedCount(ctx context.Context) (_ int, err error) {
+	ctx, _, endObservation := s.operations.queuedCount.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	streamLength, err := redis.Int(conn.Do("XLEN", s.streamKey()))
+	if err != nil {
+		return 0, err
+	}
+	numPending, err := s.pendingCount(conn)
+	if err != nil {
+		return 0, err
+	}
+	numDelayed, err := redis.Int(conn.Do("ZCARD", s.delayedKey()))
+	if err != nil {
+		return 0, err
+	}
+
+	// Entries are deleted from the stream once their record leaves the processing
+	// state, so all entries not pending on a consumer are queued.
+	return streamLength - numPending + numDelayed, nil
+}
+
+// pendingCount returns the number of stream entries delivered to a consumer but not yet acknowledged.
+func (s *store) pendingCount(conn redis.Conn) (int, error) {
+	reply, err := redis.Values(conn.Do("XPENDING", s.streamKey(), consumerGroup))
+	if err != nil {
+		if isNoGroupError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(reply) == 0 {
+		return 0, nil
+	}
+	return redis.Int(reply[0], nil)
+}
+
+// Dequeue selects the next queued record and updates its state to processing. The worker hostname
+// is used as the name of the consumer reading from the stream.
+func (s *store) Dequeue(ctx context.Context, workerHostname string, extraArguments any) (_ workerutil.Record, _ bool, err error) {
+	ctx, trace, endObservation := s.operations.dequeue.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if extraArguments != nil {
+		return nil, false, ErrExtraArguments
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer conn.Close()
+
+	if err := s.promoteDue(conn); err != nil {
+		return nil, false, err
+	}
+
+	for {
+		entryID, id, ok, err := s.readEntry(conn, workerHostname)
+		if err != nil || !ok {
+			return nil, false, err
+		}
+
+		payload, err := redis.Bytes(startScript.Do(conn, s.recordKey(id), s.streamKey(), s.logsKey(id), consumerGroup, entryID, s.now(), workerHostname))
+		if err != nil {
+			if err == redis.ErrNil {
+				// The entry was stale, e.g. because the record was canceled
+				// while queued. Try the next one.
+				continue
+			}
+			return nil, false, err
+		}
+
+		record, err := s.options.Scan(id, payload)
+		if err != nil {
+			return nil, false, err
+		}
+		trace.Log(otlog.Int("recordID", id))
+
+		return record, true, nil
+	}
+}
+
+// promoteDue adds records scheduled for a retry or requeued with a delay to the stream once they're due.
+func (s *store) promoteDue(conn redis.Conn) error {
+	ids, err := redis.Ints(conn.Do("ZRANGEBYSCORE", s.delayedKey(), "-inf", s.now(), "LIMIT", 0, batchSize))
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := promoteScript.Do(conn, s.recordKey(id), s.streamKey(), s.delayedKey(), id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEntry reads the next stream entry not yet delivered to any consumer and returns its entry ID and the
+// identifier of its record. It returns false if there is no such entry.
+func (s *store) readEntry(conn redis.Conn, consumer string) (entryID string, id int, ok bool, err error) {
+	read := func() ([]any, error) {
+		return redis.Values(conn.Do("XREADGROUP", "GROUP", consumerGroup, consumer, "COUNT", 1, "STREAMS", s.streamKey(), ">"))
+	}
+
+	reply, err := read()
+	if err != nil && isNoGroupError(err) {
+		if _, err := conn.Do("XGROUP", "CREATE", s.streamKey(), consumerGroup, "0", "MKSTREAM"); err != nil && !isBusyGroupError(err) {
+			return "", 0, false, err
+		}
+		reply, err = read()
+	}
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", 0, false, nil
+		}
+		return "", 0, false, err
+	}
+
+	// The reply has the shape [[stream, [[entry ID, [field, value, ...]], ...]]]
+	if len(reply) == 0 {
+		return "", 0, false, nil
+	}
+	stream, err := redis.Values(reply[0], nil)
+	if err != nil || len(stream) != 2 {
+		return "", 0, false, errors.Newf("unexpected XREADGROUP reply: %v", reply)
+	}
+	entries, err := redis.Values(stream[1], nil)
+	if err != nil {
+		return "", 0, false, err
+	}
+	if len(entries) == 0 {
+		return "", 0, false, nil
+	}
+	entryID, id, err = parseEntry(entries[0])
+	if err != nil {
+		return "", 0, false, err
+	}
+
+	return entryID, id, true, nil
+}
+
+// parseEntry parses a stream entry of the shape [entry ID, [field, value, ...]].
+func parseEntry(reply any) (entryID string, id int, err error) {
+	entry, err := redis.Values(reply, nil)
+	if err != nil || len(entry) != 2 {
+		return "", 0, errors.Newf("unexpected stream entry: %v", reply)
+	}
+	entryID, err = redis.String(entry[0], nil)
+	if err != nil {
+		return "", 0, err
+	}
+	fields, err := redis.StringMap(entry[1], nil)
+	if err != nil {
+		return "", 0, err
+	}
+	id, err = strconv.Atoi(fields["id"])
+	if err != nil {
+		return "", 0, errors.Wrapf(err, "invalid record ID in stream entry %s", entryID)
+	}
+
+	return entryID, id, nil
+}
+
+// Heartbeat marks the given records as currently being processed.
+func (s *store) Heartbeat(ctx context.Context, ids []int) (knownIDs []int, err error) {
+	ctx, _, endObservation := s.operations.heartbeat.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	now := s.now()
+	for _, id := range ids {
+		known, err := redis.Bool(heartbeatScript.Do(conn, s.recordKey(id), s.streamKey(), consumerGroup, now))
+		if err != nil {
+			return nil, err
+		}
+		if known {
+			knownIDs = append(knownIDs, id)
+		}
+	}
+
+	return knownIDs, nil
+}
+
+// CanceledJobs returns the identifiers of the given records that are being processed and
+// for which cancellation has been requested.
+func (s *store) CanceledJobs(ctx context.Context, knownIDs []int) (canceledIDs []int, err error) {
+	ctx, _, endObservation := s.operations.canceledJobs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if len(knownIDs) == 0 {
+		return nil, nil
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	for _, id := range knownIDs {
+		if err := conn.Send("HMGET", s.recordKey(id), "state", "cancel"); err != nil {
+			return nil, err
+		}
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, err
+	}
+	for _, id := range knownIDs {
+		fields, err := redis.Strings(conn.Receive())
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 2 && fields[0] == "processing" && fields[1] == "1" {
+			canceledIDs = append(canceledIDs, id)
+		}
+	}
+
+	return canceledIDs, nil
+}
+
+// Cancel cancels the record with the given identifier.
+func (s *store) Cancel(ctx context.Context, id int) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.cancel.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	return redis.Bool(cancelScript.Do(conn, s.recordKey(id), s.streamKey(), s.delayedKey(), s.logsKey(id), consumerGroup, id, s.now(), s.retentionSeconds()))
+}
+
+// Requeue updates the state of the record with the given identifier to queued and delays its
+// next dequeue until the given time.
+func (s *store) Requeue(ctx context.Context, id int, after time.Time) (err error) {
+	ctx, _, endObservation := s.operations.requeue.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = requeueScript.Do(conn, s.recordKey(id), s.streamKey(), s.delayedKey(), s.logsKey(id), consumerGroup, id, after.UnixMilli())
+	return err
+}
+
+// AddExecutionLogEntry adds an executor log entry to the record and returns the ID of the new entry.
+func (s *store) AddExecutionLogEntry(ctx context.Context, id int, entry workerutil.ExecutionLogEntry) (entryID int, err error) {
+	ctx, _, endObservation := s.operations.addExecutionLogEntry.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	entryID, err = redis.Int(addExecutionLogEntryScript.Do(conn, s.recordKey(id), s.logsKey(id), payload))
+	if err != nil {
+		return 0, err
+	}
+	if entryID == 0 {
+		return 0, ErrExecutionLogEntryNotUpdated
+	}
+
+	return entryID, nil
+}
+
+// UpdateExecutionLogEntry updates the executor log entry with the given ID on the given record.
+func (s *store) UpdateExecutionLogEntry(ctx context.Context, recordID, entryID int, entry workerutil.ExecutionLogEntry) (err error) {
+	ctx, _, endObservation := s.operations.updateExecutionLogEntry.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	updated, err := redis.Bool(updateExecutionLogEntryScript.Do(conn, s.recordKey(recordID), s.logsKey(recordID), entryID, payload))
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrExecutionLogEntryNotUpdated
+	}
+
+	return nil
+}
+
+// ExecutionLogs returns the execution log entries of the record with the given identifier.
+func (s *store) ExecutionLogs(ctx context.Context, id int) ([]workerutil.ExecutionLogEntry, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	payloads, err := redis.ByteSlices(conn.Do("LRANGE", s.logsKey(id), 0, -1))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]workerutil.ExecutionLogEntry, 0, len(payloads))
+	for _, payload := range payloads {
+		var entry workerutil.ExecutionLogEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// State returns the state of the record with the given identifier.
+func (s *store) State(ctx context.Context, id int) (string, bool, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	defer conn.Close()
+
+	state, err := redis.String(conn.Do("HGET", s.recordKey(id), "state"))
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	return state, true, nil
+}
+
+// MarkComplete attempts to update the state of the record to complete.
+func (s *store) MarkComplete(ctx context.Context, id int) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.markComplete.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.finish(ctx, id, "completed", "")
+}
+
+// MarkErrored attempts to update the state of the record to errored. The record is queued again
+// after `RetryAfter` unless it has been retried `MaxNumRetries` times.
+func (s *store) MarkErrored(ctx context.Context, id int, failureMessage string) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.markErrored.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.finish(ctx, id, "errored", failureMessage)
+}
+
+// MarkFailed attempts to update the state of the record to failed, or to canceled if cancellation
+// of the record was requested.
+func (s *store) MarkFailed(ctx context.Context, id int, failureMessage string) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.markFailed.With(ctx, &err, observation.Args{LogFields: []otlog.Field{
+		otlog.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.finish(ctx, id, "failed", failureMessage)
+}
+
+// finish moves a processing record into the given final state. This method returns a boolean flag
+// indicating if the record was updated.
+func (s *store) finish(ctx context.Context, id int, state, failureMessage string) (bool, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	now := s.now()
+	var retryAt int64
+	if state == "errored" && s.options.RetryAfter > 0 {
+		retryAt = now + s.options.RetryAfter.Milliseconds()
+	}
+
+	return redis.Bool(finishScript.Do(
+		conn,
+		s.recordKey(id),
+		s.streamKey(),
+		s.delayedKey(),
+		s.logsKey(id),
+		consumerGroup,
+		state,
+		failureMessage,
+		now,
+		retryAt,
+		s.options.MaxNumRetries,
+		s.retentionSeconds(),
+		id,
+	))
+}
+
+// ResetStalled moves all processing records that have not received a heartbeat within `StalledMaxAge`
+// back to the queued state, or to the failed state once they've been reset `MaxNumResets` times.
+func (s *store) ResetStalled(ctx context.Context) (resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs map[int]time.Duration, err error) {
+	ctx, _, endObservation := s.operations.resetStalled.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+
+	resetLastHeartbeatsByIDs = map[int]time.Duration{}
+	failedLastHeartbeatsByIDs = map[int]time.Duration{}
+
+	for start := "-"; ; {
+		// Each pending entry has the shape [entry ID, consumer, idle milliseconds, delivery count]
+		pending, err := redis.Values(conn.Do("XPENDING", s.streamKey(), consumerGroup, start, "+", batchSize))
+		if err != nil {
+			if isNoGroupError(err) {
+				break
+			}
+			return nil, nil, err
+		}
+
+		for _, reply := range pending {
+			entry, err := redis.Values(reply, nil)
+			if err != nil || len(entry) != 4 {
+				return nil, nil, errors.Newf("unexpected XPENDING entry: %v", reply)
+			}
+			entryID, err := redis.String(entry[0], nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			idle, err := redis.Int64(entry[2], nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			start, err = nextEntryID(entryID)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			lastHeartbeatAge := time.Duration(idle) * time.Millisecond
+			if lastHeartbeatAge < s.options.StalledMaxAge {
+				continue
+			}
+
+			id, ok, err := s.entryRecordID(conn, entryID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !ok {
+				// The entry was deleted, so its record has already moved on
+				if _, err := conn.Do("XACK", s.streamKey(), consumerGroup, entryID); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+
+			result, err := redis.Int(resetScript.Do(
+				conn,
+				s.recordKey(id),
+				s.streamKey(),
+				s.logsKey(id),
+				consumerGroup,
+				entryID,
+				s.now(),
+				s.options.MaxNumResets,
+				s.options.ResetFailureMessage,
+				s.retentionSeconds(),
+				id,
+			))
+			if err != nil {
+				return nil, nil, err
+			}
+			switch result {
+			case 1:
+				resetLastHeartbeatsByIDs[id] = lastHeartbeatAge
+			case 2:
+				failedLastHeartbeatsByIDs[id] = lastHeartbeatAge
+			}
+		}
+
+		if len(pending) < batchSize {
+			break
+		}
+	}
+
+	return resetLastHeartbeatsByIDs, failedLastHeartbeatsByIDs, nil
+}
+
+// entryRecordID returns the identifier of the record of the given stream entry, and false if
+// the entry no longer exists.
+func (s *store) entryRecordID(conn redis.Conn, entryID string) (int, bool, error) {
+	entries, err := redis.Values(conn.Do("XRANGE", s.streamKey(), entryID, entryID, "COUNT", 1))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(entries) == 0 {
+		return 0, false, nil
+	}
+	_, id, err := parseEntry(entries[0])
+	if err != nil {
+		return 0, false, err
+	}
+	return id, true, nil
+}
+
+// nextEntryID returns the smallest stream entry ID greater than the given one, which is used as
+// an inclusive range start to page through pending entries.
+func nextEntryID(entryID string) (string, error) {
+	ms, seq, ok := strings.Cut(entryID, "-")
+	if !ok {
+		return "", errors.Newf("invalid stream entry ID %q", entryID)
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid stream entry ID %q", entryID)
+	}
+	return ms + "-" + strconv.FormatUint(n+1, 10), nil
+}
+
+func isNoGroupError(err error) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "NOGROUP")
+}
+
+func isBusyGroupError(err error) bool {
+	var redisErr redis.Error
+	return errors.As(err, &redisErr) && strings.HasPrefix(string(redisErr), "BUSYGROUP")
+}
@@ -0,0 +1,327 @@
+package store
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/derision-test/glock"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type testRecord struct {
+	id      int
+	payload string
+}
+
+func (r testRecord) RecordID() int { return r.id }
+
+func scanTestRecord(id int, payload []byte) (workerutil.Record, error) {
+	return testRecord{id: id, payload: string(payload)}, nil
+}
+
+// setupForTest returns a store with the given options connected to a local Redis
+// server, using keys namespaced to the current test. Outside of CI, the test is
+// skipped if Redis is not reachable.
+func setupForTest(t *testing.T, options Options) (*store, *glock.MockClock) {
+	t.Helper()
+
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	conn := pool.Get()
+	defer conn.Close()
+
+	if _, err := conn.Do("PING"); err != nil {
+		if os.Getenv("CI") == "" {
+			t.Skip("could not connect to redis", err)
+		}
+		t.Fatal(err)
+	}
+
+	options.Name = "__test__" + strings.ReplaceAll(t.Name(), "/", "_")
+	options.Scan = scanTestRecord
+	clock := glock.NewMockClockAt(time.Now())
+	options.clock = clock
+
+	s := newStore(pool, options, &observation.TestContext)
+	keys, err := redis.Strings(conn.Do("KEYS", s.prefix+":*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if _, err := conn.Do("DEL", key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s, clock
+}
+
+func TestStoreDequeue(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupForTest(t, Options{})
+
+	for _, payload := range []string{"foo", "bar"} {
+		if _, err := s.Enqueue(ctx, []byte(payload)); err != nil {
+			t.Fatalf("unexpected error enqueueing record: %s", err)
+		}
+	}
+	assertQueuedCount(t, s, 2)
+
+	record, ok, err := s.Dequeue(ctx, "worker1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected a record to be dequeued")
+	}
+	if diff := cmp.Diff(testRecord{id: 1, payload: "foo"}, record, cmp.AllowUnexported(testRecord{})); diff != "" {
+		t.Errorf("unexpected record (-want +got):\n%s", diff)
+	}
+	assertQueuedCount(t, s, 1)
+	assertState(t, s, 1, "processing")
+
+	knownIDs, err := s.Heartbeat(ctx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error sending heartbeat: %s", err)
+	}
+	if diff := cmp.Diff([]int{1}, knownIDs); diff != "" {
+		t.Errorf("unexpected known IDs (-want +got):\n%s", diff)
+	}
+
+	if ok, err := s.MarkComplete(ctx, 1); err != nil || !ok {
+		t.Fatalf("expected record to be marked as complete: ok=%v err=%v", ok, err)
+	}
+	if ok, err := s.MarkComplete(ctx, 1); err != nil || ok {
+		t.Fatalf("expected record to be marked as complete only once: ok=%v err=%v", ok, err)
+	}
+	assertState(t, s, 1, "completed")
+
+	if _, _, err := s.Dequeue(ctx, "worker1", struct{}{}); err != ErrExtraArguments {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrExtraArguments, err)
+	}
+}
+
+func TestStoreMarkErroredRetries(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setupForTest(t, Options{RetryAfter: time.Minute, MaxNumRetries: 2})
+
+	id, err := s.Enqueue(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		assertDequeued(t, s, id)
+		if ok, err := s.MarkErrored(ctx, id, "oops"); err != nil || !ok {
+			t.Fatalf("expected record to be marked as errored: ok=%v err=%v", ok, err)
+		}
+		assertState(t, s, id, "errored")
+		assertNotDequeued(t, s)
+
+		clock.Advance(time.Minute)
+	}
+
+	// The retry limit is reached, so the record is not queued again.
+	assertNotDequeued(t, s)
+	assertQueuedCount(t, s, 0)
+}
+
+func TestStoreRequeue(t *testing.T) {
+	ctx := context.Background()
+	s, clock := setupForTest(t, Options{})
+
+	id, err := s.Enqueue(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+	assertDequeued(t, s, id)
+
+	if err := s.Requeue(ctx, id, clock.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error requeueing record: %s", err)
+	}
+	assertState(t, s, id, "queued")
+	assertQueuedCount(t, s, 1)
+	assertNotDequeued(t, s)
+
+	clock.Advance(time.Minute)
+	assertDequeued(t, s, id)
+}
+
+func TestStoreCancel(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupForTest(t, Options{})
+
+	queuedID, err := s.Enqueue(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+	if ok, err := s.Cancel(ctx, queuedID); err != nil || !ok {
+		t.Fatalf("expected record to be canceled: ok=%v err=%v", ok, err)
+	}
+	assertState(t, s, queuedID, "canceled")
+	assertNotDequeued(t, s)
+
+	processingID, err := s.Enqueue(ctx, []byte("bar"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+	assertDequeued(t, s, processingID)
+
+	if ok, err := s.Cancel(ctx, processingID); err != nil || !ok {
+		t.Fatalf("expected record to be canceled: ok=%v err=%v", ok, err)
+	}
+	canceledIDs, err := s.CanceledJobs(ctx, []int{processingID})
+	if err != nil {
+		t.Fatalf("unexpected error listing canceled jobs: %s", err)
+	}
+	if diff := cmp.Diff([]int{processingID}, canceledIDs); diff != "" {
+		t.Errorf("unexpected canceled IDs (-want +got):\n%s", diff)
+	}
+
+	if ok, err := s.MarkFailed(ctx, processingID, "canceled"); err != nil || !ok {
+		t.Fatalf("expected record to be marked as failed: ok=%v err=%v", ok, err)
+	}
+	assertState(t, s, processingID, "canceled")
+
+	if ok, err := s.Cancel(ctx, processingID); err != nil || ok {
+		t.Fatalf("expected finished record not to be canceled: ok=%v err=%v", ok, err)
+	}
+}
+
+func TestStoreResetStalled(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupForTest(t, Options{StalledMaxAge: time.Millisecond, MaxNumResets: 1})
+
+	id, err := s.Enqueue(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+
+	for _, expectedState := range []string{"queued", "failed"} {
+		assertDequeued(t, s, id)
+		// Stream idle times are measured by the Redis server clock
+		time.Sleep(10 * time.Millisecond)
+
+		resetIDs, failedIDs, err := s.ResetStalled(ctx)
+		if err != nil {
+			t.Fatalf("unexpected error resetting stalled records: %s", err)
+		}
+		ids := resetIDs
+		if expectedState == "failed" {
+			ids = failedIDs
+		}
+		if _, ok := ids[id]; !ok || len(resetIDs)+len(failedIDs) != 1 {
+			t.Fatalf("unexpected reset records. reset=%v failed=%v", resetIDs, failedIDs)
+		}
+		assertState(t, s, id, expectedState)
+
+		if expectedState == "failed" {
+			assertNotDequeued(t, s)
+		}
+	}
+}
+
+func TestStoreExecutionLogs(t *testing.T) {
+	ctx := context.Background()
+	s, _ := setupForTest(t, Options{})
+
+	if _, err := s.AddExecutionLogEntry(ctx, 42, workerutil.ExecutionLogEntry{Key: "missing"}); err != ErrExecutionLogEntryNotUpdated {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrExecutionLogEntryNotUpdated, err)
+	}
+
+	id, err := s.Enqueue(ctx, []byte("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error enqueueing record: %s", err)
+	}
+	assertDequeued(t, s, id)
+
+	entryID, err := s.AddExecutionLogEntry(ctx, id, workerutil.ExecutionLogEntry{Key: "step", Command: []string{"ls"}})
+	if err != nil {
+		t.Fatalf("unexpected error adding execution log entry: %s", err)
+	}
+	if entryID != 1 {
+		t.Fatalf("unexpected entry ID. want=%d have=%d", 1, entryID)
+	}
+
+	exitCode := 0
+	updated := workerutil.ExecutionLogEntry{Key: "step", Command: []string{"ls"}, Out: "stdout: a\n", ExitCode: &exitCode}
+	if err := s.UpdateExecutionLogEntry(ctx, id, entryID, updated); err != nil {
+		t.Fatalf("unexpected error updating execution log entry: %s", err)
+	}
+	if err := s.UpdateExecutionLogEntry(ctx, id, 2, updated); err != ErrExecutionLogEntryNotUpdated {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrExecutionLogEntryNotUpdated, err)
+	}
+
+	entries, err := s.ExecutionLogs(ctx, id)
+	if err != nil {
+		t.Fatalf("unexpected error listing execution logs: %s", err)
+	}
+	if diff := cmp.Diff([]workerutil.ExecutionLogEntry{updated}, entries); diff != "" {
+		t.Errorf("unexpected execution logs (-want +got):\n%s", diff)
+	}
+}
+
+func assertDequeued(t *testing.T, s *store, expectedID int) {
+	t.Helper()
+
+	record, ok, err := s.Dequeue(context.Background(), "worker1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if !ok {
+		t.Fatalf("expected a record to be dequeued")
+	}
+	if record.RecordID() != expectedID {
+		t.Fatalf("unexpected record ID. want=%d have=%d", expectedID, record.RecordID())
+	}
+}
+
+func assertNotDequeued(t *testing.T, s *store) {
+	t.Helper()
+
+	record, ok, err := s.Dequeue(context.Background(), "worker1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error dequeueing record: %s", err)
+	}
+	if ok {
+		t.Fatalf("unexpected record dequeued: %v", record)
+	}
+}
+
+func assertState(t *testing.T, s *store, id int, expectedState string) {
+	t.Helper()
+
+	state, ok, err := s.State(context.Background(), id)
+	if err != nil {
+		t.Fatalf("unexpected error getting state: %s", err)
+	}
+	if !ok || state != expectedState {
+		t.Fatalf("unexpected state. want=%q have=%q (exists=%v)", expectedState, state, ok)
+	}
+}
+
+func assertQueuedCount(t *testing.T, s *store, expected int) {
+	t.Helper()
+
+	count, err := s.QueuedCount(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting queued count: %s", err)
+	}
+	if count != expected {
+		t.Fatalf("unexpected queued count. want=%d have=%d", expected, count)
+	}
+}
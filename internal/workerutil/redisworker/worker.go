@@ -0,0 +1,21 @@
+package redisworker
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/redisworker/store"
+)
+
+func NewWorker(ctx context.Context, store store.Store, handler workerutil.Handler, options workerutil.WorkerOptions) *workerutil.Worker {
+	return workerutil.NewWorker(ctx, store, handler, options)
+}
+
+// NewResetter returns a resetter that periodically moves records of the given store that
+// stopped receiving heartbeats back to the queued state.
+func NewResetter(logger log.Logger, store store.Store, options dbworker.ResetterOptions) *dbworker.Resetter {
+	return dbworker.NewResetter(logger, store, options)
+}
@@ -37,6 +37,7 @@ type BatchSpec struct {
 	TransformChanges  *TransformChanges        `json:"transformChanges,omitempty" yaml:"transformChanges,omitempty"`
 	ImportChangesets  []ImportChangeset        `json:"importChangesets,omitempty" yaml:"importChangesets"`
 	ChangesetTemplate *ChangesetTemplate       `json:"changesetTemplate,omitempty" yaml:"changesetTemplate"`
+	Dependencies      []WorkspaceDependency    `json:"dependencies,omitempty" yaml:"dependencies"`
 }
 
 type ChangesetTemplate struct {
@@ -69,14 +70,20 @@ type WorkspaceConfiguration struct {
 }
 
 type OnQueryOrRepository struct {
-	RepositoriesMatchingQuery string   `json:"repositoriesMatchingQuery,omitempty" yaml:"repositoriesMatchingQuery"`
-	Repository                string   `json:"repository,omitempty" yaml:"repository"`
-	Branch                    string   `json:"branch,omitempty" yaml:"branch"`
-	Branches                  []string `json:"branches,omitempty" yaml:"branches"`
+	RepositoriesMatchingQuery   string   `json:"repositoriesMatchingQuery,omitempty" yaml:"repositoriesMatchingQuery"`
+	RepositoriesFromInsight     string   `json:"repositoriesFromInsight,omitempty" yaml:"repositoriesFromInsight"`
+	RepositoriesFromSavedSearch string   `json:"repositoriesFromSavedSearch,omitempty" yaml:"repositoriesFromSavedSearch"`
+	RepositoriesFromCSV         string   `json:"repositoriesFromCSV,omitempty" yaml:"repositoriesFromCSV"`
+	Repository                  string   `json:"repository,omitempty" yaml:"repository"`
+	Branch                      string   `json:"branch,omitempty" yaml:"branch"`
+	Branches                    []string `json:"branches,omitempty" yaml:"branches"`
+	Revision                    string   `json:"revision,omitempty" yaml:"revision"`
 }
 
 var ErrConflictingBranches = NewValidationError(errors.New("both branch and branches specified"))
 
+var ErrConflictingRevision = NewValidationError(errors.New("revision cannot be combined with branches"))
+
 func (oqor *OnQueryOrRepository) GetBranches() ([]string, error) {
 	if oqor.Branch != "" {
 		if len(oqor.Branches) > 0 {
@@ -176,6 +183,16 @@ func parseBatchSpec(schema string, data []byte) (*BatchSpec, error) {
 		}
 	}
 
+	for _, on := range spec.On {
+		if err := on.validate(); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+
+	if err := validateDependencies(spec.Dependencies); err != nil {
+		errs = errors.Append(errs, err)
+	}
+
 	return &spec, errs
 }
 
@@ -184,6 +201,12 @@ const invalidMountCharacters = ","
 func (on *OnQueryOrRepository) String() string {
 	if on.RepositoriesMatchingQuery != "" {
 		return on.RepositoriesMatchingQuery
+	} else if on.RepositoriesFromInsight != "" {
+		return "repositoriesFromInsight:" + on.RepositoriesFromInsight
+	} else if on.RepositoriesFromSavedSearch != "" {
+		return "repositoriesFromSavedSearch:" + on.RepositoriesFromSavedSearch
+	} else if on.RepositoriesFromCSV != "" {
+		return "repositoriesFromCSV"
 	} else if on.Repository != "" {
 		return "repository:" + on.Repository
 	}
@@ -205,7 +228,8 @@ func (e BatchSpecValidationError) Error() string {
 }
 
 func IsValidationError(err error) bool {
-	return errors.HasType(err, &BatchSpecValidationError{})
+	var e BatchSpecValidationError
+	return errors.As(err, &e)
 }
 
 // SkippedStepsForRepo calculates the steps required to run on the given repo.
@@ -210,6 +210,89 @@ changesetTemplate:
 		_, err := ParseBatchSpec([]byte(spec))
 		assert.Equal(t, "step 1 mount mountpoint contains invalid characters", err.Error())
 	})
+
+	t.Run("invalid dependency pattern", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+dependencies:
+  - repository: github.com/sourcegraph/*
+    dependsOn:
+      - github.com/sourcegraph/[lib
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), `invalid repository pattern "github.com/sourcegraph/[lib" in dependencies`)
+	})
+
+	t.Run("revision and branches", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repository: github.com/sourcegraph/sourcegraph
+    branches: [main, release]
+    revision: 4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), "revision cannot be combined with branches")
+	})
+
+	t.Run("invalid repositoriesFromCSV", func(t *testing.T) {
+		const spec = `
+name: test-spec
+description: A test spec
+on:
+  - repositoriesFromCSV: |
+      github.com/sourcegraph/sourcegraph,main,4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1,extra
+`
+		_, err := ParseBatchSpec([]byte(spec))
+		if err == nil {
+			t.Fatal("no error returned")
+		}
+		assert.True(t, IsValidationError(err))
+		assert.Contains(t, err.Error(), "line 1 has more than 3 columns")
+	})
+}
+
+func TestOnQueryOrRepository_CSVRepositories(t *testing.T) {
+	on := OnQueryOrRepository{RepositoriesFromCSV: `repository,branch,revision
+github.com/sourcegraph/sourcegraph,main,4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1
+# Use the default branch of src-cli.
+github.com/sourcegraph/src-cli
+
+github.com/sourcegraph/go-diff, release
+`}
+
+	have, err := on.CSVRepositories()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []OnQueryOrRepository{
+		{Repository: "github.com/sourcegraph/sourcegraph", Branch: "main", Revision: "4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1"},
+		{Repository: "github.com/sourcegraph/src-cli"},
+		{Repository: "github.com/sourcegraph/go-diff", Branch: "release"},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Errorf("unexpected repositories (-want +have):\n%s", diff)
+	}
 }
 
 func TestOnQueryOrRepository_Branches(t *testing.T) {
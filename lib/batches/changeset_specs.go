@@ -32,6 +32,7 @@ type ChangesetSpecInput struct {
 	BatchChangeAttributes *template.BatchChangeAttributes `json:"-"`
 	Template              *ChangesetTemplate              `json:"-"`
 	TransformChanges      *TransformChanges               `json:"-"`
+	Dependencies          []WorkspaceDependency           `json:"-"`
 	Path                  string
 
 	Result execution.AfterStepResult
@@ -94,6 +95,11 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 		return nil, err
 	}
 
+	dependsOn, err := DependenciesForRepository(input.Dependencies, input.Repository.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	newSpec := func(branch, diff string) (*ChangesetSpec, error) {
 		var published any = nil
 		if input.Template.Published != nil {
@@ -118,6 +124,7 @@ func BuildChangesetSpecs(input *ChangesetSpecInput) ([]*ChangesetSpec, error) {
 				},
 			},
 			Published: PublishedValue{Val: published},
+			DependsOn: dependsOn,
 		}, nil
 	}
 
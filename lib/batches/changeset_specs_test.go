@@ -144,6 +144,23 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			name: "dependencies",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Dependencies = []WorkspaceDependency{
+					{Repository: "github.com/sourcegraph/*", DependsOn: []string{"github.com/sourcegraph/go-lib"}},
+					{Repository: "github.com/sourcegraph/src-*", DependsOn: []string{"github.com/sourcegraph/go-lib", "github.com/sourcegraph/batches-*"}},
+					{Repository: "github.com/other/*", DependsOn: []string{"github.com/other/lib"}},
+				}
+			}),
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.DependsOn = []string{"github.com/sourcegraph/go-lib", "github.com/sourcegraph/batches-*"}
+				}),
+			},
+			wantErr: "",
+		},
 	}
 
 	for _, tt := range tests {
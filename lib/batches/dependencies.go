@@ -0,0 +1,70 @@
+package batches
+
+import (
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// WorkspaceDependency declares that the changesets created in the
+// repositories matching Repository should only be published or undrafted
+// once the changesets in the repositories matching DependsOn have been
+// merged.
+//
+// Both Repository and the entries in DependsOn are glob patterns matched
+// against repository names, such as "github.com/sourcegraph/*".
+type WorkspaceDependency struct {
+	Repository string   `json:"repository,omitempty" yaml:"repository"`
+	DependsOn  []string `json:"dependsOn,omitempty" yaml:"dependsOn"`
+}
+
+// DependenciesForRepository returns the patterns of the repositories whose
+// changesets have to be merged before the changeset in the repository with
+// the given name can be published.
+func DependenciesForRepository(deps []WorkspaceDependency, repoName string) ([]string, error) {
+	var dependsOn []string
+	seen := map[string]struct{}{}
+
+	for _, dep := range deps {
+		ok, err := MatchRepositoryPattern(dep.Repository, repoName)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		for _, pattern := range dep.DependsOn {
+			if _, ok := seen[pattern]; ok {
+				continue
+			}
+			seen[pattern] = struct{}{}
+			dependsOn = append(dependsOn, pattern)
+		}
+	}
+
+	return dependsOn, nil
+}
+
+// MatchRepositoryPattern returns whether the repository with the given name
+// matches the given glob pattern.
+func MatchRepositoryPattern(pattern, repoName string) (bool, error) {
+	compiled, err := glob.Compile(pattern)
+	if err != nil {
+		return false, NewValidationError(errors.Wrapf(err, "invalid repository pattern %q", pattern))
+	}
+	return compiled.Match(repoName), nil
+}
+
+// validateDependencies returns an error if any of the repository patterns in
+// the given dependencies is not a valid glob pattern.
+func validateDependencies(deps []WorkspaceDependency) (errs error) {
+	for _, dep := range deps {
+		for _, pattern := range append([]string{dep.Repository}, dep.DependsOn...) {
+			if _, err := glob.Compile(pattern); err != nil {
+				errs = errors.Append(errs, NewValidationError(errors.Wrapf(err, "invalid repository pattern %q in dependencies", pattern)))
+			}
+		}
+	}
+	return errs
+}
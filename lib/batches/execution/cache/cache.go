@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches"
@@ -112,7 +113,13 @@ func (key CacheKey) Key() (string, error) {
 	// Setup a copy of the cache key that only includes the Steps up to and
 	// including key.StepIndex.
 	clone := key
-	clone.Steps = key.Steps[0 : key.StepIndex+1]
+	clone.Steps = make([]batches.Step, key.StepIndex+1)
+	copy(clone.Steps, key.Steps[0:key.StepIndex+1])
+	// Images pinned to a digest are keyed by the digest only, so that the
+	// cache is shared between references to the same image.
+	for i := range clone.Steps {
+		clone.Steps[i].Container = ImageDigest(clone.Steps[i].Container)
+	}
 
 	// Resolve environment only for the subset of Steps.
 	envs, err := resolveStepsEnvironment(key.GlobalEnv, clone.Steps)
@@ -131,6 +138,22 @@ func (key CacheKey) Key() (string, error) {
 	return fmt.Sprintf("%s-step-%d", hash, key.StepIndex), err
 }
 
+// ImageDigest returns the digest of the given container image reference if it
+// is pinned to one, such as alpine:3@sha256:<digest>. Otherwise the reference
+// is returned as-is.
+func ImageDigest(container string) string {
+	if i := strings.LastIndex(container, "@"); i != -1 {
+		return container[i+1:]
+	}
+	return container
+}
+
+// ImagePinned returns true if the given container image reference is pinned to
+// a digest, so that it always references the same image.
+func ImagePinned(container string) bool {
+	return strings.Contains(container, "@")
+}
+
 func (key CacheKey) Slug() string {
 	return SlugForRepo(key.Repository.Name, key.Repository.BaseRev)
 }
@@ -166,6 +189,7 @@ func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, resu
 		},
 		Template:         spec.ChangesetTemplate,
 		TransformChanges: spec.TransformChanges,
+		Dependencies:     spec.Dependencies,
 		Result:           result,
 		Path:             path,
 	}
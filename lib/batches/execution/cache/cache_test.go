@@ -195,3 +195,33 @@ type testM struct {
 func (t testM) Get(steps []batches.Step) ([]MountMetadata, error) {
 	return t.m, t.err
 }
+
+func TestKeyer_KeyPinnedImage(t *testing.T) {
+	keyFor := func(container string) string {
+		t.Helper()
+		key, err := (&CacheKey{
+			Repository: repo,
+			Steps:      []batches.Step{{Run: "foo", Container: container}, {Run: "bar", Container: "alpine:3"}},
+			StepIndex:  1,
+		}).Key()
+		require.NoError(t, err)
+		return key
+	}
+
+	pinned := keyFor("sourcegraph/comby:1.0@sha256:8b29bc1")
+	assert.Equal(t, pinned, keyFor("registry.example.com/comby@sha256:8b29bc1"))
+	assert.NotEqual(t, pinned, keyFor("sourcegraph/comby:1.0@sha256:d4c0f7a"))
+	assert.NotEqual(t, pinned, keyFor("sourcegraph/comby:1.0"))
+}
+
+func TestImageDigest(t *testing.T) {
+	assert.Equal(t, "sha256:8b29bc1", ImageDigest("sourcegraph/comby:1.0@sha256:8b29bc1"))
+	assert.Equal(t, "sourcegraph/comby:1.0", ImageDigest("sourcegraph/comby:1.0"))
+	assert.Equal(t, "", ImageDigest(""))
+}
+
+func TestImagePinned(t *testing.T) {
+	assert.True(t, ImagePinned("sourcegraph/comby:1.0@sha256:8b29bc1"))
+	assert.False(t, ImagePinned("sourcegraph/comby:1.0"))
+	assert.False(t, ImagePinned(""))
+}
@@ -0,0 +1,78 @@
+package batches
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// csvHeaderRepository is the value of the first column of an optional header
+// row in a repositoriesFromCSV list.
+const csvHeaderRepository = "repository"
+
+// CSVRepositories parses the repositoriesFromCSV list of the on: rule into
+// repository: rules.
+//
+// Each row of the list contains the repository name, optionally followed by
+// the branch and a revision the repository is pinned to. An empty branch
+// means the default branch of the repository is used. A header row starting
+// with "repository" is skipped.
+func (on *OnQueryOrRepository) CSVRepositories() ([]OnQueryOrRepository, error) {
+	r := csv.NewReader(strings.NewReader(on.RepositoriesFromCSV))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	var repos []OnQueryOrRepository
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, NewValidationError(errors.Wrap(err, "parsing repositoriesFromCSV"))
+		}
+
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if first && strings.EqualFold(record[0], csvHeaderRepository) {
+			continue
+		}
+		if len(record) > 3 {
+			line, _ := r.FieldPos(0)
+			return nil, NewValidationError(errors.Newf("parsing repositoriesFromCSV: line %d has more than 3 columns", line))
+		}
+		if record[0] == "" {
+			line, _ := r.FieldPos(0)
+			return nil, NewValidationError(errors.Newf("parsing repositoriesFromCSV: line %d has no repository", line))
+		}
+
+		repo := OnQueryOrRepository{Repository: record[0]}
+		if len(record) > 1 {
+			repo.Branch = record[1]
+		}
+		if len(record) > 2 {
+			repo.Revision = record[2]
+		}
+		repos = append(repos, repo)
+	}
+
+	return repos, nil
+}
+
+// validate returns an error if the on: rule can't be resolved.
+func (on *OnQueryOrRepository) validate() error {
+	if on.RepositoriesFromCSV != "" {
+		_, err := on.CSVRepositories()
+		return err
+	}
+
+	if on.Revision != "" && len(on.Branches) > 0 {
+		return ErrConflictingRevision
+	}
+
+	return nil
+}
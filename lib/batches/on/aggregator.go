@@ -2,8 +2,7 @@ package on
 
 // RepoRevisionAggregator implements the precedence rules used when resolving
 // the on: rules in a single batch spec. Specifically, later rules generally
-// override earlier rules, but repository: rules always override
-// repositoriesMatchingQuery: rules.
+// override earlier rules, but explicit rules always override query rules.
 //
 // This is essentially a generic type, with two parameters (albeit these are
 // mostly exposed in OnResult:
@@ -31,10 +30,12 @@ func NewRepoRevisionAggregator() *RepoRevisionAggregator {
 type RepositoryRuleType int
 
 const (
-	// RepositoryRuleTypeQuery is a repositoriesMatchingQuery: rule.
+	// RepositoryRuleTypeQuery is a repositoriesMatchingQuery:,
+	// repositoriesFromSavedSearch: or repositoriesFromInsight: rule.
 	RepositoryRuleTypeQuery RepositoryRuleType = iota
 
-	// RepositoryRuleTypeExplicit is a repository: rule.
+	// RepositoryRuleTypeExplicit is a repository: or repositoriesFromCSV:
+	// rule.
 	RepositoryRuleTypeExplicit
 )
 
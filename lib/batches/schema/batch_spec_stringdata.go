@@ -41,6 +41,48 @@ const BatchSpecJSON = `{
               }
             }
           },
+          {
+            "title": "OnInsight",
+            "type": "object",
+            "description": "The repositories in the breakdown of a code insight series. Each repository is added on its default branch to the list of repositories that the batch change will be run on.",
+            "additionalProperties": false,
+            "required": ["repositoriesFromInsight"],
+            "properties": {
+              "repositoriesFromInsight": {
+                "type": "string",
+                "description": "The ID of the code insight series whose repositories should be used.",
+                "examples": ["2JqNHiKqmVs3ufxbcEBRS9IRzmY"]
+              }
+            }
+          },
+          {
+            "title": "OnSavedSearch",
+            "type": "object",
+            "description": "The repositories matched by the query of a saved search. Each matched repository is added on its default branch to the list of repositories that the batch change will be run on.",
+            "additionalProperties": false,
+            "required": ["repositoriesFromSavedSearch"],
+            "properties": {
+              "repositoriesFromSavedSearch": {
+                "type": "string",
+                "description": "The ID of the saved search whose query should be used.",
+                "examples": ["U2F2ZWRTZWFyY2g6MQ=="]
+              }
+            }
+          },
+          {
+            "title": "OnCSV",
+            "type": "object",
+            "description": "A list of specific repositories (and branches) that are added to the list of repositories that the batch change will be run on.",
+            "additionalProperties": false,
+            "required": ["repositoriesFromCSV"],
+            "properties": {
+              "repositoriesFromCSV": {
+                "type": "string",
+                "description": "A CSV list with one repository per line. Each line contains the name of the repository, optionally followed by the branch and the commit SHA the repository is pinned to. A header line starting with ` + "`" + `repository` + "`" + ` is skipped.",
+                "examples": ["repository,branch,revision\ngithub.com/foo/bar,main,4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1\ngithub.com/foo/baz\n"]
+              }
+            }
+          },
           {
             "title": "OnRepository",
             "type": "object",
@@ -63,6 +105,11 @@ const BatchSpecJSON = `{
                 "items": {
                   "type": "string"
                 }
+              },
+              "revision": {
+                "description": "The commit SHA the repository is pinned to, so that running the batch spec again produces the same workspaces. If unset, the latest commit of the branch is used. If this field is defined, branches cannot be.",
+                "type": "string",
+                "examples": ["4b5ebcfa2ff9a6ab3b6b8e4a0d1bc4d6a0b8e5f1"]
               }
             },
             "$comment": "This is a convoluted way of saying either ` + "`" + `branch` + "`" + ` or ` + "`" + `branches` + "`" + ` can be provided, but not both at once, and neither are required.",
@@ -306,6 +353,32 @@ const BatchSpecJSON = `{
         }
       }
     },
+    "dependencies": {
+      "type": ["array", "null"],
+      "description": "Declares the order in which changesets are published: changesets in repositories matching ` + "`" + `repository` + "`" + ` are only published or undrafted once the changesets in the repositories matching ` + "`" + `dependsOn` + "`" + ` have been merged.",
+      "items": {
+        "title": "WorkspaceDependency",
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["repository", "dependsOn"],
+        "properties": {
+          "repository": {
+            "type": "string",
+            "description": "A glob pattern matching the names of the repositories whose changesets depend on other changesets.",
+            "examples": ["github.com/sourcegraph/*-service"]
+          },
+          "dependsOn": {
+            "type": "array",
+            "description": "Glob patterns matching the names of the repositories whose changesets have to be merged first.",
+            "minItems": 1,
+            "items": {
+              "type": "string"
+            },
+            "examples": [["github.com/sourcegraph/go-lib"]]
+          }
+        }
+      }
+    },
     "changesetTemplate": {
       "type": "object",
       "description": "A template describing how to create (and update) changesets with the file changes produced by the command steps.",
@@ -95,6 +95,12 @@ const ChangesetSpecJSON = `{
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
+        },
+        "dependsOn": {
+          "type": "array",
+          "description": "Glob patterns matching the names of the repositories whose changesets in the same batch change have to be merged before this changeset is published or undrafted.",
+          "items": { "type": "string" },
+          "examples": [["github.com/sourcegraph/go-lib"]]
         }
       },
       "required": ["baseRepository", "baseRef", "baseRev", "headRepository", "headRef", "title", "body", "commits"],
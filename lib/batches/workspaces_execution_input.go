@@ -19,6 +19,9 @@ type WorkspacesExecutionInput struct {
 	// CachedStepResult is only required for V1 executions.
 	// TODO: Remove me once V2 is the only execution format.
 	CachedStepResult execution.AfterStepResult `json:"cachedStepResult,omitempty"`
+	// StepCacheKeys are the keys under which the results of the steps are
+	// cached, by step index. They are only set for V2 executions.
+	StepCacheKeys map[int]string `json:"stepCacheKeys,omitempty"`
 }
 
 type WorkspaceRepo struct {
@@ -25,6 +25,30 @@ func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathex
 		return nil, err
 	}
 
+	return groupState(ctx, state, root, getChildren)
+}
+
+// CorrelateElements behaves like Correlate, but reads LSIF elements from the given channel
+// rather than decoding them from newline-delimited JSON. This allows indexes in other formats
+// (e.g., SCIP) to be translated into LSIF elements and stored through the same pipeline. The
+// given channel is drained before this function returns.
+func CorrelateElements(ctx context.Context, elements <-chan Pair, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	state, err := correlateFromElements(elements, root)
+	drain(elements)
+	if err == nil {
+		// The producer may have stopped early on cancellation
+		err = ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return groupState(ctx, state, root, getChildren)
+}
+
+// groupState canonicalizes and prunes the given correlation state and converts it into the
+// format we send to the writer.
+func groupState(ctx context.Context, state *State, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
 	// Remove duplicate elements, collapse linked elements
 	canonicalize(state)
 
@@ -120,12 +144,15 @@ func correlateFromReader(ctx context.Context, r io.Reader, root string) (*State,
 	defer func() {
 		// stop producer from reading more input on correlation error
 		cancel()
-
-		for range ch {
-			// drain whatever is in the channel to help out GC
-		}
+		drain(ch)
 	}()
 
+	return correlateFromElements(ch, root)
+}
+
+// correlateFromElements reads the given element stream and returns a correlation state object.
+// The data in the correlation state is neither canonicalized nor pruned.
+func correlateFromElements(ch <-chan Pair, root string) (*State, error) {
 	wrappedState := newWrappedState(root)
 
 	i := 0
@@ -148,6 +175,13 @@ func correlateFromReader(ctx context.Context, r io.Reader, root string) (*State,
 	return wrappedState.State, nil
 }
 
+// drain consumes whatever is left in the given channel to help out GC and to unblock
+// the producer.
+func drain(ch <-chan Pair) {
+	for range ch {
+	}
+}
+
 type wrappedState struct {
 	*State
 	dumpRoot            string
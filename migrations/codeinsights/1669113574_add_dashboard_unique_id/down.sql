@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS dashboard_unique_id_idx;
+
+ALTER TABLE dashboard DROP COLUMN IF EXISTS unique_id;
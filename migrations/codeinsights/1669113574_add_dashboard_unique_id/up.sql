@@ -0,0 +1,5 @@
+ALTER TABLE dashboard ADD COLUMN IF NOT EXISTS unique_id TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS dashboard_unique_id_idx ON dashboard (unique_id);
+
+COMMENT ON COLUMN dashboard.unique_id IS 'Stable identifier of the dashboard, used to import dashboard definitions.';
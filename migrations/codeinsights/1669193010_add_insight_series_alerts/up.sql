@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id SERIAL PRIMARY KEY,
+    insight_series_id INT NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL,
+    email_user_id INT,
+    slack_webhook_url TEXT,
+    webhook_url TEXT,
+    last_evaluated_at TIMESTAMP WITH TIME ZONE,
+    last_triggered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    undelivered_channels TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_insight_series_id_idx ON insight_series_alerts (insight_series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Alert rules on insight series, evaluated after each recording of the series.';
+COMMENT ON COLUMN insight_series_alerts.kind IS 'ABOVE and BELOW trigger when the series crosses the threshold, DELTA triggers when the series changes by at least the threshold between two recordings.';
+COMMENT ON COLUMN insight_series_alerts.last_evaluated_at IS 'Time of the most recent recording of the series the alert was evaluated against.';
+COMMENT ON COLUMN insight_series_alerts.undelivered_channels IS 'Channels the alert could not be delivered to when it was last triggered. They are retried until the series is recorded again.';
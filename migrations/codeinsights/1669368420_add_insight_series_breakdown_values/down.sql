@@ -0,0 +1 @@
+ALTER TABLE insight_series DROP COLUMN IF EXISTS breakdown_values;
@@ -0,0 +1,3 @@
+ALTER TABLE insight_series ADD COLUMN IF NOT EXISTS breakdown_values TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN insight_series.breakdown_values IS 'Values the results of a breakdown series are recorded under. Values are added as they are first recorded until the series limit is reached, the results of any other value are recorded as Other.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS search_job_repo_revisions;
+DROP TABLE IF EXISTS search_jobs;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS search_jobs (
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    state text DEFAULT 'queued'::text NOT NULL,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer DEFAULT 0 NOT NULL,
+    num_failures integer DEFAULT 0 NOT NULL,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text DEFAULT ''::text NOT NULL,
+    cancel boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS search_jobs_user_id_idx ON search_jobs USING btree (user_id);
+CREATE INDEX IF NOT EXISTS search_jobs_state_idx ON search_jobs USING btree (state);
+
+CREATE TABLE IF NOT EXISTS search_job_repo_revisions (
+    id serial PRIMARY KEY,
+    search_job_id integer NOT NULL REFERENCES search_jobs(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    revision text NOT NULL,
+    commit text NOT NULL,
+    result_count integer DEFAULT 0 NOT NULL,
+    state text DEFAULT 'queued'::text NOT NULL,
+    failure_message text,
+    queued_at timestamp with time zone DEFAULT now(),
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer DEFAULT 0 NOT NULL,
+    num_failures integer DEFAULT 0 NOT NULL,
+    last_heartbeat_at timestamp with time zone,
+    execution_logs json[],
+    worker_hostname text DEFAULT ''::text NOT NULL,
+    cancel boolean DEFAULT false NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS search_job_repo_revisions_unique ON search_job_repo_revisions USING btree (search_job_id, repo_id, revision);
+CREATE INDEX IF NOT EXISTS search_job_repo_revisions_state_idx ON search_job_repo_revisions USING btree (state);
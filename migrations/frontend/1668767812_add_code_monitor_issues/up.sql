@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS cm_issues (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    enabled boolean NOT NULL,
+    include_results boolean DEFAULT false NOT NULL,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    issue_number integer,
+    issue_url text,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cm_issues_monitor ON cm_issues USING btree (monitor);
+
+COMMENT ON TABLE cm_issues IS 'Code host issue actions configured on code monitors';
+COMMENT ON COLUMN cm_issues.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issues.repo_id IS 'The repository on whose code host the issue is opened';
+COMMENT ON COLUMN cm_issues.issue_number IS 'The number of the issue opened by the action. Subsequent events comment on this issue. NULL until the action first runs';
+COMMENT ON COLUMN cm_issues.issue_url IS 'The web URL of the issue opened by the action';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue bigint REFERENCES cm_issues(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.issue IS 'The ID of the cm_issues action to execute if this is an issue job. Mutually exclusive with email, webhook, and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
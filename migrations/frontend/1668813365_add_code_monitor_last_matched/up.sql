@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS cm_last_matched (
+    monitor_id bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    match_keys text[] NOT NULL,
+    PRIMARY KEY (monitor_id, repo_id)
+);
+
+COMMENT ON TABLE cm_last_matched IS 'The matches seen in each repository on the last run of a code monitor that searches file contents, paths, or symbols rather than commits';
+COMMENT ON COLUMN cm_last_matched.match_keys IS 'Hashes identifying the lines, symbols, or paths that matched on the last run. Matches whose hash is not in this set are reported as new on the next run';
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS batch_spec_execution_cache_entries_key_idx;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS batch_spec_execution_cache_entries_key_idx ON batch_spec_execution_cache_entries(key);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS gitserver_relocator_jobs_repo_id;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS gitserver_relocator_jobs_repo_id ON gitserver_relocator_jobs(repo_id);
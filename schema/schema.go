@@ -171,6 +171,39 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "sourcegraph-operator", "http-header", "github", "gitlab"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from Azure DevOps. Takes precedence over "orgs" and "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "org/project/repo"}) and by pattern.
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on Azure DevOps.
+	//
+	// If "http", Sourcegraph will access repositories using Git URLs of the form https://dev.azure.com/myorg/myproject/_git/myrepo.
+	//
+	// If "ssh", Sourcegraph will access repositories using Git URLs of the form git@ssh.dev.azure.com:v3/myorg/myproject/myrepo. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of "org/project" strings identifying Azure DevOps projects whose repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// Token description: An Azure DevOps personal access token with the Code (Read) scope. To use Batch Changes with the site credential, the token also needs the Code (Read & write) and Code (Status) scopes.
+	Token string `json:"token"`
+	// Url description: URL of Azure DevOps Services. Only the default Azure DevOps Services URL is currently supported.
+	Url string `json:"url"`
+	// Username description: The username of the Azure DevOps user the token belongs to.
+	Username string `json:"username"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type BackendInsight struct {
 	// Description description: The description of this insight
 	Description string          `json:"description,omitempty"`
@@ -554,6 +587,12 @@ type ExcludedAWSCodeCommitRepo struct {
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedAzureDevOpsRepo struct {
+	// Name description: The name of an Azure DevOps repository ("org/project/repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps repository ("org/project/repo").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedBitbucketCloudRepo struct {
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -588,6 +627,18 @@ type ExcludedGitLabProject struct {
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 }
+type ExcludedGiteaRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Id description: The ID of a Gitea repository (as returned by the Gitea instance's API) to exclude from mirroring. Use this to exclude the repository, even if renamed.
+	Id int `json:"id,omitempty"`
+	// Name description: The name of a Gitea repository ("owner/name") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Gitea repository ("owner/name").
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -637,8 +688,14 @@ type ExperimentalFeatures struct {
 	EventLogging string `json:"eventLogging,omitempty"`
 	// Gerrit description: Allow adding Gerrit code host connections
 	Gerrit string `json:"gerrit,omitempty"`
+	// GitServerPartialClones description: Repositories which are cloned without the contents of their files (blobs). Blobs are fetched from the code host the first time they are read, which reduces the time and disk space needed to clone very large repositories.
+	GitServerPartialClones *GitServerPartialClones `json:"gitServerPartialClones,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerRebalancing description: When the list of gitserver instances changes, repositories are copied from the instance that stored them before instead of being recloned from the code host, and requests keep being sent to that instance until the copy has finished.
+	GitServerRebalancing bool `json:"gitServerRebalancing,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver instances each repository is stored on. Copies beyond the first are kept up to date by the owning gitserver after every fetch and serve reads when the owner is unreachable. A value of 1 disables replication.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// HideSourcegraphOperatorLogin description: Enables hiding Sourcegraph operator auth provider on login page.
@@ -649,6 +706,8 @@ type ExperimentalFeatures struct {
 	InsightsBackfillerV2 bool `json:"insightsBackfillerV2,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// Mercurial description: Allow adding Mercurial code host connections
+	Mercurial string `json:"mercurial,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
@@ -680,6 +739,8 @@ type ExperimentalFeatures struct {
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch   string              `json:"structuralSearch,omitempty"`
 	SubRepoPermissions *SubRepoPermissions `json:"subRepoPermissions,omitempty"`
+	// Subversion description: Allow adding Subversion code host connections
+	Subversion string `json:"subversion,omitempty"`
 	// TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 	TlsExternal *TlsExternal `json:"tls.external,omitempty"`
 	// VersionContexts description: DEPRECATED: Use search contexts instead.
@@ -996,6 +1057,70 @@ type GitLabWebhook struct {
 	Secret string `json:"secret"`
 }
 
+// GitServerPartialClones description: Repositories which are cloned without the contents of their files (blobs). Blobs are fetched from the code host the first time they are read, which reduces the time and disk space needed to clone very large repositories.
+type GitServerPartialClones struct {
+	// BlobsSizeLimitMB description: When the blobs stored by a partially cloned repository exceed this size, all blobs except the ones of the latest commit of the default branch are removed. 0 means no limit.
+	BlobsSizeLimitMB int `json:"blobsSizeLimitMB,omitempty"`
+	// Repos description: Names of the repositories to clone partially. Changes only apply to repositories cloned or recloned afterwards.
+	Repos []string `json:"repos,omitempty"`
+}
+
+// GiteaAuthorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to Gitea users with the same username and a verified email address, and the repositories visible to each Gitea user are fetched by impersonating them with the `Sudo` header. This requires `token` to belong to a Gitea site administrator.
+type GiteaAuthorization struct {
+}
+
+// GiteaConnection description: Configuration for a connection to Gitea or Forgejo.
+type GiteaConnection struct {
+	// Authorization description: If non-null, enforces Gitea repository permissions. Sourcegraph users are matched to Gitea users with the same username and a verified email address, and the repositories visible to each Gitea user are fetched by impersonating them with the `Sudo` header. This requires `token` to belong to a Gitea site administrator.
+	Authorization *GiteaAuthorization `json:"authorization,omitempty"`
+	// Exclude description: A list of repositories to never mirror from this Gitea instance. Takes precedence over "orgs", "repos", "topics", and "repositoryQuery" configuration.
+	//
+	// Supports excluding by name ({"name": "owner/name"}), by ID ({"id": 42}), by pattern, and excluding all forks or archived repositories.
+	Exclude []*ExcludedGiteaRepo `json:"exclude,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Gitea instance.
+	//
+	// If "http", Sourcegraph will access Gitea repositories using Git URLs of the form http(s)://gitea.example.com/myteam/myproject.git (using https: if the Gitea instance uses HTTPS).
+	//
+	// If "ssh", Sourcegraph will access Gitea repositories using Git URLs of the form git@gitea.example.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Gitea organizations whose repositories should be mirrored on Sourcegraph.
+	Orgs []string `json:"orgs,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Gitea.
+	RateLimit *GiteaRateLimit `json:"rateLimit,omitempty"`
+	// Repos description: An array of repository "owner/name" strings specifying which Gitea repositories to mirror on Sourcegraph.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Gitea repository. In the pattern, the variable "{host}" is replaced with the Gitea host (such as gitea.example.com), and "{nameWithOwner}" is replaced with the Gitea repository's "owner/name" (such as "myorg/myrepo").
+	//
+	// For example, if your Gitea URL is https://gitea.example.com and your Sourcegraph URL is https://src.example.com, then a repositoryPathPattern of "{host}/{nameWithOwner}" would mean that a Gitea repository at https://gitea.example.com/myorg/myrepo is available on Sourcegraph at https://src.example.com/gitea.example.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of strings specifying which Gitea repositories to mirror on Sourcegraph. The valid values are:
+	//
+	// - `all` mirrors all repositories visible to the configured token
+	//
+	// - `none` mirrors no repositories (except those specified in the `orgs`, `repos`, or `topics` configuration properties)
+	//
+	// - All other values are executed as a Gitea repository search (the `q` parameter of the /repos/search API). Example: "backend" mirrors all repositories whose name contains "backend".
+	//
+	// If multiple values are provided, their results are unioned.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: A Gitea access token with read access to the repositories to mirror. Create one at https://gitea.example.com/user/settings/applications (replace gitea.example.com with your instance's hostname). If `authorization` is set, the token must belong to a site administrator.
+	Token string `json:"token"`
+	// Topics description: An array of topics. All repositories tagged with at least one of these topics are mirrored on Sourcegraph.
+	Topics []string `json:"topics,omitempty"`
+	// Url description: URL of a Gitea or Forgejo instance, such as https://gitea.example.com.
+	Url string `json:"url"`
+}
+
+// GiteaRateLimit description: Rate limit applied when making background API requests to Gitea.
+type GiteaRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Github description: GitHub configuration, both for queries and receiving release webhooks.
 type Github struct {
 	// Repository description: The repository to get the latest version of.
@@ -1164,6 +1289,20 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MercurialConnection description: Configuration for a connection to Mercurial repositories. Their history is converted to Git by gitserver.
+type MercurialConnection struct {
+	// Repos description: List of repository paths, relative to `url`, to be synced.
+	Repos []string `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Mercurial base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if your Mercurial base URL is https://hg.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://hg.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/repos/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Mercurial server, to which the paths in `repos` are appended. Credentials can be included in the URL userinfo.
+	Url string `json:"url"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -2144,6 +2283,8 @@ type SiteConfiguration struct {
 	InsightsAggregationsProactiveResultLimit int `json:"insights.aggregations.proactiveResultLimit,omitempty"`
 	// InsightsBackfillInterruptAfter description: Set the number of seconds an insight series will spend backfilling before being interrupted. Series are interrupted to prevent long running insights from exhausting all of the available workers. Interrupted series will be placed back in the queue and retried based on their priority.
 	InsightsBackfillInterruptAfter int `json:"insights.backfill.interruptAfter,omitempty"`
+	// InsightsBreakdownsSeriesLimit description: The maximum number of series an insight series broken down by commit author or code owner is split into. Results for the remaining authors or owners are recorded in a single "Other" series.
+	InsightsBreakdownsSeriesLimit int `json:"insights.breakdowns.seriesLimit,omitempty"`
 	// InsightsCommitIndexerInterval description: The interval (in minutes) at which the insights commit indexer will check for new commits.
 	InsightsCommitIndexerInterval int `json:"insights.commit.indexer.interval,omitempty"`
 	// InsightsCommitIndexerWindowDuration description: The number of days of commits the insights commit indexer will pull during each request (0 is no limit).
@@ -2279,6 +2420,32 @@ type SubRepoPermissions struct {
 	UserCacheTTLSeconds int `json:"userCacheTTLSeconds,omitempty"`
 }
 
+// SubversionConnection description: Configuration for a connection to Subversion repositories. Their history is converted to Git by gitserver with git svn.
+type SubversionConnection struct {
+	// Authors description: Maps Subversion usernames to Git authors in the form "Name <email>", so that commits can be searched by author name and email. Changes only apply to revisions imported afterwards.
+	Authors map[string]string `json:"authors,omitempty"`
+	// AuthorsEmailDomain description: The email domain of the Git authors of Subversion usernames not listed in `authors`. Defaults to the host of `url`.
+	AuthorsEmailDomain string `json:"authorsEmailDomain,omitempty"`
+	// Exclude description: A list of repository paths, relative to `url`, to never sync.
+	Exclude []string `json:"exclude,omitempty"`
+	// Layout description: The layout of the repositories. With the "standard" layout, trunk/ is imported as the master branch, branches/* as branches and tags/* as tags. With the "root" layout, the whole repository is imported as the master branch.
+	Layout string `json:"layout,omitempty"`
+	// Password description: The password used to authenticate to the Subversion server.
+	Password string `json:"password,omitempty"`
+	// Repos description: List of repository paths, relative to `url`, to be synced. If empty, all repositories listed by the server at `url` are synced. Listing repositories requires an HTTP(S) server configured with `SVNListParentPath on`.
+	Repos []string `json:"repos,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Subversion server URL host and path, and "{repo}" is replaced with the repository path.
+	//
+	// For example, if your Subversion server URL is https://svn.example.com/repos and a repository path is "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that the repository at https://svn.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/svn.example.com/repos/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The URL of the Subversion server root, below which the repositories are served.
+	Url string `json:"url"`
+	// Username description: The username used to authenticate to the Subversion server.
+	Username string `json:"username,omitempty"`
+}
+
 // SyntaxHighlighting description: Syntax highlighting configuration
 type SyntaxHighlighting struct {
 	Engine    SyntaxHighlightingEngine   `json:"engine"`
@@ -7,6 +7,11 @@ import _ "embed"
 //go:embed aws_codecommit.schema.json
 var AWSCodeCommitSchemaJSON string
 
+// AzureDevOpsSchemaJSON is the content of the file "azuredevops.schema.json".
+//
+//go:embed azuredevops.schema.json
+var AzureDevOpsSchemaJSON string
+
 // BatchSpecSchemaJSON is the content of the file "batch_spec.schema.json".
 //
 //go:embed batch_spec.schema.json
@@ -32,6 +37,11 @@ var ChangesetSpecSchemaJSON string
 //go:embed gerrit.schema.json
 var GerritSchemaJSON string
 
+// GiteaSchemaJSON is the content of the file "gitea.schema.json".
+//
+//go:embed gitea.schema.json
+var GiteaSchemaJSON string
+
 // GitHubSchemaJSON is the content of the file "github.schema.json".
 //
 //go:embed github.schema.json
@@ -53,6 +63,11 @@ var GoModulesSchemaJSON string
 //go:embed jvm-packages.schema.json
 var JVMPackagesSchemaJSON string
 
+// MercurialSchemaJSON is the content of the file "mercurial.schema.json".
+//
+//go:embed mercurial.schema.json
+var MercurialSchemaJSON string
+
 //go:embed npm-packages.schema.json
 var NpmPackagesSchemaJSON string
 
@@ -94,3 +109,8 @@ var SettingsSchemaJSON string
 //
 //go:embed site.schema.json
 var SiteSchemaJSON string
+
+// SubversionSchemaJSON is the content of the file "subversion.schema.json".
+//
+//go:embed subversion.schema.json
+var SubversionSchemaJSON string